@@ -0,0 +1,28 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addSAMLIDPConfigColumns = `
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS is_saml BOOL NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS saml_entity_id STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS saml_metadata BYTES NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS saml_metadata_url STRING NULL;
+`
+)
+
+type SAMLIDPConfigColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *SAMLIDPConfigColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addSAMLIDPConfigColumns)
+	return err
+}
+
+func (mig *SAMLIDPConfigColumns) String() string {
+	return "04_saml_idp_config_columns"
+}
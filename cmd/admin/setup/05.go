@@ -0,0 +1,38 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addLDAPIDPConfigColumns = `
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS is_ldap BOOL NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_url STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_base_dn STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_bind_dn STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_bind_password JSONB NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_user_object_class STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_user_filters STRING[] NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_id_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_preferred_username_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_first_name_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_last_name_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_display_name_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_email_attribute STRING NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_phone_attribute STRING NULL;
+`
+)
+
+type LDAPIDPConfigColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *LDAPIDPConfigColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addLDAPIDPConfigColumns)
+	return err
+}
+
+func (mig *LDAPIDPConfigColumns) String() string {
+	return "05_ldap_idp_config_columns"
+}
@@ -0,0 +1,28 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addDeviceAuthRequestColumns = `
+ALTER TABLE auth.auth_requests ADD COLUMN IF NOT EXISTS device_code STRING NULL;
+ALTER TABLE auth.auth_requests ADD COLUMN IF NOT EXISTS user_code STRING NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS auth_device_code_idx ON auth.auth_requests (instance_id, device_code);
+CREATE UNIQUE INDEX IF NOT EXISTS auth_user_code_idx ON auth.auth_requests (instance_id, user_code);
+`
+)
+
+type DeviceAuthRequestColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *DeviceAuthRequestColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addDeviceAuthRequestColumns)
+	return err
+}
+
+func (mig *DeviceAuthRequestColumns) String() string {
+	return "06_device_auth_request_columns"
+}
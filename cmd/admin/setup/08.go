@@ -0,0 +1,36 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createPushedAuthRequests = `
+CREATE TABLE IF NOT EXISTS auth.pushed_auth_requests (
+    id STRING NOT NULL,
+    instance_id STRING NOT NULL,
+    client_id STRING NOT NULL,
+    parameters JSONB NOT NULL,
+    creation_date TIMESTAMPTZ,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, id)
+);
+ALTER TABLE IF EXISTS projections.apps_oidc_configs ADD COLUMN IF NOT EXISTS require_pushed_auth_request BOOL DEFAULT false;
+ALTER TABLE IF EXISTS projections.apps_oidc_configs ADD COLUMN IF NOT EXISTS require_request_object BOOL DEFAULT false;
+`
+)
+
+type PushedAuthRequests struct {
+	dbClient *sql.DB
+}
+
+func (mig *PushedAuthRequests) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createPushedAuthRequests)
+	return err
+}
+
+func (mig *PushedAuthRequests) String() string {
+	return "08_pushed_auth_requests"
+}
@@ -0,0 +1,23 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addTokenConfirmationColumn = `ALTER TABLE auth.tokens ADD COLUMN IF NOT EXISTS confirmation JSONB NULL;`
+)
+
+type TokenConfirmationColumn struct {
+	dbClient *sql.DB
+}
+
+func (mig *TokenConfirmationColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addTokenConfirmationColumn)
+	return err
+}
+
+func (mig *TokenConfirmationColumn) String() string {
+	return "09_token_confirmation_column"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addLogoutURIColumns = `
+ALTER TABLE IF EXISTS projections.apps_oidc_configs ADD COLUMN IF NOT EXISTS back_channel_logout_uri TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps_oidc_configs ADD COLUMN IF NOT EXISTS front_channel_logout_uri TEXT DEFAULT '';
+`
+)
+
+type LogoutURIColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *LogoutURIColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addLogoutURIColumns)
+	return err
+}
+
+func (mig *LogoutURIColumns) String() string {
+	return "10_logout_uri_columns"
+}
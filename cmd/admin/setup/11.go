@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addPasswordComplexityHistoryColumns = `
+ALTER TABLE IF EXISTS projections.password_complexity_policies ADD COLUMN IF NOT EXISTS history_depth INT8 DEFAULT 0;
+ALTER TABLE IF EXISTS projections.password_complexity_policies ADD COLUMN IF NOT EXISTS check_breached BOOLEAN DEFAULT false;
+`
+)
+
+type PasswordComplexityHistoryColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *PasswordComplexityHistoryColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addPasswordComplexityHistoryColumns)
+	return err
+}
+
+func (mig *PasswordComplexityHistoryColumns) String() string {
+	return "11_password_complexity_history_columns"
+}
@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addHumanPasswordChangedColumn = `
+ALTER TABLE IF EXISTS projections.users_humans ADD COLUMN IF NOT EXISTS password_changed TIMESTAMPTZ;
+`
+	fillHumanPasswordChangedColumn = `
+UPDATE projections.users_humans h SET password_changed = (
+	SELECT max(e.creation_date) FROM eventstore.events e
+	WHERE e.aggregate_type = 'user'
+		AND e.aggregate_id = h.user_id
+		AND e.instance_id = h.instance_id
+		AND (
+			e.event_type IN ('user.human.password.changed', 'user.password.changed')
+			OR (e.event_type IN ('user.human.added', 'user.human.selfregistered', 'user.added', 'user.selfregistered') AND e.event_data->'secret' IS NOT NULL)
+		)
+) WHERE h.password_changed IS NULL;
+`
+)
+
+type HumanPasswordChangedColumn struct {
+	dbClient *sql.DB
+}
+
+func (mig *HumanPasswordChangedColumn) Execute(ctx context.Context) error {
+	if _, err := mig.dbClient.ExecContext(ctx, addHumanPasswordChangedColumn); err != nil {
+		return err
+	}
+	_, err := mig.dbClient.ExecContext(ctx, fillHumanPasswordChangedColumn)
+	return err
+}
+
+func (mig *HumanPasswordChangedColumn) String() string {
+	return "12_human_password_changed_column"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addUserOTPCodeColumns = `
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS otp_sms_added BOOL NULL;
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS otp_email_added BOOL NULL;
+`
+)
+
+type UserOTPCodeColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserOTPCodeColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addUserOTPCodeColumns)
+	return err
+}
+
+func (mig *UserOTPCodeColumns) String() string {
+	return "13_user_otp_code_columns"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addRecoveryCodesColumns = `
+ALTER TABLE IF EXISTS projections.user_auth_methods ADD COLUMN IF NOT EXISTS remaining_codes INT8 DEFAULT 0;
+ALTER TABLE auth.users ADD COLUMN IF NOT EXISTS recovery_codes_remaining INT8 NULL;
+`
+)
+
+type RecoveryCodesColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *RecoveryCodesColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addRecoveryCodesColumns)
+	return err
+}
+
+func (mig *RecoveryCodesColumns) String() string {
+	return "14_recovery_codes_columns"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addLockoutPolicyColumns = `
+ALTER TABLE IF EXISTS projections.lockout_policies ADD COLUMN IF NOT EXISTS max_otp_attempts INT8 DEFAULT 0;
+ALTER TABLE IF EXISTS projections.lockout_policies ADD COLUMN IF NOT EXISTS max_u2f_attempts INT8 DEFAULT 0;
+ALTER TABLE IF EXISTS projections.lockout_policies ADD COLUMN IF NOT EXISTS auto_unlock_duration INT8 DEFAULT 0;
+`
+)
+
+type LockoutPolicyColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *LockoutPolicyColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addLockoutPolicyColumns)
+	return err
+}
+
+func (mig *LockoutPolicyColumns) String() string {
+	return "15_lockout_policy_columns"
+}
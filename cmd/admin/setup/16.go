@@ -0,0 +1,25 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addMinLevelOfAssuranceColumn = `
+ALTER TABLE IF EXISTS projections.apps_oidc_configs ADD COLUMN IF NOT EXISTS min_level_of_assurance SMALLINT DEFAULT 0;
+`
+)
+
+type MinLevelOfAssuranceColumn struct {
+	dbClient *sql.DB
+}
+
+func (mig *MinLevelOfAssuranceColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addMinLevelOfAssuranceColumn)
+	return err
+}
+
+func (mig *MinLevelOfAssuranceColumn) String() string {
+	return "16_min_level_of_assurance_column"
+}
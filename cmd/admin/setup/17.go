@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addMagicLinkColumns = `
+ALTER TABLE IF EXISTS projections.login_policies ADD COLUMN IF NOT EXISTS allow_magic_link BOOLEAN DEFAULT false;
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS magic_link_verification TIMESTAMPTZ NULL;
+`
+)
+
+type MagicLinkColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *MagicLinkColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addMagicLinkColumns)
+	return err
+}
+
+func (mig *MagicLinkColumns) String() string {
+	return "17_magic_link_columns"
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addUserSessionDeviceColumns = `
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS browser_user_agent TEXT NULL;
+ALTER TABLE auth.user_sessions ADD COLUMN IF NOT EXISTS remote_ip TEXT NULL;
+`
+)
+
+type UserSessionDeviceColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *UserSessionDeviceColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addUserSessionDeviceColumns)
+	return err
+}
+
+func (mig *UserSessionDeviceColumns) String() string {
+	return "18_user_session_device_columns"
+}
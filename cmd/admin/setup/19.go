@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createActionExecutions = `
+CREATE TABLE IF NOT EXISTS system.action_executions (
+    id UUID DEFAULT gen_random_uuid(),
+    instance_id TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    action_id TEXT NOT NULL,
+    flow_type INT2 NOT NULL,
+    trigger_type INT2 NOT NULL,
+    creation_date TIMESTAMPTZ NOT NULL,
+    duration INT8 NOT NULL,
+    logs TEXT[],
+    error TEXT NOT NULL DEFAULT '',
+    input TEXT NOT NULL DEFAULT '',
+
+    PRIMARY KEY (instance_id, resource_owner, creation_date, id),
+    INDEX action_idx (instance_id, resource_owner, action_id, creation_date)
+);
+`
+)
+
+type ActionExecutionsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *ActionExecutionsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createActionExecutions)
+	return err
+}
+
+func (mig *ActionExecutionsTable) String() string {
+	return "19_action_executions"
+}
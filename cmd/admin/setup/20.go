@@ -0,0 +1,51 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	projectionTableExists = `
+SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = 'projections' AND table_name = $1);
+`
+	createAppSAMLConfigsTable = `
+CREATE TABLE IF NOT EXISTS projections.apps_saml_configs (
+    app_id TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    metadata BYTES NOT NULL,
+    metadata_url TEXT NOT NULL,
+
+    PRIMARY KEY (app_id, instance_id),
+    INDEX entity_id_idx (entity_id),
+    CONSTRAINT fk_saml_ref_apps FOREIGN KEY (app_id, instance_id) REFERENCES projections.apps ON DELETE CASCADE
+);
+`
+)
+
+// AppSAMLConfigsTable creates the secondary table of the app projection on existing installations,
+// the projection itself only creates it together with projections.apps
+type AppSAMLConfigsTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *AppSAMLConfigsTable) Execute(ctx context.Context) error {
+	exists, err := projectionExists(ctx, mig.dbClient, "apps")
+	if err != nil || !exists {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, createAppSAMLConfigsTable)
+	return err
+}
+
+func (mig *AppSAMLConfigsTable) String() string {
+	return "20_app_saml_configs_table"
+}
+
+// projectionExists checks if the table of the projection was already created,
+// otherwise the projection creates its secondary tables itself
+func projectionExists(ctx context.Context, dbClient *sql.DB, table string) (exists bool, err error) {
+	err = dbClient.QueryRowContext(ctx, projectionTableExists, table).Scan(&exists)
+	return exists, err
+}
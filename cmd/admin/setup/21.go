@@ -0,0 +1,34 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createSAMLAssertions = `
+CREATE TABLE IF NOT EXISTS auth.saml_assertions (
+    instance_id STRING NOT NULL,
+    issuer STRING NOT NULL,
+    id STRING NOT NULL,
+    expiration TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, issuer, id)
+);
+`
+)
+
+// SAMLAssertions stores the ids of the assertions received from SAML identity providers
+// until they expire, so a response can only be used once
+type SAMLAssertions struct {
+	dbClient *sql.DB
+}
+
+func (mig *SAMLAssertions) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createSAMLAssertions)
+	return err
+}
+
+func (mig *SAMLAssertions) String() string {
+	return "21_saml_assertions"
+}
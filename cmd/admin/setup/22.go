@@ -0,0 +1,40 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createIDPSAMLConfigTable = `
+CREATE TABLE IF NOT EXISTS projections.idps_saml_config (
+    idp_id TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    entity_id TEXT,
+    metadata BYTES,
+    metadata_url TEXT,
+
+    PRIMARY KEY (idp_id),
+    CONSTRAINT fk_saml_ref_idp FOREIGN KEY (idp_id) REFERENCES projections.idps ON DELETE CASCADE
+);
+`
+)
+
+// IDPSAMLConfigTable creates the secondary table of the idp projection on existing installations,
+// the projection itself only creates it together with projections.idps
+type IDPSAMLConfigTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *IDPSAMLConfigTable) Execute(ctx context.Context) error {
+	exists, err := projectionExists(ctx, mig.dbClient, "idps")
+	if err != nil || !exists {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, createIDPSAMLConfigTable)
+	return err
+}
+
+func (mig *IDPSAMLConfigTable) String() string {
+	return "22_idp_saml_config_table"
+}
@@ -0,0 +1,28 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	addLDAPTLSColumns = `
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_start_tls BOOL NULL;
+ALTER TABLE auth.idp_configs ADD COLUMN IF NOT EXISTS ldap_root_ca STRING NULL;
+ALTER TABLE IF EXISTS projections.idps_ldap_config ADD COLUMN IF NOT EXISTS start_tls BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE IF EXISTS projections.idps_ldap_config ADD COLUMN IF NOT EXISTS root_ca TEXT NULL;
+`
+)
+
+type LDAPTLSColumns struct {
+	dbClient *sql.DB
+}
+
+func (mig *LDAPTLSColumns) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addLDAPTLSColumns)
+	return err
+}
+
+func (mig *LDAPTLSColumns) String() string {
+	return "23_ldap_tls_columns"
+}
@@ -0,0 +1,52 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createIDPLDAPConfigTable = `
+CREATE TABLE IF NOT EXISTS projections.idps_ldap_config (
+    idp_id TEXT NOT NULL,
+    instance_id TEXT NOT NULL,
+    url TEXT,
+    start_tls BOOLEAN NOT NULL DEFAULT false,
+    root_ca TEXT,
+    base_dn TEXT,
+    bind_dn TEXT,
+    bind_password JSONB,
+    user_object_class TEXT,
+    user_filters TEXT[],
+    id_attribute TEXT,
+    preferred_username_attribute TEXT,
+    first_name_attribute TEXT,
+    last_name_attribute TEXT,
+    display_name_attribute TEXT,
+    email_attribute TEXT,
+    phone_attribute TEXT,
+
+    PRIMARY KEY (idp_id),
+    CONSTRAINT fk_ldap_ref_idp FOREIGN KEY (idp_id) REFERENCES projections.idps ON DELETE CASCADE
+);
+`
+)
+
+// IDPLDAPConfigTable creates the secondary table of the idp projection on existing installations,
+// the projection itself only creates it together with projections.idps
+type IDPLDAPConfigTable struct {
+	dbClient *sql.DB
+}
+
+func (mig *IDPLDAPConfigTable) Execute(ctx context.Context) error {
+	exists, err := projectionExists(ctx, mig.dbClient, "idps")
+	if err != nil || !exists {
+		return err
+	}
+	_, err = mig.dbClient.ExecContext(ctx, createIDPLDAPConfigTable)
+	return err
+}
+
+func (mig *IDPLDAPConfigTable) String() string {
+	return "24_idp_ldap_config_table"
+}
@@ -0,0 +1,44 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createActionEventFlowJobs = `
+CREATE TABLE IF NOT EXISTS system.action_event_flow_jobs (
+    instance_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    sequence INT8 NOT NULL,
+    event_type TEXT NOT NULL,
+    event_data BYTES,
+    creation_date TIMESTAMPTZ NOT NULL,
+    editor_user TEXT NOT NULL,
+    trigger_type INT2 NOT NULL,
+    failure_count INT2 NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    locked_until TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (instance_id, aggregate_type, sequence),
+    INDEX locked_until_idx (locked_until, creation_date)
+);
+`
+)
+
+// ActionEventFlowJobs queues the events which trigger actions of the event flow,
+// so the actions are executed outside of the projection
+type ActionEventFlowJobs struct {
+	dbClient *sql.DB
+}
+
+func (mig *ActionEventFlowJobs) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createActionEventFlowJobs)
+	return err
+}
+
+func (mig *ActionEventFlowJobs) String() string {
+	return "25_action_event_flow_jobs"
+}
@@ -0,0 +1,36 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createLoginThrottles = `
+CREATE TABLE IF NOT EXISTS system.login_throttles (
+    instance_id TEXT NOT NULL,
+    login_name TEXT NOT NULL,
+    remote_ip TEXT NOT NULL,
+    failure_count INT8 NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, login_name, remote_ip),
+    INDEX window_start_idx (window_start)
+);
+`
+)
+
+// LoginThrottles stores the failed login checks,
+// so the throttling applies to all instances of ZITADEL
+type LoginThrottles struct {
+	dbClient *sql.DB
+}
+
+func (mig *LoginThrottles) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createLoginThrottles)
+	return err
+}
+
+func (mig *LoginThrottles) String() string {
+	return "26_login_throttles"
+}
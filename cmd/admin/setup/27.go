@@ -0,0 +1,34 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createDPoPProofs = `
+CREATE TABLE IF NOT EXISTS system.dpop_proofs (
+    jkt TEXT NOT NULL,
+    jti TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (jkt, jti),
+    INDEX expires_at_idx (expires_at)
+);
+`
+)
+
+// DPoPProofs stores the used DPoP proofs,
+// so they can't be replayed on any instance of ZITADEL
+type DPoPProofs struct {
+	dbClient *sql.DB
+}
+
+func (mig *DPoPProofs) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createDPoPProofs)
+	return err
+}
+
+func (mig *DPoPProofs) String() string {
+	return "27_dpop_proofs"
+}
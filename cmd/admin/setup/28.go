@@ -0,0 +1,45 @@
+package setup
+
+import (
+	"context"
+	"database/sql"
+)
+
+const (
+	createActionEventFlowFailedJobs = `
+CREATE INDEX IF NOT EXISTS instance_order_idx ON system.action_event_flow_jobs (instance_id, creation_date, sequence);
+
+CREATE TABLE IF NOT EXISTS system.action_event_flow_failed_jobs (
+    instance_id TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    resource_owner TEXT NOT NULL,
+    sequence INT8 NOT NULL,
+    event_type TEXT NOT NULL,
+    event_data BYTES,
+    creation_date TIMESTAMPTZ NOT NULL,
+    editor_user TEXT NOT NULL,
+    trigger_type INT2 NOT NULL,
+    failure_count INT2 NOT NULL,
+    error TEXT NOT NULL,
+    failed_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (instance_id, aggregate_type, sequence)
+);
+`
+)
+
+// ActionEventFlowFailedJobs orders the event flow jobs per instance
+// and records the jobs which failed too often
+type ActionEventFlowFailedJobs struct {
+	dbClient *sql.DB
+}
+
+func (mig *ActionEventFlowFailedJobs) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, createActionEventFlowFailedJobs)
+	return err
+}
+
+func (mig *ActionEventFlowFailedJobs) String() string {
+	return "28_action_event_flow_failed_jobs"
+}
@@ -46,9 +46,34 @@ func MustNewConfig(v *viper.Viper) *Config {
 }
 
 type Steps struct {
-	s1ProjectionTable *ProjectionTable
-	s2AssetsTable     *AssetTable
-	S3DefaultInstance *DefaultInstance
+	s1ProjectionTable            *ProjectionTable
+	s2AssetsTable                *AssetTable
+	S3DefaultInstance            *DefaultInstance
+	s4SAMLIDPConfig              *SAMLIDPConfigColumns
+	s5LDAPIDPConfig              *LDAPIDPConfigColumns
+	s6DeviceAuth                 *DeviceAuthRequestColumns
+	s7TokenActor                 *TokenActorColumn
+	s8PushedAuthRequests         *PushedAuthRequests
+	s9TokenConfirmation          *TokenConfirmationColumn
+	s10LogoutURIs                *LogoutURIColumns
+	s11PasswordComplexityHistory *PasswordComplexityHistoryColumns
+	s12HumanPasswordChanged      *HumanPasswordChangedColumn
+	s13UserOTPCodeColumns        *UserOTPCodeColumns
+	s14RecoveryCodesColumns      *RecoveryCodesColumns
+	s15LockoutPolicyColumns      *LockoutPolicyColumns
+	s16MinLevelOfAssurance       *MinLevelOfAssuranceColumn
+	s17MagicLinkColumns          *MagicLinkColumns
+	s18UserSessionDeviceColumns  *UserSessionDeviceColumns
+	s19ActionExecutionsTable     *ActionExecutionsTable
+	s20AppSAMLConfigsTable       *AppSAMLConfigsTable
+	s21SAMLAssertions            *SAMLAssertions
+	s22IDPSAMLConfigTable        *IDPSAMLConfigTable
+	s23LDAPTLSColumns            *LDAPTLSColumns
+	s24IDPLDAPConfigTable        *IDPLDAPConfigTable
+	s25ActionEventFlowJobs       *ActionEventFlowJobs
+	s26LoginThrottles            *LoginThrottles
+	s27DPoPProofs                *DPoPProofs
+	s28ActionEventFlowFailedJobs *ActionEventFlowFailedJobs
 }
 
 type encryptionKeyConfig struct {
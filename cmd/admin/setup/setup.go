@@ -58,6 +58,31 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 
 	steps.s1ProjectionTable = &ProjectionTable{dbClient: dbClient}
 	steps.s2AssetsTable = &AssetTable{dbClient: dbClient}
+	steps.s4SAMLIDPConfig = &SAMLIDPConfigColumns{dbClient: dbClient}
+	steps.s5LDAPIDPConfig = &LDAPIDPConfigColumns{dbClient: dbClient}
+	steps.s6DeviceAuth = &DeviceAuthRequestColumns{dbClient: dbClient}
+	steps.s7TokenActor = &TokenActorColumn{dbClient: dbClient}
+	steps.s8PushedAuthRequests = &PushedAuthRequests{dbClient: dbClient}
+	steps.s9TokenConfirmation = &TokenConfirmationColumn{dbClient: dbClient}
+	steps.s10LogoutURIs = &LogoutURIColumns{dbClient: dbClient}
+	steps.s11PasswordComplexityHistory = &PasswordComplexityHistoryColumns{dbClient: dbClient}
+	steps.s12HumanPasswordChanged = &HumanPasswordChangedColumn{dbClient: dbClient}
+	steps.s13UserOTPCodeColumns = &UserOTPCodeColumns{dbClient: dbClient}
+	steps.s14RecoveryCodesColumns = &RecoveryCodesColumns{dbClient: dbClient}
+	steps.s15LockoutPolicyColumns = &LockoutPolicyColumns{dbClient: dbClient}
+	steps.s16MinLevelOfAssurance = &MinLevelOfAssuranceColumn{dbClient: dbClient}
+	steps.s17MagicLinkColumns = &MagicLinkColumns{dbClient: dbClient}
+	steps.s18UserSessionDeviceColumns = &UserSessionDeviceColumns{dbClient: dbClient}
+	steps.s19ActionExecutionsTable = &ActionExecutionsTable{dbClient: dbClient}
+	steps.s20AppSAMLConfigsTable = &AppSAMLConfigsTable{dbClient: dbClient}
+	steps.s21SAMLAssertions = &SAMLAssertions{dbClient: dbClient}
+	steps.s22IDPSAMLConfigTable = &IDPSAMLConfigTable{dbClient: dbClient}
+	steps.s23LDAPTLSColumns = &LDAPTLSColumns{dbClient: dbClient}
+	steps.s24IDPLDAPConfigTable = &IDPLDAPConfigTable{dbClient: dbClient}
+	steps.s25ActionEventFlowJobs = &ActionEventFlowJobs{dbClient: dbClient}
+	steps.s26LoginThrottles = &LoginThrottles{dbClient: dbClient}
+	steps.s27DPoPProofs = &DPoPProofs{dbClient: dbClient}
+	steps.s28ActionEventFlowFailedJobs = &ActionEventFlowFailedJobs{dbClient: dbClient}
 
 	steps.S3DefaultInstance.instanceSetup = config.DefaultInstance
 	steps.S3DefaultInstance.userEncryptionKey = config.EncryptionKeys.User
@@ -78,6 +103,56 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.OnError(err).Fatal("unable to migrate step 2")
 	err = migration.Migrate(ctx, eventstoreClient, steps.S3DefaultInstance)
 	logging.OnError(err).Fatal("unable to migrate step 3")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s4SAMLIDPConfig)
+	logging.OnError(err).Fatal("unable to migrate step 4")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s5LDAPIDPConfig)
+	logging.OnError(err).Fatal("unable to migrate step 5")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s6DeviceAuth)
+	logging.OnError(err).Fatal("unable to migrate step 6")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s7TokenActor)
+	logging.OnError(err).Fatal("unable to migrate step 7")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s8PushedAuthRequests)
+	logging.OnError(err).Fatal("unable to migrate step 8")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s9TokenConfirmation)
+	logging.OnError(err).Fatal("unable to migrate step 9")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s10LogoutURIs)
+	logging.OnError(err).Fatal("unable to migrate step 10")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s11PasswordComplexityHistory)
+	logging.OnError(err).Fatal("unable to migrate step 11")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s12HumanPasswordChanged)
+	logging.OnError(err).Fatal("unable to migrate step 12")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s13UserOTPCodeColumns)
+	logging.OnError(err).Fatal("unable to migrate step 13")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s14RecoveryCodesColumns)
+	logging.OnError(err).Fatal("unable to migrate step 14")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s15LockoutPolicyColumns)
+	logging.OnError(err).Fatal("unable to migrate step 15")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s16MinLevelOfAssurance)
+	logging.OnError(err).Fatal("unable to migrate step 16")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s17MagicLinkColumns)
+	logging.OnError(err).Fatal("unable to migrate step 17")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s18UserSessionDeviceColumns)
+	logging.OnError(err).Fatal("unable to migrate step 18")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s19ActionExecutionsTable)
+	logging.OnError(err).Fatal("unable to migrate step 19")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s20AppSAMLConfigsTable)
+	logging.OnError(err).Fatal("unable to migrate step 20")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s21SAMLAssertions)
+	logging.OnError(err).Fatal("unable to migrate step 21")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s22IDPSAMLConfigTable)
+	logging.OnError(err).Fatal("unable to migrate step 22")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s23LDAPTLSColumns)
+	logging.OnError(err).Fatal("unable to migrate step 23")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s24IDPLDAPConfigTable)
+	logging.OnError(err).Fatal("unable to migrate step 24")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s25ActionEventFlowJobs)
+	logging.OnError(err).Fatal("unable to migrate step 25")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s26LoginThrottles)
+	logging.OnError(err).Fatal("unable to migrate step 26")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s27DPoPProofs)
+	logging.OnError(err).Fatal("unable to migrate step 27")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s28ActionEventFlowFailedJobs)
+	logging.OnError(err).Fatal("unable to migrate step 28")
 }
 
 func initSteps(v *viper.Viper, files ...string) func() {
@@ -11,6 +11,7 @@ import (
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	auth_es "github.com/zitadel/zitadel/internal/auth/repository/eventsourcing"
@@ -26,30 +27,33 @@ import (
 )
 
 type Config struct {
-	Log               *logging.Config
-	Port              uint16
-	ExternalPort      uint16
-	ExternalDomain    string
-	ExternalSecure    bool
-	HTTP2HostHeader   string
-	HTTP1HostHeader   string
-	WebAuthNName      string
-	Database          database.Config
-	Tracing           tracing.Config
-	Projections       projection.Config
-	Auth              auth_es.Config
-	Admin             admin_es.Config
-	UserAgentCookie   *middleware.UserAgentCookieConfig
-	OIDC              oidc.Config
-	Login             login.Config
-	Console           console.Config
-	Notification      notification.Config
-	AssetStorage      static_config.AssetStorageConfig
-	InternalAuthZ     internal_authz.Config
-	SystemDefaults    systemdefaults.SystemDefaults
-	EncryptionKeys    *encryptionKeyConfig
-	DefaultInstance   command.InstanceSetup
-	AuditLogRetention time.Duration
+	Log                      *logging.Config
+	Port                     uint16
+	ExternalPort             uint16
+	ExternalDomain           string
+	ExternalSecure           bool
+	HTTP2HostHeader          string
+	HTTP1HostHeader          string
+	WebAuthNName             string
+	Database                 database.Config
+	Tracing                  tracing.Config
+	Projections              projection.Config
+	Auth                     auth_es.Config
+	Admin                    admin_es.Config
+	UserAgentCookie          *middleware.UserAgentCookieConfig
+	ClientCertificate        middleware.ClientCertificateConfig
+	OIDC                     oidc.Config
+	SAML                     saml.Config
+	Login                    login.Config
+	Console                  console.Config
+	Notification             notification.Config
+	AssetStorage             static_config.AssetStorageConfig
+	InternalAuthZ            internal_authz.Config
+	SystemDefaults           systemdefaults.SystemDefaults
+	EncryptionKeys           *encryptionKeyConfig
+	DefaultInstance          command.InstanceSetup
+	AuditLogRetention        time.Duration
+	ActionExecutionRetention time.Duration
 }
 
 func MustNewConfig(v *viper.Viper) *Config {
@@ -24,7 +24,7 @@ export class ActionsComponent {
 
   public typeControl: FormControl = new FormControl(FlowType.FLOW_TYPE_EXTERNAL_AUTHENTICATION);
 
-  public typesForSelection: FlowType[] = [FlowType.FLOW_TYPE_EXTERNAL_AUTHENTICATION];
+  public typesForSelection: FlowType[] = [FlowType.FLOW_TYPE_EXTERNAL_AUTHENTICATION, FlowType.FLOW_TYPE_CUSTOMISE_TOKEN, FlowType.FLOW_TYPE_INTERNAL_AUTHENTICATION, FlowType.FLOW_TYPE_EVENT];
 
   public selection: Action.AsObject[] = [];
   public InfoSectionType: any = InfoSectionType;
@@ -16,11 +16,22 @@ export class AddFlowDialogComponent {
     public actions: Action.AsObject[] = [];
     public typesForSelection: FlowType[] = [
       FlowType.FLOW_TYPE_EXTERNAL_AUTHENTICATION,
+      FlowType.FLOW_TYPE_CUSTOMISE_TOKEN,
+      FlowType.FLOW_TYPE_INTERNAL_AUTHENTICATION,
+      FlowType.FLOW_TYPE_EVENT,
     ];
     public triggerTypesForSelection: TriggerType[] = [
       TriggerType.TRIGGER_TYPE_POST_AUTHENTICATION,
       TriggerType.TRIGGER_TYPE_POST_CREATION,
-      TriggerType.TRIGGER_TYPE_PRE_CREATION,      
+      TriggerType.TRIGGER_TYPE_PRE_CREATION,
+      TriggerType.TRIGGER_TYPE_PRE_USERINFO_CREATION,
+      TriggerType.TRIGGER_TYPE_PRE_ACCESS_TOKEN_CREATION,
+      TriggerType.TRIGGER_TYPE_HUMAN_ADDED,
+      TriggerType.TRIGGER_TYPE_HUMAN_REGISTERED,
+      TriggerType.TRIGGER_TYPE_USER_REMOVED,
+      TriggerType.TRIGGER_TYPE_USER_GRANT_ADDED,
+      TriggerType.TRIGGER_TYPE_USER_GRANT_CHANGED,
+      TriggerType.TRIGGER_TYPE_USER_GRANT_REMOVED,
     ];
     
     public form!: FormGroup;
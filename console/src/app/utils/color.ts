@@ -85,6 +85,9 @@ export function getMembershipColor(role: string): Color {
     case 'IAM_USER_MANAGER':
       color = COLORS[8];
       break;
+    case 'IAM_USER_IMPERSONATOR':
+      color = COLORS[7];
+      break;
 
     case 'ORG_OWNER':
       color = COLORS[16];
@@ -92,6 +95,9 @@ export function getMembershipColor(role: string): Color {
     case 'ORG_USER_MANAGER':
       color = COLORS[8];
       break;
+    case 'ORG_USER_IMPERSONATOR':
+      color = COLORS[7];
+      break;
     case 'ORG_OWNER_VIEWER':
       color = COLORS[14];
       break;
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/dop251/goja"
@@ -14,7 +15,37 @@ var ErrHalt = errors.New("interrupt")
 
 type jsAction func(*Context, *API) error
 
-func Run(ctx *Context, api *API, script, name string, timeout time.Duration, allowedToFail bool) error {
+type runConfig struct {
+	allowedHosts  []string
+	httpTransport http.RoundTripper
+
+	logs            []string
+	consoleOutput   *[]string
+	executionLogger ExecutionLogger
+	execution       *Execution
+}
+
+type Option func(*runConfig)
+
+// WithAllowedHosts defines the hosts the action can send requests to using the http module
+func WithAllowedHosts(hosts ...string) Option {
+	return func(c *runConfig) {
+		c.allowedHosts = hosts
+	}
+}
+
+// WithHTTPTransport replaces the transport of the http module, e.g. by a MockHTTPTransport in tests
+func WithHTTPTransport(transport http.RoundTripper) Option {
+	return func(c *runConfig) {
+		c.httpTransport = transport
+	}
+}
+
+func Run(ctx *Context, api *API, script, name string, timeout time.Duration, allowedToFail bool, opts ...Option) error {
+	config := new(runConfig)
+	for _, opt := range opts {
+		opt(config)
+	}
 	if timeout <= 0 || timeout > 20 {
 		timeout = 20 * time.Second
 	}
@@ -22,17 +53,22 @@ func Run(ctx *Context, api *API, script, name string, timeout time.Duration, all
 	if prepareTimeout > 5 {
 		prepareTimeout = 5 * time.Second
 	}
-	vm, err := prepareRun(script, prepareTimeout)
+	start := time.Now()
+	vm, err := prepareRun(script, prepareTimeout, newHTTPModule(config, time.Now().Add(timeout)), config.print)
 	if err != nil {
+		config.finish(ctx, start, err)
 		return err
 	}
 	var fn jsAction
 	jsFn := vm.Get(name)
 	if jsFn == nil {
-		return errors.New("function not found")
+		err = errors.New("function not found")
+		config.finish(ctx, start, err)
+		return err
 	}
 	err = vm.ExportTo(jsFn, &fn)
 	if err != nil {
+		config.finish(ctx, start, err)
 		return err
 	}
 	t := setInterrupt(vm, timeout)
@@ -43,44 +79,46 @@ func Run(ctx *Context, api *API, script, name string, timeout time.Duration, all
 	go func() {
 		defer func() {
 			r := recover()
-			if r != nil && !allowedToFail {
-				err, ok := r.(error)
-				if !ok {
-					e, ok := r.(string)
-					if ok {
-						err = errors.New(e)
-					}
-				}
-				errCh <- err
+			if r == nil {
 				return
 			}
-		}()
-		err = fn(ctx, api)
-		if err != nil && !allowedToFail {
+			err, ok := r.(error)
+			if !ok {
+				e, ok := r.(string)
+				if ok {
+					err = errors.New(e)
+				}
+			}
 			errCh <- err
-			return
-		}
-		errCh <- nil
+		}()
+		errCh <- fn(ctx, api)
 	}()
-	return <-errCh
+	err = <-errCh
+	config.finish(ctx, start, err)
+	if allowedToFail {
+		return nil
+	}
+	return err
 }
 
-func newRuntime() *goja.Runtime {
+func newRuntime(httpModule *httpModule, print func(string)) *goja.Runtime {
 	vm := goja.New()
 
 	printer := console.PrinterFunc(func(s string) {
 		logging.Log("ACTIONS-dfgg2").Debug(s)
+		print(s)
 	})
 	registry := new(require.Registry)
 	registry.Enable(vm)
 	registry.RegisterNativeModule("console", console.RequireWithPrinter(printer))
+	registry.RegisterNativeModule(httpModuleName, httpModule.require)
 	console.Enable(vm)
 
 	return vm
 }
 
-func prepareRun(script string, timeout time.Duration) (*goja.Runtime, error) {
-	vm := newRuntime()
+func prepareRun(script string, timeout time.Duration, httpModule *httpModule, print func(string)) (*goja.Runtime, error) {
+	vm := newRuntime(httpModule, print)
 	t := setInterrupt(vm, timeout)
 	defer func() {
 		t.Stop()
@@ -1,6 +1,9 @@
 package actions
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"golang.org/x/text/language"
 )
@@ -103,3 +106,50 @@ func (a *API) SetUserGrants(usergrants *[]UserGrant) *API {
 	a.set("userGrants", usergrants)
 	return a
 }
+
+// SetClaims allows actions to add custom claims to a token or userinfo
+// through api.v1.claims.setClaim and api.v1.claims.appendLogIntoClaims.
+// claims must contain the existing claims, reserved and existing claims cannot be overridden,
+// such attempts are recorded in logs instead.
+func (a *API) SetClaims(claims map[string]interface{}, logs *[]string) *API {
+	a.set("v1", map[string]interface{}{
+		"claims": map[string]interface{}{
+			"setClaim": func(key string, value interface{}) {
+				if isReservedClaim(key) {
+					*logs = append(*logs, fmt.Sprintf("claim %q is reserved", key))
+					return
+				}
+				if _, ok := claims[key]; ok {
+					*logs = append(*logs, fmt.Sprintf("claim %q already exists", key))
+					return
+				}
+				claims[key] = value
+			},
+			"appendLogIntoClaims": func(entry string) {
+				*logs = append(*logs, entry)
+			},
+		},
+	})
+	return a
+}
+
+const reservedClaimPrefix = "urn:zitadel:iam"
+
+var reservedClaims = map[string]struct{}{
+	"iss": {}, "sub": {}, "aud": {}, "exp": {}, "iat": {}, "nbf": {}, "jti": {},
+	"auth_time": {}, "nonce": {}, "acr": {}, "amr": {}, "azp": {},
+	"at_hash": {}, "c_hash": {}, "sid": {}, "client_id": {}, "scope": {},
+	"cnf": {}, "act": {}, "active": {}, "token_type": {}, "username": {},
+	"name": {}, "given_name": {}, "family_name": {}, "middle_name": {}, "nickname": {},
+	"preferred_username": {}, "profile": {}, "picture": {}, "website": {},
+	"email": {}, "email_verified": {}, "gender": {}, "birthdate": {}, "zoneinfo": {},
+	"locale": {}, "phone_number": {}, "phone_number_verified": {}, "address": {},
+	"updated_at": {},
+}
+
+func isReservedClaim(key string) bool {
+	if _, ok := reservedClaims[key]; ok {
+		return true
+	}
+	return strings.HasPrefix(key, reservedClaimPrefix)
+}
@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_SetClaims(t *testing.T) {
+	type args struct {
+		script string
+		claims map[string]interface{}
+	}
+	type want struct {
+		claims map[string]interface{}
+		logs   []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "new claim, set",
+			args: args{
+				script: `function setClaims(ctx, api) { api.v1.claims.setClaim('tenant_id', 'tenant1'); }`,
+				claims: map[string]interface{}{},
+			},
+			want: want{
+				claims: map[string]interface{}{"tenant_id": "tenant1"},
+				logs:   []string{},
+			},
+		},
+		{
+			name: "reserved claim, logged",
+			args: args{
+				script: `function setClaims(ctx, api) { api.v1.claims.setClaim('email', 'evil@example.com'); }`,
+				claims: map[string]interface{}{},
+			},
+			want: want{
+				claims: map[string]interface{}{},
+				logs:   []string{`claim "email" is reserved`},
+			},
+		},
+		{
+			name: "existing claim, logged",
+			args: args{
+				script: `function setClaims(ctx, api) { api.v1.claims.setClaim('tenant_id', 'tenant2'); }`,
+				claims: map[string]interface{}{"tenant_id": "tenant1"},
+			},
+			want: want{
+				claims: map[string]interface{}{"tenant_id": "tenant1"},
+				logs:   []string{`claim "tenant_id" already exists`},
+			},
+		},
+		{
+			name: "append log",
+			args: args{
+				script: `function setClaims(ctx, api) { api.v1.claims.appendLogIntoClaims('entry'); }`,
+				claims: map[string]interface{}{},
+			},
+			want: want{
+				claims: map[string]interface{}{},
+				logs:   []string{"entry"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := make([]string, 0)
+			err := Run(&Context{}, (&API{}).SetClaims(tt.args.claims, &logs), tt.args.script, "setClaims", time.Second, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.claims, tt.args.claims)
+			assert.Equal(t, tt.want.logs, logs)
+		})
+	}
+}
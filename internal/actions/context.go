@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 
 	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
 )
 
 type Context map[string]interface{}
@@ -34,3 +37,65 @@ func (c *Context) SetToken(t *oidc.Tokens) *Context {
 	}
 	return c
 }
+
+func (c *Context) SetUser(userID, resourceOwner, preferredLoginName string) *Context {
+	c.set("userID", userID)
+	c.set("resourceOwner", resourceOwner)
+	c.set("preferredLoginName", preferredLoginName)
+	return c
+}
+
+func (c *Context) SetUserMetadata(metadata map[string]string) *Context {
+	c.set("metadata", metadata)
+	c.set("getMetadata", func(key string) string { return metadata[key] })
+	return c
+}
+
+func (c *Context) SetHuman(human *domain.Human) *Context {
+	if human == nil {
+		return c
+	}
+	c.set("username", human.Username)
+	if human.Profile != nil {
+		c.set("firstName", human.FirstName)
+		c.set("lastName", human.LastName)
+		c.set("nickName", human.NickName)
+		c.set("displayName", human.DisplayName)
+		c.set("preferredLanguage", human.PreferredLanguage.String())
+		c.set("gender", human.Gender)
+	}
+	if human.Email != nil {
+		c.set("email", human.EmailAddress)
+	}
+	if human.Phone != nil {
+		c.set("phone", human.PhoneNumber)
+	}
+	return c
+}
+
+// SetEvent provides the event which triggered the action
+// secrets of the event (e.g. the password hash) are never passed to the script
+func (c *Context) SetEvent(event eventstore.Event) *Context {
+	if event == nil {
+		return c
+	}
+	c.set("eventType", string(event.Type()))
+	c.set("aggregateID", event.Aggregate().ID)
+	c.set("aggregateType", string(event.Aggregate().Type))
+	c.set("resourceOwner", event.Aggregate().ResourceOwner)
+	c.set("sequence", event.Sequence())
+	c.set("creationDate", event.CreationDate())
+	c.set("editorUser", event.EditorUser())
+	c.set("getEventData", func() (map[string]interface{}, error) {
+		data := make(map[string]interface{})
+		if len(event.DataAsBytes()) == 0 {
+			return data, nil
+		}
+		if err := json.Unmarshal(event.DataAsBytes(), &data); err != nil {
+			return nil, err
+		}
+		delete(data, "secret")
+		return data, nil
+	})
+	return c
+}
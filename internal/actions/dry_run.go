@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// DryRunResult is the outcome of an action which was run against a mocked context
+type DryRunResult struct {
+	Duration time.Duration
+	Logs     []string
+	Error    string
+	// Result contains the changes the action made through the api of the flow
+	Result map[string]interface{}
+}
+
+// DryRun runs the action against the given context values without any side effects:
+// the changes made through the api of the flow and trigger type are only returned
+// and http requests are answered by the given responses.
+// Errors of the action are returned as part of the result
+func DryRun(
+	flowType domain.FlowType,
+	triggerType domain.TriggerType,
+	values map[string]interface{},
+	script,
+	name string,
+	timeout time.Duration,
+	allowedHosts []string,
+	httpResponses map[string]*MockHTTPResponse,
+) (*DryRunResult, error) {
+	if !flowType.Valid() || !triggerType.Valid() {
+		return nil, errors.ThrowInvalidArgument(nil, "ACTIO-Quu5e", "Errors.Flow.FlowTypeMissing")
+	}
+	if !flowType.HasTrigger(triggerType) {
+		return nil, errors.ThrowInvalidArgument(nil, "ACTIO-ooR4e", "Errors.Flow.WrongTriggerType")
+	}
+	api, changes := dryRunAPI(flowType, triggerType)
+	var logs []string
+	start := time.Now()
+	err := Run(
+		mockContext(values),
+		api,
+		script,
+		name,
+		timeout,
+		false,
+		WithAllowedHosts(allowedHosts...),
+		WithHTTPTransport(&MockHTTPTransport{Responses: httpResponses}),
+		WithConsoleOutput(&logs),
+	)
+	result := &DryRunResult{
+		Duration: time.Since(start),
+		Logs:     logs,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Result, err = toJSONMap(changes)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACTIO-ahC4u", "Errors.Internal")
+	}
+	return result, nil
+}
+
+// mockContext provides the given values as context
+// the functions of the context are derived from the values (e.g. getMetadata from metadata)
+func mockContext(values map[string]interface{}) *Context {
+	ctx := make(Context, len(values))
+	for key, value := range values {
+		ctx.set(key, value)
+	}
+	if metadata, ok := values["metadata"].(map[string]interface{}); ok {
+		ctx.set("getMetadata", func(key string) interface{} { return metadata[key] })
+	}
+	if claims, ok := values["claims"].(map[string]interface{}); ok {
+		ctx.set("getClaim", func(claim string) interface{} { return claims[claim] })
+		ctx.set("claimsJSON", func() (string, error) {
+			c, err := json.Marshal(claims)
+			return string(c), err
+		})
+	}
+	if data, ok := values["eventData"].(map[string]interface{}); ok {
+		ctx.set("getEventData", func() map[string]interface{} { return data })
+	}
+	return &ctx
+}
+
+// dryRunAPI returns the api of the trigger
+// and the objects which are changed through the api
+func dryRunAPI(flowType domain.FlowType, triggerType domain.TriggerType) (*API, map[string]interface{}) {
+	api := &API{}
+	changes := make(map[string]interface{})
+	switch {
+	case flowType == domain.FlowTypeExternalAuthentication && triggerType == domain.TriggerTypePostAuthentication:
+		user := new(domain.ExternalUser)
+		metadata := make([]*domain.Metadata, 0)
+		api.SetExternalUser(user).SetMetadata(&metadata)
+		changes["externalUser"] = user
+		changes["metadata"] = &metadata
+	case triggerType == domain.TriggerTypePreCreation:
+		human := &domain.Human{Profile: new(domain.Profile)}
+		metadata := make([]*domain.Metadata, 0)
+		api.SetHuman(human).SetMetadata(&metadata)
+		changes["human"] = human
+		changes["metadata"] = &metadata
+	case triggerType == domain.TriggerTypePostCreation:
+		userGrants := make([]UserGrant, 0)
+		api.SetUserGrants(&userGrants)
+		changes["userGrants"] = &userGrants
+	case flowType == domain.FlowTypeInternalAuthentication && triggerType == domain.TriggerTypePostAuthentication:
+		metadata := make([]*domain.Metadata, 0)
+		api.SetMetadata(&metadata)
+		changes["metadata"] = &metadata
+	case flowType == domain.FlowTypeCustomiseToken:
+		claims := make(map[string]interface{})
+		claimLogs := make([]string, 0)
+		api.SetClaims(claims, &claimLogs)
+		changes["claims"] = claims
+		changes["claimLogs"] = &claimLogs
+	}
+	return api, changes
+}
+
+func toJSONMap(value interface{}) (map[string]interface{}, error) {
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]interface{})
+	if err = json.Unmarshal(marshalled, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
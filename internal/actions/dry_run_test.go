@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestDryRun(t *testing.T) {
+	type args struct {
+		flowType      domain.FlowType
+		triggerType   domain.TriggerType
+		values        map[string]interface{}
+		script        string
+		name          string
+		allowedHosts  []string
+		httpResponses map[string]*MockHTTPResponse
+	}
+	type want struct {
+		err       func(error) bool
+		result    map[string]interface{}
+		logs      []string
+		execError bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "wrong trigger type, invalid argument error",
+			args: args{
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePreCreation,
+			},
+			want: want{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "claims set with mocked http response, ok",
+			args: args{
+				flowType:    domain.FlowTypeCustomiseToken,
+				triggerType: domain.TriggerTypePreUserinfoCreation,
+				values: map[string]interface{}{
+					"userID":   "user1",
+					"metadata": map[string]interface{}{"tenant": "t1"},
+				},
+				script: `function addClaims(ctx, api) {
+	let http = require('zitadel/http');
+	let resp = http.fetch('https://roles.example.com/' + ctx.userID);
+	console.log('status', resp.status);
+	api.v1.claims.setClaim('tenant', ctx.getMetadata('tenant'));
+	api.v1.claims.setClaim('roles', resp.json().roles);
+	api.v1.claims.setClaim('sub', 'other');
+}`,
+				name:         "addClaims",
+				allowedHosts: []string{"roles.example.com"},
+				httpResponses: map[string]*MockHTTPResponse{
+					"https://roles.example.com/user1": {
+						StatusCode: http.StatusOK,
+						Body:       `{"roles": ["admin"]}`,
+					},
+				},
+			},
+			want: want{
+				err: func(err error) bool { return err == nil },
+				result: map[string]interface{}{
+					"claims": map[string]interface{}{
+						"tenant": "t1",
+						"roles":  []interface{}{"admin"},
+					},
+					"claimLogs": []interface{}{`claim "sub" is reserved`},
+				},
+				logs: []string{"status 200"},
+			},
+		},
+		{
+			name: "action fails, error in result",
+			args: args{
+				flowType:    domain.FlowTypeInternalAuthentication,
+				triggerType: domain.TriggerTypePostCreation,
+				script: `function grant(ctx, api) {
+	api.userGrants.push({ProjectID: 'project1', Roles: ['role1']});
+	throw new Error('failed');
+}`,
+				name: "grant",
+			},
+			want: want{
+				err: func(err error) bool { return err == nil },
+				result: map[string]interface{}{
+					"userGrants": []interface{}{
+						map[string]interface{}{"ProjectID": "project1", "ProjectGrantID": "", "Roles": []interface{}{"role1"}},
+					},
+				},
+				execError: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DryRun(tt.args.flowType, tt.args.triggerType, tt.args.values, tt.args.script, tt.args.name, 10*time.Second, tt.args.allowedHosts, tt.args.httpResponses)
+			if !tt.want.err(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want.result, got.Result)
+			assert.Equal(t, tt.want.logs, got.Logs)
+			assert.Equal(t, tt.want.execError, got.Error != "")
+		})
+	}
+}
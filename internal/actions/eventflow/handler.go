@@ -0,0 +1,167 @@
+package eventflow
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	HandlerName = "action_event_flows"
+)
+
+type Queries interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error)
+	ActionsHTTPAllowList(ctx context.Context) (*query.ActionsHTTPAllowList, error)
+}
+
+// Handler queues the events which trigger actions of the event flow
+// the events are reduced in order of their sequence and the job is stored in the same transaction as the current sequence,
+// so each event is queued exactly once and the projection is never blocked by the actions
+// the actions are executed by the worker outside of the projection
+type Handler struct {
+	crdb.StatementHandler
+	queries          Queries
+	actionExecutions actions.ExecutionLogger
+}
+
+func Start(ctx context.Context, config crdb.StatementHandlerConfig, queries Queries, actionExecutions actions.ExecutionLogger) *Handler {
+	h := &Handler{
+		queries:          queries,
+		actionExecutions: actionExecutions,
+	}
+	config.ProjectionName = HandlerName
+	config.Reducers = h.reducers()
+	h.StatementHandler = crdb.NewStatementHandler(ctx, config)
+
+	w := &worker{
+		client:           config.Client,
+		execute:          h.executeActions,
+		maxFailureCount:  config.MaxFailureCount,
+		retryFailedAfter: config.RetryFailedAfter,
+	}
+	go w.run(ctx)
+	return h
+}
+
+func (h *Handler) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  user.HumanAddedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeHumanAdded),
+				},
+				{
+					Event:  user.HumanRegisteredType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeHumanRegistered),
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserRemoved),
+				},
+			},
+		},
+		{
+			Aggregate: usergrant.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  usergrant.UserGrantAddedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserGrantAdded),
+				},
+				{
+					Event:  usergrant.UserGrantChangedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserGrantChanged),
+				},
+				{
+					Event:  usergrant.UserGrantCascadeChangedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserGrantChanged),
+				},
+				{
+					Event:  usergrant.UserGrantRemovedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserGrantRemoved),
+				},
+				{
+					Event:  usergrant.UserGrantCascadeRemovedType,
+					Reduce: h.reduceTrigger(domain.TriggerTypeUserGrantRemoved),
+				},
+			},
+		},
+	}
+}
+
+// reduceTrigger queues the event if the organisation has active actions for the trigger
+func (h *Handler) reduceTrigger(triggerType domain.TriggerType) handler.Reduce {
+	return func(event eventstore.Event) (*handler.Statement, error) {
+		ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+		triggerActions, err := h.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeEvent, triggerType, event.Aggregate().ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if len(triggerActions) == 0 {
+			return crdb.NewNoOpStatement(event), nil
+		}
+		return crdb.NewCreateStatement(
+			event,
+			[]handler.Column{
+				handler.NewCol(JobInstanceIDCol, event.Aggregate().InstanceID),
+				handler.NewCol(JobAggregateTypeCol, event.Aggregate().Type),
+				handler.NewCol(JobAggregateIDCol, event.Aggregate().ID),
+				handler.NewCol(JobResourceOwnerCol, event.Aggregate().ResourceOwner),
+				handler.NewCol(JobSequenceCol, event.Sequence()),
+				handler.NewCol(JobEventTypeCol, event.Type()),
+				handler.NewCol(JobEventDataCol, event.DataAsBytes()),
+				handler.NewCol(JobCreationDateCol, event.CreationDate()),
+				handler.NewCol(JobEditorUserCol, event.EditorUser()),
+				handler.NewCol(JobTriggerTypeCol, triggerType),
+			},
+			crdb.WithTableName(jobsTable),
+		), nil
+	}
+}
+
+func (h *Handler) executeActions(triggerType domain.TriggerType, event eventstore.Event) error {
+	ctx := authz.WithInstanceID(context.Background(), event.Aggregate().InstanceID)
+	triggerActions, err := h.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeEvent, triggerType, event.Aggregate().ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if len(triggerActions) == 0 {
+		return nil
+	}
+	allowList, err := h.queries.ActionsHTTPAllowList(ctx)
+	if err != nil {
+		return err
+	}
+	actionCtx := (&actions.Context{}).SetEvent(event)
+	for _, a := range triggerActions {
+		execution := &actions.Execution{
+			InstanceID:    event.Aggregate().InstanceID,
+			ResourceOwner: event.Aggregate().ResourceOwner,
+			ActionID:      a.ID,
+			FlowType:      domain.FlowTypeEvent,
+			TriggerType:   triggerType,
+		}
+		err = actions.Run(actionCtx, &actions.API{}, a.Script, a.Name, a.Timeout, a.AllowedToFail,
+			actions.WithAllowedHosts(allowList.Hosts...),
+			actions.WithExecutionLogger(h.actionExecutions, execution),
+		)
+		if err != nil {
+			logging.WithFields("action", a.ID, "eventType", event.Type(), "sequence", event.Sequence(), "instanceID", event.Aggregate().InstanceID).WithError(err).Warn("event flow action failed")
+			return errors.ThrowInternalf(err, "FLOW-Aeb3u", "action %s failed", a.ID)
+		}
+	}
+	return nil
+}
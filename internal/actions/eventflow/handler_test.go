@@ -0,0 +1,253 @@
+package eventflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const checkEventScript = `function checkEvent(ctx, api) {
+	let data = ctx.getEventData();
+	if (ctx.eventType !== 'user.human.added' || ctx.resourceOwner !== 'ro-id' || data.userName !== 'username') {
+		throw new Error('unexpected event');
+	}
+	if (data.secret !== undefined) {
+		throw new Error('secret must not be provided');
+	}
+}`
+
+const failScript = `function fail(ctx, api) {
+	throw new Error('downstream not available');
+}`
+
+var errQuery = errors.New("query failed")
+
+type mockQueries struct {
+	actions      []*query.Action
+	actionsErr   error
+	allowListErr error
+
+	flowType    domain.FlowType
+	triggerType domain.TriggerType
+	orgID       string
+	instanceID  string
+}
+
+func (q *mockQueries) GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error) {
+	q.flowType = flowType
+	q.triggerType = triggerType
+	q.orgID = orgID
+	q.instanceID = authz.GetInstance(ctx).InstanceID()
+	return q.actions, q.actionsErr
+}
+
+func (q *mockQueries) ActionsHTTPAllowList(context.Context) (*query.ActionsHTTPAllowList, error) {
+	if q.allowListErr != nil {
+		return nil, q.allowListErr
+	}
+	return &query.ActionsHTTPAllowList{Hosts: []string{}}, nil
+}
+
+func humanAddedEvent() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		Sequence:                      15,
+		PreviousAggregateSequence:     10,
+		PreviousAggregateTypeSequence: 10,
+		CreationDate:                  time.Now(),
+		Type:                          repository.EventType(user.HumanAddedType),
+		AggregateType:                 repository.AggregateType(user.AggregateType),
+		Data:                          []byte(`{"userName": "username", "secret": {"cryptoType": 1}}`),
+		Version:                       "v1",
+		AggregateID:                   "agg-id",
+		ResourceOwner:                 sql.NullString{String: "ro-id", Valid: true},
+		InstanceID:                    "instance-id",
+		EditorService:                 "editor-svc",
+		EditorUser:                    "editor-user",
+	})
+}
+
+func TestHandler_executeActions(t *testing.T) {
+	type args struct {
+		queries *mockQueries
+	}
+	type want struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "no actions, ok",
+			args: args{
+				queries: &mockQueries{},
+			},
+			want: want{
+				err: func(err error) bool { return err == nil },
+			},
+		},
+		{
+			name: "query actions fails, error",
+			args: args{
+				queries: &mockQueries{
+					actionsErr: errQuery,
+				},
+			},
+			want: want{
+				err: func(err error) bool { return errors.Is(err, errQuery) },
+			},
+		},
+		{
+			name: "query allow list fails, error",
+			args: args{
+				queries: &mockQueries{
+					actions:      []*query.Action{{ID: "action1", Name: "checkEvent", Script: checkEventScript}},
+					allowListErr: errQuery,
+				},
+			},
+			want: want{
+				err: func(err error) bool { return errors.Is(err, errQuery) },
+			},
+		},
+		{
+			name: "event provided to action, ok",
+			args: args{
+				queries: &mockQueries{
+					actions: []*query.Action{{ID: "action1", Name: "checkEvent", Script: checkEventScript, Timeout: 10 * time.Second}},
+				},
+			},
+			want: want{
+				err: func(err error) bool { return err == nil },
+			},
+		},
+		{
+			name: "action fails, error",
+			args: args{
+				queries: &mockQueries{
+					actions: []*query.Action{{ID: "action1", Name: "fail", Script: failScript, Timeout: 10 * time.Second}},
+				},
+			},
+			want: want{
+				err: func(err error) bool { return err != nil },
+			},
+		},
+		{
+			name: "action allowed to fail, ok",
+			args: args{
+				queries: &mockQueries{
+					actions: []*query.Action{{ID: "action1", Name: "fail", Script: failScript, Timeout: 10 * time.Second, AllowedToFail: true}},
+				},
+			},
+			want: want{
+				err: func(err error) bool { return err == nil },
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{queries: tt.args.queries}
+			err := h.executeActions(domain.TriggerTypeHumanAdded, humanAddedEvent())
+			if !tt.want.err(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.args.queries.flowType != domain.FlowTypeEvent || tt.args.queries.triggerType != domain.TriggerTypeHumanAdded {
+				t.Errorf("wrong flow or trigger type: %v %v", tt.args.queries.flowType, tt.args.queries.triggerType)
+			}
+			if tt.args.queries.orgID != "ro-id" || tt.args.queries.instanceID != "instance-id" {
+				t.Errorf("wrong org or instance: %s %s", tt.args.queries.orgID, tt.args.queries.instanceID)
+			}
+		})
+	}
+}
+
+type mockExecuter struct {
+	stmt string
+	args []interface{}
+}
+
+func (ex *mockExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	ex.stmt = stmt
+	ex.args = args
+	return nil, nil
+}
+
+func TestHandler_reduceTrigger(t *testing.T) {
+	event := humanAddedEvent()
+	tests := []struct {
+		name     string
+		queries  *mockQueries
+		wantErr  error
+		wantStmt string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "query actions fails, error",
+			queries: &mockQueries{actionsErr: errQuery},
+			wantErr: errQuery,
+		},
+		{
+			name:    "no actions, not queued",
+			queries: &mockQueries{},
+		},
+		{
+			name: "actions, queued",
+			queries: &mockQueries{
+				actions: []*query.Action{{ID: "action1", Name: "checkEvent", Script: checkEventScript}},
+			},
+			wantStmt: "INSERT INTO system.action_event_flow_jobs" +
+				" (instance_id, aggregate_type, aggregate_id, resource_owner, sequence, event_type, event_data, creation_date, editor_user, trigger_type)" +
+				" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+			wantArgs: []interface{}{
+				"instance-id",
+				eventstore.AggregateType(user.AggregateType),
+				"agg-id",
+				"ro-id",
+				uint64(15),
+				user.HumanAddedType,
+				event.DataAsBytes(),
+				event.CreationDate(),
+				"editor-user",
+				domain.TriggerTypeHumanAdded,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{queries: tt.queries}
+			stmt, err := h.reduceTrigger(domain.TriggerTypeHumanAdded)(event)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if stmt.Sequence != 15 {
+				t.Errorf("wrong sequence: %d", stmt.Sequence)
+			}
+			if tt.wantStmt == "" {
+				if stmt.Execute != nil {
+					t.Error("statement must not execute")
+				}
+				return
+			}
+			ex := new(mockExecuter)
+			if err := stmt.Execute(ex, HandlerName); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, tt.wantStmt, ex.stmt)
+			assert.Equal(t, tt.wantArgs, ex.args)
+		})
+	}
+}
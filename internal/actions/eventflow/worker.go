@@ -0,0 +1,216 @@
+package eventflow
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	jobsTable           = "system.action_event_flow_jobs"
+	failedJobsTable     = "system.action_event_flow_failed_jobs"
+	JobInstanceIDCol    = "instance_id"
+	JobAggregateTypeCol = "aggregate_type"
+	JobAggregateIDCol   = "aggregate_id"
+	JobResourceOwnerCol = "resource_owner"
+	JobSequenceCol      = "sequence"
+	JobEventTypeCol     = "event_type"
+	JobEventDataCol     = "event_data"
+	JobCreationDateCol  = "creation_date"
+	JobEditorUserCol    = "editor_user"
+	JobTriggerTypeCol   = "trigger_type"
+	JobFailureCountCol  = "failure_count"
+	JobErrorCol         = "error"
+	JobLockedUntilCol   = "locked_until"
+
+	FailedJobFailedAtCol = "failed_at"
+
+	jobEventCols = JobInstanceIDCol + ", " + JobAggregateTypeCol + ", " + JobAggregateIDCol + ", " + JobResourceOwnerCol + ", " +
+		JobSequenceCol + ", " + JobEventTypeCol + ", " + JobEventDataCol + ", " + JobCreationDateCol + ", " + JobEditorUserCol + ", " +
+		JobTriggerTypeCol
+)
+
+const (
+	jobPollInterval = time.Second
+	// jobLockDuration must exceed the time needed to execute all actions of a job,
+	// otherwise the job is executed by another worker as well
+	jobLockDuration = 5 * time.Minute
+	jobTimeout      = 5 * time.Second
+)
+
+const (
+	// claimJobStmt locks the oldest job of an instance, if no earlier job of the instance is locked or waiting for its retry,
+	// so the jobs of an instance are executed in order by one worker at a time
+	claimJobStmt = "UPDATE " + jobsTable + " SET " + JobLockedUntilCol + " = $1" +
+		" WHERE " + JobLockedUntilCol + " < $2 AND (" + JobInstanceIDCol + ", " + JobAggregateTypeCol + ", " + JobSequenceCol + ") = (" +
+		"SELECT j." + JobInstanceIDCol + ", j." + JobAggregateTypeCol + ", j." + JobSequenceCol + " FROM " + jobsTable + " j" +
+		" WHERE j." + JobLockedUntilCol + " < $2 AND NOT EXISTS (SELECT 1 FROM " + jobsTable + " e" +
+		" WHERE e." + JobInstanceIDCol + " = j." + JobInstanceIDCol +
+		" AND (e." + JobCreationDateCol + ", e." + JobSequenceCol + ") < (j." + JobCreationDateCol + ", j." + JobSequenceCol + "))" +
+		" ORDER BY j." + JobCreationDateCol + ", j." + JobSequenceCol + " LIMIT 1)" +
+		" RETURNING " + JobInstanceIDCol + ", " + JobAggregateTypeCol + ", " + JobAggregateIDCol + ", " + JobResourceOwnerCol + ", " +
+		JobSequenceCol + ", " + JobEventTypeCol + ", " + JobEventDataCol + ", " + JobCreationDateCol + ", " + JobEditorUserCol + ", " +
+		JobTriggerTypeCol + ", " + JobFailureCountCol
+	removeJobStmt = "DELETE FROM " + jobsTable +
+		" WHERE " + JobInstanceIDCol + " = $1 AND " + JobAggregateTypeCol + " = $2 AND " + JobSequenceCol + " = $3"
+	failJobStmt = "UPDATE " + jobsTable + " SET " + JobFailureCountCol + " = " + JobFailureCountCol + " + 1, " +
+		JobErrorCol + " = $1, " + JobLockedUntilCol + " = $2" +
+		" WHERE " + JobInstanceIDCol + " = $3 AND " + JobAggregateTypeCol + " = $4 AND " + JobSequenceCol + " = $5"
+	// moveFailedJobStmt records the job which failed too often, the job is removed afterwards by removeJobStmt
+	moveFailedJobStmt = "INSERT INTO " + failedJobsTable + " (" + jobEventCols + ", " + JobFailureCountCol + ", " + JobErrorCol + ", " + FailedJobFailedAtCol + ")" +
+		" SELECT " + jobEventCols + ", " + JobFailureCountCol + " + 1, $1, $2 FROM " + jobsTable +
+		" WHERE " + JobInstanceIDCol + " = $3 AND " + JobAggregateTypeCol + " = $4 AND " + JobSequenceCol + " = $5"
+)
+
+// worker executes the actions of the queued events
+// a job is locked while it's executed, so it's only executed by one worker of all instances of ZITADEL
+// the jobs of an instance are executed in order, a failed job is retried after retryFailedAfter before later jobs are executed
+// after maxFailureCount failures the job is moved to the failed jobs, where it can be inspected and queued again
+type worker struct {
+	client           *sql.DB
+	execute          func(domain.TriggerType, eventstore.Event) error
+	maxFailureCount  uint
+	retryFailedAfter time.Duration
+}
+
+type job struct {
+	instanceID    string
+	aggregateType string
+	aggregateID   string
+	resourceOwner string
+	sequence      uint64
+	eventType     string
+	eventData     []byte
+	creationDate  time.Time
+	editorUser    string
+	triggerType   domain.TriggerType
+	failureCount  uint
+}
+
+func (j *job) event() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		Sequence:      j.sequence,
+		CreationDate:  j.creationDate,
+		Type:          repository.EventType(j.eventType),
+		Data:          j.eventData,
+		EditorUser:    j.editorUser,
+		AggregateID:   j.aggregateID,
+		AggregateType: repository.AggregateType(j.aggregateType),
+		ResourceOwner: sql.NullString{String: j.resourceOwner, Valid: true},
+		InstanceID:    j.instanceID,
+	})
+}
+
+func (w *worker) run(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.executeJobs(ctx)
+		}
+	}
+}
+
+// executeJobs executes the queued jobs until none is left or the context is done
+func (w *worker) executeJobs(ctx context.Context) {
+	for ctx.Err() == nil {
+		j, err := w.claimJob(ctx)
+		if err != nil {
+			logging.New().WithError(err).Warn("unable to claim event flow job")
+			return
+		}
+		if j == nil {
+			return
+		}
+		err = w.execute(j.triggerType, j.event())
+		err = w.finishJob(ctx, j, err)
+		logging.WithFields("instanceID", j.instanceID, "sequence", j.sequence).OnError(err).Warn("unable to finish event flow job")
+	}
+}
+
+func (w *worker) claimJob(ctx context.Context) (*job, error) {
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	now := time.Now()
+	j := new(job)
+	err := w.client.QueryRowContext(ctx, claimJobStmt, now.Add(jobLockDuration), now).Scan(
+		&j.instanceID,
+		&j.aggregateType,
+		&j.aggregateID,
+		&j.resourceOwner,
+		&j.sequence,
+		&j.eventType,
+		&j.eventData,
+		&j.creationDate,
+		&j.editorUser,
+		&j.triggerType,
+		&j.failureCount,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "FLOW-ooZ4a", "Errors.Internal")
+	}
+	return j, nil
+}
+
+// finishJob removes the job if the actions succeeded or moves it to the failed jobs if the max failure count is reached
+// otherwise the failure is recorded and the job is retried after retryFailedAfter
+func (w *worker) finishJob(ctx context.Context, j *job, execErr error) error {
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	if execErr == nil {
+		_, err := w.client.ExecContext(ctx, removeJobStmt, j.instanceID, j.aggregateType, j.sequence)
+		if err != nil {
+			return caos_errors.ThrowInternal(err, "FLOW-Ue3ah", "Errors.Internal")
+		}
+		return nil
+	}
+	if j.failureCount+1 < w.maxFailureCount {
+		_, err := w.client.ExecContext(ctx, failJobStmt, execErr.Error(), time.Now().Add(w.retryFailedAfter), j.instanceID, j.aggregateType, j.sequence)
+		if err != nil {
+			return caos_errors.ThrowInternal(err, "FLOW-eeS5u", "Errors.Internal")
+		}
+		return nil
+	}
+	logging.WithFields("instanceID", j.instanceID, "eventType", j.eventType, "sequence", j.sequence).WithError(execErr).Error("event flow job failed too often, moved to failed jobs")
+	return w.moveFailedJob(ctx, j, execErr)
+}
+
+func (w *worker) moveFailedJob(ctx context.Context, j *job, execErr error) (err error) {
+	tx, err := w.client.BeginTx(ctx, nil)
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "FLOW-Zee9a", "Errors.Internal")
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback()
+			logging.OnError(rollbackErr).Debug("rollback failed")
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			err = caos_errors.ThrowInternal(err, "FLOW-Lah4o", "Errors.Internal")
+		}
+	}()
+	if _, err = tx.ExecContext(ctx, moveFailedJobStmt, execErr.Error(), time.Now(), j.instanceID, j.aggregateType, j.sequence); err != nil {
+		return caos_errors.ThrowInternal(err, "FLOW-aeX2i", "Errors.Internal")
+	}
+	if _, err = tx.ExecContext(ctx, removeJobStmt, j.instanceID, j.aggregateType, j.sequence); err != nil {
+		return caos_errors.ThrowInternal(err, "FLOW-Eib1o", "Errors.Internal")
+	}
+	return nil
+}
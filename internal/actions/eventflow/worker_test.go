@@ -0,0 +1,164 @@
+package eventflow
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+var jobCols = []string{
+	"instance_id",
+	"aggregate_type",
+	"aggregate_id",
+	"resource_owner",
+	"sequence",
+	"event_type",
+	"event_data",
+	"creation_date",
+	"editor_user",
+	"trigger_type",
+	"failure_count",
+}
+
+func expectClaimJob(m sqlmock.Sqlmock, failureCount uint) {
+	m.ExpectQuery(regexp.QuoteMeta(claimJobStmt)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(jobCols).AddRow(
+			"instance-id",
+			string(user.AggregateType),
+			"agg-id",
+			"ro-id",
+			uint64(15),
+			string(user.HumanAddedType),
+			[]byte(`{"userName": "username"}`),
+			time.Now(),
+			"editor-user",
+			domain.TriggerTypeHumanAdded,
+			failureCount,
+		))
+}
+
+func expectNoJob(m sqlmock.Sqlmock) {
+	m.ExpectQuery(regexp.QuoteMeta(claimJobStmt)).
+		WillReturnRows(sqlmock.NewRows(jobCols))
+}
+
+func TestWorker_executeJobs(t *testing.T) {
+	tests := []struct {
+		name         string
+		executeErr   error
+		expect       func(sqlmock.Sqlmock)
+		wantExecuted int
+	}{
+		{
+			name:   "no jobs",
+			expect: expectNoJob,
+		},
+		{
+			name: "job succeeds, removed",
+			expect: func(m sqlmock.Sqlmock) {
+				expectClaimJob(m, 0)
+				m.ExpectExec(regexp.QuoteMeta(removeJobStmt)).
+					WithArgs("instance-id", string(user.AggregateType), uint64(15)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectNoJob(m)
+			},
+			wantExecuted: 1,
+		},
+		{
+			name:       "job fails, retried later",
+			executeErr: errQuery,
+			expect: func(m sqlmock.Sqlmock) {
+				expectClaimJob(m, 0)
+				m.ExpectExec(regexp.QuoteMeta(failJobStmt)).
+					WithArgs(errQuery.Error(), sqlmock.AnyArg(), "instance-id", string(user.AggregateType), uint64(15)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectNoJob(m)
+			},
+			wantExecuted: 1,
+		},
+		{
+			name:       "job fails too often, moved to failed jobs",
+			executeErr: errQuery,
+			expect: func(m sqlmock.Sqlmock) {
+				expectClaimJob(m, 4)
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(moveFailedJobStmt)).
+					WithArgs(errQuery.Error(), sqlmock.AnyArg(), "instance-id", string(user.AggregateType), uint64(15)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectExec(regexp.QuoteMeta(removeJobStmt)).
+					WithArgs("instance-id", string(user.AggregateType), uint64(15)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				m.ExpectCommit()
+				expectNoJob(m)
+			},
+			wantExecuted: 1,
+		},
+		{
+			name:       "moving failed job fails, rolled back",
+			executeErr: errQuery,
+			expect: func(m sqlmock.Sqlmock) {
+				expectClaimJob(m, 4)
+				m.ExpectBegin()
+				m.ExpectExec(regexp.QuoteMeta(moveFailedJobStmt)).
+					WithArgs(errQuery.Error(), sqlmock.AnyArg(), "instance-id", string(user.AggregateType), uint64(15)).
+					WillReturnError(errQuery)
+				m.ExpectRollback()
+				expectNoJob(m)
+			},
+			wantExecuted: 1,
+		},
+		{
+			name: "claim fails",
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(claimJobStmt)).
+					WillReturnError(errQuery)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("unable to create sql mock: %v", err)
+			}
+			defer client.Close()
+			tt.expect(mock)
+
+			var executed []eventstore.Event
+			w := &worker{
+				client: client,
+				execute: func(triggerType domain.TriggerType, event eventstore.Event) error {
+					assert.Equal(t, domain.TriggerTypeHumanAdded, triggerType)
+					executed = append(executed, event)
+					return tt.executeErr
+				},
+				maxFailureCount:  5,
+				retryFailedAfter: time.Second,
+			}
+			w.executeJobs(context.Background())
+
+			if assert.Len(t, executed, tt.wantExecuted) && tt.wantExecuted > 0 {
+				event := executed[0]
+				assert.Equal(t, user.HumanAddedType, event.Type())
+				assert.Equal(t, "agg-id", event.Aggregate().ID)
+				assert.Equal(t, "ro-id", event.Aggregate().ResourceOwner)
+				assert.Equal(t, "instance-id", event.Aggregate().InstanceID)
+				assert.Equal(t, uint64(15), event.Sequence())
+				assert.Equal(t, "editor-user", event.EditorUser())
+				assert.JSONEq(t, `{"userName": "username"}`, string(event.DataAsBytes()))
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
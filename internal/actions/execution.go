@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	maxExecutionInputLength = 2048
+	maxConsoleOutputEntries = 100
+)
+
+// loggedInputs are the properties of the context which are stored in the execution log
+// personal data (e.g. email, phone, metadata) and tokens are never stored
+var loggedInputs = map[string]struct{}{
+	"userID":        {},
+	"resourceOwner": {},
+	"eventType":     {},
+	"aggregateID":   {},
+	"aggregateType": {},
+	"sequence":      {},
+	"creationDate":  {},
+	"editorUser":    {},
+}
+
+// Execution describes a single run of an action
+// the identifying fields are set by the caller, the rest is filled by Run
+type Execution struct {
+	InstanceID    string
+	ResourceOwner string
+	ActionID      string
+	FlowType      domain.FlowType
+	TriggerType   domain.TriggerType
+
+	CreationDate time.Time
+	Duration     time.Duration
+	Logs         []string
+	Error        string
+	Input        string
+}
+
+// ExecutionLogger persists the executions of actions
+type ExecutionLogger interface {
+	LogExecution(execution *Execution)
+}
+
+// WithExecutionLogger passes the execution to the logger after the action was run,
+// errors are recorded even if the action is allowed to fail
+func WithExecutionLogger(logger ExecutionLogger, execution *Execution) Option {
+	return func(c *runConfig) {
+		if logger == nil || execution == nil {
+			return
+		}
+		c.executionLogger = logger
+		c.execution = execution
+	}
+}
+
+// WithConsoleOutput returns the output of console.log, console.warn and console.error
+func WithConsoleOutput(output *[]string) Option {
+	return func(c *runConfig) {
+		c.consoleOutput = output
+	}
+}
+
+// print collects the console output of the action
+// the number of entries is limited to keep the execution log small
+func (c *runConfig) print(s string) {
+	if len(c.logs) >= maxConsoleOutputEntries {
+		return
+	}
+	c.logs = append(c.logs, s)
+}
+
+func (c *runConfig) finish(ctx *Context, start time.Time, err error) {
+	if c.consoleOutput != nil {
+		*c.consoleOutput = c.logs
+	}
+	if c.executionLogger == nil {
+		return
+	}
+	c.execution.CreationDate = start
+	c.execution.Duration = time.Since(start)
+	c.execution.Input = executionInput(ctx)
+	c.execution.Logs = c.logs
+	if err != nil {
+		c.execution.Error = err.Error()
+	}
+	c.executionLogger.LogExecution(c.execution)
+}
+
+// executionInput returns the allowed properties of the context as JSON
+// the result is truncated
+func executionInput(ctx *Context) string {
+	if ctx == nil {
+		return ""
+	}
+	input := make(map[string]interface{}, len(*ctx))
+	for key, value := range *ctx {
+		if _, ok := loggedInputs[key]; !ok {
+			continue
+		}
+		input[key] = value
+	}
+	marshalled, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	if len(marshalled) > maxExecutionInputLength {
+		return strings.ToValidUTF8(string(marshalled[:maxExecutionInputLength]), "")
+	}
+	return string(marshalled)
+}
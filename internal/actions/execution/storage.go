@@ -0,0 +1,305 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ actions.ExecutionLogger = (*Storage)(nil)
+
+const (
+	executionsTable           = "system.action_executions"
+	ExecutionColID            = "id"
+	ExecutionColInstanceID    = "instance_id"
+	ExecutionColResourceOwner = "resource_owner"
+	ExecutionColActionID      = "action_id"
+	ExecutionColFlowType      = "flow_type"
+	ExecutionColTriggerType   = "trigger_type"
+	ExecutionColCreationDate  = "creation_date"
+	ExecutionColDuration      = "duration"
+	ExecutionColLogs          = "logs"
+	ExecutionColError         = "error"
+	ExecutionColInput         = "input"
+	executionColCount         = "COUNT(*) OVER ()"
+)
+
+const (
+	logExecutionTimeout        = 5 * time.Second
+	executionQueueSize         = 1000
+	executionBatchSize         = 100
+	executionFlushInterval     = time.Second
+	removeExpiredInterval      = time.Hour
+	defaultExecutionQueryLimit = 100
+	maxExecutionQueryLimit     = 1000
+)
+
+// Storage persists the executions of actions per organisation
+// executions are queued and written in batches by the worker started with Start,
+// executions older than the retention are removed periodically
+type Storage struct {
+	client     *sql.DB
+	retention  time.Duration
+	executions chan *actions.Execution
+}
+
+type Execution struct {
+	ID           string
+	ActionID     string
+	FlowType     domain.FlowType
+	TriggerType  domain.TriggerType
+	CreationDate time.Time
+	Duration     time.Duration
+	Logs         []string
+	Error        string
+	Input        string
+}
+
+type Executions struct {
+	Count      uint64
+	Executions []*Execution
+}
+
+type SearchQuery struct {
+	Offset   uint64
+	Limit    uint64
+	Asc      bool
+	ActionID string
+}
+
+func NewStorage(client *sql.DB, retention time.Duration) *Storage {
+	return &Storage{
+		client:     client,
+		retention:  retention,
+		executions: make(chan *actions.Execution, executionQueueSize),
+	}
+}
+
+// LogExecution implements actions.ExecutionLogger
+// the execution is only queued, so the flow which executed the action is never blocked
+// if the queue is full the execution is dropped
+func (s *Storage) LogExecution(execution *actions.Execution) {
+	select {
+	case s.executions <- execution:
+	default:
+		logging.WithFields("instanceID", execution.InstanceID, "action", execution.ActionID).Warn("action execution queue full, execution not logged")
+	}
+}
+
+// Start runs the worker which writes the queued executions and removes the expired ones
+// the remaining executions are written when the context is done
+func (s *Storage) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Storage) run(ctx context.Context) {
+	flush := time.NewTicker(executionFlushInterval)
+	defer flush.Stop()
+	var expired <-chan time.Time
+	if s.retention > 0 {
+		removeExpired := time.NewTicker(removeExpiredInterval)
+		defer removeExpired.Stop()
+		expired = removeExpired.C
+	}
+
+	batch := make([]*actions.Execution, 0, executionBatchSize)
+	for {
+		select {
+		case execution := <-s.executions:
+			batch = append(batch, execution)
+			if len(batch) < executionBatchSize {
+				continue
+			}
+		case <-flush.C:
+		case <-expired:
+			s.removeExpired()
+			continue
+		case <-ctx.Done():
+			s.flush(s.drain(batch))
+			return
+		}
+		batch = s.flush(batch)
+	}
+}
+
+// drain appends the queued executions to the batch without waiting for new ones
+func (s *Storage) drain(batch []*actions.Execution) []*actions.Execution {
+	for {
+		select {
+		case execution := <-s.executions:
+			batch = append(batch, execution)
+		default:
+			return batch
+		}
+	}
+}
+
+// flush writes the batch and returns it emptied
+// failures are only logged, because they must not affect the flows which executed the actions
+func (s *Storage) flush(batch []*actions.Execution) []*actions.Execution {
+	for start := 0; start < len(batch); start += executionBatchSize {
+		end := start + executionBatchSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), logExecutionTimeout)
+		err := s.insert(ctx, batch[start:end])
+		cancel()
+		logging.WithFields("executions", end-start).OnError(err).Warn("unable to log action executions")
+	}
+	return batch[:0]
+}
+
+func (s *Storage) insert(ctx context.Context, executions []*actions.Execution) error {
+	builder := sq.Insert(executionsTable).
+		Columns(
+			ExecutionColInstanceID,
+			ExecutionColResourceOwner,
+			ExecutionColActionID,
+			ExecutionColFlowType,
+			ExecutionColTriggerType,
+			ExecutionColCreationDate,
+			ExecutionColDuration,
+			ExecutionColLogs,
+			ExecutionColError,
+			ExecutionColInput,
+		).
+		PlaceholderFormat(sq.Dollar)
+	for _, execution := range executions {
+		builder = builder.Values(
+			execution.InstanceID,
+			execution.ResourceOwner,
+			execution.ActionID,
+			execution.FlowType,
+			execution.TriggerType,
+			execution.CreationDate,
+			int64(execution.Duration),
+			pq.StringArray(execution.Logs),
+			execution.Error,
+			execution.Input,
+		)
+	}
+	stmt, args, err := builder.ToSql()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "EXECU-Ohx5e", "Errors.Internal")
+	}
+	if _, err = s.client.ExecContext(ctx, stmt, args...); err != nil {
+		return caos_errors.ThrowInternal(err, "EXECU-aiT8u", "Errors.Internal")
+	}
+	return nil
+}
+
+// removeExpired deletes the executions of all instances which are older than the retention
+func (s *Storage) removeExpired() {
+	ctx, cancel := context.WithTimeout(context.Background(), logExecutionTimeout)
+	defer cancel()
+	err := s.deleteExpired(ctx, time.Now().Add(-s.retention))
+	logging.OnError(err).Warn("unable to remove expired action executions")
+}
+
+func (s *Storage) deleteExpired(ctx context.Context, before time.Time) error {
+	stmt, args, err := sq.Delete(executionsTable).
+		Where(sq.Lt{
+			ExecutionColCreationDate: before,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return caos_errors.ThrowInternal(err, "EXECU-Eiph4", "Errors.Internal")
+	}
+	if _, err = s.client.ExecContext(ctx, stmt, args...); err != nil {
+		return caos_errors.ThrowInternal(err, "EXECU-ieN0u", "Errors.Internal")
+	}
+	return nil
+}
+
+// SearchExecutions returns the executions of the organisation, newest first if not sorted ascending
+// the limit is capped to maxExecutionQueryLimit
+func (s *Storage) SearchExecutions(ctx context.Context, instanceID, resourceOwner string, query *SearchQuery) (*Executions, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultExecutionQueryLimit
+	}
+	if limit > maxExecutionQueryLimit {
+		limit = maxExecutionQueryLimit
+	}
+	order := ExecutionColCreationDate + " DESC"
+	if query.Asc {
+		order = ExecutionColCreationDate
+	}
+	builder := sq.Select(
+		ExecutionColID,
+		ExecutionColActionID,
+		ExecutionColFlowType,
+		ExecutionColTriggerType,
+		ExecutionColCreationDate,
+		ExecutionColDuration,
+		ExecutionColLogs,
+		ExecutionColError,
+		ExecutionColInput,
+		executionColCount,
+	).
+		From(executionsTable).
+		Where(sq.Eq{
+			ExecutionColInstanceID:    instanceID,
+			ExecutionColResourceOwner: resourceOwner,
+		}).
+		OrderBy(order).
+		Offset(query.Offset).
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar)
+	if query.ActionID != "" {
+		builder = builder.Where(sq.Eq{ExecutionColActionID: query.ActionID})
+	}
+	if s.retention > 0 {
+		builder = builder.Where(sq.Gt{ExecutionColCreationDate: time.Now().Add(-s.retention)})
+	}
+	stmt, args, err := builder.ToSql()
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "EXECU-Gae5o", "Errors.Query.SQLStatement")
+	}
+	rows, err := s.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "EXECU-yo5Ee", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	executions := &Executions{Executions: make([]*Execution, 0)}
+	for rows.Next() {
+		execution := new(Execution)
+		var (
+			duration int64
+			logs     pq.StringArray
+		)
+		err = rows.Scan(
+			&execution.ID,
+			&execution.ActionID,
+			&execution.FlowType,
+			&execution.TriggerType,
+			&execution.CreationDate,
+			&duration,
+			&logs,
+			&execution.Error,
+			&execution.Input,
+			&executions.Count,
+		)
+		if err != nil {
+			return nil, caos_errors.ThrowInternal(err, "EXECU-ahK3i", "Errors.Internal")
+		}
+		execution.Duration = time.Duration(duration)
+		execution.Logs = logs
+		executions.Executions = append(executions.Executions, execution)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, caos_errors.ThrowInternal(err, "EXECU-Ra7ne", "Errors.Query.CloseRows")
+	}
+	return executions, nil
+}
@@ -0,0 +1,294 @@
+package execution
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	testNow = time.Now()
+	errTest = errors.New("test")
+)
+
+const (
+	insertStmt = "INSERT INTO system.action_executions" +
+		" (instance_id,resource_owner,action_id,flow_type,trigger_type,creation_date,duration,logs,error,input)" +
+		" VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)"
+	insertTwoStmt     = insertStmt + ",($11,$12,$13,$14,$15,$16,$17,$18,$19,$20)"
+	removeExpiredStmt = "DELETE FROM system.action_executions" +
+		" WHERE creation_date < $1"
+	searchStmt = "SELECT id, action_id, flow_type, trigger_type, creation_date, duration, logs, error, input, COUNT(*) OVER ()" +
+		" FROM system.action_executions" +
+		" WHERE instance_id = $1 AND resource_owner = $2"
+)
+
+var searchCols = []string{
+	"id",
+	"action_id",
+	"flow_type",
+	"trigger_type",
+	"creation_date",
+	"duration",
+	"logs",
+	"error",
+	"input",
+	"count",
+}
+
+func TestStorage_LogExecution(t *testing.T) {
+	execution := &actions.Execution{
+		InstanceID:    "instance1",
+		ResourceOwner: "org1",
+		ActionID:      "action1",
+		FlowType:      domain.FlowTypeCustomiseToken,
+		TriggerType:   domain.TriggerTypePreUserinfoCreation,
+		CreationDate:  testNow,
+		Duration:      time.Second,
+		Logs:          []string{"log"},
+		Error:         "failed",
+		Input:         `{"userID":"user1"}`,
+	}
+	executionArgs := []driver.Value{"instance1", "org1", "action1", domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, testNow, int64(time.Second), pq.StringArray{"log"}, "failed", `{"userID":"user1"}`}
+	tests := []struct {
+		name       string
+		executions int
+		expect     func(sqlmock.Sqlmock)
+	}{
+		{
+			name:       "nothing queued",
+			executions: 0,
+			expect:     func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:       "queued executions written in one batch",
+			executions: 2,
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(insertTwoStmt)).
+					WithArgs(append(executionArgs, executionArgs...)...).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+			},
+		},
+		{
+			name:       "insert fails",
+			executions: 1,
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(insertStmt)).
+					WithArgs(executionArgs...).
+					WillReturnError(errTest)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("unable to create sql mock: %v", err)
+			}
+			defer client.Close()
+			tt.expect(mock)
+
+			storage := NewStorage(client, time.Hour)
+			for i := 0; i < tt.executions; i++ {
+				storage.LogExecution(execution)
+			}
+			// the queued executions are written when the worker is stopped
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			storage.run(ctx)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
+
+func TestStorage_LogExecution_queueFull(t *testing.T) {
+	storage := NewStorage(nil, 0)
+	for i := 0; i < executionQueueSize+1; i++ {
+		storage.LogExecution(&actions.Execution{ActionID: "action1"})
+	}
+	assert.Len(t, storage.executions, executionQueueSize)
+}
+
+func TestStorage_flush(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	defer client.Close()
+	mock.ExpectExec(regexp.QuoteMeta(insertStmt)).
+		WillReturnResult(sqlmock.NewResult(int64(executionBatchSize), int64(executionBatchSize)))
+	mock.ExpectExec(regexp.QuoteMeta(insertStmt)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	batch := make([]*actions.Execution, executionBatchSize+1)
+	for i := range batch {
+		batch[i] = &actions.Execution{ActionID: "action1"}
+	}
+	batch = NewStorage(client, 0).flush(batch)
+
+	assert.Empty(t, batch)
+	assert.Equal(t, executionBatchSize+1, cap(batch))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations not met: %v", err)
+	}
+}
+
+func TestStorage_removeExpired(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	defer client.Close()
+	mock.ExpectExec(regexp.QuoteMeta(removeExpiredStmt)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	NewStorage(client, time.Hour).removeExpired()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations not met: %v", err)
+	}
+}
+
+func TestStorage_SearchExecutions(t *testing.T) {
+	type args struct {
+		retention time.Duration
+		query     *SearchQuery
+	}
+	type want struct {
+		executions *Executions
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		args   args
+		expect func(sqlmock.Sqlmock)
+		want   want
+	}{
+		{
+			name: "no executions",
+			args: args{
+				query: &SearchQuery{},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(searchStmt+" ORDER BY creation_date DESC LIMIT 100 OFFSET 0")).
+					WithArgs("instance1", "org1").
+					WillReturnRows(sqlmock.NewRows(searchCols))
+			},
+			want: want{
+				executions: &Executions{Executions: []*Execution{}},
+				err:        func(err error) bool { return err == nil },
+			},
+		},
+		{
+			name: "filtered by action within retention",
+			args: args{
+				retention: time.Hour,
+				query: &SearchQuery{
+					Offset:   5,
+					Limit:    10,
+					Asc:      true,
+					ActionID: "action1",
+				},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(searchStmt+" AND action_id = $3 AND creation_date > $4 ORDER BY creation_date LIMIT 10 OFFSET 5")).
+					WithArgs("instance1", "org1", "action1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(searchCols).AddRow(
+						[]driver.Value{
+							"execution1",
+							"action1",
+							domain.FlowTypeCustomiseToken,
+							domain.TriggerTypePreUserinfoCreation,
+							testNow,
+							int64(time.Second),
+							pq.StringArray{"log"},
+							"",
+							`{"userID":"user1"}`,
+							uint64(1),
+						}...,
+					))
+			},
+			want: want{
+				executions: &Executions{
+					Count: 1,
+					Executions: []*Execution{
+						{
+							ID:           "execution1",
+							ActionID:     "action1",
+							FlowType:     domain.FlowTypeCustomiseToken,
+							TriggerType:  domain.TriggerTypePreUserinfoCreation,
+							CreationDate: testNow,
+							Duration:     time.Second,
+							Logs:         []string{"log"},
+							Input:        `{"userID":"user1"}`,
+						},
+					},
+				},
+				err: func(err error) bool { return err == nil },
+			},
+		},
+		{
+			name: "limit capped",
+			args: args{
+				query: &SearchQuery{Limit: 10000},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(searchStmt+" ORDER BY creation_date DESC LIMIT 1000 OFFSET 0")).
+					WithArgs("instance1", "org1").
+					WillReturnRows(sqlmock.NewRows(searchCols))
+			},
+			want: want{
+				executions: &Executions{Executions: []*Execution{}},
+				err:        func(err error) bool { return err == nil },
+			},
+		},
+		{
+			name: "query fails",
+			args: args{
+				query: &SearchQuery{},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(searchStmt)).
+					WillReturnError(errTest)
+			},
+			want: want{
+				err: func(err error) bool { return errors.Is(err, errTest) },
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("unable to create sql mock: %v", err)
+			}
+			defer client.Close()
+			tt.expect(mock)
+
+			got, err := NewStorage(client, tt.args.retention).SearchExecutions(context.Background(), "instance1", "org1", tt.args.query)
+			if !tt.want.err(err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil {
+				assert.Equal(t, tt.want.executions, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectations not met: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+type mockExecutionLogger struct {
+	executions []*Execution
+}
+
+func (l *mockExecutionLogger) LogExecution(execution *Execution) {
+	l.executions = append(l.executions, execution)
+}
+
+func TestRun_ExecutionLogger(t *testing.T) {
+	type args struct {
+		script        string
+		allowedToFail bool
+	}
+	type want struct {
+		err       bool
+		logs      []string
+		execError string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "console output logged",
+			args: args{
+				script: `function test(ctx, api) {
+	console.log('user', ctx.userID);
+	console.warn('warning');
+}`,
+			},
+			want: want{
+				logs: []string{"user user1", "warning"},
+			},
+		},
+		{
+			name: "error logged",
+			args: args{
+				script: `function test(ctx, api) {
+	console.log('before');
+	throw new Error('failed');
+}`,
+			},
+			want: want{
+				err:       true,
+				logs:      []string{"before"},
+				execError: "failed",
+			},
+		},
+		{
+			name: "allowed to fail, error logged",
+			args: args{
+				script: `function test(ctx, api) {
+	throw new Error('failed');
+}`,
+				allowedToFail: true,
+			},
+			want: want{
+				execError: "failed",
+			},
+		},
+		{
+			name: "function not found, error logged",
+			args: args{
+				script: `function other(ctx, api) {}`,
+			},
+			want: want{
+				err:       true,
+				execError: "function not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := new(mockExecutionLogger)
+			ctx := (&Context{}).SetUser("user1", "org1", "user@example.com")
+			ctx.set("accessToken", "secret")
+			execution := &Execution{
+				InstanceID:    "instance1",
+				ResourceOwner: "org1",
+				ActionID:      "action1",
+				FlowType:      domain.FlowTypeInternalAuthentication,
+				TriggerType:   domain.TriggerTypePostAuthentication,
+			}
+			err := Run(ctx, &API{}, tt.args.script, "test", 10*time.Second, tt.args.allowedToFail, WithExecutionLogger(logger, execution))
+			if (err != nil) != tt.want.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !assert.Len(t, logger.executions, 1) {
+				return
+			}
+			logged := logger.executions[0]
+			assert.Equal(t, "action1", logged.ActionID)
+			assert.Equal(t, tt.want.logs, logged.Logs)
+			assert.Contains(t, logged.Error, tt.want.execError)
+			if tt.want.execError == "" {
+				assert.Empty(t, logged.Error)
+			}
+			assert.Contains(t, logged.Input, `"userID":"user1"`)
+			assert.NotContains(t, logged.Input, "secret")
+			assert.NotContains(t, logged.Input, "user@example.com")
+			assert.False(t, logged.CreationDate.IsZero())
+		})
+	}
+}
+
+func Test_executionInput(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  *Context
+		want string
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+			want: "",
+		},
+		{
+			name: "only allowed properties",
+			ctx: &Context{
+				"userID":      "user1",
+				"idToken":     "token",
+				"email":       "user@example.com",
+				"metadata":    map[string]string{"key": "value"},
+				"getMetadata": func(string) string { return "" },
+			},
+			want: `{"userID":"user1"}`,
+		},
+		{
+			name: "truncated",
+			ctx: &Context{
+				"userID": strings.Repeat("a", maxExecutionInputLength),
+			},
+			want: (`{"userID":"` + strings.Repeat("a", maxExecutionInputLength))[:maxExecutionInputLength],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, executionInput(tt.ctx))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	httpModuleName = "zitadel/http"
+
+	maxHTTPResponseSize = 1 << 20
+	maxHTTPRedirects    = 10
+)
+
+var (
+	ErrHTTPHostNotAllowed     = errors.New("host is not allowed")
+	ErrHTTPResponseTooLarge   = errors.New("response body too large")
+	ErrHTTPUnsupportedScheme  = errors.New("only http and https are supported")
+	ErrHTTPTooManyRedirects   = errors.New("too many redirects")
+	ErrHTTPDeadlineExceeded   = errors.New("action timeout exceeded")
+	ErrHTTPInvalidRequestBody = errors.New("request body must be a string or an object")
+)
+
+// httpModule provides a fetch like http client to actions.
+// Only hosts of the allow list can be called and requests
+// must be finished before the deadline of the action.
+type httpModule struct {
+	allowedHosts []string
+	client       *http.Client
+	deadline     time.Time
+}
+
+func newHTTPModule(config *runConfig, deadline time.Time) *httpModule {
+	m := &httpModule{
+		allowedHosts: config.allowedHosts,
+		deadline:     deadline,
+	}
+	m.client = &http.Client{
+		Transport: config.httpTransport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxHTTPRedirects {
+				return ErrHTTPTooManyRedirects
+			}
+			return m.checkURL(req.URL)
+		},
+	}
+	return m
+}
+
+func (m *httpModule) require(vm *goja.Runtime, module *goja.Object) {
+	exports := module.Get("exports").(*goja.Object)
+	err := exports.Set("fetch", m.fetch)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// fetch sends a request to the url, the optional init supports
+// `method`, `headers` and `body` like the fetch API of browsers
+func (m *httpModule) fetch(rawURL string, init map[string]interface{}) (map[string]interface{}, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.checkURL(u); err != nil {
+		return nil, err
+	}
+	if time.Now().After(m.deadline) {
+		return nil, ErrHTTPDeadlineExceeded
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), m.deadline)
+	defer cancel()
+
+	req, err := newFetchRequest(ctx, u, init)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxHTTPResponseSize {
+		return nil, ErrHTTPResponseTooLarge
+	}
+	return fetchResponse(resp, body), nil
+}
+
+func (m *httpModule) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ErrHTTPUnsupportedScheme
+	}
+	host := strings.ToLower(u.Host)
+	hostname := strings.ToLower(u.Hostname())
+	for _, allowed := range m.allowedHosts {
+		if allowed == host || allowed == hostname {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrHTTPHostNotAllowed, host)
+}
+
+func newFetchRequest(ctx context.Context, u *url.URL, init map[string]interface{}) (*http.Request, error) {
+	method := http.MethodGet
+	if m, ok := init["method"].(string); ok && m != "" {
+		method = strings.ToUpper(m)
+	}
+	var body io.Reader
+	var isJSON bool
+	switch b := init["body"].(type) {
+	case nil:
+	case string:
+		body = strings.NewReader(b)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+		isJSON = true
+	default:
+		return nil, ErrHTTPInvalidRequestBody
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if headers, ok := init["headers"].(map[string]interface{}); ok {
+		for key, value := range headers {
+			req.Header.Set(key, fmt.Sprint(value))
+		}
+	}
+	if isJSON && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+func fetchResponse(resp *http.Response, body []byte) map[string]interface{} {
+	headers := make(map[string]string, len(resp.Header))
+	for key := range resp.Header {
+		headers[strings.ToLower(key)] = resp.Header.Get(key)
+	}
+	return map[string]interface{}{
+		"status":  resp.StatusCode,
+		"ok":      resp.StatusCode >= 200 && resp.StatusCode < 300,
+		"headers": headers,
+		"body":    string(body),
+		"text": func() string {
+			return string(body)
+		},
+		"json": func() (interface{}, error) {
+			var v interface{}
+			err := json.Unmarshal(body, &v)
+			return v, err
+		},
+	}
+}
@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MockHTTPResponse is returned by MockHTTPTransport for a URL
+type MockHTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       string
+}
+
+// MockHTTPTransport replaces the network of the http module,
+// so flows can be tested without sending requests.
+// Requests to URLs without a mocked response result in 404.
+type MockHTTPTransport struct {
+	Responses map[string]*MockHTTPResponse
+	Requests  []*http.Request
+}
+
+func (t *MockHTTPTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.Requests = append(t.Requests, req)
+	mock, ok := t.Responses[req.URL.String()]
+	if !ok {
+		mock = &MockHTTPResponse{StatusCode: http.StatusNotFound}
+	}
+	header := mock.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		StatusCode: mock.StatusCode,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(mock.Body)),
+		Request:    req,
+	}, nil
+}
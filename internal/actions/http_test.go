@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_HTTPModule(t *testing.T) {
+	const script = `function fetchTenant(ctx, api) {
+	let http = require('zitadel/http');
+	let resp = http.fetch('https://entitlements.example.com/tenants', {method: 'POST', body: {user: 'user1'}});
+	if (!resp.ok) {
+		throw new Error('unexpected status ' + resp.status);
+	}
+	api.v1.claims.setClaim('tenant_id', resp.json().id);
+}`
+	type args struct {
+		allowedHosts  []string
+		responses     map[string]*MockHTTPResponse
+		allowedToFail bool
+	}
+	type want struct {
+		claims   map[string]interface{}
+		requests int
+		err      error
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "allowed host, ok",
+			args: args{
+				allowedHosts: []string{"entitlements.example.com"},
+				responses: map[string]*MockHTTPResponse{
+					"https://entitlements.example.com/tenants": {
+						StatusCode: http.StatusOK,
+						Body:       `{"id": "tenant1"}`,
+					},
+				},
+			},
+			want: want{
+				claims:   map[string]interface{}{"tenant_id": "tenant1"},
+				requests: 1,
+			},
+		},
+		{
+			name: "host not allowed, error",
+			args: args{
+				allowedHosts: []string{"other.example.com"},
+			},
+			want: want{
+				claims: map[string]interface{}{},
+				err:    ErrHTTPHostNotAllowed,
+			},
+		},
+		{
+			name: "host not allowed, allowed to fail",
+			args: args{
+				allowedToFail: true,
+			},
+			want: want{
+				claims: map[string]interface{}{},
+			},
+		},
+		{
+			name: "response too large, error",
+			args: args{
+				allowedHosts: []string{"entitlements.example.com"},
+				responses: map[string]*MockHTTPResponse{
+					"https://entitlements.example.com/tenants": {
+						StatusCode: http.StatusOK,
+						Body:       strings.Repeat("a", maxHTTPResponseSize+1),
+					},
+				},
+			},
+			want: want{
+				claims:   map[string]interface{}{},
+				requests: 1,
+				err:      ErrHTTPResponseTooLarge,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &MockHTTPTransport{Responses: tt.args.responses}
+			claims := make(map[string]interface{})
+			logs := make([]string, 0)
+			err := Run(
+				&Context{},
+				(&API{}).SetClaims(claims, &logs),
+				script,
+				"fetchTenant",
+				time.Second,
+				tt.args.allowedToFail,
+				WithAllowedHosts(tt.args.allowedHosts...),
+				WithHTTPTransport(transport),
+			)
+			if tt.want.err != nil {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, tt.want.err) || strings.Contains(err.Error(), tt.want.err.Error()), "unexpected error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want.claims, claims)
+			assert.Len(t, transport.Requests, tt.want.requests)
+		})
+	}
+}
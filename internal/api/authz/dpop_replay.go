@@ -0,0 +1,69 @@
+package authz
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	dpopProofsTable       = "system.dpop_proofs"
+	DPoPProofJKTCol       = "jkt"
+	DPoPProofIDCol        = "jti"
+	DPoPProofExpiresAtCol = "expires_at"
+)
+
+const (
+	useDPoPProofStmt = "INSERT INTO " + dpopProofsTable + " (" + DPoPProofJKTCol + ", " + DPoPProofIDCol + ", " + DPoPProofExpiresAtCol + ")" +
+		" VALUES ($1, $2, $3) ON CONFLICT (" + DPoPProofJKTCol + ", " + DPoPProofIDCol + ") DO NOTHING"
+	pruneDPoPProofsStmt = "DELETE FROM " + dpopProofsTable + " WHERE " + DPoPProofExpiresAtCol + " < $1"
+)
+
+// DPoPProofReplayCache records the used DPoP proofs (jti per key thumbprint) for their lifetime,
+// so a captured proof can't be replayed (RFC 9449, section 11.1).
+// The proofs are stored in the database to be shared by all instances of ZITADEL
+type DPoPProofReplayCache struct {
+	client *sql.DB
+
+	mutex     sync.Mutex
+	lastPrune time.Time
+}
+
+func NewDPoPProofReplayCache(client *sql.DB) *DPoPProofReplayCache {
+	return &DPoPProofReplayCache{
+		client:    client,
+		lastPrune: time.Now(),
+	}
+}
+
+// UseDPoPProof returns an error if the proof was already used
+func (c *DPoPProofReplayCache) UseDPoPProof(ctx context.Context, proof *DPoPProof) error {
+	now := time.Now()
+	c.prune(ctx, now)
+	result, err := c.client.ExecContext(ctx, useDPoPProofStmt, proof.JKT, proof.ID, proof.IssuedAt.Add(DPoPProofMaxAge))
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "AUTH-Ais4o", "Errors.Internal")
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return caos_errs.ThrowUnauthenticated(err, "AUTH-Xoh5e", "DPoP proof already used")
+	}
+	return nil
+}
+
+// prune removes the expired proofs at most once per proof lifetime of this instance of ZITADEL
+func (c *DPoPProofReplayCache) prune(ctx context.Context, now time.Time) {
+	c.mutex.Lock()
+	if c.lastPrune.Add(DPoPProofMaxAge).After(now) {
+		c.mutex.Unlock()
+		return
+	}
+	c.lastPrune = now
+	c.mutex.Unlock()
+	_, err := c.client.ExecContext(ctx, pruneDPoPProofsStmt, now)
+	logging.OnError(err).Warn("unable to prune used DPoP proofs")
+}
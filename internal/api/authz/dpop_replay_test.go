@@ -0,0 +1,52 @@
+package authz
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestDPoPProofReplayCache_UseDPoPProof(t *testing.T) {
+	issuedAt := time.Now()
+	proof := &DPoPProof{ID: "jti", JKT: "jkt", IssuedAt: issuedAt}
+	tests := []struct {
+		name    string
+		rows    int64
+		wantErr func(error) bool
+	}{
+		{
+			name: "first use, ok",
+			rows: 1,
+		},
+		{
+			name:    "already used, unauthenticated",
+			rows:    0,
+			wantErr: caos_errs.IsUnauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer client.Close()
+			mock.ExpectExec(regexp.QuoteMeta(useDPoPProofStmt)).
+				WithArgs("jkt", "jti", issuedAt.Add(DPoPProofMaxAge)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err = NewDPoPProofReplayCache(client).UseDPoPProof(context.Background(), proof)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package authz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+)
+
+// gatewayRequestKey signs the original HTTP request the gRPC gateway passes to the gRPC server of this process,
+// so clients calling the gRPC API directly can't pretend to be the gateway
+var gatewayRequestKey = newGatewayRequestKey()
+
+func newGatewayRequestKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// GatewayRequest is the HTTP request received by the gRPC gateway
+type GatewayRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// ClientCertificate is forwarded by a trusted reverse proxy terminating TLS (RFC 8705)
+	ClientCertificate string `json:"cert,omitempty"`
+}
+
+// GatewayRequestMetadata returns the signed metadata value the gRPC gateway passes the request with
+func GatewayRequestMetadata(r *http.Request) string {
+	payload, _ := json.Marshal(&GatewayRequest{
+		Method:            r.Method,
+		Path:              r.URL.Path,
+		ClientCertificate: r.Header.Get(http_util.ZitadelClientCertificate),
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signGatewayRequest(encoded))
+}
+
+// gatewayRequestFromContext returns the request of the gRPC gateway,
+// if the call was made by the gateway of this process
+func gatewayRequestFromContext(ctx context.Context) *GatewayRequest {
+	for _, value := range metautils.ExtractIncoming(ctx)[http_util.ZitadelGatewayRequest] {
+		if request := parseGatewayRequest(value); request != nil {
+			return request
+		}
+	}
+	return nil
+}
+
+func parseGatewayRequest(value string) *GatewayRequest {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return nil
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signGatewayRequest(parts[0])) {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil
+	}
+	request := new(GatewayRequest)
+	if err = json.Unmarshal(payload, request); err != nil {
+		return nil
+	}
+	return request
+}
+
+func signGatewayRequest(encoded string) []byte {
+	mac := hmac.New(sha256.New, gatewayRequestKey)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
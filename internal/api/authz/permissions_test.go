@@ -13,10 +13,11 @@ func getTestCtx(userID, orgID string) context.Context {
 
 type testVerifier struct {
 	memberships []*Membership
+	binding     *TokenBinding
 }
 
-func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, error) {
-	return "userID", "agentID", "clientID", "de", "orgID", nil
+func (v *testVerifier) VerifyAccessToken(ctx context.Context, token, clientID, projectID string) (string, string, string, string, string, *TokenBinding, error) {
+	return "userID", "agentID", "clientID", "de", "orgID", v.binding, nil
 }
 func (v *testVerifier) SearchMyMemberships(ctx context.Context) ([]*Membership, error) {
 	return v.memberships, nil
@@ -33,6 +34,9 @@ func (v *testVerifier) ExistsOrg(ctx context.Context, orgID string) error {
 func (v *testVerifier) VerifierClientID(ctx context.Context, appName string) (string, string, error) {
 	return "clientID", "projectID", nil
 }
+func (v *testVerifier) UseDPoPProof(ctx context.Context, proof *DPoPProof) error {
+	return nil
+}
 
 func equalStringArray(a, b []string) bool {
 	if len(a) != len(b) {
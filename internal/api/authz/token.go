@@ -20,20 +20,21 @@ type TokenVerifier struct {
 }
 
 type authZRepo interface {
-	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, token, verifierClientID, projectID string) (userID, agentID, clientID, prefLang, resourceOwner string, binding *TokenBinding, err error)
 	VerifierClientID(ctx context.Context, name string) (clientID, projectID string, err error)
 	SearchMyMemberships(ctx context.Context) ([]*Membership, error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	ExistsOrg(ctx context.Context, orgID string) error
+	UseDPoPProof(ctx context.Context, proof *DPoPProof) error
 }
 
 func Start(authZRepo authZRepo) (v *TokenVerifier) {
 	return &TokenVerifier{authZRepo: authZRepo}
 }
 
-func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, err error) {
-	userID, agentID, clientID, prefLang, resourceOwner, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
-	return userID, clientID, agentID, prefLang, resourceOwner, err
+func (v *TokenVerifier) VerifyAccessToken(ctx context.Context, token string, method string) (userID, clientID, agentID, prefLang, resourceOwner string, binding *TokenBinding, err error) {
+	userID, agentID, clientID, prefLang, resourceOwner, binding, err = v.authZRepo.VerifyAccessToken(ctx, token, "", GetInstance(ctx).ProjectID())
+	return userID, clientID, agentID, prefLang, resourceOwner, binding, err
 }
 
 type client struct {
@@ -80,9 +81,20 @@ func verifyAccessToken(ctx context.Context, token string, t *TokenVerifier, meth
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	parts := strings.Split(token, BearerPrefix)
+	scheme := BearerPrefix
+	if strings.HasPrefix(token, DPoPPrefix) {
+		scheme = DPoPPrefix
+	}
+	parts := strings.Split(token, scheme)
 	if len(parts) != 2 {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
 	}
-	return t.VerifyAccessToken(ctx, parts[1], method)
+	userID, clientID, agentID, prefLan, resourceOwner, binding, err := t.VerifyAccessToken(ctx, parts[1], method)
+	if err != nil {
+		return "", "", "", "", "", err
+	}
+	if err = checkTokenBinding(ctx, scheme, parts[1], method, binding, t.authZRepo); err != nil {
+		return "", "", "", "", "", err
+	}
+	return userID, clientID, agentID, prefLan, resourceOwner, nil
 }
@@ -0,0 +1,251 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/api/grpc"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	DPoPPrefix = "DPoP "
+	// DPoPProofType is the typ header of a DPoP proof JWT
+	DPoPProofType = "dpop+jwt"
+	// DPoPProofMaxAge is the time window a DPoP proof is accepted in (based on its iat claim)
+	DPoPProofMaxAge = 5 * time.Minute
+)
+
+// TokenBinding is the confirmation of a sender-constrained access token,
+// the token may only be used by the holder of the DPoP key (RFC 9449)
+// or the client certificate (RFC 8705)
+type TokenBinding struct {
+	JKT                   string
+	CertificateThumbprint string
+}
+
+// DPoPProof is a verified DPoP proof JWT (RFC 9449, section 4.2)
+type DPoPProof struct {
+	ID              string
+	Method          string
+	URL             string
+	IssuedAt        time.Time
+	AccessTokenHash string
+	// JKT is the base64url encoded SHA-256 thumbprint of the key the proof was signed with
+	JKT string
+}
+
+type dpopProofClaims struct {
+	ID              string `json:"jti"`
+	Method          string `json:"htm"`
+	URL             string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath"`
+}
+
+// ParseDPoPProof parses the DPoP proof JWT and verifies its signature with the embedded public key
+func ParseDPoPProof(proof string) (*DPoPProof, error) {
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return nil, caos_errs.ThrowUnauthenticated(err, "AUTH-Ohp4e", "invalid DPoP proof")
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "AUTH-eiF5a", "invalid DPoP proof")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); !strings.EqualFold(typ, DPoPProofType) {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "AUTH-Iev0o", "invalid DPoP proof type")
+	}
+	if strings.HasPrefix(header.Algorithm, "HS") || header.Algorithm == "none" {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "AUTH-ooG3e", "DPoP proof must be signed asymmetrically")
+	}
+	if header.JSONWebKey == nil || !header.JSONWebKey.Valid() || !header.JSONWebKey.IsPublic() {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "AUTH-Quo2a", "DPoP proof must contain a public key")
+	}
+	payload, err := jws.Verify(header.JSONWebKey)
+	if err != nil {
+		return nil, caos_errs.ThrowUnauthenticated(err, "AUTH-yai8U", "invalid DPoP proof signature")
+	}
+	claims := new(dpopProofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, caos_errs.ThrowUnauthenticated(err, "AUTH-Aen7b", "invalid DPoP proof claims")
+	}
+	if claims.ID == "" || claims.Method == "" || claims.URL == "" || claims.IssuedAt == 0 {
+		return nil, caos_errs.ThrowUnauthenticated(nil, "AUTH-eeX9i", "DPoP proof claims missing")
+	}
+	thumbprint, err := header.JSONWebKey.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, caos_errs.ThrowUnauthenticated(err, "AUTH-pha4E", "invalid DPoP proof key")
+	}
+	return &DPoPProof{
+		ID:              claims.ID,
+		Method:          claims.Method,
+		URL:             claims.URL,
+		IssuedAt:        time.Unix(claims.IssuedAt, 0),
+		AccessTokenHash: claims.AccessTokenHash,
+		JKT:             base64.RawURLEncoding.EncodeToString(thumbprint),
+	}, nil
+}
+
+// Verify checks the freshness of the proof and that it was created for the request,
+// the scheme and host of the url are only checked if they are passed, the access token hash (ath) only if an access token is passed
+func (p *DPoPProof) Verify(method, requestURL, accessToken string, now time.Time) error {
+	if p.IssuedAt.Before(now.Add(-DPoPProofMaxAge)) || p.IssuedAt.After(now.Add(DPoPProofMaxAge)) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-Ahb8o", "DPoP proof expired")
+	}
+	if !strings.EqualFold(p.Method, method) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-ni4Ae", "DPoP proof method mismatch")
+	}
+	if !equalURLWithoutQuery(p.URL, requestURL) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-Cah3u", "DPoP proof url mismatch")
+	}
+	if accessToken != "" && p.AccessTokenHash != AccessTokenHash(accessToken) {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-yoo3E", "DPoP proof access token hash mismatch")
+	}
+	return nil
+}
+
+// AccessTokenHash returns the base64url encoded SHA-256 hash of the access token (ath claim)
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func equalURLWithoutQuery(proofURL, requestURL string) bool {
+	proof, err := url.Parse(proofURL)
+	if err != nil {
+		return false
+	}
+	request, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	return (request.Scheme == "" || strings.EqualFold(proof.Scheme, request.Scheme)) &&
+		(request.Host == "" || strings.EqualFold(proof.Host, request.Host)) &&
+		proof.Path == request.Path
+}
+
+// CertificateThumbprint returns the base64url encoded SHA-256 thumbprint (x5t#S256) of the client certificate
+// forwarded by the reverse proxy terminating TLS as url encoded PEM (RFC 8705, section 3.1)
+func CertificateThumbprint(forwardedCertificate string) (string, error) {
+	certificate, err := url.QueryUnescape(forwardedCertificate)
+	if err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTH-ieR4o", "invalid client certificate")
+	}
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", caos_errs.ThrowUnauthenticated(nil, "AUTH-Ohk8a", "invalid client certificate")
+	}
+	if _, err = x509.ParseCertificate(block.Bytes); err != nil {
+		return "", caos_errs.ThrowUnauthenticated(err, "AUTH-Eo5ie", "invalid client certificate")
+	}
+	thumbprint := sha256.Sum256(block.Bytes)
+	return base64.RawURLEncoding.EncodeToString(thumbprint[:]), nil
+}
+
+type dpopProofReplayCache interface {
+	UseDPoPProof(ctx context.Context, proof *DPoPProof) error
+}
+
+// checkTokenBinding verifies that the request is sent by the holder of the key the access token is bound to.
+// The DPoP proof must be created for the method and url of the request and must not have been used before.
+// The client certificate is only present if it was forwarded by a trusted reverse proxy terminating TLS,
+// otherwise certificate bound tokens are rejected
+func checkTokenBinding(ctx context.Context, scheme, accessToken, fullMethod string, binding *TokenBinding, replayCache dpopProofReplayCache) error {
+	if binding == nil || (binding.JKT == "" && binding.CertificateThumbprint == "") {
+		if scheme == DPoPPrefix {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTH-Uu9ee", "token is not bound to a DPoP key")
+		}
+		return nil
+	}
+	if binding.JKT != "" {
+		if scheme != DPoPPrefix {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTH-Oow1e", "DPoP bound token must be sent with DPoP scheme")
+		}
+		proof, err := ParseDPoPProof(requestHeader(ctx, http_util.DPoP))
+		if err != nil {
+			return err
+		}
+		if proof.JKT != binding.JKT {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTH-Fie8a", "DPoP proof key mismatch")
+		}
+		method, requestURL := dpopRequest(ctx, fullMethod)
+		if err = proof.Verify(method, requestURL, accessToken, time.Now()); err != nil {
+			return err
+		}
+		if err = replayCache.UseDPoPProof(ctx, proof); err != nil {
+			return err
+		}
+	}
+	if binding.CertificateThumbprint != "" {
+		thumbprint, err := CertificateThumbprint(clientCertificate(ctx))
+		if err != nil {
+			return err
+		}
+		if thumbprint != binding.CertificateThumbprint {
+			return caos_errs.ThrowUnauthenticated(nil, "AUTH-aeS2u", "client certificate mismatch")
+		}
+	}
+	return nil
+}
+
+type httpRequestKey struct{}
+
+type httpRequest struct {
+	method string
+	path   string
+}
+
+// WithHTTPRequest sets the method and path of a request to an HTTP handler (e.g. assets),
+// which the DPoP proof is verified against
+func WithHTTPRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, httpRequestKey{}, &httpRequest{method: r.Method, path: r.URL.Path})
+}
+
+// dpopRequest returns the method and url the DPoP proof must have been created for:
+// the request to the HTTP handler or the gRPC gateway or else the gRPC method called directly.
+// As the scheme depends on the reverse proxy, only the host and the path are compared
+func dpopRequest(ctx context.Context, fullMethod string) (method, requestURL string) {
+	method, path := http.MethodPost, fullMethod
+	if request, ok := ctx.Value(httpRequestKey{}).(*httpRequest); ok {
+		method, path = request.method, request.path
+	} else if request := gatewayRequestFromContext(ctx); request != nil {
+		method, path = request.Method, request.Path
+	}
+	return method, "//" + GetInstance(ctx).RequestedHost() + path
+}
+
+// clientCertificate returns the client certificate forwarded by the reverse proxy,
+// the header is stripped from requests of untrusted peers by the ClientCertificateInterceptor
+func clientCertificate(ctx context.Context) string {
+	if certificate := requestHeader(ctx, http_util.ZitadelClientCertificate); certificate != "" {
+		return certificate
+	}
+	if request := gatewayRequestFromContext(ctx); request != nil {
+		return request.ClientCertificate
+	}
+	return ""
+}
+
+func requestHeader(ctx context.Context, name string) string {
+	if value := grpc.GetHeader(ctx, name); value != "" {
+		return value
+	}
+	headers, ok := http_util.HeadersFromCtx(ctx)
+	if !ok {
+		return ""
+	}
+	return headers.Get(name)
+}
@@ -0,0 +1,399 @@
+package authz
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/square/go-jose.v2"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func testDPoPProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		&jose.SignerOptions{
+			EmbedJWK:     true,
+			ExtraHeaders: map[jose.HeaderKey]interface{}{jose.HeaderType: typ},
+		},
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func testJKT(t *testing.T, key *ecdsa.PrivateKey) string {
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func Test_DPoPProof(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	now := time.Now()
+	type args struct {
+		typ         string
+		claims      map[string]interface{}
+		method      string
+		url         string
+		accessToken string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantErr  bool
+		parseErr bool
+	}{
+		{
+			name: "wrong type, error",
+			args: args{
+				typ:    "JWT",
+				claims: map[string]interface{}{"jti": "id", "htm": "POST", "htu": "https://issuer.test/oauth/v2/token", "iat": now.Unix()},
+			},
+			wantErr:  true,
+			parseErr: true,
+		},
+		{
+			name: "claims missing, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: map[string]interface{}{"htm": "POST", "htu": "https://issuer.test/oauth/v2/token", "iat": now.Unix()},
+			},
+			wantErr:  true,
+			parseErr: true,
+		},
+		{
+			name: "expired, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: map[string]interface{}{"jti": "id", "htm": "POST", "htu": "https://issuer.test/oauth/v2/token", "iat": now.Add(-time.Hour).Unix()},
+				method: "POST",
+				url:    "https://issuer.test/oauth/v2/token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "method mismatch, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: map[string]interface{}{"jti": "id", "htm": "GET", "htu": "https://issuer.test/oauth/v2/token", "iat": now.Unix()},
+				method: "POST",
+				url:    "https://issuer.test/oauth/v2/token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "url mismatch, error",
+			args: args{
+				typ:    DPoPProofType,
+				claims: map[string]interface{}{"jti": "id", "htm": "POST", "htu": "https://other.test/oauth/v2/token", "iat": now.Unix()},
+				method: "POST",
+				url:    "https://issuer.test/oauth/v2/token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "access token hash mismatch, error",
+			args: args{
+				typ:         DPoPProofType,
+				claims:      map[string]interface{}{"jti": "id", "htm": "GET", "htu": "https://issuer.test/oidc/v1/userinfo", "iat": now.Unix(), "ath": AccessTokenHash("other")},
+				method:      "GET",
+				url:         "https://issuer.test/oidc/v1/userinfo",
+				accessToken: "token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "token request, ok",
+			args: args{
+				typ:    DPoPProofType,
+				claims: map[string]interface{}{"jti": "id", "htm": "POST", "htu": "https://issuer.test/oauth/v2/token?query", "iat": now.Unix()},
+				method: "POST",
+				url:    "https://issuer.test/oauth/v2/token",
+			},
+		},
+		{
+			name: "resource request, ok",
+			args: args{
+				typ:         DPoPProofType,
+				claims:      map[string]interface{}{"jti": "id", "htm": "GET", "htu": "https://issuer.test/oidc/v1/userinfo", "iat": now.Unix(), "ath": AccessTokenHash("token")},
+				method:      "GET",
+				url:         "https://issuer.test/oidc/v1/userinfo",
+				accessToken: "token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := ParseDPoPProof(testDPoPProof(t, key, tt.args.typ, tt.args.claims))
+			if tt.parseErr {
+				assert.True(t, caos_errs.IsUnauthenticated(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testJKT(t, key), proof.JKT)
+			err = proof.Verify(tt.args.method, tt.args.url, tt.args.accessToken, now)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsUnauthenticated(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+type mockDPoPProofReplayCache struct {
+	used map[string]bool
+}
+
+func (c *mockDPoPProofReplayCache) UseDPoPProof(_ context.Context, proof *DPoPProof) error {
+	if c.used[proof.JKT+proof.ID] {
+		return caos_errs.ThrowUnauthenticated(nil, "AUTH-Xoh5e", "DPoP proof already used")
+	}
+	c.used[proof.JKT+proof.ID] = true
+	return nil
+}
+
+func testCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	thumbprint := sha256.Sum256(der)
+	return url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))), base64.RawURLEncoding.EncodeToString(thumbprint[:])
+}
+
+func Test_checkTokenBinding(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	certificate, certificateThumbprint := testCertificate(t)
+	const fullMethod = "/zitadel.auth.v1.AuthService/GetMyUser"
+	withInstance := func(ctx context.Context) context.Context {
+		return WithInstance(ctx, &instance{Domain: "issuer.test"})
+	}
+	proofFor := func(key *ecdsa.PrivateKey, jti, method, url, accessToken string) string {
+		return testDPoPProof(t, key, DPoPProofType, map[string]interface{}{
+			"jti": jti,
+			"htm": method,
+			"htu": url,
+			"iat": time.Now().Unix(),
+			"ath": AccessTokenHash(accessToken),
+		})
+	}
+	proof := func(key *ecdsa.PrivateKey, accessToken string) context.Context {
+		return withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(http_util.DPoP,
+			proofFor(key, "id", "POST", "https://issuer.test"+fullMethod, accessToken),
+		)))
+	}
+	gatewayRequest := func(method, path, certificate string) string {
+		r := httptest.NewRequest(method, path, nil)
+		if certificate != "" {
+			r.Header.Set(http_util.ZitadelClientCertificate, certificate)
+		}
+		return GatewayRequestMetadata(r)
+	}
+	type args struct {
+		ctx     context.Context
+		scheme  string
+		binding *TokenBinding
+		used    []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "unbound token, ok",
+			args: args{
+				ctx:    context.Background(),
+				scheme: BearerPrefix,
+			},
+		},
+		{
+			name: "unbound token with dpop scheme, error",
+			args: args{
+				ctx:    proof(key, "token"),
+				scheme: DPoPPrefix,
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with bearer scheme, error",
+			args: args{
+				ctx:     proof(key, "token"),
+				scheme:  BearerPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token without proof, error",
+			args: args{
+				ctx:     context.Background(),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof of other key, error",
+			args: args{
+				ctx:     proof(otherKey, "token"),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for other token, error",
+			args: args{
+				ctx:     proof(key, "other"),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for other method, error",
+			args: args{
+				ctx: withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(http_util.DPoP,
+					proofFor(key, "id", "POST", "https://issuer.test/zitadel.admin.v1.AdminService/RemoveOrg", "token"),
+				))),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for other host, error",
+			args: args{
+				ctx: withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(http_util.DPoP,
+					proofFor(key, "id", "POST", "https://other.test"+fullMethod, "token"),
+				))),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with replayed proof, error",
+			args: args{
+				ctx:     proof(key, "token"),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+				used:    []string{testJKT(t, key) + "id"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for gateway request, ok",
+			args: args{
+				ctx: withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+					http_util.DPoP, proofFor(key, "id", "GET", "https://issuer.test/auth/v1/users/me", "token"),
+					http_util.ZitadelGatewayRequest, gatewayRequest("GET", "/auth/v1/users/me", ""),
+				))),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+		},
+		{
+			name: "bound token with proof for forged gateway request, error",
+			args: args{
+				ctx: withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+					http_util.DPoP, proofFor(key, "id", "GET", "https://issuer.test/auth/v1/users/me", "token"),
+					http_util.ZitadelGatewayRequest, `eyJtZXRob2QiOiJHRVQiLCJwYXRoIjoiL2F1dGgvdjEvdXNlcnMvbWUifQ.c2lnbmF0dXJl`,
+				))),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bound token with proof for http handler request, ok",
+			args: args{
+				ctx: WithHTTPRequest(
+					withInstance(metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+						http_util.DPoP, proofFor(key, "id", "GET", "https://issuer.test/assets/v1/users/me/avatar", "token"),
+					))),
+					httptest.NewRequest("GET", "/assets/v1/users/me/avatar", nil),
+				),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+		},
+		{
+			name: "certificate bound token without certificate, error",
+			args: args{
+				ctx:     context.Background(),
+				scheme:  BearerPrefix,
+				binding: &TokenBinding{CertificateThumbprint: certificateThumbprint},
+			},
+			wantErr: true,
+		},
+		{
+			name: "certificate bound token with certificate, ok",
+			args: args{
+				ctx:     metadata.NewIncomingContext(context.Background(), metadata.Pairs(http_util.ZitadelClientCertificate, certificate)),
+				scheme:  BearerPrefix,
+				binding: &TokenBinding{CertificateThumbprint: certificateThumbprint},
+			},
+		},
+		{
+			name: "certificate bound token with certificate of gateway request, ok",
+			args: args{
+				ctx:     metadata.NewIncomingContext(context.Background(), metadata.Pairs(http_util.ZitadelGatewayRequest, gatewayRequest("GET", "/auth/v1/users/me", certificate))),
+				scheme:  BearerPrefix,
+				binding: &TokenBinding{CertificateThumbprint: certificateThumbprint},
+			},
+		},
+		{
+			name: "bound token with proof, ok",
+			args: args{
+				ctx:     proof(key, "token"),
+				scheme:  DPoPPrefix,
+				binding: &TokenBinding{JKT: testJKT(t, key)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayCache := &mockDPoPProofReplayCache{used: make(map[string]bool)}
+			for _, used := range tt.args.used {
+				replayCache.used[used] = true
+			}
+			err := checkTokenBinding(tt.args.ctx, tt.args.scheme, "token", fullMethod, tt.args.binding, replayCache)
+			if tt.wantErr {
+				assert.True(t, caos_errs.IsUnauthenticated(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -55,6 +55,24 @@ func Test_VerifyAccessToken(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "bound token with bearer scheme",
+			args: args{
+				ctx:   context.Background(),
+				token: "Bearer AUTH",
+				verifier: &TokenVerifier{
+					authZRepo: &testVerifier{memberships: []*Membership{}, binding: &TokenBinding{JKT: "jkt"}},
+					clients: func() sync.Map {
+						m := sync.Map{}
+						m.Store("service", &client{name: "name"})
+						return m
+					}(),
+					authMethods: MethodMapping{"/service/method": Option{Permission: "authenticated"}},
+				},
+				method: "/service/method",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
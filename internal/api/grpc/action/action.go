@@ -1,8 +1,13 @@
 package action
 
 import (
+	"net/http"
+
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/execution"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -13,6 +18,12 @@ func FlowTypeToDomain(flowType action_pb.FlowType) domain.FlowType {
 	switch flowType {
 	case action_pb.FlowType_FLOW_TYPE_EXTERNAL_AUTHENTICATION:
 		return domain.FlowTypeExternalAuthentication
+	case action_pb.FlowType_FLOW_TYPE_CUSTOMISE_TOKEN:
+		return domain.FlowTypeCustomiseToken
+	case action_pb.FlowType_FLOW_TYPE_INTERNAL_AUTHENTICATION:
+		return domain.FlowTypeInternalAuthentication
+	case action_pb.FlowType_FLOW_TYPE_EVENT:
+		return domain.FlowTypeEvent
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -26,6 +37,22 @@ func TriggerTypeToDomain(triggerType action_pb.TriggerType) domain.TriggerType {
 		return domain.TriggerTypePreCreation
 	case action_pb.TriggerType_TRIGGER_TYPE_POST_CREATION:
 		return domain.TriggerTypePostCreation
+	case action_pb.TriggerType_TRIGGER_TYPE_PRE_USERINFO_CREATION:
+		return domain.TriggerTypePreUserinfoCreation
+	case action_pb.TriggerType_TRIGGER_TYPE_PRE_ACCESS_TOKEN_CREATION:
+		return domain.TriggerTypePreAccessTokenCreation
+	case action_pb.TriggerType_TRIGGER_TYPE_HUMAN_ADDED:
+		return domain.TriggerTypeHumanAdded
+	case action_pb.TriggerType_TRIGGER_TYPE_HUMAN_REGISTERED:
+		return domain.TriggerTypeHumanRegistered
+	case action_pb.TriggerType_TRIGGER_TYPE_USER_REMOVED:
+		return domain.TriggerTypeUserRemoved
+	case action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_ADDED:
+		return domain.TriggerTypeUserGrantAdded
+	case action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_CHANGED:
+		return domain.TriggerTypeUserGrantChanged
+	case action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_REMOVED:
+		return domain.TriggerTypeUserGrantRemoved
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -51,6 +78,12 @@ func FlowTypeToPb(flowType domain.FlowType) action_pb.FlowType {
 	switch flowType {
 	case domain.FlowTypeExternalAuthentication:
 		return action_pb.FlowType_FLOW_TYPE_EXTERNAL_AUTHENTICATION
+	case domain.FlowTypeCustomiseToken:
+		return action_pb.FlowType_FLOW_TYPE_CUSTOMISE_TOKEN
+	case domain.FlowTypeInternalAuthentication:
+		return action_pb.FlowType_FLOW_TYPE_INTERNAL_AUTHENTICATION
+	case domain.FlowTypeEvent:
+		return action_pb.FlowType_FLOW_TYPE_EVENT
 	default:
 		return action_pb.FlowType_FLOW_TYPE_UNSPECIFIED
 	}
@@ -64,6 +97,22 @@ func TriggerTypeToPb(triggerType domain.TriggerType) action_pb.TriggerType {
 		return action_pb.TriggerType_TRIGGER_TYPE_PRE_CREATION
 	case domain.TriggerTypePostCreation:
 		return action_pb.TriggerType_TRIGGER_TYPE_POST_CREATION
+	case domain.TriggerTypePreUserinfoCreation:
+		return action_pb.TriggerType_TRIGGER_TYPE_PRE_USERINFO_CREATION
+	case domain.TriggerTypePreAccessTokenCreation:
+		return action_pb.TriggerType_TRIGGER_TYPE_PRE_ACCESS_TOKEN_CREATION
+	case domain.TriggerTypeHumanAdded:
+		return action_pb.TriggerType_TRIGGER_TYPE_HUMAN_ADDED
+	case domain.TriggerTypeHumanRegistered:
+		return action_pb.TriggerType_TRIGGER_TYPE_HUMAN_REGISTERED
+	case domain.TriggerTypeUserRemoved:
+		return action_pb.TriggerType_TRIGGER_TYPE_USER_REMOVED
+	case domain.TriggerTypeUserGrantAdded:
+		return action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_ADDED
+	case domain.TriggerTypeUserGrantChanged:
+		return action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_CHANGED
+	case domain.TriggerTypeUserGrantRemoved:
+		return action_pb.TriggerType_TRIGGER_TYPE_USER_GRANT_REMOVED
 	default:
 		return action_pb.TriggerType_TRIGGER_TYPE_UNSPECIFIED
 	}
@@ -125,3 +174,45 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func ActionExecutionsToPb(executions []*execution.Execution) []*action_pb.ActionExecution {
+	list := make([]*action_pb.ActionExecution, len(executions))
+	for i, e := range executions {
+		list[i] = ActionExecutionToPb(e)
+	}
+	return list
+}
+
+func ActionExecutionToPb(e *execution.Execution) *action_pb.ActionExecution {
+	return &action_pb.ActionExecution{
+		Id:           e.ID,
+		ActionId:     e.ActionID,
+		FlowType:     FlowTypeToPb(e.FlowType),
+		TriggerType:  TriggerTypeToPb(e.TriggerType),
+		CreationDate: timestamppb.New(e.CreationDate),
+		Duration:     durationpb.New(e.Duration),
+		Logs:         e.Logs,
+		Error:        e.Error,
+		Input:        e.Input,
+	}
+}
+
+func MockHTTPResponsesToDomain(responses []*action_pb.MockHTTPResponse) map[string]*actions.MockHTTPResponse {
+	mocks := make(map[string]*actions.MockHTTPResponse, len(responses))
+	for _, response := range responses {
+		statusCode := int(response.StatusCode)
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		header := make(http.Header, len(response.Headers))
+		for key, value := range response.Headers {
+			header.Set(key, value)
+		}
+		mocks[response.Url] = &actions.MockHTTPResponse{
+			StatusCode: statusCode,
+			Header:     header,
+			Body:       response.Body,
+		}
+	}
+	return mocks
+}
@@ -0,0 +1,32 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetAccessPolicy(ctx context.Context, req *admin_pb.GetAccessPolicyRequest) (*admin_pb.GetAccessPolicyResponse, error) {
+	policy, err := s.query.DefaultAccessPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetAccessPolicyResponse{Policy: policy_grpc.ModelAccessPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateAccessPolicy(ctx context.Context, req *admin_pb.UpdateAccessPolicyRequest) (*admin_pb.UpdateAccessPolicyResponse, error) {
+	policy, err := s.command.ChangeDefaultAccessPolicy(ctx, &domain.AccessPolicy{Rules: policy_grpc.AccessRulesToDomain(req.Rules)})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateAccessPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
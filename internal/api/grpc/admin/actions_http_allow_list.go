@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) GetActionsHTTPAllowList(ctx context.Context, _ *admin_pb.GetActionsHTTPAllowListRequest) (*admin_pb.GetActionsHTTPAllowListResponse, error) {
+	allowList, err := s.query.ActionsHTTPAllowList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetActionsHTTPAllowListResponse{
+		Details: object.ChangeToDetailsPb(allowList.Sequence, allowList.ChangeDate, allowList.AggregateID),
+		Hosts:   allowList.Hosts,
+	}, nil
+}
+
+func (s *Server) SetActionsHTTPAllowList(ctx context.Context, req *admin_pb.SetActionsHTTPAllowListRequest) (*admin_pb.SetActionsHTTPAllowListResponse, error) {
+	details, err := s.command.SetActionsHTTPAllowList(ctx, req.Hosts)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetActionsHTTPAllowListResponse{
+		Details: object.DomainToChangeDetailsPb(details),
+	}, nil
+}
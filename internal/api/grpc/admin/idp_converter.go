@@ -50,6 +50,47 @@ func addJWTIDPRequestToDomainJWTIDPConfig(req *admin_pb.AddJWTIDPRequest) *domai
 	}
 }
 
+func addSAMLIDPRequestToDomain(req *admin_pb.AddSAMLIDPRequest) *domain.IDPConfig {
+	return &domain.IDPConfig{
+		Name:         req.Name,
+		SAMLConfig:   addSAMLIDPRequestToDomainSAMLIDPConfig(req),
+		StylingType:  idp_grpc.IDPStylingTypeToDomain(req.StylingType),
+		Type:         domain.IDPConfigTypeSAML,
+		AutoRegister: req.AutoRegister,
+	}
+}
+
+func addSAMLIDPRequestToDomainSAMLIDPConfig(req *admin_pb.AddSAMLIDPRequest) *domain.SAMLIDPConfig {
+	return &domain.SAMLIDPConfig{
+		Metadata:    req.GetMetadataXml(),
+		MetadataURL: req.GetMetadataUrl(),
+	}
+}
+
+func addLDAPIDPRequestToDomain(req *admin_pb.AddLDAPIDPRequest) *domain.IDPConfig {
+	return &domain.IDPConfig{
+		Name:         req.Name,
+		LDAPConfig:   addLDAPIDPRequestToDomainLDAPIDPConfig(req),
+		StylingType:  idp_grpc.IDPStylingTypeToDomain(req.StylingType),
+		Type:         domain.IDPConfigTypeLDAP,
+		AutoRegister: req.AutoRegister,
+	}
+}
+
+func addLDAPIDPRequestToDomainLDAPIDPConfig(req *admin_pb.AddLDAPIDPRequest) *domain.LDAPIDPConfig {
+	return &domain.LDAPIDPConfig{
+		URL:                req.Url,
+		StartTLS:           req.StartTls,
+		RootCA:             req.RootCa,
+		BaseDN:             req.BaseDn,
+		BindDN:             req.BindDn,
+		BindPasswordString: req.BindPassword,
+		UserObjectClass:    req.UserObjectClass,
+		UserFilters:        req.UserFilters,
+		Attributes:         idp_grpc.LDAPAttributesToDomain(req.Attributes),
+	}
+}
+
 func updateIDPToDomain(req *admin_pb.UpdateIDPRequest) *domain.IDPConfig {
 	return &domain.IDPConfig{
 		IDPConfigID:  req.IdpId,
@@ -81,6 +122,29 @@ func updateJWTConfigToDomain(req *admin_pb.UpdateIDPJWTConfigRequest) *domain.JW
 	}
 }
 
+func updateSAMLConfigToDomain(req *admin_pb.UpdateIDPSAMLConfigRequest) *domain.SAMLIDPConfig {
+	return &domain.SAMLIDPConfig{
+		IDPConfigID: req.IdpId,
+		Metadata:    req.GetMetadataXml(),
+		MetadataURL: req.GetMetadataUrl(),
+	}
+}
+
+func updateLDAPConfigToDomain(req *admin_pb.UpdateIDPLDAPConfigRequest) *domain.LDAPIDPConfig {
+	return &domain.LDAPIDPConfig{
+		IDPConfigID:        req.IdpId,
+		URL:                req.Url,
+		StartTLS:           req.StartTls,
+		RootCA:             req.RootCa,
+		BaseDN:             req.BaseDn,
+		BindDN:             req.BindDn,
+		BindPasswordString: req.BindPassword,
+		UserObjectClass:    req.UserObjectClass,
+		UserFilters:        req.UserFilters,
+		Attributes:         idp_grpc.LDAPAttributesToDomain(req.Attributes),
+	}
+}
+
 func listIDPsToModel(instanceID string, req *admin_pb.ListIDPsRequest) (*query.IDPSearchQueries, error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	queries, err := idpQueriesToModel(req.Queries)
@@ -8,5 +8,8 @@ import (
 func UpdateLockoutPolicyToDomain(p *admin.UpdateLockoutPolicyRequest) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		MaxPasswordAttempts: uint64(p.MaxPasswordAttempts),
+		MaxOTPAttempts:      uint64(p.MaxOtpAttempts),
+		MaxU2FAttempts:      uint64(p.MaxU2FAttempts),
+		AutoUnlockDuration:  p.AutoUnlockDuration.AsDuration(),
 	}
 }
@@ -88,9 +88,11 @@ func IDPTypeToPb(idpType domain.IDPConfigType) idp_pb.IDPType {
 	case domain.IDPConfigTypeOIDC:
 		return idp_pb.IDPType_IDP_TYPE_OIDC
 	case domain.IDPConfigTypeSAML:
-		return idp_pb.IDPType_IDP_TYPE_UNSPECIFIED
+		return idp_pb.IDPType_IDP_TYPE_SAML
 	case domain.IDPConfigTypeJWT:
 		return idp_pb.IDPType_IDP_TYPE_JWT
+	case domain.IDPConfigTypeLDAP:
+		return idp_pb.IDPType_IDP_TYPE_LDAP
 	default:
 		return idp_pb.IDPType_IDP_TYPE_UNSPECIFIED
 	}
@@ -157,6 +159,12 @@ func ModelIDPViewToConfigPb(config *query.IDP) idp_pb.IDPConfig {
 			},
 		}
 	}
+	if config.SAMLIDP != nil {
+		return samlConfigToPb(config.SAMLIDP)
+	}
+	if config.LDAPIDP != nil {
+		return ldapConfigToPb(config.LDAPIDP)
+	}
 	return &idp_pb.IDP_JwtConfig{
 		JwtConfig: &idp_pb.JWTConfig{
 			JwtEndpoint:  config.Endpoint,
@@ -179,6 +187,12 @@ func IDPViewToConfigPb(config *query.IDP) idp_pb.IDPConfig {
 			},
 		}
 	}
+	if config.SAMLIDP != nil {
+		return samlConfigToPb(config.SAMLIDP)
+	}
+	if config.LDAPIDP != nil {
+		return ldapConfigToPb(config.LDAPIDP)
+	}
 	return &idp_pb.IDP_JwtConfig{
 		JwtConfig: &idp_pb.JWTConfig{
 			JwtEndpoint:  config.JWTIDP.Endpoint,
@@ -188,6 +202,55 @@ func IDPViewToConfigPb(config *query.IDP) idp_pb.IDPConfig {
 	}
 }
 
+func samlConfigToPb(config *query.SAMLIDP) *idp_pb.IDP_SamlConfig {
+	return &idp_pb.IDP_SamlConfig{
+		SamlConfig: &idp_pb.SAMLConfig{
+			EntityId:    config.EntityID,
+			MetadataXml: config.Metadata,
+			MetadataUrl: config.MetadataURL,
+		},
+	}
+}
+
+func ldapConfigToPb(config *query.LDAPIDP) *idp_pb.IDP_LdapConfig {
+	return &idp_pb.IDP_LdapConfig{
+		LdapConfig: &idp_pb.LDAPConfig{
+			Url:             config.URL,
+			StartTls:        config.StartTLS,
+			RootCa:          config.RootCA,
+			BaseDn:          config.BaseDN,
+			BindDn:          config.BindDN,
+			UserObjectClass: config.UserObjectClass,
+			UserFilters:     config.UserFilters,
+			Attributes:      LDAPAttributesToPb(config.Attributes),
+		},
+	}
+}
+
+func LDAPAttributesToPb(attributes domain.LDAPAttributes) *idp_pb.LDAPAttributes {
+	return &idp_pb.LDAPAttributes{
+		IdAttribute:                attributes.IDAttribute,
+		PreferredUsernameAttribute: attributes.PreferredUsernameAttribute,
+		FirstNameAttribute:         attributes.FirstNameAttribute,
+		LastNameAttribute:          attributes.LastNameAttribute,
+		DisplayNameAttribute:       attributes.DisplayNameAttribute,
+		EmailAttribute:             attributes.EmailAttribute,
+		PhoneAttribute:             attributes.PhoneAttribute,
+	}
+}
+
+func LDAPAttributesToDomain(attributes *idp_pb.LDAPAttributes) domain.LDAPAttributes {
+	return domain.LDAPAttributes{
+		IDAttribute:                attributes.GetIdAttribute(),
+		PreferredUsernameAttribute: attributes.GetPreferredUsernameAttribute(),
+		FirstNameAttribute:         attributes.GetFirstNameAttribute(),
+		LastNameAttribute:          attributes.GetLastNameAttribute(),
+		DisplayNameAttribute:       attributes.GetDisplayNameAttribute(),
+		EmailAttribute:             attributes.GetEmailAttribute(),
+		PhoneAttribute:             attributes.GetPhoneAttribute(),
+	}
+}
+
 func FieldNameToModel(fieldName idp_pb.IDPFieldName) query.Column {
 	switch fieldName {
 	case idp_pb.IDPFieldName_IDP_FIELD_NAME_NAME:
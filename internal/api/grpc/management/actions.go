@@ -2,7 +2,12 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
@@ -96,3 +101,44 @@ func (s *Server) DeleteAction(ctx context.Context, req *mgmt_pb.DeleteActionRequ
 	_, err = s.command.DeleteAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID, flowTypes...)
 	return &mgmt_pb.DeleteActionResponse{}, err
 }
+
+func (s *Server) ListActionExecutions(ctx context.Context, req *mgmt_pb.ListActionExecutionsRequest) (*mgmt_pb.ListActionExecutionsResponse, error) {
+	executions, err := s.actionExecutions.SearchExecutions(ctx, authz.GetInstance(ctx).InstanceID(), authz.GetCtxData(ctx).OrgID, listActionExecutionsToQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionExecutionsResponse{
+		Details: obj_grpc.ToListDetails(executions.Count, 0, time.Now()),
+		Result:  action_grpc.ActionExecutionsToPb(executions.Executions),
+	}, nil
+}
+
+func (s *Server) TestAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*mgmt_pb.TestActionResponse, error) {
+	allowList, err := s.query.ActionsHTTPAllowList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := actions.DryRun(
+		action_grpc.FlowTypeToDomain(req.FlowType),
+		action_grpc.TriggerTypeToDomain(req.TriggerType),
+		req.Context.AsMap(),
+		req.Script,
+		req.Name,
+		req.Timeout.AsDuration(),
+		allowList.Hosts,
+		action_grpc.MockHTTPResponsesToDomain(req.HttpResponses),
+	)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := structpb.NewStruct(result.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.TestActionResponse{
+		Logs:     result.Logs,
+		Error:    result.Error,
+		Duration: durationpb.New(result.Duration),
+		Result:   changes,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package management
 
 import (
+	"github.com/zitadel/zitadel/internal/actions/execution"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -53,6 +54,16 @@ func listActionsToQuery(orgID string, req *mgmt_pb.ListActionsRequest) (_ *query
 	}, nil
 }
 
+func listActionExecutionsToQuery(req *mgmt_pb.ListActionExecutionsRequest) *execution.SearchQuery {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &execution.SearchQuery{
+		Offset:   offset,
+		Limit:    limit,
+		Asc:      asc,
+		ActionID: req.ActionId,
+	}
+}
+
 func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	switch q := query.(type) {
 	case *mgmt_pb.ActionQuery_ActionNameQuery:
@@ -54,6 +54,47 @@ func addJWTIDPRequestToDomainJWTIDPConfig(req *mgmt_pb.AddOrgJWTIDPRequest) *dom
 	}
 }
 
+func addSAMLIDPRequestToDomain(req *mgmt_pb.AddOrgSAMLIDPRequest) *domain.IDPConfig {
+	return &domain.IDPConfig{
+		Name:         req.Name,
+		SAMLConfig:   addSAMLIDPRequestToDomainSAMLIDPConfig(req),
+		StylingType:  idp_grpc.IDPStylingTypeToDomain(req.StylingType),
+		Type:         domain.IDPConfigTypeSAML,
+		AutoRegister: req.AutoRegister,
+	}
+}
+
+func addSAMLIDPRequestToDomainSAMLIDPConfig(req *mgmt_pb.AddOrgSAMLIDPRequest) *domain.SAMLIDPConfig {
+	return &domain.SAMLIDPConfig{
+		Metadata:    req.GetMetadataXml(),
+		MetadataURL: req.GetMetadataUrl(),
+	}
+}
+
+func addLDAPIDPRequestToDomain(req *mgmt_pb.AddOrgLDAPIDPRequest) *domain.IDPConfig {
+	return &domain.IDPConfig{
+		Name:         req.Name,
+		LDAPConfig:   addLDAPIDPRequestToDomainLDAPIDPConfig(req),
+		StylingType:  idp_grpc.IDPStylingTypeToDomain(req.StylingType),
+		Type:         domain.IDPConfigTypeLDAP,
+		AutoRegister: req.AutoRegister,
+	}
+}
+
+func addLDAPIDPRequestToDomainLDAPIDPConfig(req *mgmt_pb.AddOrgLDAPIDPRequest) *domain.LDAPIDPConfig {
+	return &domain.LDAPIDPConfig{
+		URL:                req.Url,
+		StartTLS:           req.StartTls,
+		RootCA:             req.RootCa,
+		BaseDN:             req.BaseDn,
+		BindDN:             req.BindDn,
+		BindPasswordString: req.BindPassword,
+		UserObjectClass:    req.UserObjectClass,
+		UserFilters:        req.UserFilters,
+		Attributes:         idp_grpc.LDAPAttributesToDomain(req.Attributes),
+	}
+}
+
 func updateIDPToDomain(req *mgmt_pb.UpdateOrgIDPRequest) *domain.IDPConfig {
 	return &domain.IDPConfig{
 		IDPConfigID:  req.IdpId,
@@ -85,6 +126,29 @@ func updateJWTConfigToDomain(req *mgmt_pb.UpdateOrgIDPJWTConfigRequest) *domain.
 	}
 }
 
+func updateSAMLConfigToDomain(req *mgmt_pb.UpdateOrgIDPSAMLConfigRequest) *domain.SAMLIDPConfig {
+	return &domain.SAMLIDPConfig{
+		IDPConfigID: req.IdpId,
+		Metadata:    req.GetMetadataXml(),
+		MetadataURL: req.GetMetadataUrl(),
+	}
+}
+
+func updateLDAPConfigToDomain(req *mgmt_pb.UpdateOrgIDPLDAPConfigRequest) *domain.LDAPIDPConfig {
+	return &domain.LDAPIDPConfig{
+		IDPConfigID:        req.IdpId,
+		URL:                req.Url,
+		StartTLS:           req.StartTls,
+		RootCA:             req.RootCa,
+		BaseDN:             req.BaseDn,
+		BindDN:             req.BindDn,
+		BindPasswordString: req.BindPassword,
+		UserObjectClass:    req.UserObjectClass,
+		UserFilters:        req.UserFilters,
+		Attributes:         idp_grpc.LDAPAttributesToDomain(req.Attributes),
+	}
+}
+
 func listIDPsToModel(ctx context.Context, req *mgmt_pb.ListOrgIDPsRequest) (queries *query.IDPSearchQueries, err error) {
 	offset, limit, asc := object.ListQueryToModel(req.Query)
 	q, err := idpQueriesToModel(req.Queries)
@@ -0,0 +1,65 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	"github.com/zitadel/zitadel/internal/domain"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetAccessPolicy(ctx context.Context, req *mgmt_pb.GetAccessPolicyRequest) (*mgmt_pb.GetAccessPolicyResponse, error) {
+	policy, err := s.query.AccessPolicyByOrg(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAccessPolicyResponse{Policy: policy_grpc.ModelAccessPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultAccessPolicy(ctx context.Context, req *mgmt_pb.GetDefaultAccessPolicyRequest) (*mgmt_pb.GetDefaultAccessPolicyResponse, error) {
+	policy, err := s.query.DefaultAccessPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultAccessPolicyResponse{Policy: policy_grpc.ModelAccessPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomAccessPolicy(ctx context.Context, req *mgmt_pb.AddCustomAccessPolicyRequest) (*mgmt_pb.AddCustomAccessPolicyResponse, error) {
+	policy, err := s.command.AddAccessPolicy(ctx, authz.GetCtxData(ctx).OrgID, &domain.AccessPolicy{Rules: policy_grpc.AccessRulesToDomain(req.Rules)})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomAccessPolicyResponse{
+		Details: object.AddToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomAccessPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomAccessPolicyRequest) (*mgmt_pb.UpdateCustomAccessPolicyResponse, error) {
+	policy, err := s.command.ChangeAccessPolicy(ctx, authz.GetCtxData(ctx).OrgID, &domain.AccessPolicy{Rules: policy_grpc.AccessRulesToDomain(req.Rules)})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomAccessPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			policy.Sequence,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetAccessPolicyToDefault(ctx context.Context, req *mgmt_pb.ResetAccessPolicyToDefaultRequest) (*mgmt_pb.ResetAccessPolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveAccessPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetAccessPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
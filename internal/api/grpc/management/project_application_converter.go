@@ -56,6 +56,11 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		IDTokenUserinfoAssertion: req.IdTokenUserinfoAssertion,
 		ClockSkew:                req.ClockSkew.AsDuration(),
 		AdditionalOrigins:        req.AdditionalOrigins,
+		RequirePushedAuthRequest: req.RequirePushedAuthRequest,
+		RequireRequestObject:     req.RequireRequestObject,
+		BackChannelLogoutURI:     req.BackChannelLogoutUri,
+		FrontChannelLogoutURI:    req.FrontChannelLogoutUri,
+		MinLevelOfAssurance:      app_grpc.OIDCLevelOfAssuranceToDomain(req.MinLevelOfAssurance),
 	}
 }
 
@@ -69,6 +74,17 @@ func AddAPIAppRequestToDomain(app *mgmt_pb.AddAPIAppRequest) *domain.APIApp {
 	}
 }
 
+func AddSAMLAppRequestToDomain(app *mgmt_pb.AddSAMLAppRequest) *domain.SAMLApp {
+	return &domain.SAMLApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: app.ProjectId,
+		},
+		AppName:     app.Name,
+		Metadata:    app.GetMetadataXml(),
+		MetadataURL: app.GetMetadataUrl(),
+	}
+}
+
 func UpdateAppRequestToDomain(app *mgmt_pb.UpdateAppRequest) domain.Application {
 	return &domain.ChangeApp{
 		AppID:   app.AppId,
@@ -95,6 +111,11 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
 		ClockSkew:                app.ClockSkew.AsDuration(),
 		AdditionalOrigins:        app.AdditionalOrigins,
+		RequirePushedAuthRequest: app.RequirePushedAuthRequest,
+		RequireRequestObject:     app.RequireRequestObject,
+		BackChannelLogoutURI:     app.BackChannelLogoutUri,
+		FrontChannelLogoutURI:    app.FrontChannelLogoutUri,
+		MinLevelOfAssurance:      app_grpc.OIDCLevelOfAssuranceToDomain(app.MinLevelOfAssurance),
 	}
 }
 
@@ -108,6 +129,17 @@ func UpdateAPIAppConfigRequestToDomain(app *mgmt_pb.UpdateAPIAppConfigRequest) *
 	}
 }
 
+func UpdateSAMLAppConfigRequestToDomain(app *mgmt_pb.UpdateSAMLAppConfigRequest) *domain.SAMLApp {
+	return &domain.SAMLApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: app.ProjectId,
+		},
+		AppID:       app.AppId,
+		Metadata:    app.GetMetadataXml(),
+		MetadataURL: app.GetMetadataUrl(),
+	}
+}
+
 func AddAPIClientKeyRequestToDomain(key *mgmt_pb.AddAppKeyRequest) *domain.ApplicationKey {
 	expirationDate := time.Time{}
 	if key.ExpirationDate != nil {
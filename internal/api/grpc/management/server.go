@@ -6,6 +6,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/zitadel/zitadel/internal/actions/execution"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
@@ -33,6 +34,7 @@ type Server struct {
 	externalSecure    bool
 	issuerPath        string
 	auditLogRetention time.Duration
+	actionExecutions  *execution.Storage
 }
 
 func CreateServer(
@@ -43,6 +45,7 @@ func CreateServer(
 	externalSecure bool,
 	issuerPath string,
 	auditLogRetention time.Duration,
+	actionExecutions *execution.Storage,
 ) *Server {
 	return &Server{
 		command:           command,
@@ -54,6 +57,7 @@ func CreateServer(
 		externalSecure:    externalSecure,
 		issuerPath:        issuerPath,
 		auditLogRetention: auditLogRetention,
+		actionExecutions:  actionExecutions,
 	}
 }
 
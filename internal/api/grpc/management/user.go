@@ -586,7 +586,7 @@ func (s *Server) ListHumanAuthFactors(ctx context.Context, req *mgmt_pb.ListHuma
 	if err != nil {
 		return nil, err
 	}
-	err = query.AppendAuthMethodsQuery(domain.UserAuthMethodTypeU2F, domain.UserAuthMethodTypeOTP)
+	err = query.AppendAuthMethodsQuery(domain.UserAuthMethodTypeU2F, domain.UserAuthMethodTypeOTP, domain.UserAuthMethodTypeOTPSMS, domain.UserAuthMethodTypeOTPEmail, domain.UserAuthMethodTypeRecoveryCodes)
 	if err != nil {
 		return nil, err
 	}
@@ -689,6 +689,60 @@ func (s *Server) RemoveHumanPasswordless(ctx context.Context, req *mgmt_pb.Remov
 	}, nil
 }
 
+func (s *Server) ListUserSessions(ctx context.Context, req *mgmt_pb.ListUserSessionsRequest) (*mgmt_pb.ListUserSessionsResponse, error) {
+	queries, err := ListUserSessionsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchUserSessions(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListUserSessionsResponse{
+		Result: user_grpc.UserAgentSessionsToPb(result.UserSessions),
+		Details: obj_grpc.ToListDetails(
+			result.Count,
+			result.Sequence,
+			result.Timestamp,
+		),
+	}, nil
+}
+
+func (s *Server) TerminateUserSession(ctx context.Context, req *mgmt_pb.TerminateUserSessionRequest) (*mgmt_pb.TerminateUserSessionResponse, error) {
+	objectDetails, err := s.command.TerminateHumanSessions(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, []string{req.AgentId})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.TerminateUserSessionResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) TerminateAllUserSessions(ctx context.Context, req *mgmt_pb.TerminateAllUserSessionsRequest) (*mgmt_pb.TerminateAllUserSessionsResponse, error) {
+	queries, err := activeUserSessionsQuery(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.query.SearchUserSessions(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions.UserSessions) == 0 {
+		return &mgmt_pb.TerminateAllUserSessionsResponse{}, nil
+	}
+	agentIDs := make([]string, len(sessions.UserSessions))
+	for i, session := range sessions.UserSessions {
+		agentIDs[i] = session.UserAgentID
+	}
+	objectDetails, err := s.command.TerminateHumanSessions(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.TerminateAllUserSessionsResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) UpdateMachine(ctx context.Context, req *mgmt_pb.UpdateMachineRequest) (*mgmt_pb.UpdateMachineResponse, error) {
 	machine, err := s.command.ChangeMachine(ctx, UpdateMachineRequestToDomain(ctx, req))
 	if err != nil {
@@ -770,6 +824,40 @@ func (s *Server) RemoveMachineKey(ctx context.Context, req *mgmt_pb.RemoveMachin
 	}, nil
 }
 
+func (s *Server) GenerateMachineSecret(ctx context.Context, req *mgmt_pb.GenerateMachineSecretRequest) (*mgmt_pb.GenerateMachineSecretResponse, error) {
+	owner, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.query.GetUserByID(ctx, req.UserId, owner)
+	if err != nil {
+		return nil, err
+	}
+	secretGenerator, err := s.query.InitHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret, s.passwordHashAlg)
+	if err != nil {
+		return nil, err
+	}
+	objectDetails, secret, err := s.command.GenerateMachineSecret(ctx, req.UserId, authz.GetCtxData(ctx).OrgID, secretGenerator)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GenerateMachineSecretResponse{
+		ClientId:     user.PreferredLoginName,
+		ClientSecret: secret,
+		Details:      obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
+func (s *Server) RemoveMachineSecret(ctx context.Context, req *mgmt_pb.RemoveMachineSecretRequest) (*mgmt_pb.RemoveMachineSecretResponse, error) {
+	objectDetails, err := s.command.RemoveMachineSecret(ctx, req.UserId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveMachineSecretResponse{
+		Details: obj_grpc.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
+
 func (s *Server) GetPersonalAccessTokenByIDs(ctx context.Context, req *mgmt_pb.GetPersonalAccessTokenByIDsRequest) (*mgmt_pb.GetPersonalAccessTokenByIDsResponse, error) {
 	resourceOwner, err := query.NewPersonalAccessTokenResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
@@ -145,6 +145,9 @@ func ImportHumanUserRequestToDomain(req *mgmt_pb.ImportHumanUserRequest) (human
 	if req.Password != "" {
 		human.Password = &domain.Password{SecretString: req.Password}
 		human.Password.ChangeRequired = req.PasswordChangeRequired
+	} else if req.HashedPassword != nil {
+		human.Password = &domain.Password{EncodedHash: req.HashedPassword.Value}
+		human.Password.ChangeRequired = req.PasswordChangeRequired
 	}
 
 	return human, req.RequestPasswordlessRegistration
@@ -367,3 +370,49 @@ func MemberTypeToDomain(mType user_model.MemberType) domain.MemberType {
 		return domain.MemberTypeUnspecified
 	}
 }
+
+func ListUserSessionsRequestToQuery(ctx context.Context, req *mgmt_pb.ListUserSessionsRequest) (*query.UserSessionSearchQueries, error) {
+	resourceOwner, err := query.NewUserSessionResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := query.NewUserSessionUserIDSearchQuery(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.UserSessionSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: query.UserSessionColumnChangeDate,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwner,
+			userID,
+		},
+	}, nil
+}
+
+func activeUserSessionsQuery(ctx context.Context, userID string) (*query.UserSessionSearchQueries, error) {
+	resourceOwnerQuery, err := query.NewUserSessionResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserSessionUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	stateQuery, err := query.NewUserSessionStateSearchQuery(domain.UserSessionStateActive)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserSessionSearchQueries{
+		Queries: []query.SearchQuery{
+			resourceOwnerQuery,
+			userIDQuery,
+			stateQuery,
+		},
+	}, nil
+}
@@ -122,6 +122,23 @@ func TextMethodToQuery(method object_pb.TextQueryMethod) query.TextComparison {
 	}
 }
 
+func TimestampMethodToQuery(method object_pb.TimestampQueryMethod) query.TimestampComparison {
+	switch method {
+	case object_pb.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_EQUALS:
+		return query.TimestampEquals
+	case object_pb.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_GREATER:
+		return query.TimestampGreater
+	case object_pb.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_GREATER_OR_EQUALS:
+		return query.TimestampGreaterOrEquals
+	case object_pb.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_LESS:
+		return query.TimestampLess
+	case object_pb.TimestampQueryMethod_TIMESTAMP_QUERY_METHOD_LESS_OR_EQUALS:
+		return query.TimestampLessOrEquals
+	default:
+		return -1
+	}
+}
+
 func ListQueryToModel(query *object_pb.ListQuery) (offset, limit uint64, asc bool) {
 	if query == nil {
 		return 0, 0, false
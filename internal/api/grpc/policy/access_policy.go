@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelAccessPolicyToPb(policy *query.AccessPolicy) *policy_pb.AccessPolicy {
+	return &policy_pb.AccessPolicy{
+		IsDefault: policy.IsDefault,
+		Rules:     AccessRulesToPb(policy.Rules),
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
+
+func AccessRulesToPb(rules domain.AccessRules) []*policy_pb.AccessRule {
+	r := make([]*policy_pb.AccessRule, len(rules))
+	for i, rule := range rules {
+		r[i] = &policy_pb.AccessRule{
+			Name:           rule.Name,
+			Action:         AccessRuleActionToPb(rule.Action),
+			IpRanges:       rule.IPRanges,
+			Countries:      rule.Countries,
+			NewDevice:      rule.NewDevice,
+			ApplicationIds: rule.ApplicationIDs,
+			UserRoles:      rule.UserRoles,
+		}
+	}
+	return r
+}
+
+func AccessRulesToDomain(rules []*policy_pb.AccessRule) domain.AccessRules {
+	r := make(domain.AccessRules, len(rules))
+	for i, rule := range rules {
+		r[i] = &domain.AccessRule{
+			Name:           rule.Name,
+			Action:         AccessRuleActionToDomain(rule.Action),
+			IPRanges:       rule.IpRanges,
+			Countries:      rule.Countries,
+			NewDevice:      rule.NewDevice,
+			ApplicationIDs: rule.ApplicationIds,
+			UserRoles:      rule.UserRoles,
+		}
+	}
+	return r
+}
+
+func AccessRuleActionToPb(action domain.AccessRuleAction) policy_pb.AccessRuleAction {
+	switch action {
+	case domain.AccessRuleActionDeny:
+		return policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_DENY
+	case domain.AccessRuleActionRequireMFA:
+		return policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_REQUIRE_MFA
+	case domain.AccessRuleActionRequirePasswordless:
+		return policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_REQUIRE_PASSWORDLESS
+	default:
+		return policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_ALLOW
+	}
+}
+
+func AccessRuleActionToDomain(action policy_pb.AccessRuleAction) domain.AccessRuleAction {
+	switch action {
+	case policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_ALLOW:
+		return domain.AccessRuleActionAllow
+	case policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_DENY:
+		return domain.AccessRuleActionDeny
+	case policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_REQUIRE_MFA:
+		return domain.AccessRuleActionRequireMFA
+	case policy_pb.AccessRuleAction_ACCESS_RULE_ACTION_REQUIRE_PASSWORDLESS:
+		return domain.AccessRuleActionRequirePasswordless
+	default:
+		// unknown actions are rejected by the validation of the rules
+		return domain.AccessRuleAction(action)
+	}
+}
@@ -8,12 +8,14 @@ import (
 
 func ModelPasswordComplexityPolicyToPb(policy *query.PasswordComplexityPolicy) *policy_pb.PasswordComplexityPolicy {
 	return &policy_pb.PasswordComplexityPolicy{
-		IsDefault:    policy.IsDefault,
-		MinLength:    policy.MinLength,
-		HasUppercase: policy.HasUppercase,
-		HasLowercase: policy.HasLowercase,
-		HasNumber:    policy.HasNumber,
-		HasSymbol:    policy.HasSymbol,
+		IsDefault:     policy.IsDefault,
+		MinLength:     policy.MinLength,
+		HasUppercase:  policy.HasUppercase,
+		HasLowercase:  policy.HasLowercase,
+		HasNumber:     policy.HasNumber,
+		HasSymbol:     policy.HasSymbol,
+		HistoryDepth:  policy.HistoryDepth,
+		CheckBreached: policy.CheckBreached,
 		Details: object.ToViewDetailsPb(
 			policy.Sequence,
 			policy.CreationDate,
@@ -33,6 +33,9 @@ func AppConfigToPb(app *query.App) app_pb.AppConfig {
 	if app.OIDCConfig != nil {
 		return AppOIDCConfigToPb(app.OIDCConfig)
 	}
+	if app.SAMLConfig != nil {
+		return AppSAMLConfigToPb(app.SAMLConfig)
+	}
 	return AppAPIConfigToPb(app.APIConfig)
 }
 
@@ -57,6 +60,11 @@ func AppOIDCConfigToPb(app *query.OIDCApp) *app_pb.App_OidcConfig {
 			ClockSkew:                durationpb.New(app.ClockSkew),
 			AdditionalOrigins:        app.AdditionalOrigins,
 			AllowedOrigins:           app.AllowedOrigins,
+			RequirePushedAuthRequest: app.RequirePushedAuthRequest,
+			RequireRequestObject:     app.RequireRequestObject,
+			BackChannelLogoutUri:     app.BackChannelLogoutURI,
+			FrontChannelLogoutUri:    app.FrontChannelLogoutURI,
+			MinLevelOfAssurance:      OIDCLevelOfAssuranceToPb(app.MinLevelOfAssurance),
 		},
 	}
 }
@@ -70,6 +78,20 @@ func AppAPIConfigToPb(app *query.APIApp) app_pb.AppConfig {
 	}
 }
 
+func AppSAMLConfigToPb(app *query.SAMLApp) app_pb.AppConfig {
+	config := &app_pb.SAMLConfig{
+		EntityId: app.EntityID,
+	}
+	if app.MetadataURL != "" {
+		config.Metadata = &app_pb.SAMLConfig_MetadataUrl{MetadataUrl: app.MetadataURL}
+	} else {
+		config.Metadata = &app_pb.SAMLConfig_MetadataXml{MetadataXml: app.Metadata}
+	}
+	return &app_pb.App_SamlConfig{
+		SamlConfig: config,
+	}
+}
+
 func AppStateToPb(state domain.AppState) app_pb.AppState {
 	switch state {
 	case domain.AppStateActive:
@@ -124,6 +146,10 @@ func OIDCGrantTypesFromModel(grantTypes []domain.OIDCGrantType) []app_pb.OIDCGra
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_IMPLICIT
 		case domain.OIDCGrantTypeRefreshToken:
 			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN
+		case domain.OIDCGrantTypeDeviceCode:
+			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE
+		case domain.OIDCGrantTypeTokenExchange:
+			oidcGrantTypes[i] = app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE
 		}
 	}
 	return oidcGrantTypes
@@ -142,6 +168,10 @@ func OIDCGrantTypesToDomain(grantTypes []app_pb.OIDCGrantType) []domain.OIDCGran
 			oidcGrantTypes[i] = domain.OIDCGrantTypeImplicit
 		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN:
 			oidcGrantTypes[i] = domain.OIDCGrantTypeRefreshToken
+		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_DEVICE_CODE:
+			oidcGrantTypes[i] = domain.OIDCGrantTypeDeviceCode
+		case app_pb.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE:
+			oidcGrantTypes[i] = domain.OIDCGrantTypeTokenExchange
 		}
 	}
 	return oidcGrantTypes
@@ -240,6 +270,32 @@ func OIDCTokenTypeToDomain(tokenType app_pb.OIDCTokenType) domain.OIDCTokenType
 	}
 }
 
+func OIDCLevelOfAssuranceToPb(level domain.LevelOfAssurance) app_pb.OIDCLevelOfAssurance {
+	switch level {
+	case domain.LevelOfAssurancePassword:
+		return app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_PASSWORD
+	case domain.LevelOfAssuranceMFA:
+		return app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_MFA
+	case domain.LevelOfAssurancePhishingResistant:
+		return app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_PHISHING_RESISTANT
+	default:
+		return app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_NONE
+	}
+}
+
+func OIDCLevelOfAssuranceToDomain(level app_pb.OIDCLevelOfAssurance) domain.LevelOfAssurance {
+	switch level {
+	case app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_PASSWORD:
+		return domain.LevelOfAssurancePassword
+	case app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_MFA:
+		return domain.LevelOfAssuranceMFA
+	case app_pb.OIDCLevelOfAssurance_OIDC_LEVEL_OF_ASSURANCE_PHISHING_RESISTANT:
+		return domain.LevelOfAssurancePhishingResistant
+	default:
+		return domain.LevelOfAssuranceNone
+	}
+}
+
 func ComplianceProblemsToLocalizedMessages(problems []string) []*message_pb.LocalizedMessage {
 	converted := make([]*message_pb.LocalizedMessage, len(problems))
 	for i, p := range problems {
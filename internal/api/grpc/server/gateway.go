@@ -9,9 +9,12 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	client_middleware "github.com/zitadel/zitadel/internal/api/grpc/client/middleware"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 )
 
@@ -22,6 +25,7 @@ const (
 var (
 	customHeaders = []string{
 		"x-zitadel-",
+		"dpop",
 	}
 	jsonMarshaler = &runtime.JSONPb{
 		UnmarshalOptions: protojson.UnmarshalOptions{
@@ -35,6 +39,7 @@ var (
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, jsonMarshaler),
 		runtime.WithIncomingHeaderMatcher(headerMatcher),
 		runtime.WithOutgoingHeaderMatcher(runtime.DefaultHeaderMatcher),
+		runtime.WithMetadata(gatewayRequestMetadata),
 	}
 
 	headerMatcher = runtime.HeaderMatcherFunc(
@@ -49,6 +54,12 @@ var (
 	)
 )
 
+// gatewayRequestMetadata passes the HTTP request to the gRPC server,
+// so the DPoP proof and the client certificate can be verified against it
+func gatewayRequestMetadata(_ context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(http_util.ZitadelGatewayRequest, authz.GatewayRequestMetadata(r))
+}
+
 type Gateway interface {
 	RegisterGateway() GatewayFunc
 	GatewayPathPrefix() string
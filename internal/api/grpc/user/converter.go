@@ -1,6 +1,10 @@
 package user
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -65,7 +69,15 @@ func HumanToPb(view *query.Human, assetPrefix, owner string) *user_pb.Human {
 			Phone:           view.Phone,
 			IsPhoneVerified: view.IsPhoneVerified,
 		},
+		PasswordChanged: passwordChangedToPb(view.PasswordChanged),
+	}
+}
+
+func passwordChangedToPb(passwordChanged time.Time) *timestamppb.Timestamp {
+	if passwordChanged.IsZero() {
+		return nil
 	}
+	return timestamppb.New(passwordChanged)
 }
 
 func MachineToPb(view *query.Machine) *user_pb.Machine {
@@ -177,6 +189,20 @@ func AuthMethodToPb(mfa *query.AuthMethod) *user_pb.AuthFactor {
 		factor.Type = &user_pb.AuthFactor_Otp{
 			Otp: &user_pb.AuthFactorOTP{},
 		}
+	case domain.UserAuthMethodTypeOTPSMS:
+		factor.Type = &user_pb.AuthFactor_OtpSms{
+			OtpSms: &user_pb.AuthFactorOTPSMS{},
+		}
+	case domain.UserAuthMethodTypeOTPEmail:
+		factor.Type = &user_pb.AuthFactor_OtpEmail{
+			OtpEmail: &user_pb.AuthFactorOTPEmail{},
+		}
+	case domain.UserAuthMethodTypeRecoveryCodes:
+		factor.Type = &user_pb.AuthFactor_RecoveryCodes{
+			RecoveryCodes: &user_pb.AuthFactorRecoveryCodes{
+				Remaining: mfa.RemainingCodes,
+			},
+		}
 	case domain.UserAuthMethodTypeU2F:
 		factor.Type = &user_pb.AuthFactor_U2F{
 			U2F: &user_pb.AuthFactorU2F{
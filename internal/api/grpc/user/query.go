@@ -36,6 +36,8 @@ func UserQueryToQuery(query *user_pb.SearchQuery) (query.SearchQuery, error) {
 		return StateQueryToQuery(q.StateQuery)
 	case *user_pb.SearchQuery_TypeQuery:
 		return TypeQueryToQuery(q.TypeQuery)
+	case *user_pb.SearchQuery_PasswordChangedQuery:
+		return PasswordChangedQueryToQuery(q.PasswordChangedQuery)
 	case *user_pb.SearchQuery_ResourceOwner:
 		return ResourceOwnerQueryToQuery(q.ResourceOwner)
 	default:
@@ -75,6 +77,10 @@ func TypeQueryToQuery(q *user_pb.TypeQuery) (query.SearchQuery, error) {
 	return query.NewUserTypeSearchQuery(int32(q.Type))
 }
 
+func PasswordChangedQueryToQuery(q *user_pb.PasswordChangedQuery) (query.SearchQuery, error) {
+	return query.NewUserPasswordChangedSearchQuery(q.PasswordChanged.AsTime(), object.TimestampMethodToQuery(q.Method))
+}
+
 func ResourceOwnerQueryToQuery(q *user_pb.ResourceOwnerQuery) (query.SearchQuery, error) {
 	return query.NewUserResourceOwnerSearchQuery(q.OrgID, query.TextEquals)
 }
@@ -1,8 +1,13 @@
 package user
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
 	user_model "github.com/zitadel/zitadel/internal/user/model"
 	"github.com/zitadel/zitadel/pkg/grpc/user"
 )
@@ -44,3 +49,68 @@ func SessionStateToPb(state domain.UserSessionState) user.SessionState {
 		return user.SessionState_SESSION_STATE_UNSPECIFIED
 	}
 }
+
+func UserAgentSessionsToPb(sessions []*query.UserSession) []*user.UserAgentSession {
+	s := make([]*user.UserAgentSession, len(sessions))
+	for i, session := range sessions {
+		s[i] = UserAgentSessionToPb(session)
+	}
+	return s
+}
+
+func UserAgentSessionToPb(session *query.UserSession) *user.UserAgentSession {
+	return &user.UserAgentSession{
+		AgentId:      session.UserAgentID,
+		UserId:       session.UserID,
+		AuthState:    SessionStateToPb(session.State),
+		LastActivity: timestamppb.New(session.ChangeDate),
+		Device: &user.SessionDevice{
+			UserAgent: session.BrowserUserAgent,
+			RemoteIp:  session.RemoteIP,
+		},
+		Factors: sessionFactorsToPb(session),
+		AppIds:  session.ApplicationIDs,
+		Details: object.ToViewDetailsPb(
+			session.Sequence,
+			session.CreationDate,
+			session.ChangeDate,
+			session.ResourceOwner,
+		),
+	}
+}
+
+func sessionFactorsToPb(session *query.UserSession) []*user.SessionFactor {
+	factors := make([]*user.SessionFactor, 0)
+	appendFactor := func(factorType user.SessionFactorType, verifiedAt time.Time) {
+		if verifiedAt.IsZero() {
+			return
+		}
+		factors = append(factors, &user.SessionFactor{
+			Type:       factorType,
+			VerifiedAt: timestamppb.New(verifiedAt),
+		})
+	}
+	appendFactor(user.SessionFactorType_SESSION_FACTOR_TYPE_PASSWORD, session.PasswordVerification)
+	appendFactor(user.SessionFactorType_SESSION_FACTOR_TYPE_PASSWORDLESS, session.PasswordlessVerification)
+	appendFactor(user.SessionFactorType_SESSION_FACTOR_TYPE_MAGIC_LINK, session.MagicLinkVerification)
+	appendFactor(user.SessionFactorType_SESSION_FACTOR_TYPE_EXTERNAL_IDP, session.ExternalLoginVerification)
+	appendFactor(secondFactorTypeToPb(session.SecondFactorVerificationType), session.SecondFactorVerification)
+	return factors
+}
+
+func secondFactorTypeToPb(mfaType domain.MFAType) user.SessionFactorType {
+	switch mfaType {
+	case domain.MFATypeOTP:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_OTP
+	case domain.MFATypeOTPSMS:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_OTP_SMS
+	case domain.MFATypeOTPEmail:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_OTP_EMAIL
+	case domain.MFATypeU2F:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_U2F
+	case domain.MFATypeRecoveryCode:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_RECOVERY_CODE
+	default:
+		return user.SessionFactorType_SESSION_FACTOR_TYPE_UNSPECIFIED
+	}
+}
@@ -22,6 +22,7 @@ const (
 	ForwardedFor    = "x-forwarded-for"
 	XUserAgent      = "x-user-agent"
 	XGrpcWeb        = "x-grpc-web"
+	DPoP            = "dpop"
 	IfNoneMatch     = "If-None-Match"
 	LastModified    = "Last-Modified"
 	Etag            = "Etag"
@@ -36,6 +37,11 @@ const (
 	PermissionsPolicy       = "permissions-policy"
 
 	ZitadelOrgID = "x-zitadel-orgid"
+	// ZitadelClientCertificate is the url encoded PEM of the client certificate,
+	// which is only accepted from the reverse proxies terminating mTLS configured as trusted
+	ZitadelClientCertificate = "x-zitadel-client-cert"
+	// ZitadelGatewayRequest is the signed HTTP request the gRPC gateway passes to the gRPC server
+	ZitadelGatewayRequest = "x-zitadel-gateway-request"
 )
 
 type key int
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+// ClientCertificateConfig names the reverse proxies terminating TLS,
+// which forward the client certificate for certificate bound access tokens (RFC 8705)
+type ClientCertificateConfig struct {
+	// TrustedProxies are the IPs or CIDRs of the proxies,
+	// without any the forwarded client certificate is ignored and certificate bound tokens are rejected
+	TrustedProxies []string
+}
+
+// ClientCertificateInterceptor removes the forwarded client certificate
+// from all requests not sent by a trusted proxy (the remote address of the connection, not the x-forwarded-for header)
+func ClientCertificateInterceptor(config ClientCertificateConfig) (func(http.Handler) http.Handler, error) {
+	trustedProxies := make([]*net.IPNet, 0, len(config.TrustedProxies))
+	for _, proxy := range config.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		trustedProxies = append(trustedProxies, network)
+	}
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(http_utils.ZitadelClientCertificate) != "" && !isTrustedProxy(r, trustedProxies) {
+				r.Header.Del(http_utils.ZitadelClientCertificate)
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func isTrustedProxy(r *http.Request, trustedProxies []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
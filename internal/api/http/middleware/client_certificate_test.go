@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+func TestClientCertificateInterceptor(t *testing.T) {
+	type args struct {
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantCertificate bool
+	}{
+		{
+			name: "no trusted proxies, removed",
+			args: args{
+				remoteAddr: "10.0.0.1:1234",
+			},
+		},
+		{
+			name: "untrusted peer, removed",
+			args: args{
+				trustedProxies: []string{"10.0.0.1"},
+				remoteAddr:     "192.0.2.1:1234",
+			},
+		},
+		{
+			name: "untrusted peer with forwarded for of proxy, removed",
+			args: args{
+				trustedProxies: []string{"10.0.0.1"},
+				remoteAddr:     "192.0.2.1:1234",
+				forwardedFor:   "10.0.0.1",
+			},
+		},
+		{
+			name: "trusted proxy ip, kept",
+			args: args{
+				trustedProxies: []string{"10.0.0.1"},
+				remoteAddr:     "10.0.0.1:1234",
+			},
+			wantCertificate: true,
+		},
+		{
+			name: "trusted proxy cidr, kept",
+			args: args{
+				trustedProxies: []string{"10.0.0.0/8"},
+				remoteAddr:     "10.1.2.3:1234",
+			},
+			wantCertificate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor, err := ClientCertificateInterceptor(ClientCertificateConfig{TrustedProxies: tt.args.trustedProxies})
+			require.NoError(t, err)
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.args.remoteAddr
+			r.Header.Set(http_utils.ZitadelClientCertificate, "certificate")
+			if tt.args.forwardedFor != "" {
+				r.Header.Set(http_utils.ForwardedFor, tt.args.forwardedFor)
+			}
+			var got string
+			interceptor(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(http_utils.ZitadelClientCertificate)
+			})).ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.wantCertificate, got != "")
+		})
+	}
+}
+
+func TestClientCertificateInterceptor_invalidProxy(t *testing.T) {
+	_, err := ClientCertificateInterceptor(ClientCertificateConfig{TrustedProxies: []string{"proxy"}})
+	assert.Error(t, err)
+}
@@ -5,12 +5,12 @@ import (
 	"strings"
 	"time"
 
-	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
@@ -28,7 +28,12 @@ func (o *OPStorage) CreateAuthRequest(ctx context.Context, req *oidc.AuthRequest
 	if err != nil {
 		return nil, errors.ThrowPreconditionFailed(err, "OIDC-Gqrfg", "Errors.Internal")
 	}
+	app, err := o.query.AppByOIDCClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
 	authRequest := CreateAuthRequestToBusiness(ctx, req, userAgentID, userID)
+	authRequest.MinLevelOfAssurance = app.OIDCConfig.MinLevelOfAssurance
 	resp, err := o.repo.CreateAuthRequest(ctx, authRequest)
 	if err != nil {
 		return nil, err
@@ -88,7 +93,7 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 		applicationID = authReq.ApplicationID
 		userOrgID = authReq.UserOrgID
 	}
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), o.defaultAccessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), o.defaultAccessTokenLifetime, tokenConfirmationFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -108,7 +113,7 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	}
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, o.defaultAccessTokenLifetime,
-		o.defaultRefreshTokenIdleExpiration, o.defaultRefreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		o.defaultRefreshTokenIdleExpiration, o.defaultRefreshTokenExpiration, authTime, tokenConfirmationFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -138,30 +143,6 @@ func (o *OPStorage) TokenRequestByRefreshToken(ctx context.Context, refreshToken
 	return RefreshTokenRequestFromBusiness(tokenView), nil
 }
 
-func (o *OPStorage) TerminateSession(ctx context.Context, userID, clientID string) (err error) {
-	ctx, span := tracing.NewSpan(ctx)
-	defer func() { span.EndWithError(err) }()
-	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
-	if !ok {
-		logging.Log("OIDC-aGh4q").Error("no user agent id")
-		return errors.ThrowPreconditionFailed(nil, "OIDC-fso7F", "no user agent id")
-	}
-	userIDs, err := o.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
-	if err != nil {
-		logging.Log("OIDC-Ghgr3").WithError(err).Error("error retrieving user sessions")
-		return err
-	}
-	if len(userIDs) == 0 {
-		return nil
-	}
-	data := authz.CtxData{
-		UserID: userID,
-	}
-	err = o.command.HumansSignOut(authz.SetCtxData(ctx, data), userAgentID, userIDs)
-	logging.Log("OIDC-Dggt2").OnError(err).Error("error signing out")
-	return err
-}
-
 func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID string) *oidc.Error {
 	refreshToken, err := o.repo.RefreshTokenByID(ctx, token)
 	if err == nil {
@@ -191,6 +172,143 @@ func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID str
 	return oidc.ErrServerError().WithParent(err)
 }
 
+// ExchangeToken issues an access token in exchange of the subject token (RFC 8693)
+// the new token is restricted to the requested scopes and audience of the subject token
+// the user of the actor token is set as actor (delegation), otherwise the actor of the subject token is kept
+func (o *OPStorage) ExchangeToken(ctx context.Context, exchange *tokenExchange) (_ *domain.Token, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	if exchange.subjectToken == nil {
+		return o.impersonate(ctx, exchange)
+	}
+	subject := exchange.subjectToken
+	projectID, err := o.query.ProjectIDFromOIDCClientID(ctx, exchange.clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	if err = verifyExchangeAudience(exchange, projectID); err != nil {
+		return nil, err
+	}
+	scopes, ok := restrictTo(subject.Scopes, exchange.scopes)
+	if !ok {
+		return nil, oidc.ErrInvalidScope().WithDescription("scope exceeds the scope of the subject_token")
+	}
+	audience, ok := restrictTo(subject.Audience, exchange.audience)
+	if !ok {
+		return nil, &oidc.Error{ErrorType: "invalid_target", Description: "audience exceeds the audience of the subject_token"}
+	}
+	actor := subject.Actor
+	if exchange.actorToken != nil {
+		actor = tokenActor(ctx, exchange.actorToken)
+	}
+	token, err := o.command.ExchangeUserToken(setContextUserActor(ctx, actor), subject.ResourceOwner, subject.UserAgentID, exchange.clientID, subject.UserID,
+		audience, scopes, o.defaultAccessTokenLifetime, actor, false, tokenConfirmationFromContext(ctx))
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithParent(err)
+	}
+	return token, nil
+}
+
+// impersonate issues an access token of the user for the actor (support staff)
+// the actor needs the impersonation permission on the organisation of the user (or instance)
+// users with memberships (managers) can't be impersonated to prevent an escalation of privileges
+func (o *OPStorage) impersonate(ctx context.Context, exchange *tokenExchange) (*domain.Token, error) {
+	if exchange.actorToken == nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token required for impersonation")
+	}
+	projectID, err := o.query.ProjectIDFromOIDCClientID(ctx, exchange.clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	if err = verifyExchangeAudience(exchange, projectID); err != nil {
+		return nil, err
+	}
+	user, err := o.query.GetUserByID(ctx, exchange.subjectUserID)
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid subject_token").WithParent(err)
+	}
+	permissions, err := o.query.MyZitadelPermissions(ctx, user.ResourceOwner, exchange.actorToken.UserID)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	if !permissions.Contains(domain.PermissionUserImpersonation) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("impersonation not allowed for actor_token")
+	}
+	userIDQuery, err := query.NewMembershipUserIDQuery(user.ID)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	memberships, err := o.query.Memberships(ctx, &query.MembershipSearchQuery{Queries: []query.SearchQuery{userIDQuery}})
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	if len(memberships.Memberships) > 0 {
+		return nil, oidc.ErrInvalidRequest().WithDescription("impersonation of managers not allowed")
+	}
+	audience, ok := restrictTo([]string{projectID, exchange.clientID}, exchange.audience)
+	if !ok {
+		return nil, &oidc.Error{ErrorType: "invalid_target", Description: "audience not allowed for this client"}
+	}
+	scopes, err := o.assertProjectRoleScopes(ctx, exchange.clientID, exchange.scopes)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	actor := tokenActor(ctx, exchange.actorToken)
+	token, err := o.command.ExchangeUserToken(setContextUserActor(ctx, actor), user.ResourceOwner, "", exchange.clientID, user.ID,
+		audience, scopes, o.defaultAccessTokenLifetime, actor, true, tokenConfirmationFromContext(ctx))
+	if err != nil {
+		return nil, oidc.ErrInvalidRequest().WithParent(err)
+	}
+	return token, nil
+}
+
+// verifyExchangeAudience checks that the subject and actor token were issued for the client (or its project),
+// so tokens of other applications of the instance can't be exchanged or act on behalf of the subject
+func verifyExchangeAudience(exchange *tokenExchange, projectID string) error {
+	if exchange.subjectToken != nil && !containsAny(exchange.subjectToken.Audience, exchange.clientID, projectID) {
+		return oidc.ErrInvalidRequest().WithDescription("subject_token was not issued for this client")
+	}
+	if exchange.actorToken != nil && !containsAny(exchange.actorToken.Audience, exchange.clientID, projectID) {
+		return oidc.ErrInvalidRequest().WithDescription("actor_token was not issued for this client")
+	}
+	return nil
+}
+
+func tokenActor(ctx context.Context, token *model.TokenView) *domain.TokenActor {
+	return &domain.TokenActor{
+		Actor:         token.Actor,
+		UserID:        token.UserID,
+		Issuer:        op.IssuerFromContext(ctx),
+		ResourceOwner: token.ResourceOwner,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+	}
+}
+
+// restrictTo returns the requested values if all of them are allowed
+// or all allowed values if none are requested
+func restrictTo(allowed, requested []string) ([]string, bool) {
+	if len(requested) == 0 {
+		return allowed, true
+	}
+	for _, value := range requested {
+		if !containsAny(allowed, value) {
+			return nil, false
+		}
+	}
+	return requested, true
+}
+
+func containsAny(list []string, values ...string) bool {
+	for _, entry := range list {
+		for _, value := range values {
+			if entry == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (o *OPStorage) assertProjectRoleScopes(ctx context.Context, clientID string, scopes []string) ([]string, error) {
 	for _, scope := range scopes {
 		if strings.HasPrefix(scope, ScopeProjectRolePrefix) {
@@ -201,6 +319,27 @@ func (o *OPStorage) assertProjectRoleScopes(ctx context.Context, clientID string
 	if err != nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-AEG4d", "Errors.Internal")
 	}
+	return o.assertProjectRoleScopesByProject(ctx, projectID, scopes)
+}
+
+// assertAudienceProjectRoleScopes asserts the roles of all projects requested by the audience scope
+// it's used on grants without an application (e.g. client credentials), where the project is unknown
+func (o *OPStorage) assertAudienceProjectRoleScopes(ctx context.Context, scopes []string) (_ []string, err error) {
+	for _, scope := range scopes {
+		if strings.HasPrefix(scope, ScopeProjectRolePrefix) {
+			return scopes, nil
+		}
+	}
+	for _, projectID := range domain.AddAudScopeToAudience(nil, scopes) {
+		scopes, err = o.assertProjectRoleScopesByProject(ctx, projectID, scopes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scopes, nil
+}
+
+func (o *OPStorage) assertProjectRoleScopesByProject(ctx context.Context, projectID string, scopes []string) ([]string, error) {
 	project, err := o.query.ProjectByID(ctx, projectID)
 	if err != nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-w4wIn", "Errors.Internal")
@@ -248,3 +387,17 @@ func setContextUserSystem(ctx context.Context) context.Context {
 	}
 	return authz.SetCtxData(ctx, data)
 }
+
+// setContextUserActor sets the actor (and its organisation) as editor,
+// so the change history of the user shows who acted on behalf of it
+// the instance of the actor is the one of the request, as its token was only found in there
+func setContextUserActor(ctx context.Context, actor *domain.TokenActor) context.Context {
+	if actor == nil {
+		return setContextUserSystem(ctx)
+	}
+	return authz.SetCtxData(ctx, authz.CtxData{
+		UserID:        actor.UserID,
+		OrgID:         actor.ResourceOwner,
+		ResourceOwner: actor.ResourceOwner,
+	})
+}
@@ -21,7 +21,15 @@ const (
 	amrPassword     = "password"
 	amrMFA          = "mfa"
 	amrOTP          = "otp"
+	amrSMS          = "sms"
 	amrUserPresence = "user"
+
+	acrPassword          = "urn:zitadel:acr:password"
+	acrMFA               = "urn:zitadel:acr:mfa"
+	acrPhishingResistant = "urn:zitadel:acr:phishing-resistant"
+	// phishing resistant (hardware protected) as defined by OpenID Extended Authentication Profile (EAP)
+	acrEAPPhishingResistant         = "phr"
+	acrEAPPhishingResistantHardware = "phrh"
 )
 
 type AuthRequest struct {
@@ -33,8 +41,7 @@ func (a *AuthRequest) GetID() string {
 }
 
 func (a *AuthRequest) GetACR() string {
-	// return a.
-	return "" //PLANNED: impl
+	return ACRFromLevelOfAssurance(a.AchievedLevelOfAssurance())
 }
 
 func (a *AuthRequest) GetAMR() []string {
@@ -42,6 +49,10 @@ func (a *AuthRequest) GetAMR() []string {
 	if a.PasswordVerified {
 		amr = append(amr, amrPassword)
 	}
+	if a.MagicLinkVerified {
+		// the link contains a one-time code sent to the verified email
+		amr = append(amr, amrOTP)
+	}
 	if len(a.MFAsVerified) > 0 {
 		amr = append(amr, amrMFA)
 		for _, mfa := range a.MFAsVerified {
@@ -78,6 +89,10 @@ func (a *AuthRequest) GetRedirectURI() string {
 }
 
 func (a *AuthRequest) GetResponseType() oidc.ResponseType {
+	if _, ok := a.Request.(*domain.AuthRequestDevice); ok {
+		//the device code is exchanged like an authorization code
+		return oidc.ResponseTypeCode
+	}
 	return ResponseTypeToOIDC(a.oidc().ResponseType)
 }
 
@@ -86,6 +101,9 @@ func (a *AuthRequest) GetResponseMode() oidc.ResponseMode {
 }
 
 func (a *AuthRequest) GetScopes() []string {
+	if device, ok := a.Request.(*domain.AuthRequestDevice); ok {
+		return device.Scopes
+	}
 	return a.oidc().Scopes
 }
 
@@ -107,7 +125,11 @@ func (a *AuthRequest) Done() bool {
 }
 
 func (a *AuthRequest) oidc() *domain.AuthRequestOIDC {
-	return a.Request.(*domain.AuthRequestOIDC)
+	request, ok := a.Request.(*domain.AuthRequestOIDC)
+	if !ok {
+		return new(domain.AuthRequestOIDC)
+	}
+	return request
 }
 
 func AuthRequestFromBusiness(authReq *domain.AuthRequest) (_ op.AuthRequest, err error) {
@@ -189,8 +211,36 @@ func PromptToBusiness(oidcPrompt []string) []domain.Prompt {
 	return prompts
 }
 
+// ACRValuesToBusiness maps the requested acr_values to the levels of assurance,
+// unknown values are ignored as they are only voluntary
 func ACRValuesToBusiness(values []string) []domain.LevelOfAssurance {
-	return nil
+	levels := make([]domain.LevelOfAssurance, 0, len(values))
+	for _, value := range values {
+		switch value {
+		case acrPassword:
+			levels = append(levels, domain.LevelOfAssurancePassword)
+		case acrMFA:
+			levels = append(levels, domain.LevelOfAssuranceMFA)
+		case acrPhishingResistant,
+			acrEAPPhishingResistant,
+			acrEAPPhishingResistantHardware:
+			levels = append(levels, domain.LevelOfAssurancePhishingResistant)
+		}
+	}
+	return levels
+}
+
+func ACRFromLevelOfAssurance(level domain.LevelOfAssurance) string {
+	switch level {
+	case domain.LevelOfAssurancePassword:
+		return acrPassword
+	case domain.LevelOfAssuranceMFA:
+		return acrMFA
+	case domain.LevelOfAssurancePhishingResistant:
+		return acrPhishingResistant
+	default:
+		return ""
+	}
 }
 
 func UILocalesToBusiness(tags []language.Tag) []string {
@@ -277,8 +327,12 @@ func CodeChallengeToOIDC(challenge *domain.OIDCCodeChallenge) *oidc.CodeChalleng
 
 func AMRFromMFAType(mfaType domain.MFAType) string {
 	switch mfaType {
-	case domain.MFATypeOTP:
+	case domain.MFATypeOTP,
+		domain.MFATypeOTPEmail,
+		domain.MFATypeRecoveryCode:
 		return amrOTP
+	case domain.MFATypeOTPSMS:
+		return amrSMS
 	case domain.MFATypeU2F,
 		domain.MFATypeU2FUserVerification:
 		return amrUserPresence
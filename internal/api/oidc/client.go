@@ -3,12 +3,15 @@ package oidc
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/zitadel/oidc/v2/pkg/oidc"
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"gopkg.in/square/go-jose.v2"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -25,6 +28,7 @@ const (
 	ClaimUserMetaData      = ScopeUserMetaData
 	ScopeResourceOwner     = "urn:zitadel:iam:user:resourceowner"
 	ClaimResourceOwner     = ScopeResourceOwner + ":"
+	ClaimActionLogFormat   = "urn:zitadel:iam:action:%s:log"
 
 	oidcCtx = "oidc"
 )
@@ -123,6 +127,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo oidc.User
 	if err != nil {
 		return errors.ThrowPermissionDenied(nil, "OIDC-Dsfb2", "token is not valid or has expired")
 	}
+	if err = checkTokenConfirmation(ctx, token.Confirmation); err != nil {
+		return err
+	}
 	if token.ApplicationID != "" {
 		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID)
 		if err != nil {
@@ -176,6 +183,12 @@ func (o *OPStorage) SetIntrospectionFromToken(ctx context.Context, introspection
 			}
 			introspection.SetScopes(token.Scopes)
 			introspection.SetClientID(token.ApplicationID)
+			if token.Actor != nil {
+				introspection.AppendClaims(ClaimActor, actorClaim(token.Actor))
+			}
+			if !token.Confirmation.IsEmpty() {
+				introspection.AppendClaims(ClaimConfirmation, token.Confirmation)
+			}
 			return nil
 		}
 	}
@@ -246,20 +259,62 @@ func (o *OPStorage) setUserinfo(ctx context.Context, userInfo oidc.UserInfoSette
 			}
 		}
 	}
-	if len(roles) == 0 || applicationID == "" {
-		return nil
+	if len(roles) > 0 && applicationID != "" {
+		projectRoles, err := o.assertRoles(ctx, userID, applicationID, roles)
+		if err != nil {
+			return err
+		}
+		if len(projectRoles) > 0 {
+			userInfo.AppendClaims(ClaimProjectRoles, projectRoles)
+		}
+	}
+	triggerActions, err := o.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation, user.ResourceOwner)
+	if err != nil || len(triggerActions) == 0 {
+		return err
+	}
+	existingClaims, err := userinfoClaims(userInfo)
+	if err != nil {
+		return err
 	}
-	projectRoles, err := o.assertRoles(ctx, userID, applicationID, roles)
+	claims, err := o.complementClaims(ctx, domain.TriggerTypePreUserinfoCreation, user, triggerActions, copyClaims(existingClaims))
 	if err != nil {
 		return err
 	}
-	if len(projectRoles) > 0 {
-		userInfo.AppendClaims(ClaimProjectRoles, projectRoles)
+	for claim, value := range claims {
+		if _, ok := existingClaims[claim]; ok {
+			continue
+		}
+		userInfo.AppendClaims(claim, value)
 	}
 	return nil
 }
 
+// userinfoClaims returns the claims already set on the userinfo (including the standard claims),
+// so they can't be overridden by actions
+func userinfoClaims(userInfo oidc.UserInfoSetter) (map[string]interface{}, error) {
+	marshalled, err := json.Marshal(userInfo)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Iek0u", "Errors.Internal")
+	}
+	claims := make(map[string]interface{})
+	if err = json.Unmarshal(marshalled, &claims); err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-ohK4a", "Errors.Internal")
+	}
+	return claims, nil
+}
+
+func copyClaims(claims map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(claims))
+	for claim, value := range claims {
+		copied[claim] = value
+	}
+	return copied
+}
+
 func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clientID string, scopes []string) (claims map[string]interface{}, err error) {
+	if confirmation := tokenConfirmationFromContext(ctx); !confirmation.IsEmpty() {
+		claims = appendClaim(claims, ClaimConfirmation, confirmation)
+	}
 	roles := make([]string, 0)
 	for _, scope := range scopes {
 		switch scope {
@@ -286,17 +341,70 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 			claims = appendClaim(claims, domain.OrgDomainPrimaryClaim, strings.TrimPrefix(scope, domain.OrgDomainPrimaryScope))
 		}
 	}
-	if len(roles) == 0 || clientID == "" {
-		return claims, nil
+	if len(roles) > 0 && clientID != "" {
+		projectRoles, err := o.assertRoles(ctx, userID, clientID, roles)
+		if err != nil {
+			return nil, err
+		}
+		if len(projectRoles) > 0 {
+			claims = appendClaim(claims, ClaimProjectRoles, projectRoles)
+		}
+	}
+	triggerActions, err := o.query.GetActiveActionsByFlowAndTriggerTypeOfUser(ctx, domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation, userID)
+	if err != nil || len(triggerActions) == 0 {
+		return claims, err
+	}
+	user, err := o.query.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		claims = make(map[string]interface{})
+	}
+	return o.complementClaims(ctx, domain.TriggerTypePreAccessTokenCreation, user, triggerActions, claims)
+}
+
+// complementClaims runs the actions of the complement token flow for the given trigger
+// on the user's organisation. claims must contain the existing claims, which can't be overridden.
+// Claims set by the actions are added to claims,
+// errors while setting them are returned as log claim per action.
+func (o *OPStorage) complementClaims(ctx context.Context, triggerType domain.TriggerType, user *query.User, triggerActions []*query.Action, claims map[string]interface{}) (map[string]interface{}, error) {
+	allowList, err := o.query.ActionsHTTPAllowList(ctx)
+	if err != nil {
+		return nil, err
 	}
-	projectRoles, err := o.assertRoles(ctx, userID, clientID, roles)
+	metadata, err := o.userMetadataValues(ctx, user.ID)
 	if err != nil {
 		return nil, err
 	}
-	if len(projectRoles) > 0 {
-		claims = appendClaim(claims, ClaimProjectRoles, projectRoles)
+	actionCtx := (&actions.Context{}).
+		SetUser(user.ID, user.ResourceOwner, user.PreferredLoginName).
+		SetUserMetadata(metadata)
+	return o.runClaimActions(ctx, triggerType, actionCtx, triggerActions, allowList.Hosts, claims)
+}
+
+func (o *OPStorage) runClaimActions(ctx context.Context, triggerType domain.TriggerType, actionCtx *actions.Context, triggerActions []*query.Action, allowedHosts []string, claims map[string]interface{}) (map[string]interface{}, error) {
+	for _, a := range triggerActions {
+		logs := make([]string, 0)
+		api := (&actions.API{}).SetClaims(claims, &logs)
+		err := actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail,
+			actions.WithAllowedHosts(allowedHosts...),
+			actions.WithExecutionLogger(o.actionExecutions, &actions.Execution{
+				InstanceID:    authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: a.ResourceOwner,
+				ActionID:      a.ID,
+				FlowType:      domain.FlowTypeCustomiseToken,
+				TriggerType:   triggerType,
+			}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(logs) > 0 {
+			claims[fmt.Sprintf(ClaimActionLogFormat, a.Name)] = logs
+		}
 	}
-	return claims, err
+	return claims, nil
 }
 
 func (o *OPStorage) assertRoles(ctx context.Context, userID, applicationID string, requestedRoles []string) (map[string]map[string]string, error) {
@@ -340,6 +448,18 @@ func (o *OPStorage) assertUserMetaData(ctx context.Context, userID string) (map[
 	return userMetaData, nil
 }
 
+func (o *OPStorage) userMetadataValues(ctx context.Context, userID string) (map[string]string, error) {
+	metaData, err := o.query.SearchUserMetadata(ctx, userID, &query.UserMetadataSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(metaData.Metadata))
+	for _, md := range metaData.Metadata {
+		values[md.Key] = string(md.Value)
+	}
+	return values, nil
+}
+
 func (o *OPStorage) assertUserResourceOwner(ctx context.Context, userID string) (map[string]string, error) {
 	user, err := o.query.GetUserByID(ctx, userID)
 	if err != nil {
@@ -131,6 +131,14 @@ func (c *Client) IDTokenUserinfoClaimsAssertion() bool {
 	return c.app.OIDCConfig.AssertIDTokenUserinfo
 }
 
+func (c *Client) RequirePushedAuthRequest() bool {
+	return c.app.OIDCConfig.RequirePushedAuthRequest
+}
+
+func (c *Client) RequireRequestObject() bool {
+	return c.app.OIDCConfig.RequireRequestObject
+}
+
 func accessTokenTypeToOIDC(tokenType domain.OIDCTokenType) op.AccessTokenType {
 	switch tokenType {
 	case domain.OIDCTokenTypeBearer:
@@ -194,6 +202,10 @@ func grantTypeToOIDC(grantType domain.OIDCGrantType) oidc.GrantType {
 		return oidc.GrantTypeImplicit
 	case domain.OIDCGrantTypeRefreshToken:
 		return oidc.GrantTypeRefreshToken
+	case domain.OIDCGrantTypeDeviceCode:
+		return GrantTypeDeviceCode
+	case domain.OIDCGrantTypeTokenExchange:
+		return oidc.GrantTypeTokenExchange
 	default:
 		return oidc.GrantTypeCode
 	}
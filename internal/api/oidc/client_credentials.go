@@ -0,0 +1,91 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// GrantTypeClientCredentials is the grant type of the client credentials grant (RFC 6749, section 4.4)
+	GrantTypeClientCredentials oidc.GrantType = "client_credentials"
+)
+
+// clientCredentialsRequest contains the parameters of the client credentials grant,
+// where the client is a machine user identified by its login name and secret
+type clientCredentialsRequest struct {
+	clientCredentials
+	GrantType oidc.GrantType           `schema:"grant_type"`
+	Scopes    oidc.SpaceDelimitedArray `schema:"scope"`
+}
+
+// handleClientCredentials issues an access token for a machine user (RFC 6749, section 4.4)
+func (p *provider) handleClientCredentials(w http.ResponseWriter, r *http.Request) {
+	request := new(clientCredentialsRequest)
+	err := op.ParseAuthenticatedTokenRequest(r, p.Decoder(), request)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if request.ClientID == "" || request.ClientSecret == "" {
+		op.RequestError(w, r, oidc.ErrInvalidClient().WithDescription("client_id or client_secret missing"))
+		return
+	}
+	token, err := p.storage.clientCredentialsToken(r.Context(), request.ClientID, request.ClientSecret, request.Scopes)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	accessToken, err := op.CreateBearerToken(token.TokenID, token.AggregateID, p.Crypto())
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, &oidc.AccessTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   oidc.BearerToken,
+		ExpiresIn:   uint64(time.Until(token.Expiration).Seconds()),
+	})
+}
+
+// clientCredentialsToken authenticates the machine user by its secret and issues an access token
+// the scopes are handled like on the JWT profile grant, so the token contains the grants of the user,
+// roles of the requested projects are asserted if the project requires it
+func (o *OPStorage) clientCredentialsToken(ctx context.Context, clientID, clientSecret string, scopes []string) (_ *domain.Token, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	loginName, err := query.NewUserLoginNamesSearchQuery(clientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	user, err := o.query.GetUser(ctx, loginName)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithDescription("invalid client_id / client_secret").WithParent(err)
+	}
+	if user.Type != domain.UserTypeMachine {
+		return nil, oidc.ErrInvalidClient().WithDescription("invalid client_id / client_secret")
+	}
+	err = o.command.VerifyMachineSecret(setContextUserSystem(ctx), user.ID, user.ResourceOwner, clientSecret)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithDescription("invalid client_id / client_secret").WithParent(err)
+	}
+	scopes, err = o.ValidateJWTProfileScopes(ctx, user.ID, scopes)
+	if err != nil {
+		return nil, oidc.ErrInvalidScope().WithParent(err)
+	}
+	scopes, err = o.assertAudienceProjectRoleScopes(ctx, scopes)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	return o.command.AddUserToken(setContextUserSystem(ctx), user.ResourceOwner, "", "", user.ID,
+		[]string{op.IssuerFromContext(ctx)}, scopes, o.defaultAccessTokenLifetime, tokenConfirmationFromContext(ctx))
+}
@@ -0,0 +1,414 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	ClientRegistrationEndpoint = "/register"
+
+	clientConfigurationClientID = "client_id"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+
+	// maxClientMetadataSize limits the request bodies of the endpoints, which are reachable without authentication
+	maxClientMetadataSize = 64 << 10
+	// noChangesErrorID is the id of the error returned by ChangeOIDCApplication if the configuration didn't change
+	noChangesErrorID = "COMMAND-1m88i"
+)
+
+// clientMetadata are the client metadata of the dynamic client registration (RFC 7591, section 2),
+// extended by the logout uris (OpenID Connect Back- / Front-Channel Logout), pushed authorization requests (RFC 9126)
+// and the request object requirement (RFC 9101)
+type clientMetadata struct {
+	ClientName                         string              `json:"client_name,omitempty"`
+	RedirectURIs                       []string            `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs             []string            `json:"post_logout_redirect_uris,omitempty"`
+	ResponseTypes                      []oidc.ResponseType `json:"response_types,omitempty"`
+	GrantTypes                         []oidc.GrantType    `json:"grant_types,omitempty"`
+	ApplicationType                    string              `json:"application_type,omitempty"`
+	TokenEndpointAuthMethod            oidc.AuthMethod     `json:"token_endpoint_auth_method,omitempty"`
+	BackChannelLogoutURI               string              `json:"backchannel_logout_uri,omitempty"`
+	FrontChannelLogoutURI              string              `json:"frontchannel_logout_uri,omitempty"`
+	RequirePushedAuthorizationRequests bool                `json:"require_pushed_authorization_requests,omitempty"`
+	RequireSignedRequestObject         bool                `json:"require_signed_request_object,omitempty"`
+}
+
+// clientInformationResponse is the response of the client registration endpoint (RFC 7591, section 3.2.1)
+// and the client configuration endpoint (RFC 7592, section 3)
+type clientInformationResponse struct {
+	clientMetadata
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at,omitempty"`
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string `json:"registration_client_uri"`
+}
+
+// clientUpdateRequest is the request of the client configuration endpoint to update the client (RFC 7592, section 2.2)
+type clientUpdateRequest struct {
+	clientMetadata
+	ClientID string `json:"client_id"`
+}
+
+// handleClientRegistration registers an OIDC application in the project of the initial access token (RFC 7591, section 3)
+func (p *provider) handleClientRegistration(w http.ResponseWriter, r *http.Request) {
+	initialAccessToken, ok := bearerToken(r)
+	if !ok {
+		invalidTokenError(w)
+		return
+	}
+	metadata := new(clientMetadata)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxClientMetadataSize)).Decode(metadata); err != nil {
+		clientMetadataError(w, "invalid client metadata")
+		return
+	}
+	app, err := metadata.toOIDCApp(new(domain.OIDCApp))
+	if err != nil {
+		clientRegistrationError(w, r, err)
+		return
+	}
+	app, registrationAccessToken, err := p.storage.command.RegisterOIDCApplication(setContextUserSystem(r.Context()), initialAccessToken, app)
+	if err != nil {
+		clientRegistrationError(w, r, err)
+		return
+	}
+	response := clientInformationFromApp(r.Context(), app)
+	response.ClientSecret = app.ClientSecretString
+	if app.ClientSecretString != "" {
+		var neverExpires int64
+		response.ClientSecretExpiresAt = &neverExpires
+	}
+	response.ClientIDIssuedAt = time.Now().Unix()
+	response.RegistrationAccessToken = registrationAccessToken
+	httphelper.MarshalJSONWithStatus(w, response, http.StatusCreated)
+}
+
+// handleClientConfiguration reads, updates and deletes the registered client (RFC 7592, section 2)
+// after checking the registration access token issued on its registration
+func (p *provider) handleClientConfiguration(w http.ResponseWriter, r *http.Request) {
+	registrationAccessToken, ok := bearerToken(r)
+	if !ok {
+		invalidTokenError(w)
+		return
+	}
+	ctx := setContextUserSystem(r.Context())
+	clientID := mux.Vars(r)[clientConfigurationClientID]
+	app, err := p.storage.registeredClient(ctx, clientID, registrationAccessToken)
+	if err != nil {
+		invalidTokenError(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		httphelper.MarshalJSON(w, clientInformationFromApp(ctx, oidcAppFromQuery(app)))
+	case http.MethodPut:
+		p.handleClientUpdate(w, r.WithContext(ctx), app)
+	case http.MethodDelete:
+		_, err = p.storage.command.RemoveApplication(ctx, app.ProjectID, app.ID, app.ResourceOwner)
+		if err != nil {
+			clientRegistrationError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleClientUpdate replaces the metadata of the client (RFC 7592, section 2.2),
+// the settings which can't be registered by the client itself are kept
+func (p *provider) handleClientUpdate(w http.ResponseWriter, r *http.Request, existing *query.App) {
+	request := new(clientUpdateRequest)
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxClientMetadataSize)).Decode(request); err != nil {
+		clientMetadataError(w, "invalid client metadata")
+		return
+	}
+	if request.ClientID != existing.OIDCConfig.ClientID {
+		clientMetadataError(w, "client_id does not match")
+		return
+	}
+	app, err := request.clientMetadata.toOIDCApp(oidcAppFromQuery(existing))
+	if err != nil {
+		clientRegistrationError(w, r, err)
+		return
+	}
+	if app.AppName != existing.Name {
+		_, err = p.storage.command.ChangeApplication(r.Context(), existing.ProjectID, &domain.ChangeApp{AppID: existing.ID, AppName: app.AppName}, existing.ResourceOwner)
+		if err != nil {
+			clientRegistrationError(w, r, err)
+			return
+		}
+	}
+	changed, err := p.storage.command.ChangeOIDCApplication(r.Context(), app, existing.ResourceOwner)
+	if err != nil && !isNoChanges(err) {
+		clientRegistrationError(w, r, err)
+		return
+	}
+	if changed != nil {
+		app = changed
+	}
+	httphelper.MarshalJSON(w, clientInformationFromApp(r.Context(), app))
+}
+
+// isNoChanges reports whether the update was only rejected, because it didn't change anything
+func isNoChanges(err error) bool {
+	caosErr, ok := err.(errors.Error)
+	return ok && errors.IsPreconditionFailed(err) && caosErr.GetID() == noChangesErrorID
+}
+
+// registeredClient returns the application of the client_id, if the registration access token was issued for it
+func (o *OPStorage) registeredClient(ctx context.Context, clientID, registrationAccessToken string) (_ *query.App, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	app, err := o.query.AppByOIDCClientID(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	err = o.command.VerifyOIDCRegistrationAccessToken(ctx, app.ProjectID, app.ID, registrationAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// toOIDCApp maps the client metadata onto the application,
+// the defaults of RFC 7591 (section 2) are applied for omitted values
+func (m *clientMetadata) toOIDCApp(app *domain.OIDCApp) (*domain.OIDCApp, error) {
+	if m.ClientName = strings.TrimSpace(m.ClientName); m.ClientName == "" {
+		return nil, &oidc.Error{ErrorType: "invalid_client_metadata", Description: "client_name missing"}
+	}
+	if len(m.RedirectURIs) == 0 && !onlyGrantTypes(m.GrantTypes, GrantTypeDeviceCode, oidc.GrantTypeTokenExchange) {
+		return nil, &oidc.Error{ErrorType: "invalid_redirect_uri", Description: "redirect_uris missing"}
+	}
+	responseTypes, err := responseTypesFromOIDC(m.ResponseTypes)
+	if err != nil {
+		return nil, err
+	}
+	grantTypes, err := grantTypesFromOIDC(m.GrantTypes)
+	if err != nil {
+		return nil, err
+	}
+	authMethod, err := authMethodFromOIDC(m.TokenEndpointAuthMethod)
+	if err != nil {
+		return nil, err
+	}
+	appType, err := applicationTypeFromOIDC(m.ApplicationType, authMethod)
+	if err != nil {
+		return nil, err
+	}
+	app.AppName = m.ClientName
+	app.RedirectUris = m.RedirectURIs
+	app.PostLogoutRedirectUris = m.PostLogoutRedirectURIs
+	app.ResponseTypes = responseTypes
+	app.GrantTypes = grantTypes
+	app.ApplicationType = appType
+	app.AuthMethodType = authMethod
+	app.BackChannelLogoutURI = m.BackChannelLogoutURI
+	app.FrontChannelLogoutURI = m.FrontChannelLogoutURI
+	app.RequirePushedAuthRequest = m.RequirePushedAuthorizationRequests
+	app.RequireRequestObject = m.RequireSignedRequestObject
+	return app, nil
+}
+
+func clientInformationFromApp(ctx context.Context, app *domain.OIDCApp) *clientInformationResponse {
+	return &clientInformationResponse{
+		clientMetadata: clientMetadata{
+			ClientName:                         app.AppName,
+			RedirectURIs:                       app.RedirectUris,
+			PostLogoutRedirectURIs:             app.PostLogoutRedirectUris,
+			ResponseTypes:                      responseTypesToOIDC(app.ResponseTypes),
+			GrantTypes:                         grantTypesToOIDC(app.GrantTypes),
+			ApplicationType:                    applicationTypeToOIDC(app.ApplicationType),
+			TokenEndpointAuthMethod:            authMethodToOIDC(app.AuthMethodType),
+			BackChannelLogoutURI:               app.BackChannelLogoutURI,
+			FrontChannelLogoutURI:              app.FrontChannelLogoutURI,
+			RequirePushedAuthorizationRequests: app.RequirePushedAuthRequest,
+			RequireSignedRequestObject:         app.RequireRequestObject,
+		},
+		ClientID:              app.ClientID,
+		RegistrationClientURI: op.NewEndpoint(ClientRegistrationEndpoint + "/" + url.PathEscape(app.ClientID)).Absolute(op.IssuerFromContext(ctx)),
+	}
+}
+
+func oidcAppFromQuery(app *query.App) *domain.OIDCApp {
+	return &domain.OIDCApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   app.ProjectID,
+			ResourceOwner: app.ResourceOwner,
+		},
+		AppID:                    app.ID,
+		AppName:                  app.Name,
+		ClientID:                 app.OIDCConfig.ClientID,
+		RedirectUris:             app.OIDCConfig.RedirectURIs,
+		ResponseTypes:            app.OIDCConfig.ResponseTypes,
+		GrantTypes:               app.OIDCConfig.GrantTypes,
+		ApplicationType:          app.OIDCConfig.AppType,
+		AuthMethodType:           app.OIDCConfig.AuthMethodType,
+		PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
+		OIDCVersion:              app.OIDCConfig.Version,
+		DevMode:                  app.OIDCConfig.IsDevMode,
+		AccessTokenType:          app.OIDCConfig.AccessTokenType,
+		AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
+		IDTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
+		IDTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
+		ClockSkew:                app.OIDCConfig.ClockSkew,
+		AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
+		RequirePushedAuthRequest: app.OIDCConfig.RequirePushedAuthRequest,
+		RequireRequestObject:     app.OIDCConfig.RequireRequestObject,
+		BackChannelLogoutURI:     app.OIDCConfig.BackChannelLogoutURI,
+		FrontChannelLogoutURI:    app.OIDCConfig.FrontChannelLogoutURI,
+		MinLevelOfAssurance:      app.OIDCConfig.MinLevelOfAssurance,
+		State:                    app.State,
+	}
+}
+
+func responseTypesFromOIDC(responseTypes []oidc.ResponseType) ([]domain.OIDCResponseType, error) {
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, nil
+	}
+	types := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch responseType {
+		case oidc.ResponseTypeCode:
+			types[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDToken:
+			types[i] = domain.OIDCResponseTypeIDTokenToken
+		case oidc.ResponseTypeIDTokenOnly:
+			types[i] = domain.OIDCResponseTypeIDToken
+		default:
+			return nil, &oidc.Error{ErrorType: "invalid_client_metadata", Description: "unsupported response_type " + string(responseType)}
+		}
+	}
+	return types, nil
+}
+
+func grantTypesFromOIDC(grantTypes []oidc.GrantType) ([]domain.OIDCGrantType, error) {
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, nil
+	}
+	types := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case oidc.GrantTypeCode:
+			types[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			types[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			types[i] = domain.OIDCGrantTypeRefreshToken
+		case GrantTypeDeviceCode:
+			types[i] = domain.OIDCGrantTypeDeviceCode
+		case oidc.GrantTypeTokenExchange:
+			types[i] = domain.OIDCGrantTypeTokenExchange
+		default:
+			return nil, &oidc.Error{ErrorType: "invalid_client_metadata", Description: "unsupported grant_type " + string(grantType)}
+		}
+	}
+	return types, nil
+}
+
+func authMethodFromOIDC(authMethod oidc.AuthMethod) (domain.OIDCAuthMethodType, error) {
+	switch authMethod {
+	case oidc.AuthMethodBasic, "":
+		return domain.OIDCAuthMethodTypeBasic, nil
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, nil
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, nil
+	case oidc.AuthMethodPrivateKeyJWT:
+		return domain.OIDCAuthMethodTypePrivateKeyJWT, nil
+	default:
+		return 0, &oidc.Error{ErrorType: "invalid_client_metadata", Description: "unsupported token_endpoint_auth_method " + string(authMethod)}
+	}
+}
+
+// applicationTypeFromOIDC maps the application_type (OpenID Connect Dynamic Client Registration, section 2),
+// public web clients (without authentication on the token endpoint) are user agent applications
+func applicationTypeFromOIDC(applicationType string, authMethod domain.OIDCAuthMethodType) (domain.OIDCApplicationType, error) {
+	switch applicationType {
+	case applicationTypeWeb, "":
+		if authMethod == domain.OIDCAuthMethodTypeNone {
+			return domain.OIDCApplicationTypeUserAgent, nil
+		}
+		return domain.OIDCApplicationTypeWeb, nil
+	case applicationTypeNative:
+		return domain.OIDCApplicationTypeNative, nil
+	default:
+		return 0, &oidc.Error{ErrorType: "invalid_client_metadata", Description: "unsupported application_type " + applicationType}
+	}
+}
+
+func applicationTypeToOIDC(applicationType domain.OIDCApplicationType) string {
+	if applicationType == domain.OIDCApplicationTypeNative {
+		return applicationTypeNative
+	}
+	return applicationTypeWeb
+}
+
+func onlyGrantTypes(grantTypes []oidc.GrantType, allowed ...oidc.GrantType) bool {
+	if len(grantTypes) == 0 {
+		return false
+	}
+	for _, grantType := range grantTypes {
+		found := false
+		for _, allowedType := range allowed {
+			if grantType == allowedType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	authorization := r.Header.Get(http_utils.Authorization)
+	if !strings.HasPrefix(authorization, oidc.PrefixBearer) {
+		return "", false
+	}
+	token := strings.TrimPrefix(authorization, oidc.PrefixBearer)
+	return token, token != ""
+}
+
+// invalidTokenError is returned for missing and invalid initial and registration access tokens (RFC 6750, section 3.1)
+func invalidTokenError(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	httphelper.MarshalJSONWithStatus(w, &oidc.Error{ErrorType: "invalid_token"}, http.StatusUnauthorized)
+}
+
+func clientMetadataError(w http.ResponseWriter, description string) {
+	httphelper.MarshalJSONWithStatus(w, &oidc.Error{ErrorType: "invalid_client_metadata", Description: description}, http.StatusBadRequest)
+}
+
+// clientRegistrationError maps the errors of the registration to the error response (RFC 7591, section 3.2.2)
+func clientRegistrationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.IsPermissionDenied(err), errors.IsPreconditionFailed(err):
+		invalidTokenError(w)
+	case errors.IsErrorInvalidArgument(err):
+		clientMetadataError(w, "invalid client metadata")
+	default:
+		op.RequestError(w, r, err)
+	}
+}
@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestUserinfoClaims(t *testing.T) {
+	userInfo := oidc.NewUserInfo()
+	userInfo.SetSubject("user1")
+	userInfo.SetEmail("user@example.com", true)
+	userInfo.AppendClaims("tenant_id", "tenant1")
+
+	claims, err := userinfoClaims(userInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"sub":            "user1",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"tenant_id":      "tenant1",
+	}, claims)
+}
+
+func TestOPStorage_runClaimActions(t *testing.T) {
+	const script = `function complement(ctx, api) {
+	api.v1.claims.setClaim('email', 'evil@example.com');
+	api.v1.claims.setClaim('tenant_id', 'tenant2');
+	api.v1.claims.setClaim('department', 'sales');
+}`
+	type args struct {
+		claims map[string]interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]interface{}
+	}{
+		{
+			name: "no existing claims",
+			args: args{
+				claims: map[string]interface{}{},
+			},
+			want: map[string]interface{}{
+				"tenant_id":  "tenant2",
+				"department": "sales",
+				"urn:zitadel:iam:action:complement:log": []string{
+					`claim "email" is reserved`,
+				},
+			},
+		},
+		{
+			name: "existing claims not overridden",
+			args: args{
+				claims: map[string]interface{}{
+					"email":     "user@example.com",
+					"tenant_id": "tenant1",
+				},
+			},
+			want: map[string]interface{}{
+				"email":      "user@example.com",
+				"tenant_id":  "tenant1",
+				"department": "sales",
+				"urn:zitadel:iam:action:complement:log": []string{
+					`claim "email" is reserved`,
+					`claim "tenant_id" already exists`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &OPStorage{}
+			triggerActions := []*query.Action{{ID: "action1", Name: "complement", Script: script, Timeout: time.Second}}
+			got, err := o.runClaimActions(context.Background(), domain.TriggerTypePreUserinfoCreation, &actions.Context{}, triggerActions, nil, tt.args.claims)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
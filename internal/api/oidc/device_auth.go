@@ -0,0 +1,270 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	DeviceAuthorizationEndpoint = "/device_authorization"
+
+	// GrantTypeDeviceCode is the grant type of the device access token request (RFC 8628, section 3.4)
+	GrantTypeDeviceCode oidc.GrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	deviceCodeLength = 32
+	userCodeLength   = 8
+)
+
+var (
+	// userCodeRunes consist of consonants only, to prevent ambiguous characters and words (RFC 8628, section 6.1)
+	userCodeRunes = []rune("BCDFGHJKLMNPQRSTVWXZ")
+)
+
+type DeviceAuthConfig struct {
+	Lifetime     time.Duration
+	PollInterval time.Duration
+}
+
+// deviceAuthorizationResponse is the response of the device authorization endpoint (RFC 8628, section 3.2)
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// deviceRequest contains the parameters of the device authorization request
+// as well as of the device access token request
+type deviceRequest struct {
+	clientCredentials
+	Scopes     oidc.SpaceDelimitedArray `schema:"scope"`
+	GrantType  oidc.GrantType           `schema:"grant_type"`
+	DeviceCode string                   `schema:"device_code"`
+}
+
+// provider extends the OpenID Provider of the library by the device authorization grant (RFC 8628),
+// the token exchange grant (RFC 8693), the client credentials grant for machine users (RFC 6749, section 4.4)
+// pushed authorization requests (RFC 9126), back- and front-channel logout
+// and the dynamic client registration (RFC 7591 and RFC 7592)
+type provider struct {
+	*op.Provider
+	storage           *OPStorage
+	deviceAuth        DeviceAuthConfig
+	pushedAuthRequest PushedAuthRequestConfig
+	handler           http.Handler
+}
+
+func newProvider(openIDProvider *op.Provider, storage *OPStorage, deviceAuth DeviceAuthConfig, pushedAuthRequest PushedAuthRequestConfig, interceptors ...op.HttpInterceptor) *provider {
+	p := &provider{
+		Provider:          openIDProvider,
+		storage:           storage,
+		deviceAuth:        deviceAuth,
+		pushedAuthRequest: pushedAuthRequest,
+	}
+	router := mux.NewRouter()
+	router.Use(p.tokenBindingInterceptor)
+	grantRouter := router.NewRoute().Subrouter()
+	grantRouter.Use(
+		middleware.CORSInterceptor,
+		op.NewIssuerInterceptor(p.IssuerFromRequest).Handler,
+	)
+	for _, interceptor := range interceptors {
+		grantRouter.Use(mux.MiddlewareFunc(interceptor))
+	}
+	grantRouter.HandleFunc(oidc.DiscoveryEndpoint, p.handleDiscovery).Methods(http.MethodGet)
+	grantRouter.HandleFunc(DeviceAuthorizationEndpoint, p.handleDeviceAuthorization).Methods(http.MethodPost)
+	grantRouter.HandleFunc(PushedAuthorizationRequestEndpoint, p.handlePushedAuthRequest).Methods(http.MethodPost)
+	grantRouter.HandleFunc(ClientRegistrationEndpoint, p.handleClientRegistration).Methods(http.MethodPost)
+	grantRouter.HandleFunc(ClientRegistrationEndpoint+"/{"+clientConfigurationClientID+"}", p.handleClientConfiguration).Methods(http.MethodGet, http.MethodPut, http.MethodDelete)
+	grantRouter.HandleFunc(p.AuthorizationEndpoint().Relative(), p.handleAuthorize)
+	grantRouter.HandleFunc(p.EndSessionEndpoint().Relative(), p.handleEndSession)
+	grantRouter.HandleFunc(p.TokenEndpoint().Relative(), p.handleDeviceAccessToken).Methods(http.MethodPost).MatcherFunc(isGrantType(GrantTypeDeviceCode))
+	grantRouter.HandleFunc(p.TokenEndpoint().Relative(), p.handleTokenExchange).Methods(http.MethodPost).MatcherFunc(isGrantType(oidc.GrantTypeTokenExchange))
+	grantRouter.HandleFunc(p.TokenEndpoint().Relative(), p.handleClientCredentials).Methods(http.MethodPost).MatcherFunc(isGrantType(GrantTypeClientCredentials))
+	router.PathPrefix("/").Handler(openIDProvider.HttpHandler())
+	p.handler = router
+	return p
+}
+
+func (p *provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
+func isGrantType(grantType oidc.GrantType) mux.MatcherFunc {
+	return func(r *http.Request, _ *mux.RouteMatch) bool {
+		return r.FormValue("grant_type") == string(grantType)
+	}
+}
+
+// handleDiscovery adds the device authorization endpoint, the pushed authorization request endpoint,
+// the registration endpoint, the custom grant types and the logout capabilities to the discovery configuration of the library
+func (p *provider) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	config := op.CreateDiscoveryConfig(r, p, p.Storage())
+	config.GrantTypesSupported = append(config.GrantTypesSupported, GrantTypeDeviceCode, oidc.GrantTypeTokenExchange, GrantTypeClientCredentials)
+	config.RequestURIParameterSupported = true
+	config.RegistrationEndpoint = op.NewEndpoint(ClientRegistrationEndpoint).Absolute(config.Issuer)
+	discovery := struct {
+		*oidc.DiscoveryConfiguration
+		DeviceAuthorizationEndpoint        string `json:"device_authorization_endpoint"`
+		PushedAuthorizationRequestEndpoint string `json:"pushed_authorization_request_endpoint"`
+		RequirePushedAuthorizationRequests bool   `json:"require_pushed_authorization_requests"`
+		BackChannelLogoutSupported         bool   `json:"backchannel_logout_supported"`
+		BackChannelLogoutSessionSupported  bool   `json:"backchannel_logout_session_supported"`
+		FrontChannelLogoutSupported        bool   `json:"frontchannel_logout_supported"`
+		FrontChannelLogoutSessionSupported bool   `json:"frontchannel_logout_session_supported"`
+	}{
+		DiscoveryConfiguration:             config,
+		DeviceAuthorizationEndpoint:        op.NewEndpoint(DeviceAuthorizationEndpoint).Absolute(config.Issuer),
+		PushedAuthorizationRequestEndpoint: op.NewEndpoint(PushedAuthorizationRequestEndpoint).Absolute(config.Issuer),
+		RequirePushedAuthorizationRequests: false,
+		BackChannelLogoutSupported:         true,
+		BackChannelLogoutSessionSupported:  false,
+		FrontChannelLogoutSupported:        true,
+		FrontChannelLogoutSessionSupported: false,
+	}
+	httphelper.MarshalJSON(w, discovery)
+}
+
+// handleDeviceAuthorization issues the device and user code (RFC 8628, section 3.1)
+func (p *provider) handleDeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	request := new(deviceRequest)
+	err := op.ParseAuthenticatedTokenRequest(r, p.Decoder(), request)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	client, err := p.authorizeClient(r.Context(), &request.clientCredentials, GrantTypeDeviceCode)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	response, err := p.storage.createDeviceAuthRequest(r.Context(), client.GetID(), request.Scopes, p.deviceAuth)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, response)
+}
+
+// handleDeviceAccessToken answers the polls of the device (RFC 8628, section 3.4 and 3.5)
+func (p *provider) handleDeviceAccessToken(w http.ResponseWriter, r *http.Request) {
+	request := new(deviceRequest)
+	err := op.ParseAuthenticatedTokenRequest(r, p.Decoder(), request)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if request.DeviceCode == "" {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("device_code missing"))
+		return
+	}
+	client, err := p.authorizeClient(r.Context(), &request.clientCredentials, GrantTypeDeviceCode)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	authReq, err := p.storage.pollDeviceAuthRequest(r.Context(), client.GetID(), request.DeviceCode)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	response, err := op.CreateTokenResponse(r.Context(), authReq, client, p, true, "", "")
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, response)
+}
+
+func (o *OPStorage) createDeviceAuthRequest(ctx context.Context, clientID string, scopes []string, config DeviceAuthConfig) (_ *deviceAuthorizationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	scopes, err = o.assertProjectRoleScopes(ctx, clientID, scopes)
+	if err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "OIDC-Oa3ie", "Errors.Internal")
+	}
+	deviceCode, err := generateCode(deviceCodeLength)
+	if err != nil {
+		return nil, err
+	}
+	userCode, err := crypto.GenerateRandomString(userCodeLength, userCodeRunes)
+	if err != nil {
+		return nil, err
+	}
+	device := &domain.AuthRequestDevice{
+		Scopes:     scopes,
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		Expiration: time.Now().Add(config.Lifetime),
+		Interval:   config.PollInterval,
+	}
+	_, err = o.repo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		ApplicationID: clientID,
+		BrowserInfo:   ParseBrowserInfoFromContext(ctx),
+		Request:       device,
+	})
+	if err != nil {
+		return nil, err
+	}
+	verificationURI := strings.TrimSuffix(op.IssuerFromContext(ctx), HandlerPrefix) + login.HandlerPrefix + login.EndpointDevice
+	return &deviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                domain.FormatDeviceUserCode(userCode),
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?" + login.QueryDeviceUserCode + "=" + url.QueryEscape(domain.FormatDeviceUserCode(userCode)),
+		ExpiresIn:               int(config.Lifetime.Seconds()),
+		Interval:                int(config.PollInterval.Seconds()),
+	}, nil
+}
+
+// pollDeviceAuthRequest returns the approved request of the device code
+// or the error defined by RFC 8628 (section 3.5) as long as the user didn't approve the request
+func (o *OPStorage) pollDeviceAuthRequest(ctx context.Context, clientID, deviceCode string) (_ op.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	authReq, result, err := o.repo.PollDeviceAuthRequest(ctx, clientID, deviceCode)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("invalid device_code").WithParent(err)
+	}
+	switch result {
+	case domain.DeviceAuthPollResultApproved:
+		return &AuthRequest{authReq}, nil
+	case domain.DeviceAuthPollResultSlowDown:
+		return nil, &oidc.Error{ErrorType: "slow_down"}
+	case domain.DeviceAuthPollResultExpired:
+		return nil, &oidc.Error{ErrorType: "expired_token"}
+	case domain.DeviceAuthPollResultDenied:
+		return nil, &oidc.Error{ErrorType: "access_denied"}
+	default:
+		return nil, &oidc.Error{ErrorType: "authorization_pending"}
+	}
+}
+
+// generateCode returns a url safe random code of the given length (in bytes)
+func generateCode(length int) (string, error) {
+	code := make([]byte, length)
+	if _, err := rand.Read(code); err != nil {
+		return "", errors.ThrowInternal(err, "OIDC-ooC3e", "Errors.Internal")
+	}
+	return base64.RawURLEncoding.EncodeToString(code), nil
+}
@@ -0,0 +1,76 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+type pollResult struct {
+	request *domain.AuthRequest
+	result  domain.DeviceAuthPollResult
+	err     error
+}
+
+// mockDevicePollRepo returns the results in order, one per poll
+type mockDevicePollRepo struct {
+	repository.Repository
+	results []pollResult
+}
+
+func (m *mockDevicePollRepo) PollDeviceAuthRequest(context.Context, string, string) (*domain.AuthRequest, domain.DeviceAuthPollResult, error) {
+	r := m.results[0]
+	m.results = m.results[1:]
+	return r.request, r.result, r.err
+}
+
+func TestOPStorage_pollDeviceAuthRequest(t *testing.T) {
+	approved := &domain.AuthRequest{ID: "id", ApplicationID: "clientID"}
+	consumed := caos_errs.ThrowNotFound(nil, "CACHE-Aib2o", "Errors.AuthRequest.NotFound")
+	tests := []struct {
+		name      string
+		results   []pollResult
+		clientID  string
+		wantTypes []string
+	}{
+		{
+			name:      "pending, authorization_pending",
+			results:   []pollResult{{request: approved, result: domain.DeviceAuthPollResultPending}},
+			clientID:  "clientID",
+			wantTypes: []string{"authorization_pending"},
+		},
+		{
+			name: "approved and polled again, invalid_grant",
+			results: []pollResult{
+				{request: approved, result: domain.DeviceAuthPollResultApproved},
+				{err: consumed},
+			},
+			clientID:  "clientID",
+			wantTypes: []string{"", string(oidc.InvalidGrant)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &OPStorage{repo: &mockDevicePollRepo{results: tt.results}}
+			for i, wantType := range tt.wantTypes {
+				request, err := o.pollDeviceAuthRequest(context.Background(), tt.clientID, "deviceCode")
+				if wantType == "" {
+					if err != nil || request == nil {
+						t.Fatalf("poll %d: unexpected error = %v", i, err)
+					}
+					continue
+				}
+				oidcErr := new(oidc.Error)
+				if !errors.As(err, &oidcErr) || string(oidcErr.ErrorType) != wantType {
+					t.Fatalf("poll %d: error = %v, want %s", i, err, wantType)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v2/pkg/crypto"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// BackChannelLogoutEvent is the member of the events claim of a logout token (OpenID Connect Back-Channel Logout, section 2.4)
+	BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	// LogoutTokenType is the typ header of a logout token
+	LogoutTokenType = "logout+jwt"
+
+	logoutTokenIDLength = 32
+)
+
+type logoutTokenClaims struct {
+	Issuer     string                 `json:"iss"`
+	Subject    string                 `json:"sub"`
+	Audience   oidc.Audience          `json:"aud"`
+	IssuedAt   int64                  `json:"iat"`
+	Expiration int64                  `json:"exp"`
+	JWTID      string                 `json:"jti"`
+	Events     map[string]interface{} `json:"events"`
+}
+
+// handleEndSession ends the session of the user agent (OpenID Connect RP-Initiated Logout).
+// A logout token is sent to the back-channel logout uri of every application the user agent received tokens for.
+// If any of them registered a front-channel logout uri, the user agent is redirected to the logout page of the login,
+// where the front-channel logout uris are rendered in iframes before redirecting to the post_logout_redirect_uri
+func (p *provider) handleEndSession(w http.ResponseWriter, r *http.Request) {
+	req, err := op.ParseEndSessionRequest(r, p.Decoder())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	session, err := op.ValidateEndSessionRequest(r.Context(), req, p)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	var clientID string
+	if session.Client != nil {
+		clientID = session.Client.GetID()
+	}
+	frontChannelClientIDs, err := p.storage.terminateSession(r.Context(), session.UserID, clientID)
+	if err != nil {
+		op.RequestError(w, r, oidc.DefaultToServerError(err, "error terminating session"))
+		return
+	}
+	if len(frontChannelClientIDs) == 0 {
+		http.Redirect(w, r, session.RedirectURI, http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, frontChannelLogoutURL(p.DefaultLogoutRedirectURI(), frontChannelClientIDs, clientID, req), http.StatusFound)
+}
+
+// frontChannelLogoutURL returns the url of the logout page of the login rendering the front-channel logout uris,
+// the post_logout_redirect_uri is validated again by the login
+func frontChannelLogoutURL(logoutPage string, frontChannelClientIDs []string, clientID string, req *oidc.EndSessionRequest) string {
+	query := url.Values{login.QueryFrontChannelLogout: frontChannelClientIDs}
+	if clientID != "" && req.PostLogoutRedirectURI != "" {
+		query.Set(login.QueryClientID, clientID)
+		query.Set(login.QueryPostLogoutRedirectURI, req.PostLogoutRedirectURI)
+		query.Set(login.QueryState, req.State)
+	}
+	return logoutPage + "?" + query.Encode()
+}
+
+// TerminateSession implements the op.Storage interface
+func (o *OPStorage) TerminateSession(ctx context.Context, userID, clientID string) (err error) {
+	_, err = o.terminateSession(ctx, userID, clientID)
+	return err
+}
+
+// terminateSession signs out all users of the user agent and requests the back-channel logout of their applications,
+// it returns the client ids of the applications with a front-channel logout uri
+func (o *OPStorage) terminateSession(ctx context.Context, userID, clientID string) (frontChannelClientIDs []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		logging.Log("OIDC-aGh4q").Error("no user agent id")
+		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-fso7F", "no user agent id")
+	}
+	userIDs, err := o.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		logging.Log("OIDC-Ghgr3").WithError(err).Error("error retrieving user sessions")
+		return nil, err
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	// the tokens of the user agent have to be read before signing out, because they are removed afterwards
+	backChannelLogouts, frontChannelClientIDs := o.sessionLogouts(ctx, userAgentID, userIDs)
+	data := authz.CtxData{
+		UserID: userID,
+	}
+	err = o.command.HumansSignOut(authz.SetCtxData(ctx, data), userAgentID, userIDs, backChannelLogouts)
+	logging.Log("OIDC-Dggt2").OnError(err).Error("error signing out")
+	return frontChannelClientIDs, err
+}
+
+// sessionLogouts returns the logout tokens for the applications with a back-channel logout uri
+// and the client ids of the applications with a front-channel logout uri, which issued tokens to the user agent.
+// Errors are only logged, so they can't prevent the user from signing out
+func (o *OPStorage) sessionLogouts(ctx context.Context, userAgentID string, userIDs []string) (backChannelLogouts []*domain.BackChannelLogout, frontChannelClientIDs []string) {
+	tokens, err := o.repo.TokensByUserAgentID(ctx, userAgentID)
+	if err != nil {
+		logging.OnError(err).Warn("unable to get tokens of user agent for logout")
+		return nil, nil
+	}
+	handledClients := make(map[string]bool)
+	handledLogouts := make(map[string]bool)
+	for _, token := range tokens {
+		if token.ApplicationID == "" || !containsAny(userIDs, token.UserID) || handledLogouts[token.UserID+token.ApplicationID] {
+			continue
+		}
+		handledLogouts[token.UserID+token.ApplicationID] = true
+		app, err := o.query.AppByOIDCClientID(ctx, token.ApplicationID)
+		if err != nil {
+			logging.OnError(err).WithField("client_id", token.ApplicationID).Warn("unable to get application for logout")
+			continue
+		}
+		if app.OIDCConfig.FrontChannelLogoutURI != "" && !handledClients[token.ApplicationID] {
+			frontChannelClientIDs = append(frontChannelClientIDs, token.ApplicationID)
+		}
+		handledClients[token.ApplicationID] = true
+		if app.OIDCConfig.BackChannelLogoutURI == "" {
+			continue
+		}
+		logout, err := o.backChannelLogout(ctx, token.UserID, token.ApplicationID, app.OIDCConfig.BackChannelLogoutURI)
+		if err != nil {
+			logging.OnError(err).WithField("client_id", token.ApplicationID).Warn("unable to create logout token")
+			continue
+		}
+		backChannelLogouts = append(backChannelLogouts, logout)
+	}
+	return backChannelLogouts, frontChannelClientIDs
+}
+
+// backChannelLogout creates the signed logout token of the user for the application (OpenID Connect Back-Channel Logout, section 2.4)
+func (o *OPStorage) backChannelLogout(ctx context.Context, userID, clientID, logoutURI string) (*domain.BackChannelLogout, error) {
+	id, err := generateCode(logoutTokenIDLength)
+	if err != nil {
+		return nil, err
+	}
+	key, err := o.SigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: key.SignatureAlgorithm(),
+			Key: &jose.JSONWebKey{
+				Key:   key.Key(),
+				KeyID: key.ID(),
+			},
+		},
+		(&jose.SignerOptions{}).WithType(LogoutTokenType),
+	)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-ieH2o", "Errors.Internal")
+	}
+	now := time.Now()
+	token, err := crypto.Sign(&logoutTokenClaims{
+		Issuer:     op.IssuerFromContext(ctx),
+		Subject:    userID,
+		Audience:   oidc.Audience{clientID},
+		IssuedAt:   now.Unix(),
+		Expiration: now.Add(o.defaultLogoutTokenLifetime).Unix(),
+		JWTID:      id,
+		Events:     map[string]interface{}{BackChannelLogoutEvent: struct{}{}},
+	}, signer)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Ohn1e", "Errors.Internal")
+	}
+	return &domain.BackChannelLogout{
+		ID:          id,
+		UserID:      userID,
+		ClientID:    clientID,
+		LogoutURI:   logoutURI,
+		LogoutToken: token,
+		Expiry:      o.defaultLogoutTokenLifetime,
+	}, nil
+}
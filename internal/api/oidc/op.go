@@ -11,7 +11,9 @@ import (
 	"github.com/zitadel/oidc/v2/pkg/op"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	"github.com/zitadel/zitadel/internal/api/assets"
+	"github.com/zitadel/zitadel/internal/api/authz"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
@@ -41,9 +43,12 @@ type Config struct {
 	DefaultIdTokenLifetime            time.Duration
 	DefaultRefreshTokenIdleExpiration time.Duration
 	DefaultRefreshTokenExpiration     time.Duration
+	DefaultLogoutTokenLifetime        time.Duration
 	UserAgentCookieConfig             *middleware.UserAgentCookieConfig
 	Cache                             *middleware.CacheConfig
 	CustomEndpoints                   *EndpointConfig
+	DeviceAuth                        DeviceAuthConfig
+	PushedAuthRequest                 PushedAuthRequestConfig
 }
 
 type EndpointConfig struct {
@@ -72,22 +77,26 @@ type OPStorage struct {
 	signingKeyAlgorithm               string
 	defaultRefreshTokenIdleExpiration time.Duration
 	defaultRefreshTokenExpiration     time.Duration
+	defaultLogoutTokenLifetime        time.Duration
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    string
+	actionExecutions                  actions.ExecutionLogger
+	dpopProofs                        *authz.DPoPProofReplayCache
 }
 
-func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *sql.DB, userAgentCookie, instanceHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
+func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI string, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, encryptionAlg crypto.EncryptionAlgorithm, cryptoKey []byte, es *eventstore.Eventstore, projections *sql.DB, actionExecutions actions.ExecutionLogger, userAgentCookie, instanceHandler func(http.Handler) http.Handler) (op.OpenIDProvider, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections)
-	options, err := createOptions(config, externalSecure, userAgentCookie, instanceHandler)
+	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, actionExecutions)
+	interceptors := createInterceptors(userAgentCookie, instanceHandler)
+	options, err := createOptions(config, externalSecure, interceptors)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-D3gq1", "cannot create options: %w")
 	}
-	provider, err := op.NewDynamicOpenIDProvider(
+	openIDProvider, err := op.NewDynamicOpenIDProvider(
 		ctx,
 		HandlerPrefix,
 		opConfig,
@@ -97,7 +106,7 @@ func NewProvider(ctx context.Context, config Config, defaultLogoutRedirectURI st
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-DAtg3", "cannot create provider")
 	}
-	return provider, nil
+	return newProvider(openIDProvider, storage, config.DeviceAuth, config.PushedAuthRequest, interceptors...), nil
 }
 
 func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []byte) (*op.Config, error) {
@@ -121,17 +130,21 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func createOptions(config Config, externalSecure bool, userAgentCookie, instanceHandler func(http.Handler) http.Handler) ([]op.Option, error) {
+func createInterceptors(userAgentCookie, instanceHandler func(http.Handler) http.Handler) []op.HttpInterceptor {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+	return []op.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor,
+		instanceHandler,
+		userAgentCookie,
+		http_utils.CopyHeadersToContext,
+	}
+}
+
+func createOptions(config Config, externalSecure bool, interceptors []op.HttpInterceptor) ([]op.Option, error) {
 	options := []op.Option{
-		op.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor,
-			instanceHandler,
-			userAgentCookie,
-			http_utils.CopyHeadersToContext,
-		),
+		op.WithHttpInterceptors(interceptors...),
 	}
 	if !externalSecure {
 		options = append(options, op.WithAllowInsecure())
@@ -172,7 +185,7 @@ func customEndpoints(endpointConfig *EndpointConfig) []op.Option {
 	return options
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, projections *sql.DB) *OPStorage {
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, projections *sql.DB, actionExecutions actions.ExecutionLogger) *OPStorage {
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -184,9 +197,12 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		defaultIdTokenLifetime:            config.DefaultIdTokenLifetime,
 		defaultRefreshTokenIdleExpiration: config.DefaultRefreshTokenIdleExpiration,
 		defaultRefreshTokenExpiration:     config.DefaultRefreshTokenExpiration,
+		defaultLogoutTokenLifetime:        config.DefaultLogoutTokenLifetime,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(projections, locksTable, signingKey),
 		assetAPIPrefix:                    assets.HandlerPrefix,
+		actionExecutions:                  actionExecutions,
+		dpopProofs:                        authz.NewDPoPProofReplayCache(projections),
 	}
 }
 
@@ -0,0 +1,187 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	PushedAuthorizationRequestEndpoint = "/par"
+
+	pushedAuthRequestIDLength = 32
+)
+
+var (
+	// clientAuthenticationParameters are not part of the authorization request
+	// and therefore not stored with the pushed authorization request
+	clientAuthenticationParameters = []string{"client_secret", "client_assertion", "client_assertion_type"}
+)
+
+type PushedAuthRequestConfig struct {
+	Lifetime time.Duration
+}
+
+// pushedAuthRequestResponse is the response of the pushed authorization request endpoint (RFC 9126, section 2.2)
+type pushedAuthRequestResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int    `json:"expires_in"`
+}
+
+// requireRequestClient is implemented by clients, which are able to enforce
+// the use of pushed authorization requests and / or signed request objects
+type requireRequestClient interface {
+	RequirePushedAuthRequest() bool
+	RequireRequestObject() bool
+}
+
+// handlePushedAuthRequest authenticates the client, validates the authorization request
+// and stores it for the later use on the authorization endpoint (RFC 9126, section 2.1)
+func (p *provider) handlePushedAuthRequest(w http.ResponseWriter, r *http.Request) {
+	credentials := new(clientCredentials)
+	err := op.ParseAuthenticatedTokenRequest(r, p.Decoder(), credentials)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	client, err := p.authenticateClient(r.Context(), credentials)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if r.Form.Get("request_uri") != "" {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("request_uri must not be pushed"))
+		return
+	}
+	authReq, err := op.ParseAuthorizeRequest(r, p.Decoder())
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if authReq.ClientID != client.GetID() {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client"))
+		return
+	}
+	ctx := r.Context()
+	if authReq.RequestParam != "" {
+		if !p.RequestObjectSupported() {
+			op.RequestError(w, r, oidc.ErrRequestNotSupported())
+			return
+		}
+		authReq, err = op.ParseRequestObject(ctx, authReq, p.Storage(), op.IssuerFromContext(ctx))
+		if err != nil {
+			op.RequestError(w, r, err)
+			return
+		}
+	}
+	_, err = op.ValidateAuthRequest(ctx, authReq, p.Storage(), p.IDTokenHintVerifier(ctx))
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	parameters := make(url.Values, len(r.Form))
+	for key, values := range r.Form {
+		parameters[key] = values
+	}
+	for _, key := range clientAuthenticationParameters {
+		parameters.Del(key)
+	}
+	response, err := p.storage.createPushedAuthRequest(ctx, client.GetID(), parameters, p.pushedAuthRequest)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, response, http.StatusCreated)
+}
+
+// handleAuthorize resolves the request_uri of a pushed authorization request
+// and enforces the pushed authorization request and request object requirements of the client,
+// before the authorization request is handled by the library
+//
+// the request_uri has to be resolved before the library parses and validates the request,
+// so the stored parameters are used for the creation of the auth request
+func (p *provider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("cannot parse form").WithParent(err), p.Encoder())
+		return
+	}
+	ctx := r.Context()
+	pushed := false
+	if requestURI := r.Form.Get("request_uri"); requestURI != "" {
+		parameters, err := p.storage.pushedAuthRequestParameters(ctx, r.Form.Get("client_id"), requestURI)
+		if err != nil {
+			op.AuthRequestError(w, r, nil, err, p.Encoder())
+			return
+		}
+		r.Form = parameters
+		pushed = true
+	}
+	client, err := p.Storage().GetClientByClientID(ctx, r.Form.Get("client_id"))
+	if err != nil {
+		// invalid clients are handled by the library
+		op.Authorize(w, r, p.Provider)
+		return
+	}
+	if requirements, ok := client.(requireRequestClient); ok {
+		if requirements.RequirePushedAuthRequest() && !pushed {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("pushed authorization request required"), p.Encoder())
+			return
+		}
+		if requirements.RequireRequestObject() && r.Form.Get("request") == "" {
+			op.AuthRequestError(w, r, nil, oidc.ErrInvalidRequest().WithDescription("request object required"), p.Encoder())
+			return
+		}
+	}
+	op.Authorize(w, r, p.Provider)
+}
+
+func (o *OPStorage) createPushedAuthRequest(ctx context.Context, clientID string, parameters url.Values, config PushedAuthRequestConfig) (_ *pushedAuthRequestResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	id, err := generateCode(pushedAuthRequestIDLength)
+	if err != nil {
+		return nil, err
+	}
+	request := &domain.PushedAuthRequest{
+		ID:           id,
+		ClientID:     clientID,
+		Parameters:   parameters,
+		CreationDate: time.Now(),
+		Expiration:   time.Now().Add(config.Lifetime),
+	}
+	if err = o.repo.CreatePushedAuthRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return &pushedAuthRequestResponse{
+		RequestURI: request.RequestURI(),
+		ExpiresIn:  int(config.Lifetime.Seconds()),
+	}, nil
+}
+
+// pushedAuthRequestParameters returns the parameters of the pushed authorization request referenced by the request_uri,
+// the request can only be used once, by the client it was pushed by and until it expires (RFC 9126, section 4)
+func (o *OPStorage) pushedAuthRequestParameters(ctx context.Context, clientID, requestURI string) (_ url.Values, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	if !strings.HasPrefix(requestURI, domain.PushedAuthRequestURIPrefix) {
+		return nil, &oidc.Error{ErrorType: "invalid_request_uri", Description: "request_uri is not supported"}
+	}
+	request, err := o.repo.PushedAuthRequestByID(ctx, strings.TrimPrefix(requestURI, domain.PushedAuthRequestURIPrefix))
+	if err != nil {
+		return nil, &oidc.Error{ErrorType: "invalid_request_uri", Description: "request_uri not found", Parent: err}
+	}
+	if request.ClientID != clientID || request.IsExpired(time.Now()) {
+		return nil, &oidc.Error{ErrorType: "invalid_request_uri", Description: "request_uri not found"}
+	}
+	return request.Parameters, nil
+}
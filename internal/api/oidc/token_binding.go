@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	// DPoPTokenType is the token_type of access tokens bound to a DPoP key (RFC 9449, section 5)
+	DPoPTokenType = "DPoP"
+
+	ClaimConfirmation = "cnf"
+)
+
+type key int
+
+const (
+	tokenConfirmationKey key = iota
+)
+
+// tokenBindingInterceptor handles sender-constrained access tokens:
+// on the token endpoint the DPoP proof (RFC 9449) is verified and the thumbprint of its key is passed in the context,
+// so the issued access tokens are bound to it.
+// Without a DPoP proof, the tokens are bound to the client certificate (RFC 8705), if it's forwarded by a trusted reverse proxy
+// (the header is removed from all other requests by the middleware.ClientCertificateInterceptor).
+// On the userinfo endpoint the proof of possession is passed in the context to be checked against the binding of the token
+func (p *provider) tokenBindingInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == p.TokenEndpoint().Relative():
+			p.handleTokenBinding(w, r, next)
+		case r.URL.Path == p.UserinfoEndpoint().Relative():
+			p.handleUserinfoBinding(w, r, next)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func (p *provider) handleTokenBinding(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	confirmation, err := p.requestConfirmation(r, p.TokenEndpoint(), r.Header.Get(http_utils.DPoP), "")
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if confirmation.IsEmpty() {
+		next.ServeHTTP(w, r)
+		return
+	}
+	if confirmation.JKT == "" {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenConfirmationKey, confirmation)))
+		return
+	}
+	confirmation.CertificateThumbprint = ""
+	writer := &dpopTokenResponseWriter{ResponseWriter: w}
+	next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), tokenConfirmationKey, confirmation)))
+	writer.flush()
+}
+
+func (p *provider) handleUserinfoBinding(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	var proof, accessToken string
+	if authorization := r.Header.Get(http_utils.Authorization); strings.HasPrefix(authorization, authz.DPoPPrefix) {
+		accessToken = strings.TrimPrefix(authorization, authz.DPoPPrefix)
+		proof = r.Header.Get(http_utils.DPoP)
+		if proof == "" {
+			userinfoBindingError(w)
+			return
+		}
+		// the library only accepts the Bearer scheme, the binding of the token is checked when setting the userinfo
+		r.Header.Set(http_utils.Authorization, oidc.PrefixBearer+accessToken)
+	}
+	confirmation, err := p.requestConfirmation(r, p.UserinfoEndpoint(), proof, accessToken)
+	if err != nil {
+		userinfoBindingError(w)
+		return
+	}
+	next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tokenConfirmationKey, confirmation)))
+}
+
+func userinfoBindingError(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+// requestConfirmation returns the thumbprints of the DPoP key and the client certificate the client proved possession of
+func (p *provider) requestConfirmation(r *http.Request, endpoint op.Endpoint, dpop, accessToken string) (*domain.TokenConfirmation, error) {
+	confirmation := new(domain.TokenConfirmation)
+	if dpop != "" {
+		proof, err := authz.ParseDPoPProof(dpop)
+		if err != nil {
+			return nil, &oidc.Error{ErrorType: "invalid_dpop_proof", Description: "invalid DPoP proof", Parent: err}
+		}
+		err = proof.Verify(r.Method, endpoint.Absolute(p.IssuerFromRequest(r)), accessToken, time.Now())
+		if err != nil {
+			return nil, &oidc.Error{ErrorType: "invalid_dpop_proof", Description: "invalid DPoP proof", Parent: err}
+		}
+		if err = p.storage.dpopProofs.UseDPoPProof(r.Context(), proof); err != nil {
+			return nil, &oidc.Error{ErrorType: "invalid_dpop_proof", Description: "DPoP proof already used", Parent: err}
+		}
+		confirmation.JKT = proof.JKT
+	}
+	if certificate := r.Header.Get(http_utils.ZitadelClientCertificate); certificate != "" {
+		thumbprint, err := authz.CertificateThumbprint(certificate)
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid client certificate").WithParent(err)
+		}
+		confirmation.CertificateThumbprint = thumbprint
+	}
+	return confirmation, nil
+}
+
+func tokenConfirmationFromContext(ctx context.Context) *domain.TokenConfirmation {
+	confirmation, _ := ctx.Value(tokenConfirmationKey).(*domain.TokenConfirmation)
+	return confirmation
+}
+
+// checkTokenConfirmation verifies that the client proved possession of the key(s) the token is bound to
+func checkTokenConfirmation(ctx context.Context, confirmation *domain.TokenConfirmation) error {
+	if confirmation.IsEmpty() {
+		return nil
+	}
+	presented := tokenConfirmationFromContext(ctx)
+	if presented == nil ||
+		confirmation.JKT != "" && confirmation.JKT != presented.JKT ||
+		confirmation.CertificateThumbprint != "" && confirmation.CertificateThumbprint != presented.CertificateThumbprint {
+		return errors.ThrowPermissionDenied(nil, "OIDC-Aeph4", "token is bound to another key")
+	}
+	return nil
+}
+
+// dpopTokenResponseWriter buffers the token response to return the token_type DPoP
+// for the access tokens bound to the DPoP key
+type dpopTokenResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *dpopTokenResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *dpopTokenResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+func (w *dpopTokenResponseWriter) flush() {
+	body := w.body.Bytes()
+	if w.status == 0 || w.status == http.StatusOK {
+		response := make(map[string]interface{})
+		if err := json.Unmarshal(body, &response); err == nil && response["token_type"] == oidc.BearerToken {
+			response["token_type"] = DPoPTokenType
+			if b, err := json.Marshal(response); err == nil {
+				body = b
+			}
+		}
+	}
+	w.ResponseWriter.Header().Del(http_utils.ContentLength)
+	if w.status != 0 {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
@@ -0,0 +1,218 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v2/pkg/crypto"
+	httphelper "github.com/zitadel/oidc/v2/pkg/http"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	usr_model "github.com/zitadel/zitadel/internal/user/model"
+)
+
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+	// TokenTypeUserID identifies the subject_token as id of the user to impersonate, the actor_token is required for it
+	TokenTypeUserID = "urn:zitadel:params:oauth:token-type:user_id"
+
+	ClaimActor = "act"
+)
+
+// tokenExchangeRequest contains the parameters of the token exchange request (RFC 8693, section 2.1)
+type tokenExchangeRequest struct {
+	clientCredentials
+	GrantType          oidc.GrantType           `schema:"grant_type"`
+	SubjectToken       string                   `schema:"subject_token"`
+	SubjectTokenType   string                   `schema:"subject_token_type"`
+	ActorToken         string                   `schema:"actor_token"`
+	ActorTokenType     string                   `schema:"actor_token_type"`
+	Resource           []string                 `schema:"resource"`
+	Audience           []string                 `schema:"audience"`
+	Scopes             oidc.SpaceDelimitedArray `schema:"scope"`
+	RequestedTokenType string                   `schema:"requested_token_type"`
+}
+
+// tokenExchangeResponse is the response of the token exchange (RFC 8693, section 2.2.1)
+type tokenExchangeResponse struct {
+	AccessToken     string                   `json:"access_token"`
+	IssuedTokenType string                   `json:"issued_token_type"`
+	TokenType       string                   `json:"token_type"`
+	ExpiresIn       uint64                   `json:"expires_in,omitempty"`
+	Scopes          oidc.SpaceDelimitedArray `json:"scope,omitempty"`
+}
+
+// tokenExchange contains the verified tokens of the token exchange request
+// subjectToken is nil in case of impersonation, where the subject is identified by subjectUserID
+type tokenExchange struct {
+	clientID      string
+	subjectToken  *usr_model.TokenView
+	subjectUserID string
+	actorToken    *usr_model.TokenView
+	audience      []string
+	scopes        []string
+}
+
+// handleTokenExchange exchanges the subject token for a new access token (RFC 8693)
+func (p *provider) handleTokenExchange(w http.ResponseWriter, r *http.Request) {
+	request := new(tokenExchangeRequest)
+	err := op.ParseAuthenticatedTokenRequest(r, p.Decoder(), request)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	if request.SubjectToken == "" || request.SubjectTokenType == "" {
+		op.RequestError(w, r, oidc.ErrInvalidRequest().WithDescription("subject_token or subject_token_type missing"))
+		return
+	}
+	if len(request.Resource) > 0 {
+		op.RequestError(w, r, &oidc.Error{ErrorType: "invalid_target", Description: "resource not supported, use audience instead"})
+		return
+	}
+	client, err := p.authorizeClient(r.Context(), &request.clientCredentials, oidc.GrantTypeTokenExchange)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	issuedTokenType, accessTokenType, err := exchangeTokenType(request.RequestedTokenType, client.AccessTokenType())
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	exchange, err := p.verifyTokenExchange(r.Context(), client.GetID(), request)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	token, err := p.storage.ExchangeToken(r.Context(), exchange)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	accessToken, err := p.createExchangedAccessToken(r.Context(), client, token, accessTokenType)
+	if err != nil {
+		op.RequestError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSON(w, &tokenExchangeResponse{
+		AccessToken:     accessToken,
+		IssuedTokenType: issuedTokenType,
+		TokenType:       oidc.BearerToken,
+		ExpiresIn:       uint64(time.Until(token.Expiration).Seconds()),
+		Scopes:          token.Scopes,
+	})
+}
+
+func exchangeTokenType(requestedTokenType string, clientAccessTokenType op.AccessTokenType) (string, op.AccessTokenType, error) {
+	switch requestedTokenType {
+	case "", TokenTypeAccessToken:
+		return TokenTypeAccessToken, clientAccessTokenType, nil
+	case TokenTypeJWT:
+		return TokenTypeJWT, op.AccessTokenTypeJWT, nil
+	default:
+		return "", 0, oidc.ErrInvalidRequest().WithDescription("requested_token_type %s not supported", requestedTokenType)
+	}
+}
+
+func (p *provider) verifyTokenExchange(ctx context.Context, clientID string, request *tokenExchangeRequest) (_ *tokenExchange, err error) {
+	exchange := &tokenExchange{
+		clientID: clientID,
+		audience: request.Audience,
+		scopes:   request.Scopes,
+	}
+	switch request.SubjectTokenType {
+	case TokenTypeAccessToken, TokenTypeJWT:
+		exchange.subjectToken, err = p.tokenByAccessToken(ctx, request.SubjectToken)
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid subject_token").WithParent(err)
+		}
+		exchange.subjectUserID = exchange.subjectToken.UserID
+	case TokenTypeUserID:
+		exchange.subjectUserID = request.SubjectToken
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("subject_token_type %s not supported", request.SubjectTokenType)
+	}
+	if request.ActorToken == "" {
+		return exchange, nil
+	}
+	switch request.ActorTokenType {
+	case TokenTypeAccessToken, TokenTypeJWT:
+		exchange.actorToken, err = p.tokenByAccessToken(ctx, request.ActorToken)
+		if err != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("invalid actor_token").WithParent(err)
+		}
+		return exchange, nil
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token_type %s not supported", request.ActorTokenType)
+	}
+}
+
+// tokenByAccessToken returns the (still valid) token of an opaque or JWT access token issued by ZITADEL
+func (p *provider) tokenByAccessToken(ctx context.Context, accessToken string) (*usr_model.TokenView, error) {
+	tokenID, subject, ok := p.tokenIDAndSubject(ctx, accessToken)
+	if !ok {
+		return nil, oidc.ErrInvalidRequest().WithDescription("token invalid")
+	}
+	return p.storage.repo.TokenByID(ctx, subject, tokenID)
+}
+
+func (p *provider) tokenIDAndSubject(ctx context.Context, accessToken string) (string, string, bool) {
+	tokenIDSubject, err := p.Crypto().Decrypt(accessToken)
+	if err == nil {
+		split := strings.Split(tokenIDSubject, ":")
+		if len(split) != 2 {
+			return "", "", false
+		}
+		return split[0], split[1], true
+	}
+	claims, err := op.VerifyAccessToken(ctx, accessToken, p.AccessTokenVerifier(ctx))
+	if err != nil {
+		return "", "", false
+	}
+	return claims.GetTokenID(), claims.GetSubject(), true
+}
+
+// createExchangedAccessToken creates the access token like the library does,
+// but adds the act claim to JWT access tokens
+func (p *provider) createExchangedAccessToken(ctx context.Context, client op.Client, token *domain.Token, accessTokenType op.AccessTokenType) (string, error) {
+	if accessTokenType != op.AccessTokenTypeJWT {
+		return op.CreateBearerToken(token.TokenID, token.AggregateID, p.Crypto())
+	}
+	claims := oidc.NewAccessTokenClaims(op.IssuerFromContext(ctx), token.AggregateID, token.Audience, token.Expiration, token.TokenID, client.GetID(), client.ClockSkew())
+	privateClaims, err := p.storage.GetPrivateClaimsFromScopes(ctx, token.AggregateID, client.GetID(), client.RestrictAdditionalAccessTokenScopes()(token.Scopes))
+	if err != nil {
+		return "", err
+	}
+	if token.Actor != nil {
+		privateClaims = appendClaim(privateClaims, ClaimActor, actorClaim(token.Actor))
+	}
+	claims.SetPrivateClaims(privateClaims)
+	signingKey, err := p.storage.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	signer, err := op.SignerFromKey(signingKey)
+	if err != nil {
+		return "", err
+	}
+	return crypto.Sign(claims, signer)
+}
+
+// actorClaim returns the act claim of the (nested) actor (RFC 8693, section 4.1)
+func actorClaim(actor *domain.TokenActor) map[string]interface{} {
+	claim := map[string]interface{}{
+		"sub": actor.UserID,
+	}
+	if actor.Issuer != "" {
+		claim["iss"] = actor.Issuer
+	}
+	if actor.Actor != nil {
+		claim[ClaimActor] = actorClaim(actor.Actor)
+	}
+	return claim
+}
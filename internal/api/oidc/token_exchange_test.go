@@ -0,0 +1,226 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	usr_model "github.com/zitadel/zitadel/internal/user/model"
+)
+
+func Test_verifyExchangeAudience(t *testing.T) {
+	type args struct {
+		exchange  *tokenExchange
+		projectID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"subject token of client, ok",
+			args{
+				exchange: &tokenExchange{
+					clientID:     "clientID",
+					subjectToken: &usr_model.TokenView{Audience: []string{"clientID"}},
+				},
+				projectID: "projectID",
+			},
+			false,
+		},
+		{
+			"subject token of project, ok",
+			args{
+				exchange: &tokenExchange{
+					clientID:     "clientID",
+					subjectToken: &usr_model.TokenView{Audience: []string{"projectID"}},
+				},
+				projectID: "projectID",
+			},
+			false,
+		},
+		{
+			"subject token of other client, error",
+			args{
+				exchange: &tokenExchange{
+					clientID:     "clientID",
+					subjectToken: &usr_model.TokenView{Audience: []string{"otherClientID", "otherProjectID"}},
+				},
+				projectID: "projectID",
+			},
+			true,
+		},
+		{
+			"actor token of client, ok",
+			args{
+				exchange: &tokenExchange{
+					clientID:     "clientID",
+					subjectToken: &usr_model.TokenView{Audience: []string{"clientID"}},
+					actorToken:   &usr_model.TokenView{Audience: []string{"projectID"}},
+				},
+				projectID: "projectID",
+			},
+			false,
+		},
+		{
+			"actor token of other client, error",
+			args{
+				exchange: &tokenExchange{
+					clientID:     "clientID",
+					subjectToken: &usr_model.TokenView{Audience: []string{"clientID"}},
+					actorToken:   &usr_model.TokenView{Audience: []string{"otherClientID"}},
+				},
+				projectID: "projectID",
+			},
+			true,
+		},
+		{
+			"impersonation, actor token of other client, error",
+			args{
+				exchange: &tokenExchange{
+					clientID:      "clientID",
+					subjectUserID: "userID",
+					actorToken:    &usr_model.TokenView{Audience: []string{"otherClientID"}},
+				},
+				projectID: "projectID",
+			},
+			true,
+		},
+		{
+			"impersonation, actor token of client, ok",
+			args{
+				exchange: &tokenExchange{
+					clientID:      "clientID",
+					subjectUserID: "userID",
+					actorToken:    &usr_model.TokenView{Audience: []string{"clientID"}},
+				},
+				projectID: "projectID",
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyExchangeAudience(tt.args.exchange, tt.args.projectID)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			oidcErr := new(oidc.Error)
+			if !errors.As(err, &oidcErr) || oidcErr.ErrorType != oidc.InvalidRequest {
+				t.Errorf("verifyExchangeAudience() error = %v, want invalid_request", err)
+			}
+		})
+	}
+}
+
+func Test_tokenActor(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instanceID")
+	token := &usr_model.TokenView{
+		UserID:        "actorID",
+		ResourceOwner: "actorOrgID",
+		Actor:         &domain.TokenActor{UserID: "previousActorID"},
+	}
+	assert.Equal(t, &domain.TokenActor{
+		Actor:         &domain.TokenActor{UserID: "previousActorID"},
+		UserID:        "actorID",
+		ResourceOwner: "actorOrgID",
+		InstanceID:    "instanceID",
+	}, tokenActor(ctx, token))
+}
+
+func Test_setContextUserActor(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor *domain.TokenActor
+		want  authz.CtxData
+	}{
+		{
+			"no actor, system",
+			nil,
+			authz.CtxData{UserID: "SYSTEM"},
+		},
+		{
+			"actor, actor and its organisation",
+			&domain.TokenActor{UserID: "actorID", ResourceOwner: "actorOrgID", InstanceID: "instanceID"},
+			authz.CtxData{UserID: "actorID", OrgID: "actorOrgID", ResourceOwner: "actorOrgID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setContextUserActor(authz.WithInstanceID(context.Background(), "instanceID"), tt.actor)
+			assert.Equal(t, tt.want, authz.GetCtxData(ctx))
+			assert.Equal(t, "instanceID", authz.GetInstance(ctx).InstanceID())
+		})
+	}
+}
+
+func Test_restrictTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowed   []string
+		requested []string
+		want      []string
+		wantOk    bool
+	}{
+		{"none requested, all allowed", []string{"a", "b"}, nil, []string{"a", "b"}, true},
+		{"subset requested, subset", []string{"a", "b"}, []string{"b"}, []string{"b"}, true},
+		{"exceeding requested, not ok", []string{"a"}, []string{"a", "c"}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := restrictTo(tt.allowed, tt.requested)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func Test_actorClaim(t *testing.T) {
+	actor := &domain.TokenActor{
+		UserID:        "actorID",
+		Issuer:        "https://issuer.example.com",
+		ResourceOwner: "actorOrgID",
+		Actor:         &domain.TokenActor{UserID: "previousActorID"},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"sub": "actorID",
+		"iss": "https://issuer.example.com",
+		"act": map[string]interface{}{
+			"sub": "previousActorID",
+		},
+	}, actorClaim(actor))
+}
+
+func Test_exchangeTokenType(t *testing.T) {
+	tests := []struct {
+		name                string
+		requestedTokenType  string
+		clientTokenType     op.AccessTokenType
+		wantIssuedTokenType string
+		wantAccessTokenType op.AccessTokenType
+		wantErr             bool
+	}{
+		{"not requested, client type", "", op.AccessTokenTypeBearer, TokenTypeAccessToken, op.AccessTokenTypeBearer, false},
+		{"access token, client type", TokenTypeAccessToken, op.AccessTokenTypeJWT, TokenTypeAccessToken, op.AccessTokenTypeJWT, false},
+		{"jwt, jwt", TokenTypeJWT, op.AccessTokenTypeBearer, TokenTypeJWT, op.AccessTokenTypeJWT, false},
+		{"id token, error", "urn:ietf:params:oauth:token-type:id_token", op.AccessTokenTypeBearer, "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issued, accessTokenType, err := exchangeTokenType(tt.requestedTokenType, tt.clientTokenType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("exchangeTokenType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantIssuedTokenType, issued)
+			assert.Equal(t, tt.wantAccessTokenType, accessTokenType)
+		})
+	}
+}
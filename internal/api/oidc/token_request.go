@@ -0,0 +1,64 @@
+package oidc
+
+import (
+	"context"
+
+	"github.com/zitadel/oidc/v2/pkg/oidc"
+	"github.com/zitadel/oidc/v2/pkg/op"
+)
+
+// clientCredentials are the parameters authenticating the client on the grants handled by the provider itself
+type clientCredentials struct {
+	ClientID            string `schema:"client_id"`
+	ClientSecret        string `schema:"client_secret"`
+	ClientAssertion     string `schema:"client_assertion"`
+	ClientAssertionType string `schema:"client_assertion_type"`
+}
+
+func (c *clientCredentials) SetClientID(clientID string) {
+	c.ClientID = clientID
+}
+
+func (c *clientCredentials) SetClientSecret(clientSecret string) {
+	c.ClientSecret = clientSecret
+}
+
+// authorizeClient authenticates the client and ensures it's allowed to use the grant type
+// public clients (auth method none) are identified by the client_id only
+func (p *provider) authorizeClient(ctx context.Context, credentials *clientCredentials, grantType oidc.GrantType) (op.Client, error) {
+	client, err := p.authenticateClient(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if !op.ValidateGrantType(client, grantType) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("%s not allowed for this client", grantType)
+	}
+	return client, nil
+}
+
+func (p *provider) authenticateClient(ctx context.Context, credentials *clientCredentials) (op.Client, error) {
+	if credentials.ClientAssertionType == oidc.ClientAssertionTypeJWTAssertion {
+		if !p.AuthMethodPrivateKeyJWTSupported() {
+			return nil, oidc.ErrInvalidClient().WithDescription("auth_method private_key_jwt not supported")
+		}
+		return op.AuthorizePrivateJWTKey(ctx, credentials.ClientAssertion, p)
+	}
+	client, err := p.Storage().GetClientByClientID(ctx, credentials.ClientID)
+	if err != nil {
+		return nil, oidc.ErrInvalidClient().WithParent(err)
+	}
+	switch client.AuthMethod() {
+	case oidc.AuthMethodNone:
+		return client, nil
+	case oidc.AuthMethodPrivateKeyJWT:
+		return nil, oidc.ErrInvalidClient().WithDescription("private_key_jwt not allowed for this client")
+	case oidc.AuthMethodPost:
+		if !p.AuthMethodPostSupported() {
+			return nil, oidc.ErrInvalidClient().WithDescription("auth_method post not supported")
+		}
+	}
+	if err = op.AuthorizeClientIDSecret(ctx, credentials.ClientID, credentials.ClientSecret, p.Storage()); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
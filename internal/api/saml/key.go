@@ -0,0 +1,78 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	// the certificate is derived from the signing key, so the validity must not depend on the time of creation
+	certificateNotBefore = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	certificateNotAfter  = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// certificateCache holds the self-signed certificates of the signing keys by their id
+type certificateCache struct {
+	mutex        sync.RWMutex
+	certificates map[string][]byte
+}
+
+func newCertificateCache() *certificateCache {
+	return &certificateCache{certificates: make(map[string][]byte)}
+}
+
+// signingKey returns the current rsa key of the instance and the x509 certificate (DER) wrapping its public key
+func (p *Provider) signingKey(ctx context.Context) (*rsa.PrivateKey, []byte, error) {
+	key, err := p.keys.SigningKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKey, ok := key.Key().(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.ThrowPreconditionFailed(nil, "SAML-Wf3gs", "signing key must be a rsa key")
+	}
+	certificate, err := p.certificates.get(key.ID(), privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, certificate, nil
+}
+
+func (c *certificateCache) get(keyID string, key *rsa.PrivateKey) ([]byte, error) {
+	c.mutex.RLock()
+	certificate, ok := c.certificates[keyID]
+	c.mutex.RUnlock()
+	if ok {
+		return certificate, nil
+	}
+	certificate, err := selfSignedCertificate(keyID, key)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "SAML-Gh3k1", "unable to create certificate")
+	}
+	c.mutex.Lock()
+	c.certificates[keyID] = certificate
+	c.mutex.Unlock()
+	return certificate, nil
+}
+
+func selfSignedCertificate(keyID string, key *rsa.PrivateKey) ([]byte, error) {
+	serial := sha256.Sum256([]byte(keyID))
+	template := &x509.Certificate{
+		SerialNumber:          new(big.Int).SetBytes(serial[:16]),
+		Subject:               pkix.Name{CommonName: "ZITADEL SAML " + keyID},
+		NotBefore:             certificateNotBefore,
+		NotAfter:              certificateNotAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	return x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+}
@@ -0,0 +1,98 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/oidc/v2/pkg/op"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/metrics"
+)
+
+const (
+	HandlerPrefix = "/saml/v2"
+
+	EndpointMetadata    = "/metadata"
+	EndpointSSO         = "/SSO"
+	EndpointSSOCallback = "/SSO/callback"
+	EndpointSLO         = "/SLO"
+
+	paramSAMLRequest  = "SAMLRequest"
+	paramSAMLResponse = "SAMLResponse"
+	paramRelayState   = "RelayState"
+	paramID           = "id"
+)
+
+type Config struct {
+	DefaultAssertionLifetime time.Duration
+}
+
+// SigningKeyProvider returns the current signing key of the instance
+// it's implemented by the storage of the OIDC provider, so both protocols share the same key pairs
+type SigningKeyProvider interface {
+	SigningKey(ctx context.Context) (op.SigningKey, error)
+}
+
+type Provider struct {
+	router            http.Handler
+	repo              repository.Repository
+	command           *command.Commands
+	query             *query.Queries
+	keys              SigningKeyProvider
+	certificates      *certificateCache
+	externalSecure    bool
+	assertionLifetime time.Duration
+}
+
+func NewProvider(config Config, externalSecure bool, command *command.Commands, query *query.Queries, repo repository.Repository, keys SigningKeyProvider, userAgentCookie, instanceHandler func(http.Handler) http.Handler) *Provider {
+	provider := &Provider{
+		repo:              repo,
+		command:           command,
+		query:             query,
+		keys:              keys,
+		certificates:      newCertificateCache(),
+		externalSecure:    externalSecure,
+		assertionLifetime: config.DefaultAssertionLifetime,
+	}
+	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+	router := mux.NewRouter()
+	router.Use(
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor,
+		instanceHandler,
+		userAgentCookie,
+		http_utils.CopyHeadersToContext,
+	)
+	router.HandleFunc(EndpointMetadata, provider.handleMetadata).Methods(http.MethodGet)
+	router.HandleFunc(EndpointSSO, provider.handleSSO).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc(EndpointSSOCallback, provider.handleSSOCallback).Methods(http.MethodGet)
+	router.HandleFunc(EndpointSLO, provider.handleSLO).Methods(http.MethodGet, http.MethodPost)
+	provider.router = router
+	return provider
+}
+
+func (p *Provider) HttpHandler() http.Handler {
+	return p.router
+}
+
+// AuthCallbackURL returns the url the login will redirect to after a successful authentication
+func AuthCallbackURL(_ context.Context, authRequestID string) string {
+	return HandlerPrefix + EndpointSSOCallback + "?" + paramID + "=" + authRequestID
+}
+
+// entityID returns the identifier of the identity provider, which is the url of the metadata endpoint
+func (p *Provider) entityID(r *http.Request) string {
+	return p.endpoint(r, EndpointMetadata)
+}
+
+func (p *Provider) endpoint(r *http.Request, endpoint string) string {
+	return http_utils.BuildOrigin(r.Host, p.externalSecure) + HandlerPrefix + endpoint
+}
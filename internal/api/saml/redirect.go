@@ -0,0 +1,80 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"net/url"
+	"strings"
+
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// signRedirect signs the (already url encoded) query of the HTTP-Redirect binding
+// and returns it including the url encoded SigAlg and Signature parameters
+func signRedirect(query string, key *rsa.PrivateKey) (string, error) {
+	signingContext, err := dsig.NewSigningContext(key, nil)
+	if err != nil {
+		return "", err
+	}
+	signedQuery := query + "&SigAlg=" + url.QueryEscape(dsig.RSASHA256SignatureMethod)
+	signature, err := signingContext.SignString(signedQuery)
+	if err != nil {
+		return "", err
+	}
+	return signedQuery + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+// verifyRedirect verifies the signature of a HTTP-Redirect binding message (SAMLRequest or SAMLResponse)
+// the parameters are taken from the raw query, as the signature is computed over the original encoding
+// only rsa-sha256 is accepted, (rsa-)sha1 is rejected as it's vulnerable to collisions
+func verifyRedirect(rawQuery, messageParam string, certificates []*x509.Certificate) error {
+	params := make(map[string]string, 4)
+	for _, part := range strings.Split(rawQuery, "&") {
+		name := strings.SplitN(part, "=", 2)
+		if len(name) != 2 {
+			continue
+		}
+		params[name[0]] = name[1]
+	}
+	message, sigAlg, encodedSignature := params[messageParam], params["SigAlg"], params["Signature"]
+	if message == "" || sigAlg == "" || encodedSignature == "" {
+		return errors.ThrowInvalidArgument(nil, "SAML-Ohk2a", "missing signature parameter")
+	}
+	signedQuery := messageParam + "=" + message
+	if relayState, ok := params[paramRelayState]; ok {
+		signedQuery += "&" + paramRelayState + "=" + relayState
+	}
+	signedQuery += "&SigAlg=" + sigAlg
+
+	algorithm, err := url.QueryUnescape(sigAlg)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "SAML-ahF3e", "invalid signature algorithm")
+	}
+	if algorithm != dsig.RSASHA256SignatureMethod {
+		return errors.ThrowInvalidArgument(nil, "SAML-Eeph4", "unsupported signature algorithm")
+	}
+	rawSignature, err := url.QueryUnescape(encodedSignature)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "SAML-Ooch5", "invalid signature")
+	}
+	signature, err := base64.StdEncoding.DecodeString(rawSignature)
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "SAML-ieT2u", "invalid signature")
+	}
+	digest := sha256.Sum256([]byte(signedQuery))
+	for _, certificate := range certificates {
+		publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) == nil {
+			return nil
+		}
+	}
+	return errors.ThrowInvalidArgument(nil, "SAML-Quo3a", "invalid signature")
+}
@@ -0,0 +1,114 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedirectSignature(t *testing.T) {
+	key, certificate := testCertificate(t)
+	_, otherCertificate := testCertificate(t)
+	query := "SAMLRequest=" + url.QueryEscape("request+/=") + "&RelayState=state"
+
+	signed, err := signRedirect(query, key)
+	require.NoError(t, err)
+
+	type args struct {
+		rawQuery     string
+		certificates []*x509.Certificate
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "valid signature",
+			args: args{
+				rawQuery:     signed,
+				certificates: []*x509.Certificate{otherCertificate, certificate},
+			},
+		},
+		{
+			name: "wrong certificate",
+			args: args{
+				rawQuery:     signed,
+				certificates: []*x509.Certificate{otherCertificate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "manipulated relay state",
+			args: args{
+				rawQuery:     "SAMLRequest=" + url.QueryEscape("request+/=") + "&RelayState=other" + signed[len(query):],
+				certificates: []*x509.Certificate{certificate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing signature",
+			args: args{
+				rawQuery:     query,
+				certificates: []*x509.Certificate{certificate},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rsa-sha1 rejected",
+			args: args{
+				rawQuery:     signSHA1Redirect(t, query, key),
+				certificates: []*x509.Certificate{certificate},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyRedirect(tt.args.rawQuery, "SAMLRequest", tt.args.certificates)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// signSHA1Redirect signs the query like signRedirect, but using rsa-sha1
+func signSHA1Redirect(t *testing.T, query string, key *rsa.PrivateKey) string {
+	t.Helper()
+	signedQuery := query + "&SigAlg=" + url.QueryEscape("http://www.w3.org/2000/09/xmldsig#rsa-sha1")
+	digest := sha1.Sum([]byte(signedQuery)) //nolint:gosec
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, digest[:])
+	require.NoError(t, err)
+	return signedQuery + "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+}
+
+func testCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, certificate
+}
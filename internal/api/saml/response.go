@@ -0,0 +1,107 @@
+package saml
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/saml/schema"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	AttributeEmail     = "Email"
+	AttributeSurName   = "SurName"
+	AttributeFirstName = "FirstName"
+	AttributeFullName  = "FullName"
+	AttributeUserName  = "UserName"
+	AttributeUserID    = "UserID"
+)
+
+// postTemplate renders the HTTP-POST binding, which automatically submits the message to the service provider
+var postTemplate = template.Must(template.New("post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<noscript><p>JavaScript is disabled, please press the button to continue.</p></noscript>
+<form method="post" action="{{ .URL }}">
+<input type="hidden" name="{{ .Param }}" value="{{ .Message }}">
+{{- if .RelayState }}
+<input type="hidden" name="RelayState" value="{{ .RelayState }}">
+{{- end }}
+<noscript><input type="submit" value="Continue"></noscript>
+</form>
+</body>
+</html>`))
+
+type postData struct {
+	URL        string
+	Param      string
+	Message    string
+	RelayState string
+}
+
+func (p *Provider) sendPost(w http.ResponseWriter, url, param string, message []byte, relayState string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src 'unsafe-inline'; form-action "+url)
+	err := postTemplate.Execute(w, &postData{
+		URL:        url,
+		Param:      param,
+		Message:    schema.EncodePost(message),
+		RelayState: relayState,
+	})
+	logging.OnError(err).Debug("unable to render saml post binding")
+}
+
+func decodeMessage(binding, encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, schema.ErrInvalidMessage
+	}
+	if binding == schema.BindingHTTPRedirect {
+		return schema.DecodeRedirect(encoded)
+	}
+	return schema.DecodePost(encoded)
+}
+
+// newMessageID returns a random identifier, which must not start with a number (xs:ID)
+func newMessageID() (string, error) {
+	id := make([]byte, 20)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return "_" + hex.EncodeToString(id), nil
+}
+
+// nameIDForUser returns the subject of the assertion in the requested format
+func nameIDForUser(user *query.User, format string) (string, string, error) {
+	switch format {
+	case schema.NameIDFormatEmailAddress:
+		if user.Human == nil || user.Human.Email == "" {
+			return "", "", errors.ThrowPreconditionFailed(nil, "SAML-Kd3gs", "user has no email")
+		}
+		return user.Human.Email, format, nil
+	case schema.NameIDFormatPersistent:
+		return user.ID, format, nil
+	default:
+		return user.PreferredLoginName, schema.NameIDFormatUnspecified, nil
+	}
+}
+
+func userAttributes(user *query.User) []*schema.Attribute {
+	attributes := []*schema.Attribute{
+		{Name: AttributeUserName, Values: []string{user.PreferredLoginName}},
+		{Name: AttributeUserID, Values: []string{user.ID}},
+	}
+	if user.Human == nil {
+		return attributes
+	}
+	return append(attributes,
+		&schema.Attribute{Name: AttributeEmail, Values: []string{user.Human.Email}},
+		&schema.Attribute{Name: AttributeSurName, Values: []string{user.Human.LastName}},
+		&schema.Attribute{Name: AttributeFirstName, Values: []string{user.Human.FirstName}},
+		&schema.Attribute{Name: AttributeFullName, Values: []string{user.Human.DisplayName}},
+	)
+}
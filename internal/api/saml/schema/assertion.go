@@ -0,0 +1,273 @@
+package schema
+
+import (
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	// clockSkew is the tolerance of the validity of assertions issued by external identity providers
+	clockSkew = 2 * time.Minute
+
+	digestSHA1 = "http://www.w3.org/2000/09/xmldsig#sha1"
+)
+
+var (
+	ErrStatus               = errors.New("identity provider returned an error status")
+	ErrEncryptedAssertion   = errors.New("encrypted assertions are not supported")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signature algorithm")
+	ErrInvalidAssertion     = errors.New("invalid assertion")
+	ErrAssertionExpired     = errors.New("assertion expired or not yet valid")
+	ErrAudienceMismatch     = errors.New("assertion not issued for this service provider")
+	ErrRecipientMismatch    = errors.New("assertion not issued for this assertion consumer service")
+	ErrInResponseToMismatch = errors.New("assertion not issued for this request")
+)
+
+// Response is the samlp:Response of an external identity provider
+type Response struct {
+	XMLName      xml.Name             `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string               `xml:"ID,attr"`
+	InResponseTo string               `xml:"InResponseTo,attr"`
+	Destination  string               `xml:"Destination,attr"`
+	Issuer       string               `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       ResponseStatus       `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status"`
+	Assertions   []*ReceivedAssertion `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+}
+
+type ResponseStatus struct {
+	StatusCode struct {
+		Value string `xml:"Value,attr"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusCode"`
+	StatusMessage string `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusMessage"`
+}
+
+// ReceivedAssertion is the saml:Assertion of an external identity provider
+type ReceivedAssertion struct {
+	XMLName            xml.Name               `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	ID                 string                 `xml:"ID,attr"`
+	Issuer             string                 `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameID             string                 `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject>NameID"`
+	Confirmations      []*SubjectConfirmation `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject>SubjectConfirmation"`
+	Conditions         *Conditions            `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AttributeStatement []*ReceivedAttribute   `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement>Attribute"`
+}
+
+type SubjectConfirmation struct {
+	Method string           `xml:"Method,attr"`
+	Data   ConfirmationData `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+}
+
+type ConfirmationData struct {
+	InResponseTo string `xml:"InResponseTo,attr"`
+	Recipient    string `xml:"Recipient,attr"`
+	NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+}
+
+type Conditions struct {
+	NotBefore    string   `xml:"NotBefore,attr"`
+	NotOnOrAfter string   `xml:"NotOnOrAfter,attr"`
+	Audiences    []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction>Audience"`
+}
+
+type ReceivedAttribute struct {
+	Name         string   `xml:"Name,attr"`
+	FriendlyName string   `xml:"FriendlyName,attr"`
+	Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+}
+
+// ParseResponse parses the samlp:Response of an identity provider and returns its single assertion.
+// Either the response or the assertion must be signed by one of the certificates.
+// The assertion is always taken from the signed element returned by the verification, so unsigned content can't be injected.
+func ParseResponse(data []byte, certificates []*x509.Certificate) (*Response, *ReceivedAssertion, error) {
+	response := new(Response)
+	if err := unmarshal(data, response); err != nil {
+		return nil, nil, err
+	}
+	if response.Status.StatusCode.Value != StatusSuccess {
+		return response, nil, ErrStatus
+	}
+	document := etree.NewDocument()
+	if err := document.ReadFromBytes(data); err != nil {
+		return nil, nil, err
+	}
+	root := document.Root()
+	if len(childElements(root, NamespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, nil, ErrEncryptedAssertion
+	}
+	if len(childElements(root, dsig.Namespace, "Signature")) > 0 {
+		response = new(Response)
+		if err := verifySignature(root, certificates, response); err != nil {
+			return nil, nil, err
+		}
+		if len(response.Assertions) != 1 {
+			return nil, nil, ErrInvalidAssertion
+		}
+		return response, response.Assertions[0], nil
+	}
+	assertions := childElements(root, NamespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, nil, ErrInvalidAssertion
+	}
+	assertion := new(ReceivedAssertion)
+	if err := verifySignature(assertions[0], certificates, assertion); err != nil {
+		return nil, nil, err
+	}
+	return response, assertion, nil
+}
+
+// verifySignature verifies the enveloped signature of the element
+// and unmarshals the signed element returned by the verification into v
+func verifySignature(element *etree.Element, certificates []*x509.Certificate, v interface{}) error {
+	signatures := childElements(element, dsig.Namespace, "Signature")
+	if len(signatures) != 1 {
+		return ErrInvalidSignature
+	}
+	for _, method := range append(signatures[0].FindElements("./SignedInfo/SignatureMethod"), signatures[0].FindElements("./SignedInfo/Reference/DigestMethod")...) {
+		if isSHA1(method.SelectAttrValue(dsig.AlgorithmAttr, "")) {
+			return ErrUnsupportedAlgorithm
+		}
+	}
+	// the namespaces declared by the ancestors are copied onto the element, so it can be verified on its own
+	parentContext, err := etreeutils.NSBuildParentContext(element)
+	if err != nil {
+		return err
+	}
+	detached, err := etreeutils.NSDetatch(parentContext, element)
+	if err != nil {
+		return err
+	}
+	validationContext := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certificates})
+	signed, err := validationContext.Validate(detached)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	document := etree.NewDocument()
+	document.SetRoot(signed)
+	data, err := document.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	return unmarshal(data, v)
+}
+
+// isSHA1 returns true for the sha1 digest and signature algorithms, which are vulnerable to collisions
+func isSHA1(algorithm string) bool {
+	switch algorithm {
+	case digestSHA1, dsig.RSASHA1SignatureMethod, dsig.ECDSASHA1SignatureMethod:
+		return true
+	default:
+		return false
+	}
+}
+
+// childElements returns the child elements with the name in the namespace
+func childElements(element *etree.Element, namespace, name string) []*etree.Element {
+	var children []*etree.Element
+	for _, child := range element.ChildElements() {
+		if child.Tag == name && child.NamespaceURI() == namespace {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// Validate checks the assertion to be issued by the identity provider for the service provider (audience)
+// as answer to the request and to be currently valid.
+// The audience restriction and the recipient and expiration of the bearer confirmation are required,
+// so the assertion can't be used at another service provider or forever.
+func (a *ReceivedAssertion) Validate(issuer, audience, recipient, inResponseTo string, now time.Time) error {
+	if a.ID == "" || a.Issuer != issuer || a.NameID == "" {
+		return ErrInvalidAssertion
+	}
+	if a.Conditions == nil || !contains(a.Conditions.Audiences, audience) {
+		return ErrAudienceMismatch
+	}
+	if err := validTime(a.Conditions.NotBefore, a.Conditions.NotOnOrAfter, now); err != nil {
+		return err
+	}
+	confirmation := a.bearerConfirmation()
+	if confirmation == nil || confirmation.Data.NotOnOrAfter == "" {
+		return ErrInvalidAssertion
+	}
+	if confirmation.Data.Recipient != recipient {
+		return ErrRecipientMismatch
+	}
+	if confirmation.Data.InResponseTo != inResponseTo {
+		return ErrInResponseToMismatch
+	}
+	return validTime("", confirmation.Data.NotOnOrAfter, now)
+}
+
+// Expiration returns the time (including the clock skew) until which the validated assertion is accepted,
+// its ID has to be remembered until then to detect replays
+func (a *ReceivedAssertion) Expiration() time.Time {
+	confirmation := a.bearerConfirmation()
+	if confirmation == nil {
+		return time.Time{}
+	}
+	notOnOrAfter, err := time.Parse(time.RFC3339Nano, confirmation.Data.NotOnOrAfter)
+	if err != nil {
+		return time.Time{}
+	}
+	return notOnOrAfter.Add(clockSkew)
+}
+
+func (a *ReceivedAssertion) bearerConfirmation() *SubjectConfirmation {
+	for _, confirmation := range a.Confirmations {
+		if confirmation.Method == ConfirmationMethodBearer {
+			return confirmation
+		}
+	}
+	return nil
+}
+
+// Attributes returns the values of the attributes by their name (and friendly name)
+func (a *ReceivedAssertion) Attributes() map[string][]string {
+	attributes := make(map[string][]string, len(a.AttributeStatement)*2)
+	for _, attribute := range a.AttributeStatement {
+		attributes[attribute.Name] = append(attributes[attribute.Name], attribute.Values...)
+		if attribute.FriendlyName != "" && attribute.FriendlyName != attribute.Name {
+			attributes[attribute.FriendlyName] = append(attributes[attribute.FriendlyName], attribute.Values...)
+		}
+	}
+	return attributes
+}
+
+func validTime(notBefore, notOnOrAfter string, now time.Time) error {
+	if notBefore != "" {
+		t, err := time.Parse(time.RFC3339Nano, notBefore)
+		if err != nil {
+			return ErrInvalidAssertion
+		}
+		if now.Add(clockSkew).Before(t) {
+			return ErrAssertionExpired
+		}
+	}
+	if notOnOrAfter != "" {
+		t, err := time.Parse(time.RFC3339Nano, notOnOrAfter)
+		if err != nil {
+			return ErrInvalidAssertion
+		}
+		if !now.Add(-clockSkew).Before(t) {
+			return ErrAssertionExpired
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"github.com/beevik/etree"
+)
+
+// element builds the etree elements of the messages created by ZITADEL
+type element struct {
+	*etree.Element
+}
+
+func newElement(prefix, name string) *element {
+	return &element{Element: etree.NewElement(prefix + ":" + name)}
+}
+
+// declare adds a namespace declaration to the element
+func (e *element) declare(prefix, uri string) *element {
+	e.CreateAttr("xmlns:"+prefix, uri)
+	return e
+}
+
+// attr adds an unqualified attribute, empty values are omitted
+func (e *element) attr(name, value string) *element {
+	if value != "" {
+		e.CreateAttr(name, value)
+	}
+	return e
+}
+
+func (e *element) text(text string) *element {
+	e.SetText(text)
+	return e
+}
+
+// add appends the children and returns the element itself
+func (e *element) add(children ...*element) *element {
+	for _, child := range children {
+		if child != nil {
+			e.AddChild(child.Element)
+		}
+	}
+	return e
+}
+
+// Document returns the element prefixed with the xml declaration
+func Document(element *etree.Element) []byte {
+	document := etree.NewDocument()
+	document.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	document.SetRoot(element)
+	// writing into memory can't fail
+	data, _ := document.WriteToBytes()
+	return data
+}
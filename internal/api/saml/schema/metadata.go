@@ -0,0 +1,225 @@
+package schema
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// EntityDescriptor is the metadata of a service provider or an identity provider
+type EntityDescriptor struct {
+	XMLName          xml.Name          `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID         string            `xml:"entityID,attr"`
+	SPSSODescriptor  *SPSSODescriptor  `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+	IDPSSODescriptor *IDPSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+type SPSSODescriptor struct {
+	AuthnRequestsSigned       bool              `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned      bool              `xml:"WantAssertionsSigned,attr"`
+	KeyDescriptors            []KeyDescriptor   `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleLogoutServices      []Endpoint        `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleLogoutService"`
+	NameIDFormats             []string          `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+	AssertionConsumerServices []IndexedEndpoint `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type IDPSSODescriptor struct {
+	WantAuthnRequestsSigned bool            `xml:"WantAuthnRequestsSigned,attr"`
+	KeyDescriptors          []KeyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleLogoutServices    []Endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleLogoutService"`
+	NameIDFormats           []string        `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat"`
+	SingleSignOnServices    []Endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type KeyDescriptor struct {
+	Use     string  `xml:"use,attr"`
+	KeyInfo KeyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+}
+
+type KeyInfo struct {
+	X509Data struct {
+		X509Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# X509Certificate"`
+	} `xml:"http://www.w3.org/2000/09/xmldsig# X509Data"`
+}
+
+type Endpoint struct {
+	Binding          string `xml:"Binding,attr"`
+	Location         string `xml:"Location,attr"`
+	ResponseLocation string `xml:"ResponseLocation,attr"`
+}
+
+type IndexedEndpoint struct {
+	Endpoint
+	Index     int  `xml:"index,attr"`
+	IsDefault bool `xml:"isDefault,attr"`
+}
+
+// ParseMetadata parses the metadata of a service provider (md:EntityDescriptor)
+func ParseMetadata(data []byte) (*EntityDescriptor, error) {
+	descriptor := new(EntityDescriptor)
+	if err := unmarshal(data, descriptor); err != nil {
+		return nil, err
+	}
+	if descriptor.EntityID == "" || descriptor.SPSSODescriptor == nil {
+		return nil, ErrInvalidMessage
+	}
+	return descriptor, nil
+}
+
+// ParseIDPMetadata parses the metadata of an identity provider (md:EntityDescriptor)
+func ParseIDPMetadata(data []byte) (*EntityDescriptor, error) {
+	descriptor := new(EntityDescriptor)
+	if err := unmarshal(data, descriptor); err != nil {
+		return nil, err
+	}
+	if descriptor.EntityID == "" || descriptor.IDPSSODescriptor == nil {
+		return nil, ErrInvalidMessage
+	}
+	if _, ok := descriptor.SingleSignOnService(); !ok {
+		return nil, ErrInvalidMessage
+	}
+	return descriptor, nil
+}
+
+// SigningCertificates returns all certificates of the service provider which can be used for signing
+func (d *EntityDescriptor) SigningCertificates() ([]*x509.Certificate, error) {
+	return signingCertificates(d.SPSSODescriptor.KeyDescriptors)
+}
+
+// IDPSigningCertificates returns all certificates of the identity provider which can be used for signing
+func (d *EntityDescriptor) IDPSigningCertificates() ([]*x509.Certificate, error) {
+	return signingCertificates(d.IDPSSODescriptor.KeyDescriptors)
+}
+
+func signingCertificates(keyDescriptors []KeyDescriptor) ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0, len(keyDescriptors))
+	for _, keyDescriptor := range keyDescriptors {
+		if keyDescriptor.Use != "" && keyDescriptor.Use != "signing" {
+			continue
+		}
+		for _, encoded := range keyDescriptor.KeyInfo.X509Data.X509Certificates {
+			der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+			if err != nil {
+				return nil, err
+			}
+			certificate, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, err
+			}
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates, nil
+}
+
+// AssertionConsumerService returns the endpoint the response will be sent to
+// if the request specifies a location it must be registered in the metadata, otherwise the index or the default is used
+// only the HTTP-POST binding is supported for responses
+func (d *EntityDescriptor) AssertionConsumerService(location string, index *int) (*IndexedEndpoint, bool) {
+	var fallback *IndexedEndpoint
+	for i, acs := range d.SPSSODescriptor.AssertionConsumerServices {
+		if acs.Binding != BindingHTTPPost {
+			continue
+		}
+		endpoint := &d.SPSSODescriptor.AssertionConsumerServices[i]
+		switch {
+		case location != "":
+			if acs.Location == location {
+				return endpoint, true
+			}
+		case index != nil:
+			if acs.Index == *index {
+				return endpoint, true
+			}
+		case acs.IsDefault:
+			return endpoint, true
+		case fallback == nil:
+			fallback = endpoint
+		}
+	}
+	return fallback, fallback != nil
+}
+
+// SingleLogoutService returns the endpoint for logout messages, HTTP-POST is preferred over HTTP-Redirect
+func (d *EntityDescriptor) SingleLogoutService() (*Endpoint, bool) {
+	var redirect *Endpoint
+	for i, slo := range d.SPSSODescriptor.SingleLogoutServices {
+		switch slo.Binding {
+		case BindingHTTPPost:
+			return &d.SPSSODescriptor.SingleLogoutServices[i], true
+		case BindingHTTPRedirect:
+			if redirect == nil {
+				redirect = &d.SPSSODescriptor.SingleLogoutServices[i]
+			}
+		}
+	}
+	return redirect, redirect != nil
+}
+
+// SingleSignOnService returns the endpoint of the identity provider the AuthnRequest is sent to
+// only the HTTP-Redirect binding is supported for requests
+func (d *EntityDescriptor) SingleSignOnService() (*Endpoint, bool) {
+	for i, sso := range d.IDPSSODescriptor.SingleSignOnServices {
+		if sso.Binding == BindingHTTPRedirect {
+			return &d.IDPSSODescriptor.SingleSignOnServices[i], true
+		}
+	}
+	return nil, false
+}
+
+// IDPMetadata creates the md:EntityDescriptor of the identity provider
+func IDPMetadata(entityID, ssoURL, sloURL string, certificate []byte) *etree.Element {
+	descriptor := newElement(PrefixMetadata, "IDPSSODescriptor").
+		attr("WantAuthnRequestsSigned", "false").
+		attr("protocolSupportEnumeration", NamespaceProtocol)
+	descriptor.add(
+		newElement(PrefixMetadata, "KeyDescriptor").attr("use", "signing").add(keyInfo(certificate)),
+		newElement(PrefixMetadata, "SingleLogoutService").attr("Binding", BindingHTTPRedirect).attr("Location", sloURL),
+		newElement(PrefixMetadata, "SingleLogoutService").attr("Binding", BindingHTTPPost).attr("Location", sloURL),
+	)
+	for _, format := range []string{NameIDFormatUnspecified, NameIDFormatEmailAddress, NameIDFormatPersistent} {
+		descriptor.add(newElement(PrefixMetadata, "NameIDFormat").text(format))
+	}
+	descriptor.add(
+		newElement(PrefixMetadata, "SingleSignOnService").attr("Binding", BindingHTTPRedirect).attr("Location", ssoURL),
+		newElement(PrefixMetadata, "SingleSignOnService").attr("Binding", BindingHTTPPost).attr("Location", ssoURL),
+	)
+	return newElement(PrefixMetadata, "EntityDescriptor").
+		declare(PrefixMetadata, NamespaceMetadata).
+		attr("entityID", entityID).
+		add(descriptor).Element
+}
+
+// SPMetadata creates the md:EntityDescriptor of ZITADEL as service provider of an external identity provider
+func SPMetadata(entityID, acsURL string) *etree.Element {
+	return newElement(PrefixMetadata, "EntityDescriptor").
+		declare(PrefixMetadata, NamespaceMetadata).
+		attr("entityID", entityID).
+		add(
+			newElement(PrefixMetadata, "SPSSODescriptor").
+				attr("AuthnRequestsSigned", "false").
+				attr("WantAssertionsSigned", "true").
+				attr("protocolSupportEnumeration", NamespaceProtocol).
+				add(
+					newElement(PrefixMetadata, "NameIDFormat").text(NameIDFormatPersistent),
+					newElement(PrefixMetadata, "AssertionConsumerService").
+						attr("Binding", BindingHTTPPost).
+						attr("Location", acsURL).
+						attr("index", "0").
+						attr("isDefault", "true"),
+				),
+		).Element
+}
+
+// keyInfo returns the ds:KeyInfo containing the (DER encoded) certificate
+func keyInfo(certificate []byte) *element {
+	return newElement(dsig.DefaultPrefix, dsig.KeyInfoTag).declare(dsig.DefaultPrefix, dsig.Namespace).add(
+		newElement(dsig.DefaultPrefix, dsig.X509DataTag).add(
+			newElement(dsig.DefaultPrefix, dsig.X509CertificateTag).text(base64.StdEncoding.EncodeToString(certificate)),
+		),
+	)
+}
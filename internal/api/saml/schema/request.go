@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/beevik/etree"
+)
+
+type AuthnRequest struct {
+	XMLName                       xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                            string        `xml:"ID,attr"`
+	Version                       string        `xml:"Version,attr"`
+	IssueInstant                  string        `xml:"IssueInstant,attr"`
+	Destination                   string        `xml:"Destination,attr"`
+	ForceAuthn                    bool          `xml:"ForceAuthn,attr"`
+	IsPassive                     bool          `xml:"IsPassive,attr"`
+	ProtocolBinding               string        `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL   string        `xml:"AssertionConsumerServiceURL,attr"`
+	AssertionConsumerServiceIndex *int          `xml:"AssertionConsumerServiceIndex,attr"`
+	Issuer                        string        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                  *NameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy"`
+	Signature                     *struct{}     `xml:"http://www.w3.org/2000/09/xmldsig# Signature"`
+}
+
+type NameIDPolicy struct {
+	Format      string `xml:"Format,attr"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// ParseAuthnRequest parses the samlp:AuthnRequest
+func ParseAuthnRequest(data []byte) (*AuthnRequest, error) {
+	request := new(AuthnRequest)
+	if err := unmarshal(data, request); err != nil {
+		return nil, err
+	}
+	if request.ID == "" || request.Version != Version || request.Issuer == "" {
+		return nil, ErrInvalidMessage
+	}
+	return request, nil
+}
+
+// NameIDFormat returns the requested format of the subject or unspecified
+func (r *AuthnRequest) NameIDFormat() string {
+	if r.NameIDPolicy == nil || r.NameIDPolicy.Format == "" {
+		return NameIDFormatUnspecified
+	}
+	return r.NameIDPolicy.Format
+}
+
+type LogoutRequest struct {
+	XMLName      xml.Name  `xml:"urn:oasis:names:tc:SAML:2.0:protocol LogoutRequest"`
+	ID           string    `xml:"ID,attr"`
+	Version      string    `xml:"Version,attr"`
+	IssueInstant string    `xml:"IssueInstant,attr"`
+	Destination  string    `xml:"Destination,attr"`
+	Issuer       string    `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameID       string    `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+	SessionIndex []string  `xml:"urn:oasis:names:tc:SAML:2.0:protocol SessionIndex"`
+	Signature    *struct{} `xml:"http://www.w3.org/2000/09/xmldsig# Signature"`
+}
+
+// ParseLogoutRequest parses the samlp:LogoutRequest
+func ParseLogoutRequest(data []byte) (*LogoutRequest, error) {
+	request := new(LogoutRequest)
+	if err := unmarshal(data, request); err != nil {
+		return nil, err
+	}
+	if request.ID == "" || request.Version != Version || request.Issuer == "" {
+		return nil, ErrInvalidMessage
+	}
+	return request, nil
+}
+
+// NewAuthnRequest creates the samlp:AuthnRequest sent to an external identity provider,
+// which is asked to post the response to the assertion consumer service
+func NewAuthnRequest(id, issuer, destination, acsURL string, issueInstant time.Time) *etree.Element {
+	return newElement(PrefixProtocol, "AuthnRequest").
+		declare(PrefixProtocol, NamespaceProtocol).
+		declare(PrefixAssertion, NamespaceAssertion).
+		attr("ID", id).
+		attr("Version", Version).
+		attr("IssueInstant", Time(issueInstant)).
+		attr("Destination", destination).
+		attr("ProtocolBinding", BindingHTTPPost).
+		attr("AssertionConsumerServiceURL", acsURL).
+		add(
+			issuerElement(issuer),
+			newElement(PrefixProtocol, "NameIDPolicy").attr("AllowCreate", "true"),
+		).Element
+}
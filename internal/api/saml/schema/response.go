@@ -0,0 +1,162 @@
+package schema
+
+import (
+	"crypto/rsa"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// Assertion contains the information about the authenticated user which will be signed and sent to the service provider
+type Assertion struct {
+	ID           string
+	Issuer       string
+	Audience     string
+	Recipient    string
+	InResponseTo string
+	NameID       string
+	NameIDFormat string
+	SessionIndex string
+	IssueInstant time.Time
+	AuthnInstant time.Time
+	NotOnOrAfter time.Time
+	Attributes   []*Attribute
+}
+
+type Attribute struct {
+	Name   string
+	Values []string
+}
+
+// NewResponse creates a successful samlp:Response containing the signed saml:Assertion
+func NewResponse(id string, assertion *Assertion, key *rsa.PrivateKey, certificate []byte) (*etree.Element, error) {
+	signedAssertion, err := assertion.signed(key, certificate)
+	if err != nil {
+		return nil, err
+	}
+	return newResponse(id, assertion.InResponseTo, assertion.Issuer, assertion.Recipient, assertion.IssueInstant, StatusSuccess, "").
+		add(signedAssertion).Element, nil
+}
+
+// NewErrorResponse creates a samlp:Response without assertion, informing the service provider about the failure
+func NewErrorResponse(id, inResponseTo, issuer, destination, status, message string, issueInstant time.Time) *etree.Element {
+	return newResponse(id, inResponseTo, issuer, destination, issueInstant, status, message).Element
+}
+
+// NewLogoutResponse creates the samlp:LogoutResponse
+func NewLogoutResponse(id, inResponseTo, issuer, destination, status string, issueInstant time.Time) *etree.Element {
+	return newElement(PrefixProtocol, "LogoutResponse").
+		declare(PrefixProtocol, NamespaceProtocol).
+		declare(PrefixAssertion, NamespaceAssertion).
+		attr("ID", id).
+		attr("Version", Version).
+		attr("IssueInstant", Time(issueInstant)).
+		attr("Destination", destination).
+		attr("InResponseTo", inResponseTo).
+		add(
+			issuerElement(issuer),
+			statusElement(status, ""),
+		).Element
+}
+
+func newResponse(id, inResponseTo, issuer, destination string, issueInstant time.Time, status, message string) *element {
+	return newElement(PrefixProtocol, "Response").
+		declare(PrefixProtocol, NamespaceProtocol).
+		declare(PrefixAssertion, NamespaceAssertion).
+		attr("ID", id).
+		attr("Version", Version).
+		attr("IssueInstant", Time(issueInstant)).
+		attr("Destination", destination).
+		attr("InResponseTo", inResponseTo).
+		add(
+			issuerElement(issuer),
+			statusElement(status, message),
+		)
+}
+
+func issuerElement(issuer string) *element {
+	return newElement(PrefixAssertion, "Issuer").text(issuer)
+}
+
+func statusElement(code, message string) *element {
+	status := newElement(PrefixProtocol, "Status").add(
+		newElement(PrefixProtocol, "StatusCode").attr("Value", code),
+	)
+	if message != "" {
+		status.add(newElement(PrefixProtocol, "StatusMessage").text(message))
+	}
+	return status
+}
+
+// signed creates the assertion with an enveloped rsa-sha256 signature (using exclusive canonicalization),
+// which is inserted after the saml:Issuer as required by the schema
+func (a *Assertion) signed(key *rsa.PrivateKey, certificate []byte) (*element, error) {
+	assertion := a.element()
+	signingContext, err := dsig.NewSigningContext(key, [][]byte{certificate})
+	if err != nil {
+		return nil, err
+	}
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signature, err := signingContext.ConstructSignature(assertion.Element, true)
+	if err != nil {
+		return nil, err
+	}
+	assertion.InsertChildAt(1, signature)
+	return assertion, nil
+}
+
+func (a *Assertion) element() *element {
+	nameIDFormat := a.NameIDFormat
+	if nameIDFormat == "" {
+		nameIDFormat = NameIDFormatUnspecified
+	}
+	attributes := newElement(PrefixAssertion, "AttributeStatement")
+	for _, attribute := range a.Attributes {
+		attributeElement := newElement(PrefixAssertion, "Attribute").
+			attr("Name", attribute.Name).
+			attr("NameFormat", AttributeNameFormatURI)
+		for _, value := range attribute.Values {
+			attributeElement.add(newElement(PrefixAssertion, "AttributeValue").text(value))
+		}
+		attributes.add(attributeElement)
+	}
+	if len(attributes.ChildElements()) == 0 {
+		attributes = nil
+	}
+
+	return newElement(PrefixAssertion, "Assertion").
+		declare(PrefixAssertion, NamespaceAssertion).
+		attr("ID", a.ID).
+		attr("Version", Version).
+		attr("IssueInstant", Time(a.IssueInstant)).
+		add(
+			issuerElement(a.Issuer),
+			newElement(PrefixAssertion, "Subject").add(
+				newElement(PrefixAssertion, "NameID").attr("Format", nameIDFormat).text(a.NameID),
+				newElement(PrefixAssertion, "SubjectConfirmation").attr("Method", ConfirmationMethodBearer).add(
+					newElement(PrefixAssertion, "SubjectConfirmationData").
+						attr("InResponseTo", a.InResponseTo).
+						attr("NotOnOrAfter", Time(a.NotOnOrAfter)).
+						attr("Recipient", a.Recipient),
+				),
+			),
+			newElement(PrefixAssertion, "Conditions").
+				attr("NotBefore", Time(a.IssueInstant)).
+				attr("NotOnOrAfter", Time(a.NotOnOrAfter)).
+				add(
+					newElement(PrefixAssertion, "AudienceRestriction").add(
+						newElement(PrefixAssertion, "Audience").text(a.Audience),
+					),
+				),
+			newElement(PrefixAssertion, "AuthnStatement").
+				attr("AuthnInstant", Time(a.AuthnInstant)).
+				attr("SessionIndex", a.SessionIndex).
+				add(
+					newElement(PrefixAssertion, "AuthnContext").add(
+						newElement(PrefixAssertion, "AuthnContextClassRef").text(AuthnContextPasswordProtectedTransport),
+					),
+				),
+			attributes,
+		)
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"time"
+)
+
+const (
+	NamespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	NamespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	NamespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	PrefixProtocol  = "samlp"
+	PrefixAssertion = "saml"
+	PrefixMetadata  = "md"
+
+	Version = "2.0"
+
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	NameIDFormatUnspecified  = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	NameIDFormatEmailAddress = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIDFormatPersistent   = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+
+	StatusSuccess          = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	StatusRequester        = "urn:oasis:names:tc:SAML:2.0:status:Requester"
+	StatusResponder        = "urn:oasis:names:tc:SAML:2.0:status:Responder"
+	StatusRequestDenied    = "urn:oasis:names:tc:SAML:2.0:status:RequestDenied"
+	StatusNoPassive        = "urn:oasis:names:tc:SAML:2.0:status:NoPassive"
+	StatusPartialLogout    = "urn:oasis:names:tc:SAML:2.0:status:PartialLogout"
+	AttributeNameFormatURI = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+
+	AuthnContextPasswordProtectedTransport = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+	ConfirmationMethodBearer               = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// TimeFormat is the xs:dateTime representation used for all instants (always UTC)
+	TimeFormat = "2006-01-02T15:04:05.000Z"
+)
+
+var ErrInvalidMessage = errors.New("invalid saml message")
+
+// Time formats the instant as xs:dateTime in UTC
+func Time(t time.Time) string {
+	return t.UTC().Format(TimeFormat)
+}
+
+// DecodeRedirect decodes a message of the HTTP-Redirect binding (base64 encoded and deflated)
+func DecodeRedirect(encoded string) ([]byte, error) {
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(compressed)), maxMessageSize))
+}
+
+// EncodeRedirect encodes a message for the HTTP-Redirect binding (deflated and base64 encoded)
+func EncodeRedirect(message []byte) (string, error) {
+	buffer := new(bytes.Buffer)
+	writer, err := flate.NewWriter(buffer, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = writer.Write(message); err != nil {
+		return "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// DecodePost decodes a message of the HTTP-POST binding (base64 encoded)
+func DecodePost(encoded string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// EncodePost encodes a message for the HTTP-POST binding
+func EncodePost(message []byte) string {
+	return base64.StdEncoding.EncodeToString(message)
+}
+
+const maxMessageSize = 1 << 20
+
+func unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 || len(data) > maxMessageSize {
+		return ErrInvalidMessage
+	}
+	return xml.Unmarshal(data, v)
+}
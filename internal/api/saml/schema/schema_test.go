@@ -0,0 +1,437 @@
+package schema
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com/metadata">
+  <md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true" protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://sp.example.com/slo/redirect"/>
+    <md:SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/slo/post"/>
+    <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress</md:NameIDFormat>
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://sp.example.com/acs/redirect" index="0"/>
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/acs/1" index="1"/>
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://sp.example.com/acs/2" index="2" isDefault="true"/>
+  </md:SPSSODescriptor>
+</md:EntityDescriptor>`
+
+func TestParseMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		metadata     string
+		wantEntityID string
+		wantErr      bool
+	}{
+		{
+			name:     "invalid xml",
+			metadata: "<md:EntityDescriptor",
+			wantErr:  true,
+		},
+		{
+			name:     "wrong root element",
+			metadata: `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata"/>`,
+			wantErr:  true,
+		},
+		{
+			name:     "missing sp descriptor",
+			metadata: `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://sp.example.com/metadata"/>`,
+			wantErr:  true,
+		},
+		{
+			name:         "valid",
+			metadata:     testMetadata,
+			wantEntityID: "https://sp.example.com/metadata",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMetadata([]byte(tt.metadata))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEntityID, got.EntityID)
+		})
+	}
+}
+
+func TestEntityDescriptor_AssertionConsumerService(t *testing.T) {
+	descriptor, err := ParseMetadata([]byte(testMetadata))
+	require.NoError(t, err)
+	index := 1
+	unknownIndex := 5
+
+	type args struct {
+		location string
+		index    *int
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantLocation string
+		wantOK       bool
+	}{
+		{
+			name:         "default",
+			wantLocation: "https://sp.example.com/acs/2",
+			wantOK:       true,
+		},
+		{
+			name:         "by index",
+			args:         args{index: &index},
+			wantLocation: "https://sp.example.com/acs/1",
+			wantOK:       true,
+		},
+		{
+			name: "unknown index",
+			args: args{index: &unknownIndex},
+		},
+		{
+			name:         "by location",
+			args:         args{location: "https://sp.example.com/acs/1"},
+			wantLocation: "https://sp.example.com/acs/1",
+			wantOK:       true,
+		},
+		{
+			name: "unregistered location",
+			args: args{location: "https://evil.example.com/acs"},
+		},
+		{
+			name: "unsupported binding",
+			args: args{location: "https://sp.example.com/acs/redirect"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := descriptor.AssertionConsumerService(tt.args.location, tt.args.index)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.wantLocation, got.Location)
+			}
+		})
+	}
+}
+
+func TestEntityDescriptor_SingleLogoutService(t *testing.T) {
+	descriptor, err := ParseMetadata([]byte(testMetadata))
+	require.NoError(t, err)
+	got, ok := descriptor.SingleLogoutService()
+	require.True(t, ok)
+	assert.Equal(t, BindingHTTPPost, got.Binding)
+	assert.Equal(t, "https://sp.example.com/slo/post", got.Location)
+}
+
+func TestParseAuthnRequest(t *testing.T) {
+	request := `<samlp:AuthnRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_id" Version="2.0" IssueInstant="2022-01-01T00:00:00Z" AssertionConsumerServiceURL="https://sp.example.com/acs/1">
+  <saml:Issuer>https://sp.example.com/metadata</saml:Issuer>
+  <samlp:NameIDPolicy Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress" AllowCreate="true"/>
+</samlp:AuthnRequest>`
+	encoded, err := EncodeRedirect([]byte(request))
+	require.NoError(t, err)
+	decoded, err := DecodeRedirect(encoded)
+	require.NoError(t, err)
+
+	got, err := ParseAuthnRequest(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, "_id", got.ID)
+	assert.Equal(t, "https://sp.example.com/metadata", got.Issuer)
+	assert.Equal(t, "https://sp.example.com/acs/1", got.AssertionConsumerServiceURL)
+	assert.Equal(t, NameIDFormatEmailAddress, got.NameIDFormat())
+	assert.Nil(t, got.Signature)
+
+	_, err = ParseAuthnRequest([]byte(`<samlp:AuthnRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="_id" Version="1.1"/>`))
+	assert.Error(t, err)
+}
+
+func TestNewLogoutResponse(t *testing.T) {
+	got := NewLogoutResponse("_id", "_request", "https://idp.example.com", "https://sp.example.com/slo", StatusSuccess, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t,
+		`<?xml version="1.0" encoding="UTF-8"?>`+
+			`<samlp:LogoutResponse xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_id" Version="2.0" IssueInstant="2022-01-01T00:00:00.000Z" Destination="https://sp.example.com/slo" InResponseTo="_request">`+
+			`<saml:Issuer>https://idp.example.com</saml:Issuer>`+
+			`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>`+
+			`</samlp:LogoutResponse>`,
+		string(Document(got)),
+	)
+}
+
+func TestParseIDPMetadata(t *testing.T) {
+	_, certificate := testCertificate(t)
+	metadata := IDPMetadata("https://idp.example.com/metadata", "https://idp.example.com/sso", "https://idp.example.com/slo", certificate.Raw)
+
+	descriptor, err := ParseIDPMetadata(Document(metadata))
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com/metadata", descriptor.EntityID)
+	sso, ok := descriptor.SingleSignOnService()
+	require.True(t, ok)
+	assert.Equal(t, "https://idp.example.com/sso", sso.Location)
+	certificates, err := descriptor.IDPSigningCertificates()
+	require.NoError(t, err)
+	require.Len(t, certificates, 1)
+	assert.Equal(t, certificate.Raw, certificates[0].Raw)
+
+	_, err = ParseIDPMetadata([]byte(testMetadata))
+	assert.Error(t, err)
+}
+
+func TestParseResponse(t *testing.T) {
+	key, certificate := testCertificate(t)
+	_, otherCertificate := testCertificate(t)
+	now := time.Now()
+	assertion := &Assertion{
+		ID:           "_assertion",
+		Issuer:       "https://idp.example.com/metadata",
+		Audience:     "https://sp.example.com/metadata",
+		Recipient:    "https://sp.example.com/acs",
+		InResponseTo: "_request",
+		NameID:       "user",
+		IssueInstant: now,
+		AuthnInstant: now,
+		NotOnOrAfter: now.Add(5 * time.Minute),
+		Attributes:   []*Attribute{{Name: "Email", Values: []string{"user@example.com"}}},
+	}
+	signedAssertion, err := NewResponse("_response", assertion, key, certificate.Raw)
+	require.NoError(t, err)
+	signedResponse, err := NewResponse("_response", assertion, key, certificate.Raw)
+	require.NoError(t, err)
+	signResponse(t, signedResponse, key, certificate.Raw)
+	errorResponse := NewErrorResponse("_response", "_request", assertion.Issuer, assertion.Recipient, StatusRequestDenied, "denied", now)
+
+	tests := []struct {
+		name         string
+		data         string
+		certificates []*x509.Certificate
+		wantErr      error
+	}{
+		{
+			name:         "signed assertion",
+			data:         string(Document(signedAssertion)),
+			certificates: []*x509.Certificate{certificate},
+		},
+		{
+			name:         "signed response",
+			data:         string(Document(signedResponse)),
+			certificates: []*x509.Certificate{certificate},
+		},
+		{
+			name:         "error status",
+			data:         string(Document(errorResponse)),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrStatus,
+		},
+		{
+			name:         "unsigned response",
+			data:         strings.Replace(string(Document(errorResponse)), StatusRequestDenied, StatusSuccess, 1),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrInvalidAssertion,
+		},
+		{
+			name:         "wrong certificate",
+			data:         string(Document(signedAssertion)),
+			certificates: []*x509.Certificate{otherCertificate},
+			wantErr:      ErrInvalidSignature,
+		},
+		{
+			name:         "manipulated assertion",
+			data:         strings.Replace(string(Document(signedAssertion)), ">user<", ">admin<", 1),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrInvalidSignature,
+		},
+		{
+			name:         "manipulated reference",
+			data:         strings.Replace(string(Document(signedAssertion)), `ID="_assertion"`, `ID="_other"`, 1),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrInvalidSignature,
+		},
+		{
+			name:         "sha1 digest rejected",
+			data:         strings.Replace(string(Document(signedAssertion)), "http://www.w3.org/2001/04/xmlenc#sha256", "http://www.w3.org/2000/09/xmldsig#sha1", 1),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrUnsupportedAlgorithm,
+		},
+		{
+			name:         "rsa-sha1 signature rejected",
+			data:         strings.Replace(string(Document(signedAssertion)), "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256", "http://www.w3.org/2000/09/xmldsig#rsa-sha1", 1),
+			certificates: []*x509.Certificate{certificate},
+			wantErr:      ErrUnsupportedAlgorithm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got, err := ParseResponse([]byte(tt.data), tt.certificates)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", got.NameID)
+			assert.Equal(t, []string{"user@example.com"}, got.Attributes()["Email"])
+			assert.NoError(t, got.Validate(assertion.Issuer, assertion.Audience, assertion.Recipient, "_request", now))
+		})
+	}
+}
+
+func TestReceivedAssertion_Validate(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	validAssertion := func() *ReceivedAssertion {
+		return &ReceivedAssertion{
+			ID:     "_assertion",
+			Issuer: "issuer",
+			NameID: "user",
+			Confirmations: []*SubjectConfirmation{{
+				Method: ConfirmationMethodBearer,
+				Data: ConfirmationData{
+					InResponseTo: "_request",
+					Recipient:    "acs",
+					NotOnOrAfter: "2022-01-01T00:05:00Z",
+				},
+			}},
+			Conditions: &Conditions{
+				NotBefore:    "2022-01-01T00:00:00Z",
+				NotOnOrAfter: "2022-01-01T00:05:00Z",
+				Audiences:    []string{"audience"},
+			},
+		}
+	}
+	type args struct {
+		issuer, audience, recipient, inResponseTo string
+		now                                       time.Time
+	}
+	tests := []struct {
+		name    string
+		modify  func(*ReceivedAssertion)
+		args    args
+		wantErr error
+	}{
+		{
+			name: "valid",
+			args: args{"issuer", "audience", "acs", "_request", now},
+		},
+		{
+			name:    "wrong issuer",
+			args:    args{"other", "audience", "acs", "_request", now},
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "missing id",
+			modify:  func(a *ReceivedAssertion) { a.ID = "" },
+			args:    args{"issuer", "audience", "acs", "_request", now},
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "wrong audience",
+			args:    args{"issuer", "other", "acs", "_request", now},
+			wantErr: ErrAudienceMismatch,
+		},
+		{
+			name:    "missing conditions",
+			modify:  func(a *ReceivedAssertion) { a.Conditions = nil },
+			args:    args{"issuer", "audience", "acs", "_request", now},
+			wantErr: ErrAudienceMismatch,
+		},
+		{
+			name:    "missing audience restriction",
+			modify:  func(a *ReceivedAssertion) { a.Conditions.Audiences = nil },
+			args:    args{"issuer", "audience", "acs", "_request", now},
+			wantErr: ErrAudienceMismatch,
+		},
+		{
+			name:    "wrong recipient",
+			args:    args{"issuer", "audience", "other", "_request", now},
+			wantErr: ErrRecipientMismatch,
+		},
+		{
+			name:    "missing recipient",
+			modify:  func(a *ReceivedAssertion) { a.Confirmations[0].Data.Recipient = "" },
+			args:    args{"issuer", "audience", "acs", "_request", now},
+			wantErr: ErrRecipientMismatch,
+		},
+		{
+			name:    "missing bearer confirmation",
+			modify:  func(a *ReceivedAssertion) { a.Confirmations[0].Method = "urn:oasis:names:tc:SAML:2.0:cm:holder-of-key" },
+			args:    args{"issuer", "audience", "acs", "_request", now},
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name: "missing expiration",
+			modify: func(a *ReceivedAssertion) {
+				a.Conditions.NotOnOrAfter = ""
+				a.Confirmations[0].Data.NotOnOrAfter = ""
+			},
+			args:    args{"issuer", "audience", "acs", "_request", now.Add(24 * time.Hour)},
+			wantErr: ErrInvalidAssertion,
+		},
+		{
+			name:    "wrong request",
+			args:    args{"issuer", "audience", "acs", "_other", now},
+			wantErr: ErrInResponseToMismatch,
+		},
+		{
+			name:    "expired",
+			args:    args{"issuer", "audience", "acs", "_request", now.Add(10 * time.Minute)},
+			wantErr: ErrAssertionExpired,
+		},
+		{
+			name:    "not yet valid",
+			args:    args{"issuer", "audience", "acs", "_request", now.Add(-10 * time.Minute)},
+			wantErr: ErrAssertionExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertion := validAssertion()
+			if tt.modify != nil {
+				tt.modify(assertion)
+			}
+			err := assertion.Validate(tt.args.issuer, tt.args.audience, tt.args.recipient, tt.args.inResponseTo, tt.args.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, time.Date(2022, 1, 1, 0, 5, 0, 0, time.UTC).Add(clockSkew), assertion.Expiration())
+		})
+	}
+}
+
+// signResponse adds an enveloped signature over the whole response after its issuer
+func signResponse(t *testing.T, response *etree.Element, key *rsa.PrivateKey, certificate []byte) {
+	t.Helper()
+	signingContext, err := dsig.NewSigningContext(key, [][]byte{certificate})
+	require.NoError(t, err)
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signature, err := signingContext.ConstructSignature(response, true)
+	require.NoError(t, err)
+	response.InsertChildAt(1, signature)
+}
+
+func testCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return key, certificate
+}
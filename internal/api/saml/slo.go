@@ -0,0 +1,114 @@
+package saml
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/saml/schema"
+)
+
+// handleSLO receives the samlp:LogoutRequest, terminates the sessions of the user agent
+// and answers with a samlp:LogoutResponse
+func (p *Provider) handleSLO(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "unable to parse request", http.StatusBadRequest)
+		return
+	}
+	binding := schema.BindingHTTPRedirect
+	if r.Method == http.MethodPost {
+		binding = schema.BindingHTTPPost
+	}
+	data, err := decodeMessage(binding, r.Form.Get(paramSAMLRequest))
+	if err != nil {
+		http.Error(w, "invalid SAMLRequest", http.StatusBadRequest)
+		return
+	}
+	request, err := schema.ParseLogoutRequest(data)
+	if err != nil {
+		http.Error(w, "invalid SAMLRequest", http.StatusBadRequest)
+		return
+	}
+	_, descriptor, err := p.serviceProvider(r, request.Issuer)
+	if err != nil {
+		http.Error(w, "unknown service provider", http.StatusBadRequest)
+		return
+	}
+	slo, ok := descriptor.SingleLogoutService()
+	if !ok {
+		http.Error(w, "no single logout service", http.StatusBadRequest)
+		return
+	}
+	status := schema.StatusSuccess
+	if err = p.terminateSessions(r); err != nil {
+		logging.OnError(err).Warn("unable to terminate sessions on saml logout")
+		status = schema.StatusPartialLogout
+	}
+	p.sendLogoutResponse(w, r, request.ID, slo, r.Form.Get(paramRelayState), status)
+}
+
+func (p *Provider) terminateSessions(r *http.Request) error {
+	ctx := r.Context()
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil
+	}
+	userIDs, err := p.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil || len(userIDs) == 0 {
+		return err
+	}
+	return p.command.HumansSignOut(authz.SetCtxData(ctx, authz.CtxData{UserID: userIDs[0]}), userAgentID, userIDs, nil)
+}
+
+func (p *Provider) sendLogoutResponse(w http.ResponseWriter, r *http.Request, inResponseTo string, slo *schema.Endpoint, relayState, status string) {
+	location := slo.Location
+	if slo.ResponseLocation != "" {
+		location = slo.ResponseLocation
+	}
+	id, err := newMessageID()
+	if err != nil {
+		http.Error(w, "unable to create response", http.StatusInternalServerError)
+		return
+	}
+	response := schema.NewLogoutResponse(id, inResponseTo, p.entityID(r), location, status, time.Now())
+	if slo.Binding == schema.BindingHTTPPost {
+		p.sendPost(w, location, paramSAMLResponse, schema.Document(response), relayState)
+		return
+	}
+	redirectURL, err := p.signedRedirect(r, location, schema.Document(response), relayState)
+	if err != nil {
+		logging.OnError(err).Warn("unable to sign saml logout response")
+		http.Error(w, "unable to create response", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+// signedRedirect returns the url of the HTTP-Redirect binding including the query signature
+func (p *Provider) signedRedirect(r *http.Request, location string, message []byte, relayState string) (string, error) {
+	key, _, err := p.signingKey(r.Context())
+	if err != nil {
+		return "", err
+	}
+	encoded, err := schema.EncodeRedirect(message)
+	if err != nil {
+		return "", err
+	}
+	query := paramSAMLResponse + "=" + url.QueryEscape(encoded)
+	if relayState != "" {
+		query += "&" + paramRelayState + "=" + url.QueryEscape(relayState)
+	}
+	query, err = signRedirect(query, key)
+	if err != nil {
+		return "", err
+	}
+	separator := "?"
+	if parsed, err := url.Parse(location); err == nil && parsed.RawQuery != "" {
+		separator = "&"
+	}
+	return location + separator + query, nil
+}
@@ -0,0 +1,234 @@
+package saml
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/saml/schema"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (p *Provider) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	_, certificate, err := p.signingKey(r.Context())
+	if err != nil {
+		logging.OnError(err).Warn("unable to get signing key for saml metadata")
+		http.Error(w, "unable to get signing key", http.StatusInternalServerError)
+		return
+	}
+	metadata := schema.IDPMetadata(p.entityID(r), p.endpoint(r, EndpointSSO), p.endpoint(r, EndpointSLO), certificate)
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(schema.Document(metadata))
+	logging.OnError(err).Debug("unable to write saml metadata")
+}
+
+// handleSSO receives the samlp:AuthnRequest over the HTTP-Redirect or HTTP-POST binding
+// and hands the user over to the login UI
+func (p *Provider) handleSSO(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "unable to parse request", http.StatusBadRequest)
+		return
+	}
+	binding := schema.BindingHTTPRedirect
+	if r.Method == http.MethodPost {
+		binding = schema.BindingHTTPPost
+	}
+	data, err := decodeMessage(binding, r.Form.Get(paramSAMLRequest))
+	if err != nil {
+		http.Error(w, "invalid SAMLRequest", http.StatusBadRequest)
+		return
+	}
+	request, err := schema.ParseAuthnRequest(data)
+	if err != nil {
+		http.Error(w, "invalid SAMLRequest", http.StatusBadRequest)
+		return
+	}
+	app, descriptor, err := p.serviceProvider(r, request.Issuer)
+	if err != nil {
+		http.Error(w, "unknown service provider", http.StatusBadRequest)
+		return
+	}
+	acs, ok := descriptor.AssertionConsumerService(request.AssertionConsumerServiceURL, request.AssertionConsumerServiceIndex)
+	if !ok {
+		http.Error(w, "no valid assertion consumer service", http.StatusBadRequest)
+		return
+	}
+	relayState := r.Form.Get(paramRelayState)
+	if err = verifyRequestSignature(r, binding, request.Signature != nil, descriptor); err != nil {
+		p.sendErrorResponse(w, r, request.ID, acs.Location, relayState, schema.StatusRequester, err.Error())
+		return
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		p.sendErrorResponse(w, r, request.ID, acs.Location, relayState, schema.StatusResponder, "no user agent id")
+		return
+	}
+	authRequest, err := p.repo.CreateAuthRequest(ctx, createAuthRequestToBusiness(r, request, app, acs.Location, relayState, binding, userAgentID))
+	if err != nil {
+		p.sendErrorResponse(w, r, request.ID, acs.Location, relayState, schema.StatusResponder, err.Error())
+		return
+	}
+	http.Redirect(w, r, login.HandlerPrefix+login.EndpointLogin+"?"+login.QueryAuthRequestID+"="+authRequest.ID, http.StatusFound)
+}
+
+// handleSSOCallback is called by the login UI after the user has been authenticated
+// and posts the signed assertion to the service provider
+func (p *Provider) handleSSOCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		http.Error(w, "no user agent id", http.StatusBadRequest)
+		return
+	}
+	authRequest, err := p.repo.AuthRequestByIDCheckLoggedIn(ctx, r.URL.Query().Get(paramID), userAgentID)
+	if err != nil {
+		http.Error(w, "auth request not found", http.StatusBadRequest)
+		return
+	}
+	samlRequest, ok := authRequest.Request.(*domain.AuthRequestSAML)
+	if !ok {
+		http.Error(w, "auth request not found", http.StatusBadRequest)
+		return
+	}
+	if !isDone(authRequest) {
+		p.sendErrorResponse(w, r, samlRequest.RequestID, authRequest.CallbackURI, authRequest.TransferState, schema.StatusRequestDenied, "user not authenticated")
+		return
+	}
+	response, err := p.createResponse(r, authRequest, samlRequest)
+	if err != nil {
+		logging.OnError(err).Warn("unable to create saml response")
+		p.sendErrorResponse(w, r, samlRequest.RequestID, authRequest.CallbackURI, authRequest.TransferState, schema.StatusResponder, "unable to create response")
+		return
+	}
+	err = p.repo.DeleteAuthRequest(ctx, authRequest.ID)
+	logging.OnError(err).Debug("unable to delete saml auth request")
+	p.sendPost(w, authRequest.CallbackURI, paramSAMLResponse, schema.Document(response), authRequest.TransferState)
+}
+
+func (p *Provider) createResponse(r *http.Request, authRequest *domain.AuthRequest, samlRequest *domain.AuthRequestSAML) (*etree.Element, error) {
+	ctx := r.Context()
+	key, certificate, err := p.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := p.query.GetUserByID(ctx, authRequest.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nameID, nameIDFormat, err := nameIDForUser(user, samlRequest.NameIDFormat)
+	if err != nil {
+		return nil, err
+	}
+	responseID, err := newMessageID()
+	if err != nil {
+		return nil, err
+	}
+	assertionID, err := newMessageID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return schema.NewResponse(responseID, &schema.Assertion{
+		ID:           assertionID,
+		Issuer:       p.entityID(r),
+		Audience:     authRequest.ApplicationID,
+		Recipient:    authRequest.CallbackURI,
+		InResponseTo: samlRequest.RequestID,
+		NameID:       nameID,
+		NameIDFormat: nameIDFormat,
+		SessionIndex: authRequest.ID,
+		IssueInstant: now,
+		AuthnInstant: authRequest.AuthTime,
+		NotOnOrAfter: now.Add(p.assertionLifetime),
+		Attributes:   userAttributes(user),
+	}, key, certificate)
+}
+
+// serviceProvider returns the active SAML application of the entityID and its parsed metadata
+func (p *Provider) serviceProvider(r *http.Request, entityID string) (*query.App, *schema.EntityDescriptor, error) {
+	app, err := p.query.AppBySAMLEntityID(r.Context(), entityID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if app.State != domain.AppStateActive || app.SAMLConfig == nil {
+		return nil, nil, errors.ThrowPreconditionFailed(nil, "SAML-Gd2hq", "Errors.Project.App.NotActive")
+	}
+	descriptor, err := schema.ParseMetadata(app.SAMLConfig.Metadata)
+	if err != nil {
+		return nil, nil, errors.ThrowPreconditionFailed(err, "SAML-Dbt42", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	return app, descriptor, nil
+}
+
+// verifyRequestSignature checks the signature of the request if the service provider announced to sign them
+// only signatures of the HTTP-Redirect binding are supported
+func verifyRequestSignature(r *http.Request, binding string, xmlSigned bool, descriptor *schema.EntityDescriptor) error {
+	if !descriptor.SPSSODescriptor.AuthnRequestsSigned {
+		return nil
+	}
+	if binding != schema.BindingHTTPRedirect || xmlSigned {
+		return errors.ThrowUnimplemented(nil, "SAML-f3Tg2", "only signed requests of the HTTP-Redirect binding are supported")
+	}
+	certificates, err := descriptor.SigningCertificates()
+	if err != nil {
+		return errors.ThrowPreconditionFailed(err, "SAML-Hwe2f", "invalid signing certificate")
+	}
+	if err = verifyRedirect(r.URL.RawQuery, paramSAMLRequest, certificates); err != nil {
+		return errors.ThrowPreconditionFailed(err, "SAML-Mn3gs", "invalid request signature")
+	}
+	return nil
+}
+
+func (p *Provider) sendErrorResponse(w http.ResponseWriter, r *http.Request, inResponseTo, destination, relayState, status, message string) {
+	id, err := newMessageID()
+	if err != nil {
+		http.Error(w, "unable to create response", http.StatusInternalServerError)
+		return
+	}
+	response := schema.NewErrorResponse(id, inResponseTo, p.entityID(r), destination, status, message, time.Now())
+	p.sendPost(w, destination, paramSAMLResponse, schema.Document(response), relayState)
+}
+
+func createAuthRequestToBusiness(r *http.Request, request *schema.AuthnRequest, app *query.App, acsURL, relayState, binding, userAgentID string) *domain.AuthRequest {
+	var prompt []domain.Prompt
+	if request.ForceAuthn {
+		prompt = append(prompt, domain.PromptLogin)
+	}
+	if request.IsPassive {
+		prompt = append(prompt, domain.PromptNone)
+	}
+	return &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		BrowserInfo:   domain.BrowserInfoFromRequest(r),
+		ApplicationID: app.SAMLConfig.EntityID,
+		CallbackURI:   acsURL,
+		TransferState: relayState,
+		Prompt:        prompt,
+		InstanceID:    authz.GetInstance(r.Context()).InstanceID(),
+		Request: &domain.AuthRequestSAML{
+			RequestID:    request.ID,
+			BindingType:  binding,
+			Issuer:       request.Issuer,
+			Destination:  request.Destination,
+			NameIDFormat: request.NameIDFormat(),
+		},
+	}
+}
+
+func isDone(authRequest *domain.AuthRequest) bool {
+	for _, step := range authRequest.PossibleSteps {
+		if step.Type() == domain.NextStepRedirectToCallback {
+			return true
+		}
+	}
+	return false
+}
@@ -43,6 +43,7 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 	data := passwordData{
 		baseData:    l.getBaseData(r, authReq, "Change Password", errID, errMessage),
 		profileData: l.getProfileData(authReq),
+		Expired:     isPasswordExpired(authReq),
 	}
 	policy, description, _ := l.getPasswordComplexityPolicy(r, authReq, authReq.UserOrgID)
 	if policy != nil {
@@ -65,6 +66,18 @@ func (l *Login) renderChangePassword(w http.ResponseWriter, r *http.Request, aut
 	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplChangePassword], data, nil)
 }
 
+func isPasswordExpired(authReq *domain.AuthRequest) bool {
+	if authReq == nil {
+		return false
+	}
+	for _, step := range authReq.PossibleSteps {
+		if changePassword, ok := step.(*domain.ChangePasswordStep); ok && changePassword.Expired {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *Login) renderChangePasswordDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
 	var errType, errMessage string
 	data := l.getUserData(r, authReq, "Password Change Done", errType, errMessage)
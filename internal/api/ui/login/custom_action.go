@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 func (l *Login) customExternalUserMapping(ctx context.Context, user *domain.ExternalUser, tokens *oidc.Tokens, req *domain.AuthRequest, config *iam_model.IDPConfigView) (*domain.ExternalUser, error) {
@@ -27,10 +28,14 @@ func (l *Login) customExternalUserMapping(ctx context.Context, user *domain.Exte
 	if err != nil {
 		return nil, err
 	}
+	opts, err := l.actionOptions(ctx, triggerActions)
+	if err != nil {
+		return nil, err
+	}
 	actionCtx := (&actions.Context{}).SetToken(tokens)
 	api := (&actions.API{}).SetExternalUser(user).SetMetadata(&user.Metadatas)
 	for _, a := range triggerActions {
-		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail)
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostAuthentication))...)
 		if err != nil {
 			return nil, err
 		}
@@ -38,15 +43,19 @@ func (l *Login) customExternalUserMapping(ctx context.Context, user *domain.Exte
 	return user, err
 }
 
-func (l *Login) customExternalUserToLoginUserMapping(user *domain.Human, tokens *oidc.Tokens, req *domain.AuthRequest, config *iam_model.IDPConfigView, metadata []*domain.Metadata, resourceOwner string) (*domain.Human, []*domain.Metadata, error) {
-	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(context.TODO(), domain.FlowTypeExternalAuthentication, domain.TriggerTypePreCreation, resourceOwner)
+func (l *Login) customExternalUserToLoginUserMapping(ctx context.Context, user *domain.Human, tokens *oidc.Tokens, req *domain.AuthRequest, config *iam_model.IDPConfigView, metadata []*domain.Metadata, resourceOwner string) (*domain.Human, []*domain.Metadata, error) {
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeExternalAuthentication, domain.TriggerTypePreCreation, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts, err := l.actionOptions(ctx, triggerActions)
 	if err != nil {
 		return nil, nil, err
 	}
 	actionCtx := (&actions.Context{}).SetToken(tokens)
 	api := (&actions.API{}).SetHuman(user).SetMetadata(&metadata)
 	for _, a := range triggerActions {
-		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail)
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeExternalAuthentication, domain.TriggerTypePreCreation))...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -54,8 +63,12 @@ func (l *Login) customExternalUserToLoginUserMapping(user *domain.Human, tokens
 	return user, metadata, err
 }
 
-func (l *Login) customGrants(userID string, tokens *oidc.Tokens, req *domain.AuthRequest, config *iam_model.IDPConfigView, resourceOwner string) ([]*domain.UserGrant, error) {
-	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(context.TODO(), domain.FlowTypeExternalAuthentication, domain.TriggerTypePostCreation, resourceOwner)
+func (l *Login) customGrants(ctx context.Context, userID string, tokens *oidc.Tokens, req *domain.AuthRequest, config *iam_model.IDPConfigView, resourceOwner string) ([]*domain.UserGrant, error) {
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostCreation, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := l.actionOptions(ctx, triggerActions)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +76,49 @@ func (l *Login) customGrants(userID string, tokens *oidc.Tokens, req *domain.Aut
 	actionUserGrants := make([]actions.UserGrant, 0)
 	api := (&actions.API{}).SetUserGrants(&actionUserGrants)
 	for _, a := range triggerActions {
-		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail)
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeExternalAuthentication, domain.TriggerTypePostCreation))...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return actionUserGrantsToDomain(userID, actionUserGrants), err
+}
+
+func (l *Login) customRegistrationMapping(ctx context.Context, user *domain.Human, resourceOwner string) (*domain.Human, []*domain.Metadata, error) {
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeInternalAuthentication, domain.TriggerTypePreCreation, resourceOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts, err := l.actionOptions(ctx, triggerActions)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata := make([]*domain.Metadata, 0)
+	actionCtx := (&actions.Context{}).SetHuman(user)
+	api := (&actions.API{}).SetHuman(user).SetMetadata(&metadata)
+	for _, a := range triggerActions {
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeInternalAuthentication, domain.TriggerTypePreCreation))...)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return user, metadata, err
+}
+
+func (l *Login) customRegistrationGrants(ctx context.Context, user *domain.Human, userID, resourceOwner string) ([]*domain.UserGrant, error) {
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostCreation, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := l.actionOptions(ctx, triggerActions)
+	if err != nil {
+		return nil, err
+	}
+	actionCtx := (&actions.Context{}).SetHuman(user).SetUser(userID, resourceOwner, user.Username)
+	actionUserGrants := make([]actions.UserGrant, 0)
+	api := (&actions.API{}).SetUserGrants(&actionUserGrants)
+	for _, a := range triggerActions {
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostCreation))...)
 		if err != nil {
 			return nil, err
 		}
@@ -71,6 +126,56 @@ func (l *Login) customGrants(userID string, tokens *oidc.Tokens, req *domain.Aut
 	return actionUserGrantsToDomain(userID, actionUserGrants), err
 }
 
+func (l *Login) customPasswordAuthentication(ctx context.Context, authReq *domain.AuthRequest) ([]*domain.Metadata, error) {
+	triggerActions, err := l.query.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication, authReq.UserOrgID)
+	if err != nil || len(triggerActions) == 0 {
+		return nil, err
+	}
+	opts, err := l.actionOptions(ctx, triggerActions)
+	if err != nil {
+		return nil, err
+	}
+	userMetadata, err := l.query.SearchUserMetadata(ctx, authReq.UserID, &query.UserMetadataSearchQueries{})
+	if err != nil {
+		return nil, err
+	}
+	metadataValues := make(map[string]string, len(userMetadata.Metadata))
+	for _, md := range userMetadata.Metadata {
+		metadataValues[md.Key] = string(md.Value)
+	}
+	actionCtx := (&actions.Context{}).SetUser(authReq.UserID, authReq.UserOrgID, authReq.LoginName).SetUserMetadata(metadataValues)
+	metadata := make([]*domain.Metadata, 0)
+	api := (&actions.API{}).SetMetadata(&metadata)
+	for _, a := range triggerActions {
+		err = actions.Run(actionCtx, api, a.Script, a.Name, a.Timeout, a.AllowedToFail, append(opts, l.executionLogger(ctx, a, domain.FlowTypeInternalAuthentication, domain.TriggerTypePostAuthentication))...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return metadata, err
+}
+
+func (l *Login) actionOptions(ctx context.Context, triggerActions []*query.Action) ([]actions.Option, error) {
+	if len(triggerActions) == 0 {
+		return nil, nil
+	}
+	allowList, err := l.query.ActionsHTTPAllowList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []actions.Option{actions.WithAllowedHosts(allowList.Hosts...)}, nil
+}
+
+func (l *Login) executionLogger(ctx context.Context, action *query.Action, flowType domain.FlowType, triggerType domain.TriggerType) actions.Option {
+	return actions.WithExecutionLogger(l.actionExecutions, &actions.Execution{
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		ResourceOwner: action.ResourceOwner,
+		ActionID:      action.ID,
+		FlowType:      flowType,
+		TriggerType:   triggerType,
+	})
+}
+
 func actionUserGrantsToDomain(userID string, actionUserGrants []actions.UserGrant) []*domain.UserGrant {
 	if actionUserGrants == nil {
 		return nil
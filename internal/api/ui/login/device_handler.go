@@ -0,0 +1,107 @@
+package login
+
+import (
+	"net/http"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplDeviceUserCode = "deviceusercode"
+	tmplDeviceAction   = "deviceaction"
+	tmplDeviceDone     = "devicedone"
+
+	QueryDeviceUserCode = "user_code"
+)
+
+type deviceUserCodeFormData struct {
+	UserCode string `schema:"user_code"`
+}
+
+type deviceUserCodeData struct {
+	baseData
+	UserCode string
+}
+
+type deviceActionFormData struct {
+	Deny bool `schema:"deny"`
+}
+
+type deviceDoneData struct {
+	userData
+	Approved bool
+}
+
+// handleDeviceUserCode renders the page, where the user enters the code displayed on the device
+// the code is prefilled if the device provided the verification_uri_complete
+func (l *Login) handleDeviceUserCode(w http.ResponseWriter, r *http.Request) {
+	l.renderDeviceUserCode(w, r, r.FormValue(QueryDeviceUserCode), nil)
+}
+
+func (l *Login) handleDeviceUserCodeCheck(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceUserCodeFormData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderDeviceUserCode(w, r, data.UserCode, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	authReq, err := l.authRepo.DeviceAuthRequestByUserCode(r.Context(), data.UserCode, userAgentID)
+	if err != nil {
+		l.renderDeviceUserCode(w, r, data.UserCode, err)
+		return
+	}
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderDeviceUserCode(w http.ResponseWriter, r *http.Request, userCode string, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := deviceUserCodeData{
+		baseData: l.getBaseData(r, nil, "Device Authorization", errID, errMessage),
+		UserCode: userCode,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplDeviceUserCode], data, nil)
+}
+
+// renderDeviceAction asks the authenticated user to approve or deny the device authorization request
+// it replaces the redirect to the callback of the application, which the device doesn't have
+func (l *Login) renderDeviceAction(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := l.getUserData(r, authReq, "Device Authorization", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceAction], data, nil)
+}
+
+func (l *Login) handleDeviceAction(w http.ResponseWriter, r *http.Request) {
+	data := new(deviceActionFormData)
+	authReq, err := l.getAuthRequestAndParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if data.Deny {
+		err = l.authRepo.DenyDeviceAuthRequest(r.Context(), authReq.ID, userAgentID)
+	} else {
+		err = l.authRepo.ApproveDeviceAuthRequest(r.Context(), authReq.ID, userAgentID)
+	}
+	if err != nil {
+		l.renderDeviceAction(w, r, authReq, err)
+		return
+	}
+	l.renderDeviceDone(w, r, authReq, !data.Deny)
+}
+
+func (l *Login) renderDeviceDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, approved bool) {
+	data := deviceDoneData{
+		userData: l.getUserData(r, authReq, "Device Authorization Done", "", ""),
+		Approved: approved,
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplDeviceDone], data, nil)
+}
@@ -87,12 +87,25 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if idpConfig.IsLDAP {
+		// the directory is asked for the password of the login name entered on the login page
+		if authReq.UserID == "" {
+			l.renderLogin(w, r, authReq, nil)
+			return
+		}
+		l.renderPassword(w, r, authReq, nil)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	err = l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, idpConfig.IDPConfigID, userAgentID)
 	if err != nil {
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
+	if idpConfig.IsSAML {
+		l.handleSAMLAuthorize(w, r, authReq, idpConfig)
+		return
+	}
 	if !idpConfig.IsOIDC {
 		l.handleJWTAuthorize(w, r, authReq, idpConfig)
 		return
@@ -192,7 +205,12 @@ func (l *Login) getRPConfig(ctx context.Context, idpConfig *iam_model.IDPConfigV
 }
 
 func (l *Login) handleExternalUserAuthenticated(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, idpConfig *iam_model.IDPConfigView, userAgentID string, tokens *oidc.Tokens) {
-	externalUser := l.mapTokenToLoginUser(tokens, idpConfig)
+	l.handleExternalUser(w, r, authReq, idpConfig, userAgentID, l.mapTokenToLoginUser(tokens, idpConfig), tokens)
+}
+
+// handleExternalUser checks the login of the user authenticated by the external identity provider
+// tokens are only passed to the actions and may be nil if the identity provider doesn't issue any (e.g. SAML)
+func (l *Login) handleExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, idpConfig *iam_model.IDPConfigView, userAgentID string, externalUser *domain.ExternalUser, tokens *oidc.Tokens) {
 	externalUser, err := l.customExternalUserMapping(r.Context(), externalUser, tokens, authReq, idpConfig)
 	if err != nil {
 		l.renderError(w, r, authReq, err)
@@ -369,7 +387,7 @@ func (l *Login) handleAutoRegister(w http.ResponseWriter, r *http.Request, authR
 	}
 	linkingUser := authReq.LinkingUsers[len(authReq.LinkingUsers)-1]
 	user, externalIDP, metadata := l.mapExternalUserToLoginUser(orgIamPolicy, linkingUser, idpConfig)
-	user, metadata, err = l.customExternalUserToLoginUserMapping(user, nil, authReq, idpConfig, metadata, resourceOwner)
+	user, metadata, err = l.customExternalUserToLoginUserMapping(r.Context(), user, nil, authReq, idpConfig, metadata, resourceOwner)
 	if err != nil {
 		l.renderExternalNotFoundOption(w, r, authReq, iam, orgIamPolicy, nil, nil, err)
 		return
@@ -384,7 +402,7 @@ func (l *Login) handleAutoRegister(w http.ResponseWriter, r *http.Request, authR
 		l.renderError(w, r, authReq, err)
 		return
 	}
-	userGrants, err := l.customGrants(authReq.UserID, nil, authReq, idpConfig, resourceOwner)
+	userGrants, err := l.customGrants(r.Context(), authReq.UserID, nil, authReq, idpConfig, resourceOwner)
 	if err != nil {
 		l.renderError(w, r, authReq, err)
 		return
@@ -66,12 +66,24 @@ func (l *Login) handleExternalRegister(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	if idpConfig.IsLDAP {
+		// LDAP users are registered after their first successful password check,
+		// which is why the login name is asked for on the login page
+		l.renderLogin(w, r, authReq, nil)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	err = l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, idpConfig.IDPConfigID, userAgentID)
 	if err != nil {
 		l.renderLogin(w, r, authReq, err)
 		return
 	}
+	if idpConfig.IsSAML {
+		// SAML identity providers post to a single assertion consumer service,
+		// unknown users are therefore registered through the external not found option
+		l.handleSAMLAuthorize(w, r, authReq, idpConfig)
+		return
+	}
 	if !idpConfig.IsOIDC {
 		l.handleJWTAuthorize(w, r, authReq, idpConfig)
 		return
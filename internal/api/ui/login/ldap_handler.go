@@ -0,0 +1,92 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+	"github.com/zitadel/zitadel/internal/ldap"
+)
+
+// handleLDAPPasswordCheck verifies the password against the directories of the allowed LDAP identity providers
+// the first directory accepting the password is handled like any other external identity provider,
+// so the user is either logged in by its link or linked / registered
+// it returns false if none of the directories accepted the password
+func (l *Login) handleLDAPPasswordCheck(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, password string) bool {
+	userAgentID, ok := http_mw.UserAgentIDFromCtx(r.Context())
+	if !ok {
+		return false
+	}
+	for _, idp := range authReq.LDAPIDPs() {
+		idpConfig, err := l.getIDPConfigByID(r, idp.IDPConfigID)
+		if err != nil {
+			logging.WithFields("idpConfigID", idp.IDPConfigID).OnError(err).Warn("unable to get ldap idp config")
+			continue
+		}
+		entry, err := l.authenticateLDAP(r, idpConfig, authReq.LoginName, password)
+		if err != nil {
+			logging.WithFields("idpConfigID", idp.IDPConfigID).WithError(err).Debug("ldap authentication failed")
+			continue
+		}
+		err = l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, idpConfig.IDPConfigID, userAgentID)
+		if err != nil {
+			l.renderLogin(w, r, authReq, err)
+			return true
+		}
+		l.handleExternalUser(w, r, authReq, idpConfig, userAgentID, mapLDAPEntryToLoginUser(entry, idpConfig, authReq.LoginName), nil)
+		return true
+	}
+	return false
+}
+
+func (l *Login) authenticateLDAP(r *http.Request, idpConfig *iam_model.IDPConfigView, username, password string) (*ldap.Entry, error) {
+	var bindPassword string
+	if idpConfig.LDAPBindPassword != nil {
+		var err error
+		bindPassword, err = crypto.DecryptString(idpConfig.LDAPBindPassword, l.idpConfigAlg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ldap.Authenticate(r.Context(), &ldap.Config{
+		URL:             idpConfig.LDAPURL,
+		StartTLS:        idpConfig.LDAPStartTLS,
+		RootCA:          idpConfig.LDAPRootCA,
+		BaseDN:          idpConfig.LDAPBaseDN,
+		BindDN:          idpConfig.LDAPBindDN,
+		BindPassword:    bindPassword,
+		UserObjectClass: idpConfig.LDAPUserObjectClass,
+		UserFilters:     idpConfig.LDAPUserFilters,
+		Attributes:      idpConfig.LDAPAttributes.Names(),
+	}, username, password)
+}
+
+func mapLDAPEntryToLoginUser(entry *ldap.Entry, idpConfig *iam_model.IDPConfigView, loginName string) *domain.ExternalUser {
+	attributes := idpConfig.LDAPAttributes
+	externalUserID := entry.Attribute(attributes.IDAttribute)
+	if externalUserID == "" {
+		externalUserID = entry.DN
+	}
+	username := entry.Attribute(attributes.PreferredUsernameAttribute)
+	if username == "" {
+		username = loginName
+	}
+	displayName := entry.Attribute(attributes.DisplayNameAttribute)
+	if displayName == "" {
+		displayName = username
+	}
+	return &domain.ExternalUser{
+		IDPConfigID:       idpConfig.IDPConfigID,
+		ExternalUserID:    externalUserID,
+		PreferredUsername: username,
+		DisplayName:       displayName,
+		FirstName:         entry.Attribute(attributes.FirstNameAttribute),
+		LastName:          entry.Attribute(attributes.LastNameAttribute),
+		Email:             entry.Attribute(attributes.EmailAttribute),
+		Phone:             entry.Attribute(attributes.PhoneAttribute),
+	}
+}
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -43,11 +44,22 @@ func (l *Login) renderSuccessAndCallback(w http.ResponseWriter, r *http.Request,
 		userData: l.getUserData(r, authReq, "Login Successful", errID, errMessage),
 	}
 	if authReq != nil {
-		data.RedirectURI = l.oidcAuthCallbackURL(r.Context(), "") //the id will be set via the html (maybe change this with the login refactoring)
+		data.RedirectURI = l.authCallbackURL(r.Context(), authReq, "") //the id will be set via the html (maybe change this with the login refactoring)
 	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplLoginSuccess], data, nil)
 }
 
 func (l *Login) redirectToCallback(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest) {
-	http.Redirect(w, r, l.oidcAuthCallbackURL(r.Context(), authReq.ID), http.StatusFound)
+	if authReq.Request != nil && authReq.Request.Type() == domain.AuthRequestTypeDevice {
+		l.renderDeviceAction(w, r, authReq, nil)
+		return
+	}
+	http.Redirect(w, r, l.authCallbackURL(r.Context(), authReq, authReq.ID), http.StatusFound)
+}
+
+func (l *Login) authCallbackURL(ctx context.Context, authReq *domain.AuthRequest, id string) string {
+	if authReq.Request != nil && authReq.Request.Type() == domain.AuthRequestTypeSAML {
+		return l.samlAuthCallbackURL(ctx, id)
+	}
+	return l.oidcAuthCallbackURL(ctx, id)
 }
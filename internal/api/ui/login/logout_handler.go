@@ -2,17 +2,99 @@ package login
 
 import (
 	"net/http"
+	"net/url"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 )
 
 const (
 	tmplLogoutDone = "logoutdone"
+
+	QueryFrontChannelLogout    = "frontchannel"
+	QueryClientID              = "client_id"
+	QueryPostLogoutRedirectURI = "post_logout_redirect_uri"
+	QueryState                 = "state"
 )
 
+type logoutDoneData struct {
+	userData
+	FrontChannelLogoutURIs []string
+	PostLogoutRedirectURI  string
+}
+
 func (l *Login) handleLogoutDone(w http.ResponseWriter, r *http.Request) {
 	l.renderLogoutDone(w, r)
 }
 
+// renderLogoutDone renders the front-channel logout uris of the applications passed by the end_session_endpoint in iframes
+// (OpenID Connect Front-Channel Logout) and redirects to the post_logout_redirect_uri of the client afterwards
 func (l *Login) renderLogoutDone(w http.ResponseWriter, r *http.Request) {
-	data := l.getUserData(r, nil, "Logout Done", "", "")
+	data := logoutDoneData{
+		userData:               l.getUserData(r, nil, "Logout Done", "", ""),
+		FrontChannelLogoutURIs: l.frontChannelLogoutURIs(r),
+		PostLogoutRedirectURI:  l.postLogoutRedirectURI(r),
+	}
+	if len(data.FrontChannelLogoutURIs) > 0 {
+		policy := csp()
+		policy.FrameSrc = http_mw.CSPSourceOpts()
+		for _, logoutURI := range data.FrontChannelLogoutURIs {
+			policy.FrameSrc = policy.FrameSrc.AddHost(uriOrigin(logoutURI))
+		}
+		w.Header().Set(http_utils.ContentSecurityPolicy, policy.Value(http_mw.GetNonce(r), r.Host))
+	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), nil), l.renderer.Templates[tmplLogoutDone], data, nil)
 }
+
+// frontChannelLogoutURIs returns the front-channel logout uris of the requested applications,
+// unknown applications or applications without front-channel logout uri are ignored
+func (l *Login) frontChannelLogoutURIs(r *http.Request) []string {
+	clientIDs := r.URL.Query()[QueryFrontChannelLogout]
+	logoutURIs := make([]string, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		app, err := l.query.AppByOIDCClientID(r.Context(), clientID)
+		if err != nil || app.OIDCConfig.FrontChannelLogoutURI == "" {
+			continue
+		}
+		logoutURIs = append(logoutURIs, app.OIDCConfig.FrontChannelLogoutURI)
+	}
+	return logoutURIs
+}
+
+// postLogoutRedirectURI returns the post_logout_redirect_uri including the state,
+// if it's registered for the client
+func (l *Login) postLogoutRedirectURI(r *http.Request) string {
+	query := r.URL.Query()
+	redirectURI := query.Get(QueryPostLogoutRedirectURI)
+	if redirectURI == "" || query.Get(QueryClientID) == "" {
+		return ""
+	}
+	app, err := l.query.AppByOIDCClientID(r.Context(), query.Get(QueryClientID))
+	if err != nil {
+		return ""
+	}
+	for _, uri := range app.OIDCConfig.PostLogoutRedirectURIs {
+		if uri != redirectURI {
+			continue
+		}
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return ""
+		}
+		if state := query.Get(QueryState); state != "" {
+			values := parsed.Query()
+			values.Set(QueryState, state)
+			parsed.RawQuery = values.Encode()
+		}
+		return parsed.String()
+	}
+	return ""
+}
+
+func uriOrigin(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
@@ -0,0 +1,59 @@
+package login
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	tmplMagicLinkSent = "magiclinksent"
+)
+
+func MagicLinkLink(origin, authRequestID, userID, code, orgID string) string {
+	return fmt.Sprintf("%s%s?%s=%s&userID=%s&code=%s&orgID=%s", externalLink(origin), EndpointMagicLink, QueryAuthRequestID, authRequestID, userID, code, orgID)
+}
+
+// handleMagicLink either sends a new login link to the user (no code provided)
+// or verifies the code of the link the user clicked in the email.
+// The auth request is loaded with the user agent of the browser, so the link only works
+// in the browser the login was started in.
+func (l *Login) handleMagicLink(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if authReq == nil {
+		l.defaultRedirect(w, r)
+		return
+	}
+	code := r.FormValue(queryCode)
+	if code == "" {
+		err = l.authRepo.SendMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+		l.renderMagicLinkSent(w, r, authReq, err)
+		return
+	}
+	if err = l.throttle.check(r, authReq); err != nil {
+		l.renderMagicLinkSent(w, r, authReq, err)
+		return
+	}
+	err = l.authRepo.VerifyMagicLink(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, code, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+	if err != nil {
+		l.throttle.failed(r, authReq)
+		l.renderMagicLinkSent(w, r, authReq, err)
+		return
+	}
+	l.throttle.succeeded(r, authReq)
+	l.renderNextStep(w, r, authReq)
+}
+
+func (l *Login) renderMagicLinkSent(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, err error) {
+	var errID, errMessage string
+	if err != nil {
+		errID, errMessage = l.getErrorMessage(r, err)
+	}
+	data := l.getUserData(r, authReq, "Magic Link Sent", errID, errMessage)
+	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMagicLinkSent], data, nil)
+}
@@ -86,10 +86,30 @@ func (l *Login) handleMFACreation(w http.ResponseWriter, r *http.Request, authRe
 	case domain.MFATypeU2F:
 		l.renderRegisterU2F(w, r, authReq, nil)
 		return
+	case domain.MFATypeOTPSMS,
+		domain.MFATypeOTPEmail:
+		l.handleOTPCodeCreation(w, r, authReq, data)
+		return
 	}
 	l.renderError(w, r, authReq, caos_errs.ThrowPreconditionFailed(nil, "APP-Or3HO", "Errors.User.MFA.NoProviders"))
 }
 
+// handleOTPCodeCreation directly adds the SMS or email factor,
+// since the phone number or email address was already verified
+func (l *Login) handleOTPCodeCreation(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaVerifyData) {
+	var err error
+	if data.MFAType == domain.MFATypeOTPSMS {
+		_, err = l.command.AddHumanOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	} else {
+		_, err = l.command.AddHumanOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
+	}
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	l.renderMFAInitDone(w, r, authReq, &mfaDoneData{MFAType: data.MFAType})
+}
+
 func (l *Login) handleOTPCreation(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, data *mfaVerifyData) {
 	otp, err := l.command.AddHumanOTP(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID)
 	if err != nil {
@@ -33,14 +33,27 @@ func (l *Login) handleMFAVerify(w http.ResponseWriter, r *http.Request) {
 		l.renderMFAVerifySelected(w, r, authReq, step, data.SelectedProvider, nil)
 		return
 	}
-	if data.MFAType == domain.MFATypeOTP {
-		userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if err = l.throttle.check(r, authReq); err != nil {
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
+	}
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	switch data.MFAType {
+	case domain.MFATypeOTP:
 		err = l.authRepo.VerifyMFAOTP(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
-		if err != nil {
-			l.renderMFAVerifySelected(w, r, authReq, step, domain.MFATypeOTP, err)
-			return
-		}
+	case domain.MFATypeOTPSMS:
+		err = l.authRepo.VerifyMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeOTPEmail:
+		err = l.authRepo.VerifyMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	case domain.MFATypeRecoveryCode:
+		err = l.authRepo.VerifyMFARecoveryCode(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Code, userAgentID, domain.BrowserInfoFromRequest(r))
+	}
+	if err != nil {
+		l.throttle.failed(r, authReq)
+		l.renderMFAVerifySelected(w, r, authReq, step, data.MFAType, err)
+		return
 	}
+	l.throttle.succeeded(r, authReq)
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -50,10 +63,18 @@ func (l *Login) renderMFAVerify(w http.ResponseWriter, r *http.Request, authReq
 		return
 	}
 	provider := verificationStep.MFAProviders[len(verificationStep.MFAProviders)-1]
+	// recovery codes are only a fallback and must be chosen by the user
+	if provider == domain.MFATypeRecoveryCode && len(verificationStep.MFAProviders) > 1 {
+		provider = verificationStep.MFAProviders[len(verificationStep.MFAProviders)-2]
+	}
 	l.renderMFAVerifySelected(w, r, authReq, verificationStep, provider, err)
 }
 
 func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, verificationStep *domain.MFAVerificationStep, selectedProvider domain.MFAType, err error) {
+	// a new code is only sent if the page is not rendered because of an error (e.g. an invalid code)
+	if err == nil && verificationStep != nil && (selectedProvider == domain.MFATypeOTPSMS || selectedProvider == domain.MFATypeOTPEmail) {
+		err = l.sendMFAOTPCode(r, authReq, selectedProvider)
+	}
 	var errID, errMessage string
 	if err != nil {
 		errID, errMessage = l.getErrorMessage(r, err)
@@ -70,6 +91,11 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	case domain.MFATypeOTP:
 		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, domain.MFATypeOTP)
 		data.SelectedMFAProvider = domain.MFATypeOTP
+	case domain.MFATypeOTPSMS,
+		domain.MFATypeOTPEmail,
+		domain.MFATypeRecoveryCode:
+		data.MFAProviders = removeSelectedProviderFromList(verificationStep.MFAProviders, selectedProvider)
+		data.SelectedMFAProvider = selectedProvider
 	default:
 		l.renderError(w, r, authReq, err)
 		return
@@ -77,6 +103,14 @@ func (l *Login) renderMFAVerifySelected(w http.ResponseWriter, r *http.Request,
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplMFAVerify], data, nil)
 }
 
+func (l *Login) sendMFAOTPCode(r *http.Request, authReq *domain.AuthRequest, mfaType domain.MFAType) error {
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
+	if mfaType == domain.MFATypeOTPSMS {
+		return l.authRepo.SendMFAOTPSMS(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+	}
+	return l.authRepo.SendMFAOTPEmail(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, userAgentID, domain.BrowserInfoFromRequest(r))
+}
+
 func removeSelectedProviderFromList(providers []domain.MFAType, selected domain.MFAType) []domain.MFAType {
 	for i := len(providers) - 1; i >= 0; i-- {
 		if providers[i] == selected {
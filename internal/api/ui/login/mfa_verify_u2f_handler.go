@@ -69,11 +69,17 @@ func (l *Login) handleU2FVerification(w http.ResponseWriter, r *http.Request) {
 		l.renderU2FVerification(w, r, authReq, step.MFAProviders, err)
 		return
 	}
+	if err = l.throttle.check(r, authReq); err != nil {
+		l.renderU2FVerification(w, r, authReq, step.MFAProviders, err)
+		return
+	}
 	userAgentID, _ := http_mw.UserAgentIDFromCtx(r.Context())
 	err = l.authRepo.VerifyMFAU2F(setContext(r.Context(), authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, authReq.ID, userAgentID, credData, domain.BrowserInfoFromRequest(r))
 	if err != nil {
+		l.throttle.failed(r, authReq)
 		l.renderU2FVerification(w, r, authReq, step.MFAProviders, err)
 		return
 	}
+	l.throttle.succeeded(r, authReq)
 	l.renderNextStep(w, r, authReq)
 }
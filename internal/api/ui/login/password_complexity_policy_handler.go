@@ -66,6 +66,14 @@ func (l *Login) generatePolicyDescription(r *http.Request, authReq *domain.AuthR
 		hassymbol := l.renderer.LocalizeFromRequest(translator, r, "Password.HasSymbol", nil)
 		description += "<li id=\"symbol\" class=\"invalid\"><i class=\"lgn-icon-times-solid lgn-warn\"></i><span>" + hassymbol + "</span></li>"
 	}
+	if policy.HistoryDepth > 0 {
+		history := l.renderer.LocalizeFromRequest(translator, r, "Password.History", nil)
+		description += "<li><i class=\"lgn-icon-exclamation-circle-solid\"></i><span>" + history + "</span></li>"
+	}
+	if policy.CheckBreached {
+		breached := l.renderer.LocalizeFromRequest(translator, r, "Password.Breached", nil)
+		description += "<li><i class=\"lgn-icon-exclamation-circle-solid\"></i><span>" + breached + "</span></li>"
+	}
 	confirmation := l.renderer.LocalizeFromRequest(translator, r, "Password.Confirmation", nil)
 	description += "<li id=\"confirmation\" class=\"invalid\"><i class=\"lgn-icon-times-solid lgn-warn\"></i><span>" + confirmation + "</span></li>"
 
@@ -1,6 +1,7 @@
 package login
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -27,6 +28,9 @@ func (l *Login) renderPassword(w http.ResponseWriter, r *http.Request, authReq *
 			}
 			return true
 		},
+		"showMagicLink": func() bool {
+			return authReq.LoginPolicy != nil && authReq.LoginPolicy.AllowMagicLink
+		},
 	}
 	l.renderer.RenderTemplate(w, r, l.getTranslator(r.Context(), authReq), l.renderer.Templates[tmplPassword], data, funcs)
 }
@@ -38,8 +42,25 @@ func (l *Login) handlePasswordCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderError(w, r, authReq, err)
 		return
 	}
-	err = l.authRepo.VerifyPassword(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Password, authReq.AgentID, domain.BrowserInfoFromRequest(r))
+	if err = l.throttle.check(r, authReq); err != nil {
+		l.renderPassword(w, r, authReq, err)
+		return
+	}
+	var actionErr error
+	postAuthentication := func() error {
+		actionErr = l.postPasswordAuthentication(r.Context(), authReq)
+		return actionErr
+	}
+	err = l.authRepo.VerifyPassword(setContext(r.Context(), authReq.UserOrgID), authReq.ID, authReq.UserID, authReq.UserOrgID, data.Password, authReq.AgentID, domain.BrowserInfoFromRequest(r), postAuthentication)
+	if actionErr != nil {
+		l.renderPassword(w, r, authReq, actionErr)
+		return
+	}
 	if err != nil {
+		if l.handleLDAPPasswordCheck(w, r, authReq, data.Password) {
+			return
+		}
+		l.throttle.failed(r, authReq)
 		if authReq.LoginPolicy.IgnoreUnknownUsernames {
 			l.renderLogin(w, r, authReq, err)
 			return
@@ -47,5 +68,18 @@ func (l *Login) handlePasswordCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderPassword(w, r, authReq, err)
 		return
 	}
+	l.throttle.succeeded(r, authReq)
 	l.renderNextStep(w, r, authReq)
 }
+
+// postPasswordAuthentication executes the post authentication actions and stores the metadata set by them
+// it's called after the password matched and before the check is recorded as succeeded,
+// so a failing action aborts the login
+func (l *Login) postPasswordAuthentication(ctx context.Context, authReq *domain.AuthRequest) error {
+	metadata, err := l.customPasswordAuthentication(ctx, authReq)
+	if err != nil || len(metadata) == 0 {
+		return err
+	}
+	_, err = l.command.BulkSetUserMetadata(setContext(ctx, authReq.UserOrgID), authReq.UserID, authReq.UserOrgID, metadata...)
+	return err
+}
@@ -84,7 +84,29 @@ func (l *Login) handleRegisterCheck(w http.ResponseWriter, r *http.Request) {
 		l.renderRegister(w, r, authRequest, data, err)
 		return
 	}
-	user, err := l.command.RegisterHuman(setContext(r.Context(), resourceOwner), resourceOwner, data.toHumanDomain(), nil, memberRoles, initCodeGenerator, phoneCodeGenerator)
+	human, metadata, err := l.customRegistrationMapping(r.Context(), data.toHumanDomain(), resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
+	user, err := l.command.RegisterHuman(setContext(r.Context(), resourceOwner), resourceOwner, human, nil, memberRoles, initCodeGenerator, phoneCodeGenerator)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
+	if len(metadata) > 0 {
+		_, err = l.command.BulkSetUserMetadata(setContext(r.Context(), resourceOwner), user.AggregateID, resourceOwner, metadata...)
+		if err != nil {
+			l.renderRegister(w, r, authRequest, data, err)
+			return
+		}
+	}
+	userGrants, err := l.customRegistrationGrants(r.Context(), human, user.AggregateID, resourceOwner)
+	if err != nil {
+		l.renderRegister(w, r, authRequest, data, err)
+		return
+	}
+	err = l.appendUserGrants(r.Context(), userGrants, resourceOwner)
 	if err != nil {
 		l.renderRegister(w, r, authRequest, data, err)
 		return
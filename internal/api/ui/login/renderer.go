@@ -63,8 +63,10 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplInitUser:                     "init_user.html",
 		tmplInitUserDone:                 "init_user_done.html",
 		tmplPasswordResetDone:            "password_reset_done.html",
+		tmplMagicLinkSent:                "magic_link_sent.html",
 		tmplChangePassword:               "change_password.html",
 		tmplChangePasswordDone:           "change_password_done.html",
+		tmplPasswordExpiryWarning:        "password_expiry_warning.html",
 		tmplRegisterOption:               "register_option.html",
 		tmplRegister:                     "register.html",
 		tmplExternalRegisterOverview:     "external_register_overview.html",
@@ -75,6 +77,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplLinkUsersDone:                "link_users_done.html",
 		tmplExternalNotFoundOption:       "external_not_found_option.html",
 		tmplLoginSuccess:                 "login_success.html",
+		tmplDeviceUserCode:               "device_user_code.html",
+		tmplDeviceAction:                 "device_action.html",
+		tmplDeviceDone:                   "device_done.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
@@ -145,6 +150,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"passwordResetUrl": func(id string) string {
 			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointPasswordReset, QueryAuthRequestID, id))
 		},
+		"magicLinkUrl": func(id string) string {
+			return path.Join(r.pathPrefix, fmt.Sprintf("%s?%s=%s", EndpointMagicLink, QueryAuthRequestID, id))
+		},
 		"passwordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointPassword)
 		},
@@ -178,6 +186,9 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changePasswordUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangePassword)
 		},
+		"passwordExpiryWarningUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointPasswordExpiryWarning)
+		},
 		"registerOptionUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointRegisterOption)
 		},
@@ -193,6 +204,12 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		"changeUsernameUrl": func() string {
 			return path.Join(r.pathPrefix, EndpointChangeUsername)
 		},
+		"deviceUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDevice)
+		},
+		"deviceActionUrl": func() string {
+			return path.Join(r.pathPrefix, EndpointDeviceAction)
+		},
 		"externalNotFoundOptionUrl": func(action string) string {
 			return path.Join(r.pathPrefix, EndpointExternalNotFoundOption+"?"+action+"=true")
 		},
@@ -288,6 +305,8 @@ func (l *Login) chooseNextStep(w http.ResponseWriter, r *http.Request, authReq *
 		l.redirectToLoginSuccess(w, r, authReq.ID)
 	case *domain.ChangePasswordStep:
 		l.renderChangePassword(w, r, authReq, err)
+	case *domain.PasswordExpiryWarningStep:
+		l.renderPasswordExpiryWarning(w, r, authReq, step, err)
 	case *domain.VerifyEMailStep:
 		l.renderMailVerification(w, r, authReq, "", err)
 	case *domain.MFAPromptStep:
@@ -591,6 +610,7 @@ type passwordData struct {
 	HasLowercase              string
 	HasNumber                 string
 	HasSymbol                 string
+	Expired                   bool
 }
 
 type userSelectionData struct {
@@ -0,0 +1,225 @@
+package login
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/saml/schema"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	iam_model "github.com/zitadel/zitadel/internal/iam/model"
+)
+
+const (
+	querySAMLRequest    = "SAMLRequest"
+	queryRelayState     = "RelayState"
+	relayStateSeparator = "."
+	samlRequestIDPrefix = "_"
+)
+
+var (
+	samlAttributesUsername = []string{"username", "uid", "urn:oid:0.9.2342.19200300.100.1.1", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/upn"}
+	samlAttributesEmail    = []string{"email", "mail", "urn:oid:0.9.2342.19200300.100.1.3", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"}
+	samlAttributesGiven    = []string{"givenName", "firstName", "urn:oid:2.5.4.42", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/givenname"}
+	samlAttributesSurname  = []string{"sn", "surname", "lastName", "urn:oid:2.5.4.4", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/surname"}
+	samlAttributesDisplay  = []string{"displayName", "urn:oid:2.16.840.1.113730.3.1.241", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name"}
+	samlAttributesPhone    = []string{"telephoneNumber", "mobile", "urn:oid:2.5.4.20", "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/mobilephone"}
+)
+
+type samlMetadataData struct {
+	IDPConfigID string `schema:"idpConfigID"`
+}
+
+type samlResponseData struct {
+	SAMLResponse string `schema:"SAMLResponse"`
+	RelayState   string `schema:"RelayState"`
+}
+
+// handleSAMLMetadata returns the metadata of ZITADEL as service provider of the SAML identity provider
+func (l *Login) handleSAMLMetadata(w http.ResponseWriter, r *http.Request) {
+	data := new(samlMetadataData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	idpConfig, err := l.getIDPConfigByID(r, data.IDPConfigID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	if !idpConfig.IsSAML {
+		l.renderError(w, r, nil, caos_errors.ThrowPreconditionFailed(nil, "LOGIN-Hs3fw", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	metadata := schema.SPMetadata(l.samlEntityID(r.Context(), idpConfig.IDPConfigID), l.baseURL(r.Context())+EndpointSAMLACS)
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write(schema.Document(metadata))
+	logging.OnError(err).Debug("unable to write saml metadata")
+}
+
+// handleSAMLAuthorize sends the samlp:AuthnRequest to the identity provider using the HTTP-Redirect binding
+// the user agent is passed (encrypted) in the RelayState, because the cookie is not sent on the cross site post to the ACS
+func (l *Login) handleSAMLAuthorize(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, idpConfig *iam_model.IDPConfigView) {
+	descriptor, err := schema.ParseIDPMetadata(idpConfig.SAMLMetadata)
+	if err != nil {
+		l.renderLogin(w, r, authReq, caos_errors.ThrowPreconditionFailed(err, "LOGIN-Jw2fs", "Errors.IdentityProvider.InvalidConfig"))
+		return
+	}
+	sso, ok := descriptor.SingleSignOnService()
+	if !ok {
+		l.renderLogin(w, r, authReq, caos_errors.ThrowPreconditionFailed(nil, "LOGIN-Gd3sq", "Errors.IdentityProvider.InvalidConfig"))
+		return
+	}
+	userAgentID, ok := http_mw.UserAgentIDFromCtx(r.Context())
+	if !ok {
+		l.renderLogin(w, r, authReq, caos_errors.ThrowPreconditionFailed(nil, "LOGIN-Bs2qa", "Errors.AuthRequest.UserAgentNotFound"))
+		return
+	}
+	nonce, err := l.idpConfigAlg.Encrypt([]byte(userAgentID))
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	request := schema.NewAuthnRequest(samlRequestIDPrefix+authReq.ID, l.samlEntityID(r.Context(), idpConfig.IDPConfigID), sso.Location, l.baseURL(r.Context())+EndpointSAMLACS, time.Now())
+	encoded, err := schema.EncodeRedirect(schema.Document(request))
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	redirect, err := url.Parse(sso.Location)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	q := redirect.Query()
+	q.Set(querySAMLRequest, encoded)
+	q.Set(queryRelayState, authReq.ID+relayStateSeparator+base64.RawURLEncoding.EncodeToString(nonce))
+	redirect.RawQuery = q.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// handleSAMLACS is the assertion consumer service receiving the samlp:Response of the identity provider
+func (l *Login) handleSAMLACS(w http.ResponseWriter, r *http.Request) {
+	data := new(samlResponseData)
+	err := l.getParseData(r, data)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReqID, userAgentID, err := l.parseRelayState(data.RelayState)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	authReq, err := l.authRepo.AuthRequestByID(r.Context(), authReqID, userAgentID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	idpConfig, err := l.authRepo.GetIDPConfigByID(r.Context(), authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	if !idpConfig.IsSAML {
+		l.renderError(w, r, authReq, caos_errors.ThrowPreconditionFailed(nil, "LOGIN-Vd2gw", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	assertion, err := l.verifySAMLResponse(r.Context(), data.SAMLResponse, authReq, idpConfig)
+	if err != nil {
+		l.renderLogin(w, r, authReq, err)
+		return
+	}
+	l.handleExternalUser(w, r, authReq, idpConfig, userAgentID, mapSAMLAssertionToLoginUser(assertion, idpConfig), nil)
+}
+
+func (l *Login) verifySAMLResponse(ctx context.Context, encoded string, authReq *domain.AuthRequest, idpConfig *iam_model.IDPConfigView) (*schema.ReceivedAssertion, error) {
+	descriptor, err := schema.ParseIDPMetadata(idpConfig.SAMLMetadata)
+	if err != nil {
+		return nil, caos_errors.ThrowPreconditionFailed(err, "LOGIN-Pw3gs", "Errors.IdentityProvider.InvalidConfig")
+	}
+	certificates, err := descriptor.IDPSigningCertificates()
+	if err != nil {
+		return nil, caos_errors.ThrowPreconditionFailed(err, "LOGIN-Ud2fq", "Errors.IdentityProvider.InvalidConfig")
+	}
+	message, err := schema.DecodePost(encoded)
+	if err != nil {
+		return nil, caos_errors.ThrowInvalidArgument(err, "LOGIN-Ks2fd", "Errors.IdentityProvider.SAMLResponseInvalid")
+	}
+	_, assertion, err := schema.ParseResponse(message, certificates)
+	if err != nil {
+		return nil, caos_errors.ThrowInvalidArgument(err, "LOGIN-Tq3gs", "Errors.IdentityProvider.SAMLResponseInvalid")
+	}
+	err = assertion.Validate(idpConfig.SAMLEntityID, l.samlEntityID(ctx, idpConfig.IDPConfigID), l.baseURL(ctx)+EndpointSAMLACS, samlRequestIDPrefix+authReq.ID, time.Now())
+	if err != nil {
+		return nil, caos_errors.ThrowInvalidArgument(err, "LOGIN-Ch2fw", "Errors.IdentityProvider.SAMLResponseInvalid")
+	}
+	if err = l.authRepo.UseSAMLAssertion(ctx, assertion.Issuer, assertion.ID, assertion.Expiration()); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+func (l *Login) parseRelayState(relayState string) (string, string, error) {
+	parts := strings.SplitN(relayState, relayStateSeparator, 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", caos_errors.ThrowInvalidArgument(nil, "LOGIN-Ws3fd", "Errors.AuthRequest.MissingParameters")
+	}
+	id, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", "", caos_errors.ThrowInvalidArgument(err, "LOGIN-Ld2gq", "Errors.AuthRequest.MissingParameters")
+	}
+	userAgentID, err := l.idpConfigAlg.DecryptString(id, l.idpConfigAlg.EncryptionKeyID())
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], userAgentID, nil
+}
+
+// samlEntityID returns the identifier of ZITADEL as service provider, which is the url of its metadata
+func (l *Login) samlEntityID(ctx context.Context, idpConfigID string) string {
+	return l.baseURL(ctx) + EndpointSAMLMetadata + "?" + queryIDPConfigID + "=" + url.QueryEscape(idpConfigID)
+}
+
+func mapSAMLAssertionToLoginUser(assertion *schema.ReceivedAssertion, idpConfig *iam_model.IDPConfigView) *domain.ExternalUser {
+	attributes := assertion.Attributes()
+	email := samlAttribute(attributes, samlAttributesEmail)
+	username := samlAttribute(attributes, samlAttributesUsername)
+	if username == "" {
+		username = email
+	}
+	displayName := samlAttribute(attributes, samlAttributesDisplay)
+	if displayName == "" {
+		displayName = username
+	}
+	return &domain.ExternalUser{
+		IDPConfigID:       idpConfig.IDPConfigID,
+		ExternalUserID:    assertion.NameID,
+		PreferredUsername: username,
+		DisplayName:       displayName,
+		FirstName:         samlAttribute(attributes, samlAttributesGiven),
+		LastName:          samlAttribute(attributes, samlAttributesSurname),
+		Email:             email,
+		Phone:             samlAttribute(attributes, samlAttributesPhone),
+	}
+}
+
+// samlAttribute returns the first value of the first attribute present in the assertion
+func samlAttribute(attributes map[string][]string, names []string) string {
+	for _, name := range names {
+		for _, value := range attributes[name] {
+			if value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
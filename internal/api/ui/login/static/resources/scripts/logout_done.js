@@ -0,0 +1,34 @@
+const frontChannelLogoutTimeout = 5000;
+
+document.addEventListener('DOMContentLoaded', function () {
+    redirectAfterFrontChannelLogout();
+});
+
+function redirectAfterFrontChannelLogout() {
+    let link = document.getElementById("redirect-link");
+    if (!link) {
+        return;
+    }
+    let frames = document.getElementsByClassName("lgn-frontchannel-logout");
+    let pending = frames.length;
+    let redirected = false;
+    let redirect = function () {
+        if (!redirected) {
+            redirected = true;
+            window.location.href = link.href;
+        }
+    };
+    if (pending === 0) {
+        redirect();
+        return;
+    }
+    for (let i = 0; i < frames.length; i++) {
+        frames[i].addEventListener("load", function () {
+            pending--;
+            if (pending === 0) {
+                redirect();
+            }
+        });
+    }
+    setTimeout(redirect, frontChannelLogoutTimeout);
+}
@@ -0,0 +1,147 @@
+package login
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	throttleTable           = "system.login_throttles"
+	ThrottleInstanceIDCol   = "instance_id"
+	ThrottleLoginNameCol    = "login_name"
+	ThrottleRemoteIPCol     = "remote_ip"
+	ThrottleFailureCountCol = "failure_count"
+	ThrottleWindowStartCol  = "window_start"
+)
+
+const (
+	throttleFailuresStmt = "SELECT " + ThrottleFailureCountCol + " FROM " + throttleTable +
+		" WHERE " + ThrottleInstanceIDCol + " = $1 AND " + ThrottleLoginNameCol + " = $2 AND " + ThrottleRemoteIPCol + " = $3" +
+		" AND " + ThrottleWindowStartCol + " > $4"
+	// throttleFailedStmt counts the failure in the current window or starts a new window if the current one expired
+	throttleFailedStmt = "INSERT INTO " + throttleTable + " AS t (" +
+		ThrottleInstanceIDCol + ", " + ThrottleLoginNameCol + ", " + ThrottleRemoteIPCol + ", " + ThrottleFailureCountCol + ", " + ThrottleWindowStartCol +
+		") VALUES ($1, $2, $3, 1, $4)" +
+		" ON CONFLICT (" + ThrottleInstanceIDCol + ", " + ThrottleLoginNameCol + ", " + ThrottleRemoteIPCol + ") DO UPDATE SET " +
+		ThrottleFailureCountCol + " = CASE WHEN t." + ThrottleWindowStartCol + " > $5 THEN t." + ThrottleFailureCountCol + " + 1 ELSE 1 END, " +
+		ThrottleWindowStartCol + " = CASE WHEN t." + ThrottleWindowStartCol + " > $5 THEN t." + ThrottleWindowStartCol + " ELSE $4 END"
+	throttleResetStmt = "DELETE FROM " + throttleTable +
+		" WHERE " + ThrottleInstanceIDCol + " = $1 AND " + ThrottleLoginNameCol + " = $2 AND " + ThrottleRemoteIPCol + " = $3"
+	throttlePruneStmt = "DELETE FROM " + throttleTable + " WHERE " + ThrottleWindowStartCol + " <= $1"
+)
+
+// ThrottleConfig limits the failed password and second factor checks
+// per login name from the same remote IP within the window.
+// A MaxAttempts of 0 disables the throttling.
+type ThrottleConfig struct {
+	MaxAttempts uint64
+	Window      time.Duration
+}
+
+// throttle stores the failed checks in the database,
+// so they are shared by all instances of ZITADEL and survive restarts.
+// The failures are keyed on the login name and the remote IP,
+// so others can't lock out a user by failing checks with its login name.
+type throttle struct {
+	config ThrottleConfig
+	client *sql.DB
+
+	mutex     sync.Mutex
+	lastPrune time.Time
+}
+
+type throttleKey struct {
+	instanceID string
+	loginName  string
+	remoteIP   string
+}
+
+func newThrottle(config ThrottleConfig, client *sql.DB) *throttle {
+	return &throttle{
+		config:    config,
+		client:    client,
+		lastPrune: time.Now(),
+	}
+}
+
+// check returns an error if the login name of the request reached
+// the maximum failed attempts from the remote IP in the current window
+func (t *throttle) check(r *http.Request, authReq *domain.AuthRequest) error {
+	if t.config.MaxAttempts == 0 || authReq == nil {
+		return nil
+	}
+	key := newThrottleKey(r, authReq)
+	var failures uint64
+	err := t.client.QueryRowContext(r.Context(), throttleFailuresStmt, key.instanceID, key.loginName, key.remoteIP, time.Now().Add(-t.config.Window)).Scan(&failures)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "LOGIN-Ahx3u", "Errors.Internal")
+	}
+	if failures >= t.config.MaxAttempts {
+		return caos_errs.ThrowPreconditionFailed(nil, "LOGIN-w8Gqe", "Errors.User.Throttled")
+	}
+	return nil
+}
+
+// failed counts a failed check for the login name and the remote IP of the request
+func (t *throttle) failed(r *http.Request, authReq *domain.AuthRequest) {
+	if t.config.MaxAttempts == 0 || authReq == nil {
+		return
+	}
+	now := time.Now()
+	t.prune(r.Context(), now)
+	key := newThrottleKey(r, authReq)
+	_, err := t.client.ExecContext(r.Context(), throttleFailedStmt, key.instanceID, key.loginName, key.remoteIP, now, now.Add(-t.config.Window))
+	logging.WithFields("instanceID", key.instanceID).OnError(err).Warn("unable to store failed login check")
+}
+
+// succeeded resets the failed checks of the login name from the remote IP
+func (t *throttle) succeeded(r *http.Request, authReq *domain.AuthRequest) {
+	if t.config.MaxAttempts == 0 || authReq == nil {
+		return
+	}
+	key := newThrottleKey(r, authReq)
+	_, err := t.client.ExecContext(r.Context(), throttleResetStmt, key.instanceID, key.loginName, key.remoteIP)
+	logging.WithFields("instanceID", key.instanceID).OnError(err).Warn("unable to reset failed login checks")
+}
+
+// prune removes expired entries at most once per window of this instance of ZITADEL
+func (t *throttle) prune(ctx context.Context, now time.Time) {
+	t.mutex.Lock()
+	if t.lastPrune.Add(t.config.Window).After(now) {
+		t.mutex.Unlock()
+		return
+	}
+	t.lastPrune = now
+	t.mutex.Unlock()
+	_, err := t.client.ExecContext(ctx, throttlePruneStmt, now.Add(-t.config.Window))
+	logging.OnError(err).Warn("unable to prune failed login checks")
+}
+
+func newThrottleKey(r *http.Request, authReq *domain.AuthRequest) *throttleKey {
+	loginName := authReq.LoginName
+	if loginName == "" {
+		loginName = authReq.UserID
+	}
+	key := &throttleKey{
+		instanceID: authReq.InstanceID,
+		loginName:  strings.ToLower(loginName),
+	}
+	if ip := http_utils.RemoteIPFromRequest(r); ip != nil {
+		key.remoteIP = ip.String()
+	}
+	return key
+}
@@ -0,0 +1,225 @@
+package login
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var errThrottleQuery = errors.New("query failed")
+
+func throttleRequest(remoteAddr string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, EndpointPassword, nil)
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func throttleAuthRequest() *domain.AuthRequest {
+	return &domain.AuthRequest{
+		InstanceID: "instance1",
+		LoginName:  "User@Example.com",
+		UserID:     "user1",
+	}
+}
+
+func newMockThrottle(t *testing.T, config ThrottleConfig, expect func(sqlmock.Sqlmock)) (*throttle, func()) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unable to create sql mock: %v", err)
+	}
+	expect(mock)
+	return newThrottle(config, client), func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("expectations not met: %v", err)
+		}
+		client.Close()
+	}
+}
+
+func TestThrottle_check(t *testing.T) {
+	type args struct {
+		config  ThrottleConfig
+		authReq *domain.AuthRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		expect  func(sqlmock.Sqlmock)
+		wantErr func(error) bool
+	}{
+		{
+			name: "disabled, ok",
+			args: args{
+				config:  ThrottleConfig{},
+				authReq: throttleAuthRequest(),
+			},
+			expect: func(sqlmock.Sqlmock) {},
+		},
+		{
+			name: "no auth request, ok",
+			args: args{
+				config: ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+			},
+			expect: func(sqlmock.Sqlmock) {},
+		},
+		{
+			name: "no failures, ok",
+			args: args{
+				config:  ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+				authReq: throttleAuthRequest(),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(throttleFailuresStmt)).
+					WithArgs("instance1", "user@example.com", "192.0.2.1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"failure_count"}))
+			},
+		},
+		{
+			name: "failures below max attempts, ok",
+			args: args{
+				config:  ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+				authReq: throttleAuthRequest(),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(throttleFailuresStmt)).
+					WithArgs("instance1", "user@example.com", "192.0.2.1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"failure_count"}).AddRow(2))
+			},
+		},
+		{
+			name: "max attempts reached, precondition failed",
+			args: args{
+				config:  ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+				authReq: throttleAuthRequest(),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(throttleFailuresStmt)).
+					WithArgs("instance1", "user@example.com", "192.0.2.1", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"failure_count"}).AddRow(3))
+			},
+			wantErr: caos_errs.IsPreconditionFailed,
+		},
+		{
+			name: "query fails, internal",
+			args: args{
+				config:  ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+				authReq: throttleAuthRequest(),
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(throttleFailuresStmt)).
+					WillReturnError(errThrottleQuery)
+			},
+			wantErr: caos_errs.IsInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th, done := newMockThrottle(t, tt.args.config, tt.expect)
+			defer done()
+			err := th.check(throttleRequest("192.0.2.1:1234"), tt.args.authReq)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestThrottle_failed(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    ThrottleConfig
+		lastPrune time.Time
+		expect    func(sqlmock.Sqlmock)
+	}{
+		{
+			name:   "disabled, nothing stored",
+			config: ThrottleConfig{},
+			expect: func(sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "failure stored",
+			config:    ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+			lastPrune: time.Now(),
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(throttleFailedStmt)).
+					WithArgs("instance1", "user@example.com", "192.0.2.1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:      "window passed since last prune, expired failures pruned",
+			config:    ThrottleConfig{MaxAttempts: 3, Window: time.Minute},
+			lastPrune: time.Now().Add(-2 * time.Minute),
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(regexp.QuoteMeta(throttlePruneStmt)).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+				m.ExpectExec(regexp.QuoteMeta(throttleFailedStmt)).
+					WithArgs("instance1", "user@example.com", "192.0.2.1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th, done := newMockThrottle(t, tt.config, tt.expect)
+			defer done()
+			th.lastPrune = tt.lastPrune
+			th.failed(throttleRequest("192.0.2.1:1234"), throttleAuthRequest())
+		})
+	}
+}
+
+func TestThrottle_succeeded(t *testing.T) {
+	th, done := newMockThrottle(t, ThrottleConfig{MaxAttempts: 3, Window: time.Minute}, func(m sqlmock.Sqlmock) {
+		m.ExpectExec(regexp.QuoteMeta(throttleResetStmt)).
+			WithArgs("instance1", "user@example.com", "192.0.2.1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	})
+	defer done()
+	th.succeeded(throttleRequest("192.0.2.1:1234"), throttleAuthRequest())
+}
+
+func TestNewThrottleKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       *http.Request
+		authReq *domain.AuthRequest
+		want    *throttleKey
+	}{
+		{
+			name:    "login name and remote ip",
+			r:       throttleRequest("192.0.2.1:1234"),
+			authReq: throttleAuthRequest(),
+			want:    &throttleKey{instanceID: "instance1", loginName: "user@example.com", remoteIP: "192.0.2.1"},
+		},
+		{
+			name:    "other remote ip, other key",
+			r:       throttleRequest("198.51.100.7:1234"),
+			authReq: throttleAuthRequest(),
+			want:    &throttleKey{instanceID: "instance1", loginName: "user@example.com", remoteIP: "198.51.100.7"},
+		},
+		{
+			name:    "no login name, user id",
+			r:       throttleRequest("192.0.2.1:1234"),
+			authReq: &domain.AuthRequest{InstanceID: "instance1", UserID: "user1"},
+			want:    &throttleKey{instanceID: "instance1", loginName: "user1", remoteIP: "192.0.2.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newThrottleKey(tt.r, tt.authReq))
+		})
+	}
+}
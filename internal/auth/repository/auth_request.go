@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 )
@@ -14,14 +15,31 @@ type AuthRequestRepository interface {
 	SaveAuthCode(ctx context.Context, id, code, userAgentID string) error
 	DeleteAuthRequest(ctx context.Context, id string) error
 
+	DeviceAuthRequestByUserCode(ctx context.Context, userCode, userAgentID string) (*domain.AuthRequest, error)
+	ApproveDeviceAuthRequest(ctx context.Context, id, userAgentID string) error
+	DenyDeviceAuthRequest(ctx context.Context, id, userAgentID string) error
+	PollDeviceAuthRequest(ctx context.Context, clientID, deviceCode string) (*domain.AuthRequest, domain.DeviceAuthPollResult, error)
+
+	CreatePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) error
+	PushedAuthRequestByID(ctx context.Context, id string) (*domain.PushedAuthRequest, error)
+
+	UseSAMLAssertion(ctx context.Context, issuer, id string, expiration time.Time) error
+
 	CheckLoginName(ctx context.Context, id, loginName, userAgentID string) error
 	CheckExternalUserLogin(ctx context.Context, authReqID, userAgentID string, user *domain.ExternalUser, info *domain.BrowserInfo) error
 	SetExternalUserLogin(ctx context.Context, authReqID, userAgentID string, user *domain.ExternalUser) error
 	SelectUser(ctx context.Context, id, userID, userAgentID string) error
 	SelectExternalIDP(ctx context.Context, authReqID, idpConfigID, userAgentID string) error
-	VerifyPassword(ctx context.Context, id, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo) error
+	VerifyPassword(ctx context.Context, id, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo, postAuthentication func() error) error
 
 	VerifyMFAOTP(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
+	SendMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) error
+	VerifyMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
+	SendMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) error
+	VerifyMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
+	SendMagicLink(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) error
+	VerifyMagicLink(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
+	VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) error
 	BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (*domain.WebAuthNLogin, error)
 	VerifyMFAU2F(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string, credentialData []byte, info *domain.BrowserInfo) error
 	BeginPasswordlessSetup(ctx context.Context, userID, resourceOwner string, preferredPlatformType domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error)
@@ -34,4 +52,5 @@ type AuthRequestRepository interface {
 	LinkExternalUsers(ctx context.Context, authReqID, userAgentID string, info *domain.BrowserInfo) error
 	AutoRegisterExternalUser(ctx context.Context, user *domain.Human, externalIDP *domain.UserIDPLink, orgMemberRoles []string, authReqID, userAgentID, resourceOwner string, metadatas []*domain.Metadata, info *domain.BrowserInfo) error
 	ResetLinkingUsers(ctx context.Context, authReqID, userAgentID string) error
+	SkipPasswordExpiryWarning(ctx context.Context, authReqID, userAgentID string) error
 }
@@ -2,6 +2,7 @@ package eventstore
 
 import (
 	"context"
+	"net"
 	"time"
 
 	"github.com/zitadel/logging"
@@ -45,6 +46,9 @@ type AuthRequestRepo struct {
 	OrgViewProvider           orgViewProvider
 	LoginPolicyViewProvider   loginPolicyViewProvider
 	LockoutPolicyViewProvider lockoutPolicyViewProvider
+	PasswordAgePolicyProvider passwordAgePolicyProvider
+	AccessPolicyProvider      accessPolicyProvider
+	CountryProvider           countryProvider
 	PrivacyPolicyProvider     privacyPolicyProvider
 	IDPProviderViewProvider   idpProviderViewProvider
 	UserGrantProvider         userGrantProvider
@@ -80,6 +84,18 @@ type lockoutPolicyViewProvider interface {
 	LockoutPolicyByOrg(context.Context, string) (*query.LockoutPolicy, error)
 }
 
+type passwordAgePolicyProvider interface {
+	PasswordAgePolicyByOrg(context.Context, string) (*query.PasswordAgePolicy, error)
+}
+
+type accessPolicyProvider interface {
+	AccessPolicyByOrg(context.Context, string) (*query.AccessPolicy, error)
+}
+
+type countryProvider interface {
+	Country(net.IP) string
+}
+
 type idpProviderViewProvider interface {
 	IDPProvidersByAggregateIDAndState(string, string, iam_model.IDPConfigState) ([]*iam_view_model.IDPProviderView, error)
 }
@@ -90,6 +106,7 @@ type userEventProvider interface {
 
 type userCommandProvider interface {
 	BulkAddedUserIDPLinks(ctx context.Context, userID, resourceOwner string, externalIDPs []*domain.UserIDPLink) error
+	UnlockUserAfterLockout(ctx context.Context, userID, resourceOwner string, lockoutPolicy *domain.LockoutPolicy) (bool, error)
 }
 
 type orgViewProvider interface {
@@ -99,11 +116,13 @@ type orgViewProvider interface {
 
 type userGrantProvider interface {
 	ProjectByOIDCClientID(context.Context, string) (*query.Project, error)
+	ProjectBySAMLEntityID(context.Context, string) (*query.Project, error)
 	UserGrantsByProjectAndUserID(string, string) ([]*query.UserGrant, error)
 }
 
 type projectProvider interface {
 	ProjectByOIDCClientID(context.Context, string) (*query.Project, error)
+	ProjectBySAMLEntityID(context.Context, string) (*query.Project, error)
 	OrgProjectMappingByIDs(orgID, projectID, instanceID string) (*project_view_model.OrgProjectMapping, error)
 }
 
@@ -123,7 +142,7 @@ func (repo *AuthRequestRepo) CreateAuthRequest(ctx context.Context, request *dom
 		return nil, err
 	}
 	request.ID = reqID
-	project, err := repo.ProjectProvider.ProjectByOIDCClientID(ctx, request.ApplicationID)
+	project, err := projectByRequest(ctx, request, repo.ProjectProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -200,12 +219,122 @@ func (repo *AuthRequestRepo) AuthRequestByCode(ctx context.Context, code string)
 	return request, nil
 }
 
+// DeviceAuthRequestByUserCode returns the device authorization request of the user code
+// the request is bound to the user agent entering the code first
+func (repo *AuthRequestRepo) DeviceAuthRequestByUserCode(ctx context.Context, userCode, userAgentID string) (_ *domain.AuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.AuthRequests.GetAuthRequestByUserCode(ctx, domain.NormalizeDeviceUserCode(userCode))
+	if err != nil {
+		return nil, err
+	}
+	device, ok := request.Request.(*domain.AuthRequestDevice)
+	if !ok || device.State != domain.DeviceAuthStateInitiated || device.IsExpired(time.Now()) {
+		return nil, errors.ThrowNotFound(nil, "EVENT-Ahx2o", "Errors.AuthRequest.NotFound")
+	}
+	if request.AgentID != "" && request.AgentID != userAgentID {
+		return nil, errors.ThrowPermissionDenied(nil, "EVENT-oo4Ai", "Errors.AuthRequest.UserAgentNotCorresponding")
+	}
+	if request.AgentID == "" {
+		request.AgentID = userAgentID
+		if err = repo.AuthRequests.UpdateAuthRequest(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+	return repo.getAuthRequestNextSteps(ctx, request.ID, userAgentID, false)
+}
+
+// ApproveDeviceAuthRequest grants the device the tokens of the authenticated user
+func (repo *AuthRequestRepo) ApproveDeviceAuthRequest(ctx context.Context, id, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, device, err := repo.getDeviceAuthRequest(ctx, id, userAgentID)
+	if err != nil {
+		return err
+	}
+	steps, err := repo.nextSteps(ctx, request, true)
+	if err != nil {
+		return err
+	}
+	if len(steps) != 1 || steps[0].Type() != domain.NextStepRedirectToCallback {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Quai5", "Errors.AuthRequest.NotAuthenticated")
+	}
+	device.State = domain.DeviceAuthStateApproved
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
+// DenyDeviceAuthRequest rejects the device authorization request,
+// the device will receive an access_denied error on its next poll
+func (repo *AuthRequestRepo) DenyDeviceAuthRequest(ctx context.Context, id, userAgentID string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, device, err := repo.getDeviceAuthRequest(ctx, id, userAgentID)
+	if err != nil {
+		return err
+	}
+	device.State = domain.DeviceAuthStateDenied
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
+// PollDeviceAuthRequest records the poll of the device and returns its result
+// approved, expired and denied requests are removed,
+// so an approved request can only be exchanged for tokens once
+func (repo *AuthRequestRepo) PollDeviceAuthRequest(ctx context.Context, clientID, deviceCode string) (_ *domain.AuthRequest, _ domain.DeviceAuthPollResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.AuthRequests.GetAuthRequestByDeviceCode(ctx, deviceCode)
+	if err != nil {
+		return nil, 0, err
+	}
+	device, ok := request.Request.(*domain.AuthRequestDevice)
+	if !ok || request.ApplicationID != clientID {
+		return nil, 0, errors.ThrowNotFound(nil, "EVENT-ieW3e", "Errors.AuthRequest.NotFound")
+	}
+	result := device.Poll(time.Now())
+	switch result {
+	case domain.DeviceAuthPollResultApproved:
+		err = repo.AuthRequests.ConsumeAuthRequest(ctx, request.ID)
+	case domain.DeviceAuthPollResultExpired,
+		domain.DeviceAuthPollResultDenied:
+		err = repo.AuthRequests.DeleteAuthRequest(ctx, request.ID)
+	default:
+		err = repo.AuthRequests.UpdateAuthRequest(ctx, request)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return request, result, nil
+}
+
 func (repo *AuthRequestRepo) DeleteAuthRequest(ctx context.Context, id string) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	return repo.AuthRequests.DeleteAuthRequest(ctx, id)
 }
 
+func (repo *AuthRequestRepo) CreatePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request.InstanceID = authz.GetInstance(ctx).InstanceID()
+	return repo.AuthRequests.SavePushedAuthRequest(ctx, request)
+}
+
+// PushedAuthRequestByID returns the pushed authorization request,
+// which can only be retrieved once
+func (repo *AuthRequestRepo) PushedAuthRequestByID(ctx context.Context, id string) (_ *domain.PushedAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return repo.AuthRequests.GetAndDeletePushedAuthRequest(ctx, id)
+}
+
+// UseSAMLAssertion marks the assertion of the identity provider as used,
+// so a captured response can't be replayed as long as the assertion is valid
+func (repo *AuthRequestRepo) UseSAMLAssertion(ctx context.Context, issuer, id string, expiration time.Time) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	return repo.AuthRequests.UseSAMLAssertion(ctx, issuer, id, expiration)
+}
+
 func (repo *AuthRequestRepo) CheckLoginName(ctx context.Context, id, loginName, userAgentID string) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -286,7 +415,7 @@ func (repo *AuthRequestRepo) SelectUser(ctx context.Context, id, userID, userAge
 	if err != nil {
 		return err
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, userID, false)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, userID, false)
 	if err != nil {
 		return err
 	}
@@ -301,7 +430,7 @@ func (repo *AuthRequestRepo) SelectUser(ctx context.Context, id, userID, userAge
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
-func (repo *AuthRequestRepo) VerifyPassword(ctx context.Context, authReqID, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo) (err error) {
+func (repo *AuthRequestRepo) VerifyPassword(ctx context.Context, authReqID, userID, resourceOwner, password, userAgentID string, info *domain.BrowserInfo, postAuthentication func() error) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 	request, err := repo.getAuthRequestEnsureUser(ctx, authReqID, userAgentID, userID)
@@ -315,7 +444,7 @@ func (repo *AuthRequestRepo) VerifyPassword(ctx context.Context, authReqID, user
 	if err != nil {
 		return err
 	}
-	err = repo.Command.HumanCheckPassword(ctx, resourceOwner, userID, password, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+	err = repo.Command.HumanCheckPassword(ctx, resourceOwner, userID, password, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy), postAuthentication)
 	if isIgnoreUserInvalidPasswordError(err, request) {
 		return errors.ThrowInvalidArgument(nil, "EVENT-Jsf32", "Errors.User.UsernameOrPassword.Invalid")
 	}
@@ -323,13 +452,22 @@ func (repo *AuthRequestRepo) VerifyPassword(ctx context.Context, authReqID, user
 }
 
 func isIgnoreUserNotFoundError(err error, request *domain.AuthRequest) bool {
-	return request != nil && request.LoginPolicy != nil && request.LoginPolicy.IgnoreUnknownUsernames && errors.IsNotFound(err) && errors.Contains(err, "Errors.User.NotFound")
+	return request != nil && ignoreUnknownUsernames(request) && errors.IsNotFound(err) && errors.Contains(err, "Errors.User.NotFound")
 }
 
 func isIgnoreUserInvalidPasswordError(err error, request *domain.AuthRequest) bool {
 	return request != nil && request.LoginPolicy != nil && request.LoginPolicy.IgnoreUnknownUsernames && errors.IsErrorInvalidArgument(err) && errors.Contains(err, "Errors.User.Password.Invalid")
 }
 
+// ignoreUnknownUsernames returns true if unknown users proceed to the password step,
+// either because the login policy hides them or because the password might be known by an LDAP directory
+func ignoreUnknownUsernames(request *domain.AuthRequest) bool {
+	if request.LoginPolicy != nil && request.LoginPolicy.IgnoreUnknownUsernames {
+		return true
+	}
+	return len(request.LDAPIDPs()) > 0
+}
+
 func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 	return &domain.LockoutPolicy{
 		ObjectRoot: es_models.ObjectRoot{
@@ -341,7 +479,24 @@ func lockoutPolicyToDomain(policy *query.LockoutPolicy) *domain.LockoutPolicy {
 		},
 		Default:             policy.IsDefault,
 		MaxPasswordAttempts: policy.MaxPasswordAttempts,
+		MaxOTPAttempts:      policy.MaxOTPAttempts,
+		MaxU2FAttempts:      policy.MaxU2FAttempts,
 		ShowLockOutFailures: policy.ShowFailures,
+		AutoUnlockDuration:  policy.AutoUnlockDuration,
+	}
+}
+
+func passwordAgePolicyToDomain(policy *query.PasswordAgePolicy) *domain.PasswordAgePolicy {
+	return &domain.PasswordAgePolicy{
+		ObjectRoot: es_models.ObjectRoot{
+			AggregateID:   policy.ID,
+			Sequence:      policy.Sequence,
+			ResourceOwner: policy.ResourceOwner,
+			CreationDate:  policy.CreationDate,
+			ChangeDate:    policy.ChangeDate,
+		},
+		MaxAgeDays:     policy.MaxAgeDays,
+		ExpireWarnDays: policy.ExpireWarnDays,
 	}
 }
 
@@ -352,7 +507,99 @@ func (repo *AuthRequestRepo) VerifyMFAOTP(ctx context.Context, authRequestID, us
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckMFAOTP(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) SendMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPSMSCode(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPSMS(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPSMS(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) SendMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanSendOTPEmailCode(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFAOTPEmail(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckOTPEmail(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
+}
+
+func (repo *AuthRequestRepo) SendMagicLink(ctx context.Context, authRequestID, userID, resourceOwner, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	if !request.LoginPolicy.AllowMagicLink {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-Eiph6", "Errors.User.MagicLink.NotAllowed")
+	}
+	return repo.Command.HumanRequestMagicLink(ctx, userID, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMagicLink(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	if !request.LoginPolicy.AllowMagicLink {
+		return errors.ThrowPreconditionFailed(nil, "EVENT-ooX5i", "Errors.User.MagicLink.NotAllowed")
+	}
+	return repo.Command.HumanCheckMagicLink(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info))
+}
+
+func (repo *AuthRequestRepo) VerifyMFARecoveryCode(ctx context.Context, authRequestID, userID, resourceOwner, code, userAgentID string, info *domain.BrowserInfo) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+	request, err := repo.getAuthRequestEnsureUser(ctx, authRequestID, userAgentID, userID)
+	if err != nil {
+		return err
+	}
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanCheckRecoveryCode(ctx, userID, code, resourceOwner, request.WithCurrentInfo(info), lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) BeginMFAU2FLogin(ctx context.Context, userID, resourceOwner, authRequestID, userAgentID string) (login *domain.WebAuthNLogin, err error) {
@@ -373,7 +620,11 @@ func (repo *AuthRequestRepo) VerifyMFAU2F(ctx context.Context, userID, resourceO
 	if err != nil {
 		return err
 	}
-	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request, true)
+	policy, err := repo.getLockoutPolicy(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	return repo.Command.HumanFinishU2FLogin(ctx, userID, resourceOwner, credentialData, request, true, lockoutPolicyToDomain(policy))
 }
 
 func (repo *AuthRequestRepo) BeginPasswordlessSetup(ctx context.Context, userID, resourceOwner string, authenticatorPlatform domain.AuthenticatorAttachment) (login *domain.WebAuthNToken, err error) {
@@ -459,6 +710,16 @@ func (repo *AuthRequestRepo) ResetLinkingUsers(ctx context.Context, authReqID, u
 	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
 }
 
+// SkipPasswordExpiryWarning postpones the change of the expiring password for the auth request
+func (repo *AuthRequestRepo) SkipPasswordExpiryWarning(ctx context.Context, authReqID, userAgentID string) error {
+	request, err := repo.getAuthRequest(ctx, authReqID, userAgentID)
+	if err != nil {
+		return err
+	}
+	request.PasswordExpirySkipped = true
+	return repo.AuthRequests.UpdateAuthRequest(ctx, request)
+}
+
 func (repo *AuthRequestRepo) AutoRegisterExternalUser(ctx context.Context, registerUser *domain.Human, externalIDP *domain.UserIDPLink, orgMemberRoles []string, authReqID, userAgentID, resourceOwner string, metadatas []*domain.Metadata, info *domain.BrowserInfo) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -516,7 +777,7 @@ func (repo *AuthRequestRepo) getAuthRequestEnsureUser(ctx context.Context, authR
 	if request.UserID != userID {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-GBH32", "Errors.User.NotMatchingUserID")
 	}
-	_, err = activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, request.UserID, false)
+	_, err = activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, request.UserID, false)
 	if err != nil {
 		return request, err
 	}
@@ -538,6 +799,21 @@ func (repo *AuthRequestRepo) getAuthRequest(ctx context.Context, id, userAgentID
 	return request, nil
 }
 
+func (repo *AuthRequestRepo) getDeviceAuthRequest(ctx context.Context, id, userAgentID string) (*domain.AuthRequest, *domain.AuthRequestDevice, error) {
+	request, err := repo.getAuthRequest(ctx, id, userAgentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	device, ok := request.Request.(*domain.AuthRequestDevice)
+	if !ok {
+		return nil, nil, errors.ThrowPreconditionFailed(nil, "EVENT-ahG5i", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+	if device.State != domain.DeviceAuthStateInitiated || device.IsExpired(time.Now()) {
+		return nil, nil, errors.ThrowPreconditionFailed(nil, "EVENT-Iequ9", "Errors.AuthRequest.DeviceExpired")
+	}
+	return request, device, nil
+}
+
 func (repo *AuthRequestRepo) getLoginPolicyAndIDPProviders(ctx context.Context, orgID string) (*query.LoginPolicy, []*domain.IDPProvider, error) {
 	policy, err := repo.LoginPolicyViewProvider.LoginPolicyByID(ctx, orgID)
 	if err != nil {
@@ -577,6 +853,16 @@ func (repo *AuthRequestRepo) fillPolicies(ctx context.Context, request *domain.A
 		return err
 	}
 	request.LockoutPolicy = lockoutPolicyToDomain(lockoutPolicy)
+	passwordAgePolicy, err := repo.PasswordAgePolicyProvider.PasswordAgePolicyByOrg(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	request.PasswordAgePolicy = passwordAgePolicyToDomain(passwordAgePolicy)
+	accessPolicy, err := repo.getAccessPolicy(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	request.AccessPolicy = accessPolicy
 	privacyPolicy, err := repo.GetPrivacyPolicy(ctx, orgID)
 	if err != nil {
 		return err
@@ -645,8 +931,8 @@ func (repo *AuthRequestRepo) checkLoginName(ctx context.Context, request *domain
 			}
 		}
 	}
-	if request.LoginPolicy.IgnoreUnknownUsernames {
-		if errors.IsNotFound(err) || (user != nil && user.State == int32(domain.UserStateInactive)) {
+	if ignoreUnknownUsernames(request) {
+		if errors.IsNotFound(err) || (request.LoginPolicy.IgnoreUnknownUsernames && user != nil && user.State == int32(domain.UserStateInactive)) {
 			if request.LabelPolicy.HideLoginNameSuffix {
 				preferredLoginName = loginName
 			}
@@ -702,6 +988,7 @@ func queryLoginPolicyToDomain(policy *query.LoginPolicy) *domain.LoginPolicy {
 		PasswordlessType:           policy.PasswordlessType,
 		HidePasswordReset:          policy.HidePasswordReset,
 		IgnoreUnknownUsernames:     policy.IgnoreUnknownUsernames,
+		AllowMagicLink:             policy.AllowMagicLink,
 		PasswordCheckLifetime:      policy.PasswordCheckLifetime,
 		ExternalLoginCheckLifetime: policy.ExternalLoginCheckLifetime,
 		MFAInitSkipLifetime:        policy.MFAInitSkipLifetime,
@@ -730,7 +1017,7 @@ func (repo *AuthRequestRepo) checkExternalUserLogin(ctx context.Context, request
 	if err != nil {
 		return err
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, externalIDP.UserID, false)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, externalIDP.UserID, false)
 	if err != nil {
 		return err
 	}
@@ -776,7 +1063,7 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		}
 		return steps, nil
 	}
-	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, request.UserID, request.LoginPolicy.IgnoreUnknownUsernames)
+	user, err := activeUserByID(ctx, repo.UserViewProvider, repo.UserEventProvider, repo.OrgViewProvider, repo.LockoutPolicyViewProvider, repo.UserCommandProvider, request.UserID, ignoreUnknownUsernames(request))
 	if err != nil {
 		return nil, err
 	}
@@ -788,6 +1075,18 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return nil, err
 	}
 
+	accessAction, err := repo.accessRuleAction(ctx, request, user, userSession)
+	if err != nil {
+		return nil, err
+	}
+	if accessAction == domain.AccessRuleActionDeny {
+		return nil, errors.ThrowPermissionDenied(nil, "EVENT-aeTh2", "Errors.User.AccessDenied")
+	}
+	requirePasswordless := accessAction == domain.AccessRuleActionRequirePasswordless
+	if requirePasswordless && !user.IsPasswordlessReady() {
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Ohm4a", "Errors.Login.AccessPolicy.PasswordlessRequired")
+	}
+
 	isInternalLogin := request.SelectedIDPConfigID == "" && userSession.SelectedIDPConfigID == ""
 	if !isInternalLogin && len(request.LinkingUsers) == 0 && !checkVerificationTimeMaxAge(userSession.ExternalLoginVerification, request.LoginPolicy.ExternalLoginCheckLifetime, request) {
 		selectedIDPConfigID := request.SelectedIDPConfigID
@@ -797,13 +1096,13 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, &domain.ExternalLoginStep{SelectedIDPConfigID: selectedIDPConfigID}), nil
 	}
 	if isInternalLogin || (!isInternalLogin && len(request.LinkingUsers) > 0) {
-		step := repo.firstFactorChecked(request, user, userSession)
+		step := repo.firstFactorChecked(request, user, userSession, requirePasswordless)
 		if step != nil {
 			return append(steps, step), nil
 		}
 	}
 
-	step, ok, err := repo.mfaChecked(userSession, request, user)
+	step, ok, err := repo.mfaChecked(userSession, request, user, accessAction == domain.AccessRuleActionRequireMFA)
 	if err != nil {
 		return nil, err
 	}
@@ -811,8 +1110,9 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		return append(steps, step), nil
 	}
 
-	if user.PasswordChangeRequired {
-		steps = append(steps, &domain.ChangePasswordStep{})
+	passwordExpired := isInternalLogin && user.PasswordSet && request.PasswordAgePolicy.IsPasswordExpired(user.PasswordChanged, time.Now())
+	if user.PasswordChangeRequired || passwordExpired {
+		steps = append(steps, &domain.ChangePasswordStep{Expired: passwordExpired})
 	}
 	if !user.IsEmailVerified {
 		steps = append(steps, &domain.VerifyEMailStep{})
@@ -821,10 +1121,14 @@ func (repo *AuthRequestRepo) nextSteps(ctx context.Context, request *domain.Auth
 		steps = append(steps, &domain.ChangeUsernameStep{})
 	}
 
-	if user.PasswordChangeRequired || !user.IsEmailVerified || user.UsernameChangeRequired {
+	if user.PasswordChangeRequired || passwordExpired || !user.IsEmailVerified || user.UsernameChangeRequired {
 		return steps, nil
 	}
 
+	if isInternalLogin && user.PasswordSet && !request.PasswordExpirySkipped && request.PasswordAgePolicy.IsPasswordExpiryWarning(user.PasswordChanged, time.Now()) {
+		return append(steps, &domain.PasswordExpiryWarningStep{Expiry: request.PasswordAgePolicy.PasswordExpiry(user.PasswordChanged)}), nil
+	}
+
 	if request.LinkingUsers != nil && len(request.LinkingUsers) != 0 {
 		return append(steps, &domain.LinkUsersStep{}), nil
 	}
@@ -879,21 +1183,31 @@ func (repo *AuthRequestRepo) usersForUserSelection(request *domain.AuthRequest)
 	return users, nil
 }
 
-func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, user *user_model.UserView, userSession *user_model.UserSessionView) domain.NextStep {
+func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, user *user_model.UserView, userSession *user_model.UserSessionView, requirePasswordless bool) domain.NextStep {
 	if user.InitRequired {
 		return &domain.InitUserStep{PasswordSet: user.PasswordSet}
 	}
 
 	var step domain.NextStep
-	if request.LoginPolicy.PasswordlessType != domain.PasswordlessTypeNotAllowed && user.IsPasswordlessReady() {
+	if (request.LoginPolicy.PasswordlessType != domain.PasswordlessTypeNotAllowed || requirePasswordless) && user.IsPasswordlessReady() {
 		if checkVerificationTimeMaxAge(userSession.PasswordlessVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) {
 			request.AuthTime = userSession.PasswordlessVerification
 			return nil
 		}
 		step = &domain.PasswordlessStep{
-			PasswordSet: user.PasswordSet,
+			PasswordSet: user.PasswordSet && !requirePasswordless,
 		}
 	}
+	if requirePasswordless {
+		// a verified password doesn't satisfy the access policy
+		return step
+	}
+
+	if request.LoginPolicy.AllowMagicLink && checkVerificationTimeMaxAge(userSession.MagicLinkVerification, request.LoginPolicy.PasswordCheckLifetime, request) {
+		request.MagicLinkVerified = true
+		request.AuthTime = userSession.MagicLinkVerification
+		return nil
+	}
 
 	if user.PasswordlessInitRequired {
 		return &domain.PasswordlessRegistrationPromptStep{}
@@ -914,12 +1228,32 @@ func (repo *AuthRequestRepo) firstFactorChecked(request *domain.AuthRequest, use
 	return &domain.PasswordStep{}
 }
 
-func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView) (domain.NextStep, bool, error) {
+func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView, request *domain.AuthRequest, user *user_model.UserView, forceMFA bool) (domain.NextStep, bool, error) {
+	if checkVerificationTimeMaxAge(userSession.PasswordlessVerification, request.LoginPolicy.MultiFactorCheckLifetime, request) {
+		// passwordless is multi factor and phishing resistant by itself
+		request.MFAsVerified = append(request.MFAsVerified, domain.MFATypeU2FUserVerification)
+		return nil, true, nil
+	}
 	mfaLevel := request.MFALevel()
-	allowedProviders, required := user.MFATypesAllowed(mfaLevel, request.LoginPolicy)
+	levelOfAssurance := request.LevelOfAssurance()
+	phishingResistant := levelOfAssurance >= domain.LevelOfAssurancePhishingResistant
+	forceMFA = forceMFA || levelOfAssurance >= domain.LevelOfAssuranceMFA
+	loginPolicy := request.LoginPolicy
+	if forceMFA && !loginPolicy.ForceMFA || phishingResistant {
+		forcedPolicy := *loginPolicy
+		forcedPolicy.ForceMFA = loginPolicy.ForceMFA || forceMFA
+		if phishingResistant {
+			forcedPolicy.SecondFactors = phishingResistantSecondFactors(loginPolicy.SecondFactors)
+		}
+		loginPolicy = &forcedPolicy
+	}
+	allowedProviders, required := user.MFATypesAllowed(mfaLevel, loginPolicy)
+	if phishingResistant {
+		allowedProviders = phishingResistantMFATypes(allowedProviders)
+	}
 	promptRequired := (user.MFAMaxSetUp < mfaLevel) || (len(allowedProviders) == 0 && required)
 	if promptRequired || !repo.mfaSkippedOrSetUp(user, request) {
-		types := user.MFATypesSetupPossible(mfaLevel, request.LoginPolicy)
+		types := user.MFATypesSetupPossible(mfaLevel, loginPolicy)
 		if promptRequired && len(types) == 0 {
 			return nil, false, errors.ThrowPreconditionFailed(nil, "LOGIN-5Hm8s", "Errors.Login.LoginPolicy.MFA.ForceAndNotConfigured")
 		}
@@ -940,7 +1274,8 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 		}
 		fallthrough
 	case domain.MFALevelSecondFactor:
-		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) {
+		if checkVerificationTimeMaxAge(userSession.SecondFactorVerification, request.LoginPolicy.SecondFactorCheckLifetime, request) &&
+			(!phishingResistant || userSession.SecondFactorVerificationType.IsPhishingResistant()) {
 			request.MFAsVerified = append(request.MFAsVerified, userSession.SecondFactorVerificationType)
 			request.AuthTime = userSession.SecondFactorVerification
 			return nil, true, nil
@@ -958,6 +1293,28 @@ func (repo *AuthRequestRepo) mfaChecked(userSession *user_model.UserSessionView,
 	}, false, nil
 }
 
+// phishingResistantSecondFactors returns the second factors of the login policy,
+// which satisfy the level of assurance phishing resistant
+func phishingResistantSecondFactors(secondFactors []domain.SecondFactorType) []domain.SecondFactorType {
+	factors := make([]domain.SecondFactorType, 0, len(secondFactors))
+	for _, factor := range secondFactors {
+		if factor == domain.SecondFactorTypeU2F {
+			factors = append(factors, factor)
+		}
+	}
+	return factors
+}
+
+func phishingResistantMFATypes(mfaTypes []domain.MFAType) []domain.MFAType {
+	types := make([]domain.MFAType, 0, len(mfaTypes))
+	for _, mfaType := range mfaTypes {
+		if mfaType.IsPhishingResistant() {
+			types = append(types, mfaType)
+		}
+	}
+	return types
+}
+
 func (repo *AuthRequestRepo) mfaSkippedOrSetUp(user *user_model.UserView, request *domain.AuthRequest) bool {
 	if user.MFAMaxSetUp > domain.MFALevelNotSetUp {
 		return true
@@ -1001,6 +1358,57 @@ func (repo *AuthRequestRepo) getLockoutPolicy(ctx context.Context, orgID string)
 	return policy, err
 }
 
+func (repo *AuthRequestRepo) getAccessPolicy(ctx context.Context, orgID string) (*domain.AccessPolicy, error) {
+	policy, err := repo.AccessPolicyProvider.AccessPolicyByOrg(ctx, orgID)
+	if errors.IsNotFound(err) {
+		return new(domain.AccessPolicy), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return accessPolicyToDomain(policy), nil
+}
+
+func accessPolicyToDomain(p *query.AccessPolicy) *domain.AccessPolicy {
+	return &domain.AccessPolicy{
+		ObjectRoot: es_models.ObjectRoot{
+			AggregateID:   p.ID,
+			Sequence:      p.Sequence,
+			ResourceOwner: p.ResourceOwner,
+			CreationDate:  p.CreationDate,
+			ChangeDate:    p.ChangeDate,
+		},
+		Default: p.IsDefault,
+		Rules:   p.Rules,
+	}
+}
+
+// accessRuleAction evaluates the access policy of the request against the signals of the login
+func (repo *AuthRequestRepo) accessRuleAction(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userSession *user_model.UserSessionView) (_ domain.AccessRuleAction, err error) {
+	if request.AccessPolicy == nil || len(request.AccessPolicy.Rules) == 0 {
+		return domain.AccessRuleActionAllow, nil
+	}
+	rules := request.AccessPolicy.Rules
+	access := &domain.AccessContext{
+		ApplicationID: request.ApplicationID,
+		// a user agent is new to the user if the session was created during the current request
+		NewDevice: userSession.CreationDate.IsZero() || userSession.CreationDate.After(request.CreationDate),
+	}
+	if request.BrowserInfo != nil {
+		access.IP = request.BrowserInfo.RemoteIP
+	}
+	if access.IP != nil && rules.RequiresCountry() {
+		access.Country = repo.CountryProvider.Country(access.IP)
+	}
+	if rules.RequiresUserRoles() {
+		access.UserRoles, err = userRolesOfRequest(ctx, request, user, repo.UserGrantProvider)
+		if err != nil {
+			return domain.AccessRuleActionAllow, err
+		}
+	}
+	return rules.Evaluate(access), nil
+}
+
 func (repo *AuthRequestRepo) getLabelPolicy(ctx context.Context, orgID string) (*domain.LabelPolicy, error) {
 	policy, err := repo.LabelPolicyProvider.ActiveLabelPolicyByOrg(ctx, orgID)
 	if err != nil {
@@ -1048,6 +1456,9 @@ func (repo *AuthRequestRepo) getLoginTexts(ctx context.Context, aggregateID stri
 }
 
 func (repo *AuthRequestRepo) hasSucceededPage(ctx context.Context, request *domain.AuthRequest, provider applicationProvider) (bool, error) {
+	if request.Request == nil || request.Request.Type() != domain.AuthRequestTypeOIDC {
+		return false, nil
+	}
 	app, err := provider.AppByOIDCClientID(ctx, request.ApplicationID)
 	if err != nil {
 		return false, err
@@ -1136,9 +1547,17 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 			user_repo.UserIDPLoginCheckSucceededType,
 			user_repo.HumanMFAOTPCheckSucceededType,
 			user_repo.HumanMFAOTPCheckFailedType,
+			user_repo.HumanMFAOTPSMSCheckSucceededType,
+			user_repo.HumanMFAOTPSMSCheckFailedType,
+			user_repo.HumanMFAOTPEmailCheckSucceededType,
+			user_repo.HumanMFAOTPEmailCheckFailedType,
+			user_repo.HumanMFARecoveryCodeCheckSucceededType,
+			user_repo.HumanMFARecoveryCodeCheckFailedType,
 			user_repo.HumanSignedOutType,
 			user_repo.HumanPasswordlessTokenCheckSucceededType,
 			user_repo.HumanPasswordlessTokenCheckFailedType,
+			user_repo.HumanMagicLinkCheckSucceededType,
+			user_repo.HumanMagicLinkCheckFailedType,
 			user_repo.HumanU2FTokenCheckSucceededType,
 			user_repo.HumanU2FTokenCheckFailedType:
 			eventData, err := user_view_model.UserSessionFromEvent(event)
@@ -1158,8 +1577,7 @@ func userSessionByIDs(ctx context.Context, provider userSessionViewProvider, eve
 	return user_view_model.UserSessionToModel(&sessionCopy, provider.PrefixAvatarURL()), nil
 }
 
-func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
-	// PLANNED: Check LockoutPolicy
+func activeUserByID(ctx context.Context, userViewProvider userViewProvider, userEventProvider userEventProvider, queries orgViewProvider, lockoutPolicyProvider lockoutPolicyViewProvider, userCommandProvider userCommandProvider, userID string, ignoreUnknownUsernames bool) (user *user_model.UserView, err error) {
 	user, err = userByID(ctx, userViewProvider, userEventProvider, userID)
 	if err != nil {
 		if ignoreUnknownUsernames && errors.IsNotFound(err) {
@@ -1174,6 +1592,11 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	if user.HumanView == nil {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-Lm69x", "Errors.User.NotHuman")
 	}
+	if user.State == user_model.UserStateLocked {
+		if err = unlockUserAfterLockout(ctx, lockoutPolicyProvider, userCommandProvider, user); err != nil {
+			return nil, err
+		}
+	}
 	if user.State == user_model.UserStateLocked || user.State == user_model.UserStateSuspend {
 		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-FJ262", "Errors.User.Locked")
 	}
@@ -1190,6 +1613,26 @@ func activeUserByID(ctx context.Context, userViewProvider userViewProvider, user
 	return user, nil
 }
 
+// unlockUserAfterLockout unlocks a user locked because of too many failed checks
+// as soon as the auto unlock duration of the lockout policy has passed
+func unlockUserAfterLockout(ctx context.Context, lockoutPolicyProvider lockoutPolicyViewProvider, userCommandProvider userCommandProvider, user *user_model.UserView) error {
+	policy, err := lockoutPolicyProvider.LockoutPolicyByOrg(ctx, user.ResourceOwner)
+	if err != nil {
+		return err
+	}
+	if policy.AutoUnlockDuration == 0 {
+		return nil
+	}
+	unlocked, err := userCommandProvider.UnlockUserAfterLockout(ctx, user.ID, user.ResourceOwner, lockoutPolicyToDomain(policy))
+	if err != nil {
+		return err
+	}
+	if unlocked {
+		user.State = user_model.UserStateActive
+	}
+	return nil
+}
+
 func userByID(ctx context.Context, viewProvider userViewProvider, eventProvider userEventProvider, userID string) (*user_model.UserView, error) {
 	user, viewErr := viewProvider.UserByID(userID, authz.GetInstance(ctx).InstanceID())
 	if viewErr != nil && !errors.IsNotFound(viewErr) {
@@ -1254,16 +1697,26 @@ func linkingIDPConfigExistingInAllowedIDPs(linkingUsers []*domain.ExternalUser,
 	return true
 }
 
-func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userGrantProvider userGrantProvider) (_ bool, err error) {
-	var project *query.Project
+type projectByClientProvider interface {
+	ProjectByOIDCClientID(context.Context, string) (*query.Project, error)
+	ProjectBySAMLEntityID(context.Context, string) (*query.Project, error)
+}
+
+func projectByRequest(ctx context.Context, request *domain.AuthRequest, provider projectByClientProvider) (*query.Project, error) {
 	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC:
-		project, err = userGrantProvider.ProjectByOIDCClientID(ctx, request.ApplicationID)
-		if err != nil {
-			return false, err
-		}
+	case domain.AuthRequestTypeOIDC, domain.AuthRequestTypeDevice:
+		return provider.ProjectByOIDCClientID(ctx, request.ApplicationID)
+	case domain.AuthRequestTypeSAML:
+		return provider.ProjectBySAMLEntityID(ctx, request.ApplicationID)
 	default:
-		return false, errors.ThrowPreconditionFailed(nil, "EVENT-dfrw2", "Errors.AuthRequest.RequestTypeNotSupported")
+		return nil, errors.ThrowPreconditionFailed(nil, "EVENT-dfrw2", "Errors.AuthRequest.RequestTypeNotSupported")
+	}
+}
+
+func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userGrantProvider userGrantProvider) (_ bool, err error) {
+	project, err := projectByRequest(ctx, request, userGrantProvider)
+	if err != nil {
+		return false, err
 	}
 	if !project.ProjectRoleCheck {
 		return false, nil
@@ -1275,16 +1728,26 @@ func userGrantRequired(ctx context.Context, request *domain.AuthRequest, user *u
 	return len(grants) == 0, nil
 }
 
+func userRolesOfRequest(ctx context.Context, request *domain.AuthRequest, user *user_model.UserView, userGrantProvider userGrantProvider) ([]string, error) {
+	project, err := projectByRequest(ctx, request, userGrantProvider)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := userGrantProvider.UserGrantsByProjectAndUserID(project.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0)
+	for _, grant := range grants {
+		roles = append(roles, grant.Roles...)
+	}
+	return roles, nil
+}
+
 func projectRequired(ctx context.Context, request *domain.AuthRequest, projectProvider projectProvider) (_ bool, err error) {
-	var project *query.Project
-	switch request.Request.Type() {
-	case domain.AuthRequestTypeOIDC:
-		project, err = projectProvider.ProjectByOIDCClientID(ctx, request.ApplicationID)
-		if err != nil {
-			return false, err
-		}
-	default:
-		return false, errors.ThrowPreconditionFailed(nil, "EVENT-dfrw2", "Errors.AuthRequest.RequestTypeNotSupported")
+	project, err := projectByRequest(ctx, request, projectProvider)
+	if err != nil {
+		return false, err
 	}
 	if !project.HasProjectCheck {
 		return false, nil
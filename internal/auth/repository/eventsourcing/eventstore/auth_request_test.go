@@ -2,10 +2,14 @@ package eventstore
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"net"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/auth/repository/eventsourcing/view"
@@ -56,6 +60,7 @@ type mockViewUserSession struct {
 	PasswordVerification      time.Time
 	SecondFactorVerification  time.Time
 	MultiFactorVerification   time.Time
+	MagicLinkVerification     time.Time
 	Users                     []mockUser
 }
 
@@ -72,6 +77,7 @@ func (m *mockViewUserSession) UserSessionByIDs(string, string, string) (*user_vi
 		PasswordVerification:      m.PasswordVerification,
 		SecondFactorVerification:  m.SecondFactorVerification,
 		MultiFactorVerification:   m.MultiFactorVerification,
+		MagicLinkVerification:     m.MagicLinkVerification,
 	}, nil
 }
 
@@ -132,6 +138,7 @@ type mockViewUser struct {
 	PasswordInitRequired     bool
 	PasswordSet              bool
 	PasswordChangeRequired   bool
+	PasswordChanged          time.Time
 	IsEmailVerified          bool
 	OTPState                 int32
 	MFAMaxSetUp              int32
@@ -156,6 +163,18 @@ func (m *mockLockoutPolicy) LockoutPolicyByOrg(context.Context, string) (*query.
 	return m.policy, nil
 }
 
+type mockUserCommand struct {
+	unlocked bool
+}
+
+func (m *mockUserCommand) BulkAddedUserIDPLinks(context.Context, string, string, []*domain.UserIDPLink) error {
+	return nil
+}
+
+func (m *mockUserCommand) UnlockUserAfterLockout(context.Context, string, string, *domain.LockoutPolicy) (bool, error) {
+	return m.unlocked, nil
+}
+
 func (m *mockViewUser) UserByID(string, string) (*user_view_model.UserView, error) {
 	return &user_view_model.UserView{
 		State:    int32(user_model.UserStateActive),
@@ -166,6 +185,7 @@ func (m *mockViewUser) UserByID(string, string) (*user_view_model.UserView, erro
 			PasswordInitRequired:     m.PasswordInitRequired,
 			PasswordSet:              m.PasswordSet,
 			PasswordChangeRequired:   m.PasswordChangeRequired,
+			PasswordChanged:          m.PasswordChanged,
 			IsEmailVerified:          m.IsEmailVerified,
 			OTPState:                 m.OTPState,
 			MFAMaxSetUp:              m.MFAMaxSetUp,
@@ -215,6 +235,10 @@ func (m *mockUserGrants) ProjectByOIDCClientID(ctx context.Context, s string) (*
 	return &query.Project{ProjectRoleCheck: m.roleCheck}, nil
 }
 
+func (m *mockUserGrants) ProjectBySAMLEntityID(ctx context.Context, s string) (*query.Project, error) {
+	return &query.Project{ProjectRoleCheck: m.roleCheck}, nil
+}
+
 func (m *mockUserGrants) UserGrantsByProjectAndUserID(s string, s2 string) ([]*query.UserGrant, error) {
 	var grants []*query.UserGrant
 	if m.userGrants > 0 {
@@ -232,6 +256,10 @@ func (m *mockProject) ProjectByOIDCClientID(ctx context.Context, s string) (*que
 	return &query.Project{HasProjectCheck: m.projectCheck}, nil
 }
 
+func (m *mockProject) ProjectBySAMLEntityID(ctx context.Context, s string) (*query.Project, error) {
+	return &query.Project{HasProjectCheck: m.projectCheck}, nil
+}
+
 func (m *mockProject) OrgProjectMappingByIDs(orgID, projectID, instanceID string) (*proj_view_model.OrgProjectMapping, error) {
 	if m.hasProject {
 		return &proj_view_model.OrgProjectMapping{OrgID: orgID, ProjectID: projectID}, nil
@@ -251,6 +279,7 @@ func (m *mockApp) AppByOIDCClientID(ctx context.Context, id string) (*query.App,
 }
 
 func TestAuthRequestRepo_nextSteps(t *testing.T) {
+	passwordChanged := time.Now().UTC().Add(-28 * 24 * time.Hour)
 	type fields struct {
 		AuthRequests            *cache.AuthRequestCache
 		View                    *view.View
@@ -263,6 +292,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 		applicationProvider     applicationProvider
 		loginPolicyProvider     loginPolicyViewProvider
 		lockoutPolicyProvider   lockoutPolicyViewProvider
+		userCommandProvider     userCommandProvider
 	}
 	type args struct {
 		request       *domain.AuthRequest
@@ -425,6 +455,26 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			nil,
 			errors.IsNotFound,
 		},
+		{
+			"user not found, ldap idp allowed, password step",
+			fields{
+				userSessionViewProvider: &mockViewNoUserSession{},
+				userViewProvider:        &mockViewNoUser{},
+				userEventProvider:       &mockEventUser{},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID:      "UserID",
+					LoginPolicy: &domain.LoginPolicy{AllowExternalIDP: true},
+					AllowedExternalIDPs: []*domain.IDPProvider{
+						{IDPConfigID: "IDPConfigID", IDPConfigType: domain.IDPConfigTypeLDAP},
+					},
+				},
+				false,
+			},
+			[]domain.NextStep{&domain.PasswordStep{}},
+			nil,
+		},
 		{
 			"user not active, precondition failed error",
 			fields{
@@ -468,6 +518,55 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			nil,
 			errors.IsPreconditionFailed,
 		},
+		{
+			"user locked, lockout not expired, precondition failed error",
+			fields{
+				userViewProvider: &mockViewUser{},
+				userEventProvider: &mockEventUser{
+					&es_models.Event{
+						AggregateType: user_repo.AggregateType,
+						Type:          es_models.EventType(user_repo.UserLockedType),
+					},
+				},
+				orgViewProvider: &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures:       true,
+						AutoUnlockDuration: time.Hour,
+					},
+				},
+				userCommandProvider: &mockUserCommand{unlocked: false},
+			},
+			args{&domain.AuthRequest{UserID: "UserID", LoginPolicy: &domain.LoginPolicy{}}, false},
+			nil,
+			errors.IsPreconditionFailed,
+		},
+		{
+			"user locked, lockout expired, password step",
+			fields{
+				userSessionViewProvider: &mockViewNoUserSession{},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+				},
+				userEventProvider: &mockEventUser{
+					&es_models.Event{
+						AggregateType: user_repo.AggregateType,
+						Type:          es_models.EventType(user_repo.UserLockedType),
+					},
+				},
+				orgViewProvider: &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures:       true,
+						AutoUnlockDuration: time.Hour,
+					},
+				},
+				userCommandProvider: &mockUserCommand{unlocked: true},
+			},
+			args{&domain.AuthRequest{UserID: "UserID", LoginPolicy: &domain.LoginPolicy{}}, false},
+			[]domain.NextStep{&domain.PasswordStep{}},
+			nil,
+		},
 		{
 			"org error, internal error",
 			fields{
@@ -878,6 +977,67 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			}},
 			nil,
 		},
+		{
+			"magic link verified, mfa not verified, mfa check step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					MagicLinkVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+					OTPState:    int32(user_model.MFAStateReady),
+					MFAMaxSetUp: int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						AllowMagicLink:            true,
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			}},
+			nil,
+		},
+		{
+			"magic link verified but not allowed, password check step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					MagicLinkVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						PasswordCheckLifetime: 10 * 24 * time.Hour,
+					},
+				}, false},
+			[]domain.NextStep{&domain.PasswordStep{}},
+			nil,
+		},
 		{
 			"external user, mfa not verified, mfa check step",
 			fields{
@@ -947,6 +1107,82 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.ChangePasswordStep{}},
 			nil,
 		},
+		{
+			"password expired, password change step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     time.Now().UTC().Add(-5 * time.Minute),
+					SecondFactorVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					PasswordChanged: time.Now().UTC().Add(-31 * 24 * time.Hour),
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+					PasswordAgePolicy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+				}, false},
+			[]domain.NextStep{&domain.ChangePasswordStep{Expired: true}},
+			nil,
+		},
+		{
+			"password expires soon, password expiry warning step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification:     time.Now().UTC().Add(-5 * time.Minute),
+					SecondFactorVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					PasswordChanged: passwordChanged,
+					IsEmailVerified: true,
+					MFAMaxSetUp:     int32(domain.MFALevelSecondFactor),
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{
+				&domain.AuthRequest{
+					UserID: "UserID",
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						PasswordCheckLifetime:     10 * 24 * time.Hour,
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+					PasswordAgePolicy: &domain.PasswordAgePolicy{
+						MaxAgeDays:     30,
+						ExpireWarnDays: 5,
+					},
+				}, false},
+			[]domain.NextStep{&domain.PasswordExpiryWarningStep{
+				Expiry: passwordChanged.AddDate(0, 0, 30),
+			}},
+			nil,
+		},
 		{
 			"email not verified and no password change required, mail verification step",
 			fields{
@@ -1269,6 +1505,127 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 			[]domain.NextStep{&domain.RedirectToCallbackStep{}},
 			nil,
 		},
+		{
+			"access denied by access policy, permission denied error",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{&domain.AuthRequest{
+				UserID:      "UserID",
+				BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP("10.0.0.1")},
+				LoginPolicy: &domain.LoginPolicy{},
+				AccessPolicy: &domain.AccessPolicy{
+					Rules: domain.AccessRules{{Action: domain.AccessRuleActionDeny, IPRanges: []string{"10.0.0.0/8"}}},
+				},
+			}, false},
+			nil,
+			errors.IsPermissionDenied,
+		},
+		{
+			"access policy requires passwordless, not set up, precondition failed error",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{},
+				userViewProvider: &mockViewUser{
+					PasswordSet: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{&domain.AuthRequest{
+				UserID:      "UserID",
+				LoginPolicy: &domain.LoginPolicy{},
+				AccessPolicy: &domain.AccessPolicy{
+					Rules: domain.AccessRules{{Action: domain.AccessRuleActionRequirePasswordless}},
+				},
+			}, false},
+			nil,
+			errors.IsPreconditionFailed,
+		},
+		{
+			"access policy requires passwordless, password verified, passwordless check step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:        true,
+					PasswordlessTokens: user_view_model.WebAuthNTokens{&user_view_model.WebAuthNView{ID: "id", State: int32(user_model.MFAStateReady)}},
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{&domain.AuthRequest{
+				UserID: "UserID",
+				LoginPolicy: &domain.LoginPolicy{
+					PasswordlessType:      domain.PasswordlessTypeNotAllowed,
+					PasswordCheckLifetime: 10 * 24 * time.Hour,
+				},
+				AccessPolicy: &domain.AccessPolicy{
+					Rules: domain.AccessRules{{Action: domain.AccessRuleActionRequirePasswordless}},
+				},
+			}, false},
+			[]domain.NextStep{&domain.PasswordlessStep{PasswordSet: false}},
+			nil,
+		},
+		{
+			"access policy requires mfa, mfa not set up, required mfa prompt step",
+			fields{
+				userSessionViewProvider: &mockViewUserSession{
+					PasswordVerification: time.Now().UTC().Add(-5 * time.Minute),
+				},
+				userViewProvider: &mockViewUser{
+					PasswordSet:     true,
+					IsEmailVerified: true,
+				},
+				userEventProvider: &mockEventUser{},
+				orgViewProvider:   &mockViewOrg{State: domain.OrgStateActive},
+				lockoutPolicyProvider: &mockLockoutPolicy{
+					policy: &query.LockoutPolicy{
+						ShowFailures: true,
+					},
+				},
+			},
+			args{&domain.AuthRequest{
+				UserID:      "UserID",
+				BrowserInfo: &domain.BrowserInfo{RemoteIP: net.ParseIP("172.16.0.1")},
+				LoginPolicy: &domain.LoginPolicy{
+					SecondFactors:         []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+					PasswordCheckLifetime: 10 * 24 * time.Hour,
+					MFAInitSkipLifetime:   30 * 24 * time.Hour,
+				},
+				AccessPolicy: &domain.AccessPolicy{
+					Rules: domain.AccessRules{
+						{Action: domain.AccessRuleActionAllow, IPRanges: []string{"10.0.0.0/8"}},
+						{Action: domain.AccessRuleActionRequireMFA},
+					},
+				},
+			}, false},
+			[]domain.NextStep{&domain.MFAPromptStep{
+				Required:     true,
+				MFAProviders: []domain.MFAType{domain.MFATypeOTP},
+			}},
+			nil,
+		},
 		{
 			"linking users, password step",
 			fields{
@@ -1352,6 +1709,7 @@ func TestAuthRequestRepo_nextSteps(t *testing.T) {
 				ApplicationProvider:       tt.fields.applicationProvider,
 				LoginPolicyViewProvider:   tt.fields.loginPolicyProvider,
 				LockoutPolicyViewProvider: tt.fields.lockoutPolicyProvider,
+				UserCommandProvider:       tt.fields.userCommandProvider,
 			}
 			got, err := repo.nextSteps(context.Background(), tt.args.request, tt.args.checkLoggedIn)
 			if (err != nil && tt.wantErr == nil) || (tt.wantErr != nil && !tt.wantErr(err)) {
@@ -1390,6 +1748,7 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		{
 			"not set up, forced by policy, no mfas configured, error",
 			args{
+				userSession: &user_model.UserSessionView{},
 				request: &domain.AuthRequest{
 					LoginPolicy: &domain.LoginPolicy{
 						ForceMFA:            true,
@@ -1409,6 +1768,7 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		{
 			"not set up, no mfas configured, no prompt and true",
 			args{
+				userSession: &user_model.UserSessionView{},
 				request: &domain.AuthRequest{
 					LoginPolicy: &domain.LoginPolicy{
 						MFAInitSkipLifetime: 30 * 24 * time.Hour,
@@ -1427,6 +1787,7 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		{
 			"not set up, prompt and false",
 			args{
+				userSession: &user_model.UserSessionView{},
 				request: &domain.AuthRequest{
 					LoginPolicy: &domain.LoginPolicy{
 						SecondFactors:       []domain.SecondFactorType{domain.SecondFactorTypeOTP},
@@ -1450,6 +1811,7 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		{
 			"not set up, forced by org, true",
 			args{
+				userSession: &user_model.UserSessionView{},
 				request: &domain.AuthRequest{
 					LoginPolicy: &domain.LoginPolicy{
 						ForceMFA:            true,
@@ -1475,6 +1837,7 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 		{
 			"not set up and skipped, true",
 			args{
+				userSession: &user_model.UserSessionView{},
 				request: &domain.AuthRequest{
 					LoginPolicy: &domain.LoginPolicy{
 						MFAInitSkipLifetime: 30 * 24 * time.Hour,
@@ -1536,11 +1899,113 @@ func TestAuthRequestRepo_mfaChecked(t *testing.T) {
 			false,
 			nil,
 		},
+		{
+			"not set up, mfa level requested, prompt required",
+			args{
+				userSession: &user_model.UserSessionView{},
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssuranceMFA},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:       []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						MFAInitSkipLifetime: 30 * 24 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp:    domain.MFALevelNotSetUp,
+						MFAInitSkipped: time.Now().UTC(),
+					},
+				},
+			},
+			&domain.MFAPromptStep{
+				Required: true,
+				MFAProviders: []domain.MFAType{
+					domain.MFATypeOTP,
+				},
+			},
+			false,
+			nil,
+		},
+		{
+			"passwordless checked, mfa level requested, true",
+			args{
+				userSession: &user_model.UserSessionView{PasswordlessVerification: time.Now().UTC().Add(-5 * time.Minute)},
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssuranceMFA},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:            []domain.SecondFactorType{domain.SecondFactorTypeOTP},
+						MultiFactorCheckLifetime: 12 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelNotSetUp,
+					},
+				},
+			},
+			nil,
+			true,
+			nil,
+		},
+		{
+			"otp checked, phishing resistant required by application, check u2f and false",
+			args{
+				request: &domain.AuthRequest{
+					MinLevelOfAssurance: domain.LevelOfAssurancePhishingResistant,
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP, domain.SecondFactorTypeU2F},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+						U2FTokens:   []*user_model.WebAuthNView{{TokenID: "id", State: user_model.MFAStateReady}},
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     time.Now().UTC().Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeOTP,
+				},
+			},
+			&domain.MFAVerificationStep{
+				MFAProviders: []domain.MFAType{domain.MFATypeU2F},
+			},
+			false,
+			nil,
+		},
+		{
+			"u2f checked, phishing resistant requested, true",
+			args{
+				request: &domain.AuthRequest{
+					PossibleLOAs: []domain.LevelOfAssurance{domain.LevelOfAssurancePhishingResistant},
+					LoginPolicy: &domain.LoginPolicy{
+						SecondFactors:             []domain.SecondFactorType{domain.SecondFactorTypeOTP, domain.SecondFactorTypeU2F},
+						SecondFactorCheckLifetime: 18 * time.Hour,
+					},
+				},
+				user: &user_model.UserView{
+					HumanView: &user_model.HumanView{
+						MFAMaxSetUp: domain.MFALevelSecondFactor,
+						OTPState:    user_model.MFAStateReady,
+						U2FTokens:   []*user_model.WebAuthNView{{TokenID: "id", State: user_model.MFAStateReady}},
+					},
+				},
+				userSession: &user_model.UserSessionView{
+					SecondFactorVerification:     time.Now().UTC().Add(-5 * time.Hour),
+					SecondFactorVerificationType: domain.MFATypeU2F,
+				},
+			},
+			nil,
+			true,
+			nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &AuthRequestRepo{}
-			got, ok, err := repo.mfaChecked(tt.args.userSession, tt.args.request, tt.args.user)
+			got, ok, err := repo.mfaChecked(tt.args.userSession, tt.args.request, tt.args.user, false)
 			if (tt.errFunc != nil && !tt.errFunc(err)) || (err != nil && tt.errFunc == nil) {
 				t.Errorf("got wrong err: %v ", err)
 				return
@@ -1895,3 +2360,118 @@ func Test_userByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRequestRepo_PollDeviceAuthRequest(t *testing.T) {
+	selectRequest := regexp.QuoteMeta("SELECT request, request_type FROM auth.auth_requests WHERE instance_id = $1 and device_code = $2")
+	deleteRequest := regexp.QuoteMeta("DELETE FROM auth.auth_requests WHERE instance_id = $1 and id = $2")
+	request := func(state domain.DeviceAuthState) []byte {
+		data, _ := json.Marshal(&domain.AuthRequest{
+			ID:            "id",
+			ApplicationID: "clientID",
+			Request: &domain.AuthRequestDevice{
+				DeviceCode: "deviceCode",
+				Expiration: time.Now().Add(time.Minute),
+				Interval:   5 * time.Second,
+				State:      state,
+			},
+		})
+		return data
+	}
+	type poll struct {
+		expect     func(sqlmock.Sqlmock)
+		clientID   string
+		wantResult domain.DeviceAuthPollResult
+		wantErr    func(error) bool
+	}
+	tests := []struct {
+		name  string
+		polls []poll
+	}{
+		{
+			"pending, updated",
+			[]poll{
+				{
+					expect: func(m sqlmock.Sqlmock) {
+						m.ExpectQuery(selectRequest).WillReturnRows(sqlmock.NewRows([]string{"request", "request_type"}).AddRow(request(domain.DeviceAuthStateInitiated), domain.AuthRequestTypeDevice))
+						m.ExpectExec(regexp.QuoteMeta("UPDATE auth.auth_requests")).WillReturnResult(sqlmock.NewResult(0, 1))
+					},
+					wantResult: domain.DeviceAuthPollResultPending,
+				},
+			},
+		},
+		{
+			"approved, consumed and second poll fails",
+			[]poll{
+				{
+					expect: func(m sqlmock.Sqlmock) {
+						m.ExpectQuery(selectRequest).WillReturnRows(sqlmock.NewRows([]string{"request", "request_type"}).AddRow(request(domain.DeviceAuthStateApproved), domain.AuthRequestTypeDevice))
+						m.ExpectExec(deleteRequest).WithArgs("", "id").WillReturnResult(sqlmock.NewResult(0, 1))
+					},
+					wantResult: domain.DeviceAuthPollResultApproved,
+				},
+				{
+					expect: func(m sqlmock.Sqlmock) {
+						m.ExpectQuery(selectRequest).WillReturnError(sql.ErrNoRows)
+					},
+					wantErr: errors.IsNotFound,
+				},
+			},
+		},
+		{
+			"approved, other client not found",
+			[]poll{
+				{
+					expect: func(m sqlmock.Sqlmock) {
+						m.ExpectQuery(selectRequest).WillReturnRows(sqlmock.NewRows([]string{"request", "request_type"}).AddRow(request(domain.DeviceAuthStateApproved), domain.AuthRequestTypeDevice))
+					},
+					clientID: "otherClientID",
+					wantErr:  errors.IsNotFound,
+				},
+			},
+		},
+		{
+			"approved, already consumed by concurrent poll",
+			[]poll{
+				{
+					expect: func(m sqlmock.Sqlmock) {
+						m.ExpectQuery(selectRequest).WillReturnRows(sqlmock.NewRows([]string{"request", "request_type"}).AddRow(request(domain.DeviceAuthStateApproved), domain.AuthRequestTypeDevice))
+						m.ExpectExec(deleteRequest).WithArgs("", "id").WillReturnResult(sqlmock.NewResult(0, 0))
+					},
+					wantErr: errors.IsNotFound,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer db.Close()
+			repo := &AuthRequestRepo{
+				AuthRequests: cache.Start(db),
+			}
+			for _, p := range tt.polls {
+				p.expect(mock)
+				clientID := p.clientID
+				if clientID == "" {
+					clientID = "clientID"
+				}
+				_, result, err := repo.PollDeviceAuthRequest(context.Background(), clientID, "deviceCode")
+				if p.wantErr == nil && err != nil {
+					t.Fatalf("PollDeviceAuthRequest() unexpected error = %v", err)
+				}
+				if p.wantErr != nil && !p.wantErr(err) {
+					t.Fatalf("PollDeviceAuthRequest() error = %v", err)
+				}
+				if p.wantErr == nil {
+					assert.Equal(t, p.wantResult, result)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
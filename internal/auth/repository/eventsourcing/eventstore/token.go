@@ -66,6 +66,21 @@ func (repo *TokenRepo) TokenByID(ctx context.Context, userID, tokenID string) (*
 	return model.TokenViewToModel(token), nil
 }
 
+// TokensByUserAgentID returns the active tokens issued to the user agent (browser)
+func (repo *TokenRepo) TokensByUserAgentID(ctx context.Context, agentID string) ([]*usr_model.TokenView, error) {
+	tokens, err := repo.View.TokensByUserAgentID(agentID, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*usr_model.TokenView, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Expiration.After(time.Now().UTC()) {
+			result = append(result, model.TokenViewToModel(token))
+		}
+	}
+	return result, nil
+}
+
 func (r *TokenRepo) getUserEvents(ctx context.Context, userID, instanceID string, sequence uint64) ([]*models.Event, error) {
 	query, err := usr_view.UserByIDQuery(userID, instanceID, sequence)
 	if err != nil {
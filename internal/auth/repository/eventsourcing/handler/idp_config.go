@@ -109,7 +109,11 @@ func (i *IDPConfig) processIdpConfig(providerType iam_model.IDPProviderType, eve
 		org.IDPOIDCConfigAddedEventType, instance.IDPOIDCConfigAddedEventType,
 		org.IDPOIDCConfigChangedEventType, instance.IDPOIDCConfigChangedEventType,
 		org.IDPJWTConfigAddedEventType, instance.IDPJWTConfigAddedEventType,
-		org.IDPJWTConfigChangedEventType, instance.IDPJWTConfigChangedEventType:
+		org.IDPJWTConfigChangedEventType, instance.IDPJWTConfigChangedEventType,
+		org.IDPSAMLConfigAddedEventType, instance.IDPSAMLConfigAddedEventType,
+		org.IDPSAMLConfigChangedEventType, instance.IDPSAMLConfigChangedEventType,
+		org.IDPLDAPConfigAddedEventType, instance.IDPLDAPConfigAddedEventType,
+		org.IDPLDAPConfigChangedEventType, instance.IDPLDAPConfigChangedEventType:
 		err = idp.SetData(event)
 		if err != nil {
 			return err
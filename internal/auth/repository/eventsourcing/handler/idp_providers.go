@@ -182,6 +182,10 @@ func (i *IDPProvider) fillConfigData(provider *iam_view_model.IDPProviderView, c
 		provider.IDPConfigType = int32(domain.IDPConfigTypeOIDC)
 	} else if config.JWTIDP != nil {
 		provider.IDPConfigType = int32(domain.IDPConfigTypeJWT)
+	} else if config.SAMLIDP != nil {
+		provider.IDPConfigType = int32(domain.IDPConfigTypeSAML)
+	} else if config.LDAPIDP != nil {
+		provider.IDPConfigType = int32(domain.IDPConfigTypeLDAP)
 	}
 	switch config.State {
 	case domain.IDPConfigStateActive:
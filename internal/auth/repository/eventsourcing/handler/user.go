@@ -151,6 +151,13 @@ func (u *User) ProcessUser(event *es_models.Event) (err error) {
 		user_repo.HumanMFAOTPAddedType,
 		user_repo.HumanMFAOTPVerifiedType,
 		user_repo.HumanMFAOTPRemovedType,
+		user_repo.HumanMFAOTPSMSAddedType,
+		user_repo.HumanMFAOTPSMSRemovedType,
+		user_repo.HumanMFAOTPEmailAddedType,
+		user_repo.HumanMFAOTPEmailRemovedType,
+		user_repo.HumanMFARecoveryCodesAddedType,
+		user_repo.HumanMFARecoveryCodeCheckSucceededType,
+		user_repo.HumanMFARecoveryCodesRemovedType,
 		user_repo.HumanU2FTokenAddedType,
 		user_repo.HumanU2FTokenVerifiedType,
 		user_repo.HumanU2FTokenRemovedType,
@@ -103,10 +103,18 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.UserIDPLoginCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckFailedType,
+		user.HumanMFAOTPSMSCheckSucceededType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPEmailCheckSucceededType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFARecoveryCodeCheckSucceededType,
+		user.HumanMFARecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckSucceededType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanPasswordlessTokenCheckSucceededType,
 		user.HumanPasswordlessTokenCheckFailedType,
+		user.HumanMagicLinkCheckSucceededType,
+		user.HumanMagicLinkCheckFailedType,
 		user.HumanSignedOutType:
 		eventData, err := view_model.UserSessionFromEvent(event)
 		if err != nil {
@@ -134,6 +142,8 @@ func (u *UserSession) Reduce(event *models.Event) (err error) {
 		user.UserDeactivatedType,
 		user.HumanPasswordChangedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailRemovedType,
 		user.HumanProfileChangedType,
 		user.HumanAvatarAddedType,
 		user.HumanAvatarRemovedType,
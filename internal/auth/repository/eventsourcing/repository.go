@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	v1 "github.com/zitadel/zitadel/internal/eventstore/v1"
 	es_spol "github.com/zitadel/zitadel/internal/eventstore/v1/spooler"
+	"github.com/zitadel/zitadel/internal/geoip"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -20,6 +21,7 @@ import (
 type Config struct {
 	SearchLimit uint64
 	Spooler     spooler.SpoolerConfig
+	GeoIP       geoip.Config
 }
 
 type EsRepository struct {
@@ -47,6 +49,11 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Comma
 
 	authReq := cache.Start(dbClient)
 
+	countries, err := geoip.Start(conf.GeoIP)
+	if err != nil {
+		return nil, err
+	}
+
 	spool := spooler.StartSpooler(conf.Spooler, es, view, dbClient, systemDefaults, queries)
 
 	userRepo := eventstore.UserRepo{
@@ -82,6 +89,9 @@ func Start(conf Config, systemDefaults sd.SystemDefaults, command *command.Comma
 			UserEventProvider:         &userRepo,
 			IDPProviderViewProvider:   view,
 			LockoutPolicyViewProvider: queries,
+			PasswordAgePolicyProvider: queries,
+			AccessPolicyProvider:      queries,
+			CountryProvider:           countries,
 			LoginPolicyViewProvider:   queries,
 			UserGrantProvider:         queryView,
 			ProjectProvider:           queryView,
@@ -20,6 +20,10 @@ func (v *View) TokensByUserID(userID, instanceID string) ([]*model.TokenView, er
 	return usr_view.TokensByUserID(v.Db, tokenTable, userID, instanceID)
 }
 
+func (v *View) TokensByUserAgentID(agentID, instanceID string) ([]*model.TokenView, error) {
+	return usr_view.TokensByUserAgentID(v.Db, tokenTable, agentID, instanceID)
+}
+
 func (v *View) PutToken(token *model.TokenView, event *models.Event) error {
 	err := usr_view.PutToken(v.Db, tokenTable, token)
 	if err != nil {
@@ -9,4 +9,5 @@ import (
 type TokenRepository interface {
 	IsTokenValid(ctx context.Context, userID, tokenID string) (bool, error)
 	TokenByID(ctx context.Context, userID, tokenID string) (*usr_model.TokenView, error)
+	TokensByUserAgentID(ctx context.Context, agentID string) ([]*usr_model.TokenView, error)
 }
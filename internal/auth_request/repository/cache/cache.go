@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
@@ -35,8 +37,20 @@ func (c *AuthRequestCache) GetAuthRequestByCode(ctx context.Context, code string
 	return c.getAuthRequest("code", code, authz.GetInstance(ctx).InstanceID())
 }
 
+func (c *AuthRequestCache) GetAuthRequestByDeviceCode(ctx context.Context, deviceCode string) (*domain.AuthRequest, error) {
+	return c.getAuthRequest("device_code", deviceCode, authz.GetInstance(ctx).InstanceID())
+}
+
+func (c *AuthRequestCache) GetAuthRequestByUserCode(ctx context.Context, userCode string) (*domain.AuthRequest, error) {
+	return c.getAuthRequest("user_code", userCode, authz.GetInstance(ctx).InstanceID())
+}
+
 func (c *AuthRequestCache) SaveAuthRequest(_ context.Context, request *domain.AuthRequest) error {
-	return c.saveAuthRequest(request, "INSERT INTO auth.auth_requests (id, request, instance_id, creation_date, change_date, request_type) VALUES($1, $2, $3, $4, $4, $5)", request.CreationDate, request.Request.Type())
+	var deviceCode, userCode string
+	if device, ok := request.Request.(*domain.AuthRequestDevice); ok {
+		deviceCode, userCode = device.DeviceCode, device.UserCode
+	}
+	return c.saveAuthRequest(request, "INSERT INTO auth.auth_requests (id, request, instance_id, creation_date, change_date, request_type, device_code, user_code) VALUES($1, $2, $3, $4, $4, $5, NULLIF($6, ''), NULLIF($7, ''))", request.CreationDate, request.Request.Type(), deviceCode, userCode)
 }
 
 func (c *AuthRequestCache) UpdateAuthRequest(_ context.Context, request *domain.AuthRequest) error {
@@ -54,6 +68,85 @@ func (c *AuthRequestCache) DeleteAuthRequest(ctx context.Context, id string) err
 	return nil
 }
 
+// ConsumeAuthRequest deletes the auth request and fails if it was already deleted,
+// so a request (e.g. an approved device authorization) can only be used once
+func (c *AuthRequestCache) ConsumeAuthRequest(ctx context.Context, id string) error {
+	result, err := c.client.ExecContext(ctx, "DELETE FROM auth.auth_requests WHERE instance_id = $1 and id = $2", authz.GetInstance(ctx).InstanceID(), id)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Zoo7e", "unable to delete auth request")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-ieP0a", "Errors.Internal")
+	}
+	if rows == 0 {
+		return caos_errs.ThrowNotFound(nil, "CACHE-Aib2o", "Errors.AuthRequest.NotFound")
+	}
+	return nil
+}
+
+// SavePushedAuthRequest stores the pushed authorization request
+// and removes the expired ones of the instance
+func (c *AuthRequestCache) SavePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) error {
+	_, err := c.client.ExecContext(ctx, "DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 and expiration < $2", request.InstanceID, request.CreationDate)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Eeg4a", "unable to delete expired pushed auth requests")
+	}
+	parameters, err := json.Marshal(request.Parameters)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-ahX8i", "Errors.Internal")
+	}
+	_, err = c.client.ExecContext(ctx, "INSERT INTO auth.pushed_auth_requests (id, instance_id, client_id, parameters, creation_date, expiration) VALUES($1, $2, $3, $4, $5, $6)",
+		request.ID, request.InstanceID, request.ClientID, parameters, request.CreationDate, request.Expiration)
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-Ohd0e", "Errors.Internal")
+	}
+	return nil
+}
+
+// GetAndDeletePushedAuthRequest returns the pushed authorization request and deletes it at the same time,
+// so the request_uri can only be used once
+func (c *AuthRequestCache) GetAndDeletePushedAuthRequest(ctx context.Context, id string) (*domain.PushedAuthRequest, error) {
+	request := &domain.PushedAuthRequest{
+		ID:         id,
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+	}
+	var parameters []byte
+	err := c.client.QueryRowContext(ctx, "DELETE FROM auth.pushed_auth_requests WHERE instance_id = $1 and id = $2 RETURNING client_id, parameters, creation_date, expiration", request.InstanceID, id).
+		Scan(&request.ClientID, &parameters, &request.CreationDate, &request.Expiration)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, caos_errs.ThrowNotFound(err, "CACHE-Phu6u", "Errors.AuthRequest.NotFound")
+		}
+		return nil, caos_errs.ThrowInternal(err, "CACHE-ez3Ae", "Errors.Internal")
+	}
+	if err = json.Unmarshal(parameters, &request.Parameters); err != nil {
+		return nil, caos_errs.ThrowInternal(err, "CACHE-Hah9o", "Errors.Internal")
+	}
+	return request, nil
+}
+
+// UseSAMLAssertion records the id of an assertion of the identity provider until it expires
+// and removes the expired ones of the instance,
+// an already used assertion (replay) results in an already exists error
+func (c *AuthRequestCache) UseSAMLAssertion(ctx context.Context, issuer, id string, expiration time.Time) error {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	_, err := c.client.ExecContext(ctx, "DELETE FROM auth.saml_assertions WHERE instance_id = $1 and expiration < $2", instanceID, time.Now())
+	if err != nil {
+		return caos_errs.ThrowInternal(err, "CACHE-ooT4a", "unable to delete expired saml assertions")
+	}
+	_, err = c.client.ExecContext(ctx, "INSERT INTO auth.saml_assertions (instance_id, issuer, id, expiration) VALUES($1, $2, $3, $4)",
+		instanceID, issuer, id, expiration)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return caos_errs.ThrowAlreadyExists(err, "CACHE-Eesh7", "Errors.IdentityProvider.SAMLResponseInvalid")
+		}
+		return caos_errs.ThrowInternal(err, "CACHE-Dae5u", "Errors.Internal")
+	}
+	return nil
+}
+
 func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domain.AuthRequest, error) {
 	var b []byte
 	var requestType domain.AuthRequestType
@@ -75,12 +168,12 @@ func (c *AuthRequestCache) getAuthRequest(key, value, instanceID string) (*domai
 	return request, nil
 }
 
-func (c *AuthRequestCache) saveAuthRequest(request *domain.AuthRequest, query string, date time.Time, param interface{}) error {
+func (c *AuthRequestCache) saveAuthRequest(request *domain.AuthRequest, query string, date time.Time, params ...interface{}) error {
 	b, err := json.Marshal(request)
 	if err != nil {
 		return caos_errs.ThrowInternal(err, "CACHE-os0GH", "Errors.Internal")
 	}
-	_, err = c.client.Exec(query, request.ID, b, request.InstanceID, date, param)
+	_, err = c.client.Exec(query, append([]interface{}{request.ID, b, request.InstanceID, date}, params...)...)
 	if err != nil {
 		return caos_errs.ThrowInternal(err, "CACHE-su3GK", "Errors.Internal")
 	}
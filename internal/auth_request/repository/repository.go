@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 )
@@ -11,7 +12,15 @@ type AuthRequestCache interface {
 
 	GetAuthRequestByID(ctx context.Context, id string) (*domain.AuthRequest, error)
 	GetAuthRequestByCode(ctx context.Context, code string) (*domain.AuthRequest, error)
+	GetAuthRequestByDeviceCode(ctx context.Context, deviceCode string) (*domain.AuthRequest, error)
+	GetAuthRequestByUserCode(ctx context.Context, userCode string) (*domain.AuthRequest, error)
 	SaveAuthRequest(ctx context.Context, request *domain.AuthRequest) error
 	UpdateAuthRequest(ctx context.Context, request *domain.AuthRequest) error
 	DeleteAuthRequest(ctx context.Context, id string) error
+	ConsumeAuthRequest(ctx context.Context, id string) error
+
+	SavePushedAuthRequest(ctx context.Context, request *domain.PushedAuthRequest) error
+	GetAndDeletePushedAuthRequest(ctx context.Context, id string) (*domain.PushedAuthRequest, error)
+
+	UseSAMLAssertion(ctx context.Context, issuer, id string, expiration time.Time) error
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/authz/repository"
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
 	authz_view "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
@@ -16,6 +17,7 @@ import (
 type EsRepository struct {
 	eventstore.UserMembershipRepo
 	eventstore.TokenVerifierRepo
+	*authz.DPoPProofReplayCache
 }
 
 func Start(queries *query.Queries, dbClient *sql.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm) (repository.Repository, error) {
@@ -40,6 +42,7 @@ func Start(queries *query.Queries, dbClient *sql.DB, keyEncryptionAlgorithm cryp
 			View:                 view,
 			Query:                queries,
 		},
+		authz.NewDPoPProofReplayCache(dbClient),
 	}, nil
 }
 
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
 )
 
 type TokenVerifierRepository interface {
-	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error)
+	VerifyAccessToken(ctx context.Context, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, binding *authz.TokenBinding, err error)
 	ProjectIDAndOriginsByClientID(ctx context.Context, clientID string) (projectID string, origins []string, err error)
 	VerifierClientID(ctx context.Context, appName string) (clientID, projectID string, err error)
+	UseDPoPProof(ctx context.Context, proof *authz.DPoPProof) error
 }
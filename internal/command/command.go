@@ -36,11 +36,13 @@ type Commands struct {
 	smsEncryption               crypto.EncryptionAlgorithm
 	userEncryption              crypto.EncryptionAlgorithm
 	userPasswordAlg             crypto.HashAlgorithm
+	breachedPasswords           crypto.BreachedPasswords
 	machineKeySize              int
 	applicationKeySize          int
 	domainVerificationAlg       crypto.EncryptionAlgorithm
 	domainVerificationGenerator crypto.Generator
 	domainVerificationValidator func(domain, token, verifier string, checkType http.CheckType) error
+	clientRegistrationToken     func(ids ...string) (token, hash string, err error)
 
 	multifactors       domain.MultifactorConfigs
 	webauthnConfig     *webauthn_helper.Config
@@ -97,7 +99,14 @@ func StartCommands(es *eventstore.Eventstore,
 	keypair.RegisterEventMappers(repo.eventstore)
 	action.RegisterEventMappers(repo.eventstore)
 
-	repo.userPasswordAlg = crypto.NewBCrypt(defaults.SecretGenerators.PasswordSaltCost)
+	repo.userPasswordAlg, err = defaults.PasswordHasher.NewHasher(defaults.SecretGenerators.PasswordSaltCost)
+	if err != nil {
+		return nil, err
+	}
+	repo.breachedPasswords, err = defaults.BreachedPasswords.NewBreachedPasswords()
+	if err != nil {
+		return nil, err
+	}
 	repo.machineKeySize = int(defaults.SecretGenerators.MachineKeySize)
 	repo.applicationKeySize = int(defaults.SecretGenerators.ApplicationKeySize)
 
@@ -110,6 +119,7 @@ func StartCommands(es *eventstore.Eventstore,
 
 	repo.domainVerificationGenerator = crypto.NewEncryptionGenerator(defaults.DomainVerification.VerificationGenerator, repo.domainVerificationAlg)
 	repo.domainVerificationValidator = http.ValidateDomain
+	repo.clientRegistrationToken = domain.NewClientRegistrationToken
 	return repo, nil
 }
 
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+// SetActionsHTTPAllowList replaces the hosts actions are allowed to send HTTP requests to
+func (c *Commands) SetActionsHTTPAllowList(ctx context.Context, hosts []string) (*domain.ObjectDetails, error) {
+	hosts, err := normalizeActionsHTTPHosts(hosts)
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.getActionsHTTPAllowList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(writeModel.Hosts) == 0 && len(hosts) == 0 || reflect.DeepEqual(writeModel.Hosts, hosts) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Eix9a", "Errors.NoChangesFound")
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, instance.NewActionsHTTPAllowListSetEvent(ctx, instanceAgg, hosts))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) getActionsHTTPAllowList(ctx context.Context) (_ *InstanceActionsHTTPAllowListWriteModel, err error) {
+	writeModel := NewInstanceActionsHTTPAllowListWriteModel(ctx)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+// normalizeActionsHTTPHosts lowercases and deduplicates the hosts
+// and ensures they only consist of a hostname and an optional port
+func normalizeActionsHTTPHosts(hosts []string) ([]string, error) {
+	normalized := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		u, err := url.Parse("//" + host)
+		if err != nil || host == "" || u.Host != host || u.Hostname() == "" || u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return nil, caos_errs.ThrowInvalidArgument(err, "COMMAND-Ohc3i", "Errors.Action.HTTPHostInvalid")
+		}
+		if !listContainsID(normalized, host) {
+			normalized = append(normalized, host)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,44 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceActionsHTTPAllowListWriteModel struct {
+	eventstore.WriteModel
+
+	Hosts []string
+}
+
+func NewInstanceActionsHTTPAllowListWriteModel(ctx context.Context) *InstanceActionsHTTPAllowListWriteModel {
+	return &InstanceActionsHTTPAllowListWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   authz.GetInstance(ctx).InstanceID(),
+			ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+		},
+	}
+}
+
+func (wm *InstanceActionsHTTPAllowListWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.ActionsHTTPAllowListSetEvent:
+			wm.Hosts = e.Hosts
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceActionsHTTPAllowListWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(instance.ActionsHTTPAllowListSetEventType).
+		Builder()
+}
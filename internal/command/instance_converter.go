@@ -32,6 +32,7 @@ func writeModelToLoginPolicy(wm *LoginPolicyWriteModel) *domain.LoginPolicy {
 		AllowExternalIDP:           wm.AllowExternalIDP,
 		HidePasswordReset:          wm.HidePasswordReset,
 		IgnoreUnknownUsernames:     wm.IgnoreUnknownUsernames,
+		AllowMagicLink:             wm.AllowMagicLink,
 		ForceMFA:                   wm.ForceMFA,
 		PasswordlessType:           wm.PasswordlessType,
 		DefaultRedirectURI:         wm.DefaultRedirectURI,
@@ -103,12 +104,14 @@ func writeModelToPasswordAgePolicy(wm *PasswordAgePolicyWriteModel) *domain.Pass
 
 func writeModelToPasswordComplexityPolicy(wm *PasswordComplexityPolicyWriteModel) *domain.PasswordComplexityPolicy {
 	return &domain.PasswordComplexityPolicy{
-		ObjectRoot:   writeModelToObjectRoot(wm.WriteModel),
-		MinLength:    wm.MinLength,
-		HasLowercase: wm.HasLowercase,
-		HasUppercase: wm.HasUppercase,
-		HasNumber:    wm.HasNumber,
-		HasSymbol:    wm.HasSymbol,
+		ObjectRoot:    writeModelToObjectRoot(wm.WriteModel),
+		MinLength:     wm.MinLength,
+		HasLowercase:  wm.HasLowercase,
+		HasUppercase:  wm.HasUppercase,
+		HasNumber:     wm.HasNumber,
+		HasSymbol:     wm.HasSymbol,
+		HistoryDepth:  wm.HistoryDepth,
+		CheckBreached: wm.CheckBreached,
 	}
 }
 
@@ -116,7 +119,17 @@ func writeModelToLockoutPolicy(wm *LockoutPolicyWriteModel) *domain.LockoutPolic
 	return &domain.LockoutPolicy{
 		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
 		MaxPasswordAttempts: wm.MaxPasswordAttempts,
+		MaxOTPAttempts:      wm.MaxOTPAttempts,
+		MaxU2FAttempts:      wm.MaxU2FAttempts,
 		ShowLockOutFailures: wm.ShowLockOutFailures,
+		AutoUnlockDuration:  wm.AutoUnlockDuration,
+	}
+}
+
+func writeModelToAccessPolicy(wm *AccessPolicyWriteModel) *domain.AccessPolicy {
+	return &domain.AccessPolicy{
+		ObjectRoot: writeModelToObjectRoot(wm.WriteModel),
+		Rules:      wm.Rules,
 	}
 }
 
@@ -165,6 +178,31 @@ func writeModelToIDPJWTConfig(wm *JWTConfigWriteModel) *domain.JWTIDPConfig {
 	}
 }
 
+func writeModelToIDPSAMLConfig(wm *SAMLConfigWriteModel) *domain.SAMLIDPConfig {
+	return &domain.SAMLIDPConfig{
+		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
+		IDPConfigID: wm.IDPConfigID,
+		EntityID:    wm.EntityID,
+		Metadata:    wm.Metadata,
+		MetadataURL: wm.MetadataURL,
+	}
+}
+
+func writeModelToIDPLDAPConfig(wm *LDAPConfigWriteModel) *domain.LDAPIDPConfig {
+	return &domain.LDAPIDPConfig{
+		ObjectRoot:      writeModelToObjectRoot(wm.WriteModel),
+		IDPConfigID:     wm.IDPConfigID,
+		URL:             wm.URL,
+		StartTLS:        wm.StartTLS,
+		RootCA:          wm.RootCA,
+		BaseDN:          wm.BaseDN,
+		BindDN:          wm.BindDN,
+		UserObjectClass: wm.UserObjectClass,
+		UserFilters:     wm.UserFilters,
+		Attributes:      wm.Attributes,
+	}
+}
+
 func writeModelToIDPProvider(wm *IdentityProviderWriteModel) *domain.IDPProvider {
 	return &domain.IDPProvider{
 		ObjectRoot:  writeModelToObjectRoot(wm.WriteModel),
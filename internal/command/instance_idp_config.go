@@ -15,7 +15,7 @@ import (
 )
 
 func (c *Commands) AddDefaultIDPConfig(ctx context.Context, config *domain.IDPConfig) (*domain.IDPConfig, error) {
-	if config.OIDCConfig == nil && config.JWTConfig == nil {
+	if config.OIDCConfig == nil && config.JWTConfig == nil && config.SAMLConfig == nil && config.LDAPConfig == nil {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "IDP-s8nn3", "Errors.IDPConfig.Invalid")
 	}
 	idpConfigID, err := c.idGenerator.Next()
@@ -65,6 +65,44 @@ func (c *Commands) AddDefaultIDPConfig(ctx context.Context, config *domain.IDPCo
 			config.JWTConfig.KeysEndpoint,
 			config.JWTConfig.HeaderName,
 		))
+	} else if config.SAMLConfig != nil {
+		entityID, metadata, err := samlIDPMetadata(ctx, config.SAMLConfig)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, instance.NewIDPSAMLConfigAddedEvent(
+			ctx,
+			instanceAgg,
+			idpConfigID,
+			entityID,
+			metadata,
+			config.SAMLConfig.MetadataURL,
+		))
+	} else if config.LDAPConfig != nil {
+		if !config.LDAPConfig.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "IDP-Hs2fq", "Errors.IDPConfig.LDAPInvalid")
+		}
+		var bindPassword *crypto.CryptoValue
+		if config.LDAPConfig.BindPasswordString != "" {
+			bindPassword, err = crypto.Encrypt([]byte(config.LDAPConfig.BindPasswordString), c.idpConfigEncryption)
+			if err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, instance.NewIDPLDAPConfigAddedEvent(
+			ctx,
+			instanceAgg,
+			idpConfigID,
+			config.LDAPConfig.URL,
+			config.LDAPConfig.StartTLS,
+			config.LDAPConfig.RootCA,
+			config.LDAPConfig.BaseDN,
+			config.LDAPConfig.BindDN,
+			bindPassword,
+			config.LDAPConfig.UserObjectClass,
+			config.LDAPConfig.UserFilters,
+			config.LDAPConfig.Attributes,
+		))
 	}
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
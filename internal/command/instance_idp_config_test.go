@@ -195,6 +195,178 @@ func TestCommandSide_AddDefaultIDPConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "idp config saml invalid metadata, error",
+			fields: fields{
+				eventstore:  eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "config1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				config: &domain.IDPConfig{
+					Name: "name1",
+					SAMLConfig: &domain.SAMLIDPConfig{
+						Metadata: []byte(testSAMLMetadata),
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp config saml add, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewIDPConfigAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"config1",
+									"name1",
+									domain.IDPConfigTypeSAML,
+									domain.IDPConfigStylingTypeUnspecified,
+									false,
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewIDPSAMLConfigAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"config1",
+									"https://idp.example.com/metadata",
+									[]byte(testSAMLIDPMetadata),
+									"",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", idpconfig.NewAddIDPConfigNameUniqueConstraint("name1", "INSTANCE")),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "config1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				config: &domain.IDPConfig{
+					Name: "name1",
+					Type: domain.IDPConfigTypeSAML,
+					SAMLConfig: &domain.SAMLIDPConfig{
+						Metadata: []byte(testSAMLIDPMetadata),
+					},
+				},
+			},
+			res: res{
+				want: &domain.IDPConfig{
+					ObjectRoot: models.ObjectRoot{
+						InstanceID:    "INSTANCE",
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+					},
+					IDPConfigID: "config1",
+					Name:        "name1",
+					State:       domain.IDPConfigStateActive,
+				},
+			},
+		},
+		{
+			name: "idp config ldap invalid, error",
+			fields: fields{
+				eventstore:  eventstoreExpect(t),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "config1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				config: &domain.IDPConfig{
+					Name: "name1",
+					LDAPConfig: &domain.LDAPIDPConfig{
+						URL: "ldaps://ad.example.com",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp config ldap add, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewIDPConfigAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"config1",
+									"name1",
+									domain.IDPConfigTypeLDAP,
+									domain.IDPConfigStylingTypeUnspecified,
+									true,
+								),
+							),
+							eventFromEventPusherWithInstanceID(
+								"INSTANCE",
+								instance.NewIDPLDAPConfigAddedEvent(context.Background(),
+									&instance.NewAggregate("INSTANCE").Aggregate,
+									"config1",
+									"ldaps://ad.example.com",
+									false,
+									"",
+									"dc=example,dc=com",
+									"cn=service,dc=example,dc=com",
+									&crypto.CryptoValue{
+										CryptoType: crypto.TypeEncryption,
+										Algorithm:  "enc",
+										KeyID:      "id",
+										Crypted:    []byte("secret"),
+									},
+									"user",
+									[]string{"sAMAccountName", "userPrincipalName"},
+									testLDAPAttributes(),
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraintWithInstanceID("INSTANCE", idpconfig.NewAddIDPConfigNameUniqueConstraint("name1", "INSTANCE")),
+					),
+				),
+				idGenerator:  id_mock.NewIDGeneratorExpectIDs(t, "config1"),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				config: &domain.IDPConfig{
+					Name:         "name1",
+					Type:         domain.IDPConfigTypeLDAP,
+					AutoRegister: true,
+					LDAPConfig: &domain.LDAPIDPConfig{
+						URL:                "ldaps://ad.example.com",
+						BaseDN:             "dc=example,dc=com",
+						BindDN:             "cn=service,dc=example,dc=com",
+						BindPasswordString: "secret",
+						UserObjectClass:    "user",
+						UserFilters:        []string{"sAMAccountName", "userPrincipalName"},
+						Attributes:         testLDAPAttributes(),
+					},
+				},
+			},
+			res: res{
+				want: &domain.IDPConfig{
+					ObjectRoot: models.ObjectRoot{
+						InstanceID:    "INSTANCE",
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+					},
+					IDPConfigID:  "config1",
+					Name:         "name1",
+					AutoRegister: true,
+					State:        domain.IDPConfigStateActive,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
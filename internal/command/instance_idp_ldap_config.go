@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func (c *Commands) ChangeDefaultIDPLDAPConfig(ctx context.Context, config *domain.LDAPIDPConfig) (*domain.LDAPIDPConfig, error) {
+	if config.IDPConfigID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Lw2fs", "Errors.IDMissing")
+	}
+	if !config.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Hd3fq", "Errors.IDPConfig.LDAPInvalid")
+	}
+	existingConfig := NewInstanceIDPLDAPConfigWriteModel(ctx, config.IDPConfigID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingConfig.State == domain.IDPConfigStateRemoved || existingConfig.State == domain.IDPConfigStateUnspecified {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Qs2gd", "Errors.IDPConfig.NotExisting")
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingConfig.WriteModel)
+	changedEvent, hasChanged, err := existingConfig.NewChangedEvent(
+		ctx,
+		instanceAgg,
+		config,
+		c.idpConfigEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Fw3gq", "Errors.IAM.IDPConfig.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingConfig, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToIDPLDAPConfig(&existingConfig.LDAPConfigWriteModel), nil
+}
@@ -0,0 +1,104 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+type InstanceIDPLDAPConfigWriteModel struct {
+	LDAPConfigWriteModel
+}
+
+func NewInstanceIDPLDAPConfigWriteModel(ctx context.Context, idpConfigID string) *InstanceIDPLDAPConfigWriteModel {
+	return &InstanceIDPLDAPConfigWriteModel{
+		LDAPConfigWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+			IDPConfigID: idpConfigID,
+		},
+	}
+}
+
+func (wm *InstanceIDPLDAPConfigWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPConfigAddedEvent:
+			if wm.IDPConfigID != e.IDPConfigID {
+				continue
+			}
+			wm.LDAPConfigWriteModel.AppendEvents(&e.LDAPConfigAddedEvent)
+		case *instance.IDPLDAPConfigChangedEvent:
+			if wm.IDPConfigID != e.IDPConfigID {
+				continue
+			}
+			wm.LDAPConfigWriteModel.AppendEvents(&e.LDAPConfigChangedEvent)
+		case *instance.IDPConfigReactivatedEvent:
+			if wm.IDPConfigID != e.ConfigID {
+				continue
+			}
+			wm.LDAPConfigWriteModel.AppendEvents(&e.IDPConfigReactivatedEvent)
+		case *instance.IDPConfigDeactivatedEvent:
+			if wm.IDPConfigID != e.ConfigID {
+				continue
+			}
+			wm.LDAPConfigWriteModel.AppendEvents(&e.IDPConfigDeactivatedEvent)
+		case *instance.IDPConfigRemovedEvent:
+			if wm.IDPConfigID != e.ConfigID {
+				continue
+			}
+			wm.LDAPConfigWriteModel.AppendEvents(&e.IDPConfigRemovedEvent)
+		default:
+			wm.LDAPConfigWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceIDPLDAPConfigWriteModel) Reduce() error {
+	if err := wm.LDAPConfigWriteModel.Reduce(); err != nil {
+		return err
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InstanceIDPLDAPConfigWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.IDPLDAPConfigAddedEventType,
+			instance.IDPLDAPConfigChangedEventType,
+			instance.IDPConfigReactivatedEventType,
+			instance.IDPConfigDeactivatedEventType,
+			instance.IDPConfigRemovedEventType).
+		Builder()
+}
+
+func (wm *InstanceIDPLDAPConfigWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	config *domain.LDAPIDPConfig,
+	secretCrypto crypto.Crypto,
+) (*instance.IDPLDAPConfigChangedEvent, bool, error) {
+	changes, err := wm.ldapConfigChanges(config, secretCrypto)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := instance.NewIDPLDAPConfigChangedEvent(ctx, aggregate, config.IDPConfigID, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
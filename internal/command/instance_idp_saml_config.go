@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func (c *Commands) ChangeDefaultIDPSAMLConfig(ctx context.Context, config *domain.SAMLIDPConfig) (*domain.SAMLIDPConfig, error) {
+	if config.IDPConfigID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Gh2qd", "Errors.IDMissing")
+	}
+	existingConfig := NewInstanceIDPSAMLConfigWriteModel(ctx, config.IDPConfigID)
+	err := c.eventstore.FilterToQueryReducer(ctx, existingConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingConfig.State == domain.IDPConfigStateRemoved || existingConfig.State == domain.IDPConfigStateUnspecified {
+		return nil, caos_errs.ThrowNotFound(nil, "INSTANCE-Jd2gw", "Errors.IDPConfig.NotExisting")
+	}
+	entityID, metadata, err := samlIDPMetadata(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceAgg := InstanceAggregateFromWriteModel(&existingConfig.WriteModel)
+	changedEvent, hasChanged, err := existingConfig.NewChangedEvent(
+		ctx,
+		instanceAgg,
+		config.IDPConfigID,
+		entityID,
+		metadata,
+		config.MetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Vz3nq", "Errors.IAM.IDPConfig.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingConfig, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToIDPSAMLConfig(&existingConfig.SAMLConfigWriteModel), nil
+}
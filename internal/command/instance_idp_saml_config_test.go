@@ -0,0 +1,265 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	testSAMLIDPCertificate = "MIICEjCCAXugAwIBAgIUKgHIzWi5S7dzLID+9XgQvY8NkBkwDQYJKoZIhvcNAQELBQAwGjEYMBYGA1UEAwwPaWRwLmV4YW1wbGUuY29tMCAXDTI2MTAxNzE4MzAxN1oYDzIxMjYwOTIzMTgzMDE3WjAaMRgwFgYDVQQDDA9pZHAuZXhhbXBsZS5jb20wgZ8wDQYJKoZIhvcNAQEBBQADgY0AMIGJAoGBALEQegQa1InTnrNZ3ksn6MfZmqt4slMFfgiwIKwPy59pOLrNDiAtFW0pgfLPCZZt9pB85BUWv1H76x1Y8G6Sej/VyUikrPi0laon/vRneRO5zhzjOfJ54diGcdZNCmwwFwsR8Mjmn8iuGO5BYv1Xj3DgwJ+kNgf9LxNTKk78xKtpAgMBAAGjUzBRMB0GA1UdDgQWBBQjBIJ6kO4Mutx0jo5QoptgoddMATAfBgNVHSMEGDAWgBQjBIJ6kO4Mutx0jo5QoptgoddMATAPBgNVHRMBAf8EBTADAQH/MA0GCSqGSIb3DQEBCwUAA4GBADKclhWZXJT9e1xr3Qd9cHkgL3JmDAd9tffoQf7FysVBtgxESvqwbNbNdibOjp0n1ybiVa1cxYZj+vfeaBA2mO7WO/XWb+zD94QSX/HsPXL1GkheRTP+2/IoY88sxGPVZbmBGlaIpKDwumQqD+Ny/0CbHf12r/SElpzgBu6uOT32"
+	testSAMLIDPMetadata    = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="signing">
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>` + testSAMLIDPCertificate + `</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+	testSAMLIDPMetadataChanged = `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="https://idp.example.com/changed">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor>
+      <ds:KeyInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+        <ds:X509Data>
+          <ds:X509Certificate>` + testSAMLIDPCertificate + `</ds:X509Certificate>
+        </ds:X509Data>
+      </ds:KeyInfo>
+    </md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/changed/sso"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+)
+
+func TestCommandSide_ChangeDefaultIDPSAMLConfig(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx    context.Context
+			config *domain.SAMLIDPConfig
+		}
+	)
+	type res struct {
+		want *domain.SAMLIDPConfig
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid config, error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				config: &domain.SAMLIDPConfig{},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp config not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.SAMLIDPConfig{
+					IDPConfigID: "config1",
+					Metadata:    []byte(testSAMLIDPMetadata),
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "metadata missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"name1",
+								domain.IDPConfigTypeSAML,
+								domain.IDPConfigStylingTypeUnspecified,
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPSAMLConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"https://idp.example.com/metadata",
+								[]byte(testSAMLIDPMetadata),
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.SAMLIDPConfig{
+					IDPConfigID: "config1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"name1",
+								domain.IDPConfigTypeSAML,
+								domain.IDPConfigStylingTypeUnspecified,
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPSAMLConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"https://idp.example.com/metadata",
+								[]byte(testSAMLIDPMetadata),
+								"",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.SAMLIDPConfig{
+					IDPConfigID: "config1",
+					Metadata:    []byte(testSAMLIDPMetadata),
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "idp config saml change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewIDPConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"name1",
+								domain.IDPConfigTypeSAML,
+								domain.IDPConfigStylingTypeUnspecified,
+								false,
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPSAMLConfigAddedEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								"config1",
+								"https://idp.example.com/metadata",
+								[]byte(testSAMLIDPMetadata),
+								"",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newDefaultIDPSAMLConfigChangedEvent(context.Background(),
+									"config1",
+									"https://idp.example.com/changed",
+									[]byte(testSAMLIDPMetadataChanged),
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.SAMLIDPConfig{
+					IDPConfigID: "config1",
+					Metadata:    []byte(testSAMLIDPMetadataChanged),
+				},
+			},
+			res: res{
+				want: &domain.SAMLIDPConfig{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "INSTANCE",
+						ResourceOwner: "INSTANCE",
+					},
+					IDPConfigID: "config1",
+					EntityID:    "https://idp.example.com/changed",
+					Metadata:    []byte(testSAMLIDPMetadataChanged),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ChangeDefaultIDPSAMLConfig(tt.args.ctx, tt.args.config)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newDefaultIDPSAMLConfigChangedEvent(ctx context.Context, configID, entityID string, metadata []byte) *instance.IDPSAMLConfigChangedEvent {
+	event, _ := instance.NewIDPSAMLConfigChangedEvent(ctx,
+		&instance.NewAggregate("INSTANCE").Aggregate,
+		configID,
+		[]idpconfig.SAMLConfigChanges{
+			idpconfig.ChangeSAMLEntityID(entityID),
+			idpconfig.ChangeSAMLMetadata(metadata),
+		},
+	)
+	return event
+}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+func (c *Commands) AddDefaultAccessPolicy(ctx context.Context, rules domain.AccessRules) (*domain.ObjectDetails, error) {
+	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, prepareAddDefaultAccessPolicy(instanceAgg, rules))
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// ChangeDefaultAccessPolicy changes the rules of the default access policy,
+// the policy is added if the instance was set up without one
+func (c *Commands) ChangeDefaultAccessPolicy(ctx context.Context, policy *domain.AccessPolicy) (*domain.AccessPolicy, error) {
+	if !policy.Rules.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-Ohx3e", "Errors.IAM.AccessPolicy.RuleInvalid")
+	}
+	existingPolicy, err := c.defaultAccessPolicyWriteModelByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instanceAgg := InstanceAggregateFromWriteModel(&existingPolicy.AccessPolicyWriteModel.WriteModel)
+	var event eventstore.Command
+	if existingPolicy.State == domain.PolicyStateActive {
+		changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, instanceAgg, policy.Rules)
+		if !hasChanged {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "INSTANCE-Wae4o", "Errors.IAM.AccessPolicy.NotChanged")
+		}
+		event = changedEvent
+	} else {
+		event = instance.NewAccessPolicyAddedEvent(ctx, instanceAgg, policy.Rules)
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToAccessPolicy(&existingPolicy.AccessPolicyWriteModel), nil
+}
+
+func (c *Commands) defaultAccessPolicyWriteModelByID(ctx context.Context) (policy *InstanceAccessPolicyWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewInstanceAccessPolicyWriteModel(ctx)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func prepareAddDefaultAccessPolicy(
+	a *instance.Aggregate,
+	rules domain.AccessRules,
+) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if !rules.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "INSTANCE-ahS3u", "Errors.IAM.AccessPolicy.RuleInvalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			writeModel := NewInstanceAccessPolicyWriteModel(ctx)
+			events, err := filter(ctx, writeModel.Query())
+			if err != nil {
+				return nil, err
+			}
+			writeModel.AppendEvents(events...)
+			if err = writeModel.Reduce(); err != nil {
+				return nil, err
+			}
+			if writeModel.State == domain.PolicyStateActive {
+				return nil, caos_errs.ThrowAlreadyExists(nil, "INSTANCE-Iej8i", "Errors.IAM.AccessPolicy.AlreadyExists")
+			}
+			return []eventstore.Command{
+				instance.NewAccessPolicyAddedEvent(ctx, &a.Aggregate, rules),
+			}, nil
+		}, nil
+	}
+}
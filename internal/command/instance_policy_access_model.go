@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type InstanceAccessPolicyWriteModel struct {
+	AccessPolicyWriteModel
+}
+
+func NewInstanceAccessPolicyWriteModel(ctx context.Context) *InstanceAccessPolicyWriteModel {
+	return &InstanceAccessPolicyWriteModel{
+		AccessPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceAccessPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.AccessPolicyAddedEvent:
+			wm.AccessPolicyWriteModel.AppendEvents(&e.AccessPolicyAddedEvent)
+		case *instance.AccessPolicyChangedEvent:
+			wm.AccessPolicyWriteModel.AppendEvents(&e.AccessPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceAccessPolicyWriteModel) Reduce() error {
+	return wm.AccessPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceAccessPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AccessPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.AccessPolicyAddedEventType,
+			instance.AccessPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceAccessPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	rules domain.AccessRules,
+) (*instance.AccessPolicyChangedEvent, bool) {
+	changes := make([]policy.AccessPolicyChanges, 0)
+	if wm.rulesChanged(rules) {
+		changes = append(changes, policy.ChangeAccessRules(rules))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewAccessPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -17,7 +17,7 @@ import (
 
 func (c *Commands) AddDefaultLoginPolicy(
 	ctx context.Context,
-	allowUsernamePassword, allowRegister, allowExternalIDP, forceMFA, hidePasswordReset, ignoreUnknownUsernames bool,
+	allowUsernamePassword, allowRegister, allowExternalIDP, forceMFA, hidePasswordReset, ignoreUnknownUsernames, allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime, externalLoginCheckLifetime, mfaInitSkipLifetime, secondFactorCheckLifetime, multiFactorCheckLifetime time.Duration,
@@ -29,6 +29,7 @@ func (c *Commands) AddDefaultLoginPolicy(
 		forceMFA,
 		hidePasswordReset,
 		ignoreUnknownUsernames,
+		allowMagicLink,
 		passwordlessType,
 		defaultRedirectURI,
 		passwordCheckLifetime,
@@ -83,6 +84,7 @@ func (c *Commands) changeDefaultLoginPolicy(ctx context.Context, instanceAgg *ev
 		policy.ForceMFA,
 		policy.HidePasswordReset,
 		policy.IgnoreUnknownUsernames,
+		policy.AllowMagicLink,
 		policy.PasswordlessType,
 		policy.DefaultRedirectURI,
 		policy.PasswordCheckLifetime,
@@ -293,6 +295,7 @@ func prepareAddDefaultLoginPolicy(
 	forceMFA bool,
 	hidePasswordReset bool,
 	ignoreUnknownUsernames bool,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime time.Duration,
@@ -323,6 +326,7 @@ func prepareAddDefaultLoginPolicy(
 					forceMFA,
 					hidePasswordReset,
 					ignoreUnknownUsernames,
+					allowMagicLink,
 					passwordlessType,
 					defaultRedirectURI,
 					passwordCheckLifetime,
@@ -30,6 +30,7 @@ func TestCommandSide_AddDefaultLoginPolicy(t *testing.T) {
 		forceMFA                   bool
 		hidePasswordReset          bool
 		ignoreUnknownUsernames     bool
+		allowMagicLink             bool
 		passwordlessType           domain.PasswordlessType
 		defaultRedirectURI         string
 		passwordCheckLifetime      time.Duration
@@ -63,6 +64,7 @@ func TestCommandSide_AddDefaultLoginPolicy(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -103,6 +105,7 @@ func TestCommandSide_AddDefaultLoginPolicy(t *testing.T) {
 									true,
 									true,
 									true,
+									false,
 									domain.PasswordlessTypeAllowed,
 									"https://example.com/redirect",
 									time.Hour*1,
@@ -152,6 +155,7 @@ func TestCommandSide_AddDefaultLoginPolicy(t *testing.T) {
 				tt.args.forceMFA,
 				tt.args.hidePasswordReset,
 				tt.args.ignoreUnknownUsernames,
+				tt.args.allowMagicLink,
 				tt.args.passwordlessType,
 				tt.args.defaultRedirectURI,
 				tt.args.passwordCheckLifetime,
@@ -225,6 +229,7 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -275,6 +280,7 @@ func TestCommandSide_ChangeDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"https://example.com/redirect",
 								time.Hour*1,
@@ -435,6 +441,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -473,6 +480,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -531,6 +539,7 @@ func TestCommandSide_AddIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -671,6 +680,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -709,6 +719,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -760,6 +771,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -816,6 +828,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -880,6 +893,7 @@ func TestCommandSide_RemoveIDPProviderDefaultLoginPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								false,
 								domain.PasswordlessTypeAllowed,
 								"",
 								time.Hour*1,
@@ -67,7 +67,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 							instance.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -98,7 +98,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 								instance.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 									&instance.NewAggregate("INSTANCE").Aggregate,
 									8,
-									true, true, true, true,
+									true, true, true, true, 0, false,
 								),
 							),
 						},
@@ -125,7 +125,7 @@ func TestCommandSide_AddDefaultPasswordComplexityPolicy(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol)
+			got, err := r.AddDefaultPasswordComplexityPolicy(tt.args.ctx, tt.args.minLength, tt.args.hasLowercase, tt.args.hasUppercase, tt.args.hasNumber, tt.args.hasSymbol, 0, false)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -210,7 +210,7 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 							instance.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -240,7 +240,7 @@ func TestCommandSide_ChangeDefaultPasswordComplexityPolicy(t *testing.T) {
 							instance.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&instance.NewAggregate("INSTANCE").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -125,6 +125,12 @@ func expectFilter(events ...*repository.Event) expect {
 	}
 }
 
+func expectFilterError(err error) expect {
+	return func(m *mock.MockRepository) {
+		m.ExpectFilterEventsError(err)
+	}
+}
+
 func expectFilterOrgDomainNotFound() expect {
 	return func(m *mock.MockRepository) {
 		m.ExpectFilterNoEventsNoError()
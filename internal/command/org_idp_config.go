@@ -17,7 +17,7 @@ func (c *Commands) AddIDPConfig(ctx context.Context, config *domain.IDPConfig, r
 	if resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-0j8gs", "Errors.ResourceOwnerMissing")
 	}
-	if config.OIDCConfig == nil && config.JWTConfig == nil {
+	if config.OIDCConfig == nil && config.JWTConfig == nil && config.SAMLConfig == nil && config.LDAPConfig == nil {
 		return nil, errors.ThrowInvalidArgument(nil, "Org-eUpQU", "Errors.idp.config.notset")
 	}
 
@@ -66,6 +66,44 @@ func (c *Commands) AddIDPConfig(ctx context.Context, config *domain.IDPConfig, r
 			config.JWTConfig.KeysEndpoint,
 			config.JWTConfig.HeaderName,
 		))
+	} else if config.SAMLConfig != nil {
+		entityID, metadata, err := samlIDPMetadata(ctx, config.SAMLConfig)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, org_repo.NewIDPSAMLConfigAddedEvent(
+			ctx,
+			orgAgg,
+			idpConfigID,
+			entityID,
+			metadata,
+			config.SAMLConfig.MetadataURL,
+		))
+	} else if config.LDAPConfig != nil {
+		if !config.LDAPConfig.IsValid() {
+			return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Rw2fd", "Errors.IDPConfig.LDAPInvalid")
+		}
+		var bindPassword *crypto.CryptoValue
+		if config.LDAPConfig.BindPasswordString != "" {
+			bindPassword, err = crypto.Encrypt([]byte(config.LDAPConfig.BindPasswordString), c.idpConfigEncryption)
+			if err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, org_repo.NewIDPLDAPConfigAddedEvent(
+			ctx,
+			orgAgg,
+			idpConfigID,
+			config.LDAPConfig.URL,
+			config.LDAPConfig.StartTLS,
+			config.LDAPConfig.RootCA,
+			config.LDAPConfig.BaseDN,
+			config.LDAPConfig.BindDN,
+			bindPassword,
+			config.LDAPConfig.UserObjectClass,
+			config.LDAPConfig.UserFilters,
+			config.LDAPConfig.Attributes,
+		))
 	}
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
 	if err != nil {
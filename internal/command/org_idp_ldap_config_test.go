@@ -0,0 +1,311 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestCommandSide_ChangeIDPLDAPConfig(t *testing.T) {
+	type fields struct {
+		eventstore   *eventstore.Eventstore
+		secretCrypto crypto.EncryptionAlgorithm
+	}
+	type args struct {
+		ctx           context.Context
+		config        *domain.LDAPIDPConfig
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.LDAPIDPConfig
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "resourceowner missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.LDAPIDPConfig{
+					IDPConfigID: "config1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				config:        &domain.LDAPIDPConfig{},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid url, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.LDAPIDPConfig{
+					IDPConfigID:     "config1",
+					URL:             "https://ad.example.com",
+					BaseDN:          "dc=example,dc=com",
+					UserObjectClass: "user",
+					UserFilters:     []string{"sAMAccountName"},
+					Attributes:      testLDAPAttributes(),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp config not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.LDAPIDPConfig{
+					IDPConfigID:     "config1",
+					URL:             "ldaps://ad.example.com",
+					BaseDN:          "dc=example,dc=com",
+					UserObjectClass: "user",
+					UserFilters:     []string{"sAMAccountName"},
+					Attributes:      testLDAPAttributes(),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPConfigAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"config1",
+								"name1",
+								domain.IDPConfigTypeLDAP,
+								domain.IDPConfigStylingTypeUnspecified,
+								true,
+							),
+						),
+						eventFromEventPusher(
+							org.NewIDPLDAPConfigAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"config1",
+								"ldaps://ad.example.com",
+								false,
+								"",
+								"dc=example,dc=com",
+								"cn=service,dc=example,dc=com",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("secret"),
+								},
+								"user",
+								[]string{"sAMAccountName"},
+								testLDAPAttributes(),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.LDAPIDPConfig{
+					IDPConfigID:     "config1",
+					URL:             "ldaps://ad.example.com",
+					BaseDN:          "dc=example,dc=com",
+					BindDN:          "cn=service,dc=example,dc=com",
+					UserObjectClass: "user",
+					UserFilters:     []string{"sAMAccountName"},
+					Attributes:      testLDAPAttributes(),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "idp config ldap change, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPConfigAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"config1",
+								"name1",
+								domain.IDPConfigTypeLDAP,
+								domain.IDPConfigStylingTypeUnspecified,
+								true,
+							),
+						),
+						eventFromEventPusher(
+							org.NewIDPLDAPConfigAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								"config1",
+								"ldaps://ad.example.com",
+								false,
+								"",
+								"dc=example,dc=com",
+								"cn=service,dc=example,dc=com",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("secret"),
+								},
+								"user",
+								[]string{"sAMAccountName"},
+								testLDAPAttributes(),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								newIDPLDAPConfigChangedEvent(context.Background(),
+									"config1",
+									[]idpconfig.LDAPConfigChanges{
+										idpconfig.ChangeLDAPBindPassword(&crypto.CryptoValue{
+											CryptoType: crypto.TypeEncryption,
+											Algorithm:  "enc",
+											KeyID:      "id",
+											Crypted:    []byte("secret-changed"),
+										}),
+										idpconfig.ChangeLDAPURL("ldap://ad2.example.com"),
+										idpconfig.ChangeLDAPStartTLS(true),
+										idpconfig.ChangeLDAPUserFilters([]string{"sAMAccountName", "mail"}),
+										idpconfig.ChangeLDAPPhoneAttribute("mobile"),
+									},
+								),
+							),
+						},
+					),
+				),
+				secretCrypto: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx: context.Background(),
+				config: &domain.LDAPIDPConfig{
+					IDPConfigID:        "config1",
+					URL:                "ldap://ad2.example.com",
+					StartTLS:           true,
+					BaseDN:             "dc=example,dc=com",
+					BindDN:             "cn=service,dc=example,dc=com",
+					BindPasswordString: "secret-changed",
+					UserObjectClass:    "user",
+					UserFilters:        []string{"sAMAccountName", "mail"},
+					Attributes: domain.LDAPAttributes{
+						IDAttribute:                "objectGUID",
+						PreferredUsernameAttribute: "sAMAccountName",
+						FirstNameAttribute:         "givenName",
+						LastNameAttribute:          "sn",
+						EmailAttribute:             "mail",
+						PhoneAttribute:             "mobile",
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.LDAPIDPConfig{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					IDPConfigID:     "config1",
+					URL:             "ldap://ad2.example.com",
+					StartTLS:        true,
+					BaseDN:          "dc=example,dc=com",
+					BindDN:          "cn=service,dc=example,dc=com",
+					UserObjectClass: "user",
+					UserFilters:     []string{"sAMAccountName", "mail"},
+					Attributes: domain.LDAPAttributes{
+						IDAttribute:                "objectGUID",
+						PreferredUsernameAttribute: "sAMAccountName",
+						FirstNameAttribute:         "givenName",
+						LastNameAttribute:          "sn",
+						EmailAttribute:             "mail",
+						PhoneAttribute:             "mobile",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:          tt.fields.eventstore,
+				idpConfigEncryption: tt.fields.secretCrypto,
+			}
+			got, err := r.ChangeIDPLDAPConfig(tt.args.ctx, tt.args.config, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newIDPLDAPConfigChangedEvent(ctx context.Context, configID string, changes []idpconfig.LDAPConfigChanges) *org.IDPLDAPConfigChangedEvent {
+	event, _ := org.NewIDPLDAPConfigChangedEvent(ctx,
+		&org.NewAggregate("org1").Aggregate,
+		configID,
+		changes,
+	)
+	return event
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func (c *Commands) AddAccessPolicy(ctx context.Context, resourceOwner string, policy *domain.AccessPolicy) (*domain.AccessPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Ooqu5", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.Rules.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-ieR5a", "Errors.Org.AccessPolicy.RuleInvalid")
+	}
+	addedPolicy, err := c.orgAccessPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if addedPolicy.State == domain.PolicyStateActive {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "ORG-Ahj4i", "Errors.Org.AccessPolicy.AlreadyExists")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&addedPolicy.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewAccessPolicyAddedEvent(ctx, orgAgg, policy.Rules))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToAccessPolicy(&addedPolicy.AccessPolicyWriteModel), nil
+}
+
+func (c *Commands) ChangeAccessPolicy(ctx context.Context, resourceOwner string, policy *domain.AccessPolicy) (*domain.AccessPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Ek5ku", "Errors.ResourceOwnerMissing")
+	}
+	if !policy.Rules.IsValid() {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Gah0f", "Errors.Org.AccessPolicy.RuleInvalid")
+	}
+	existingPolicy, err := c.orgAccessPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Tha9e", "Errors.Org.AccessPolicy.NotFound")
+	}
+
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.AccessPolicyWriteModel.WriteModel)
+	changedEvent, hasChanged := existingPolicy.NewChangedEvent(ctx, orgAgg, policy.Rules)
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "ORG-Ii4ae", "Errors.Org.AccessPolicy.NotChanged")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToAccessPolicy(&existingPolicy.AccessPolicyWriteModel), nil
+}
+
+func (c *Commands) RemoveAccessPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if orgID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Xoh5u", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := c.orgAccessPolicyWriteModelByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State == domain.PolicyStateUnspecified || existingPolicy.State == domain.PolicyStateRemoved {
+		return nil, caos_errs.ThrowNotFound(nil, "ORG-Oog1i", "Errors.Org.AccessPolicy.NotFound")
+	}
+	orgAgg := OrgAggregateFromWriteModel(&existingPolicy.WriteModel)
+
+	pushedEvents, err := c.eventstore.Push(ctx, org.NewAccessPolicyRemovedEvent(ctx, orgAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingPolicy, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingPolicy.AccessPolicyWriteModel.WriteModel), nil
+}
+
+func (c *Commands) orgAccessPolicyWriteModelByID(ctx context.Context, orgID string) (*OrgAccessPolicyWriteModel, error) {
+	policy := NewOrgAccessPolicyWriteModel(orgID)
+	err := c.eventstore.FilterToQueryReducer(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type OrgAccessPolicyWriteModel struct {
+	AccessPolicyWriteModel
+}
+
+func NewOrgAccessPolicyWriteModel(orgID string) *OrgAccessPolicyWriteModel {
+	return &OrgAccessPolicyWriteModel{
+		AccessPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgAccessPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.AccessPolicyAddedEvent:
+			wm.AccessPolicyWriteModel.AppendEvents(&e.AccessPolicyAddedEvent)
+		case *org.AccessPolicyChangedEvent:
+			wm.AccessPolicyWriteModel.AppendEvents(&e.AccessPolicyChangedEvent)
+		case *org.AccessPolicyRemovedEvent:
+			wm.AccessPolicyWriteModel.AppendEvents(&e.AccessPolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgAccessPolicyWriteModel) Reduce() error {
+	return wm.AccessPolicyWriteModel.Reduce()
+}
+
+func (wm *OrgAccessPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AccessPolicyWriteModel.AggregateID).
+		EventTypes(org.AccessPolicyAddedEventType,
+			org.AccessPolicyChangedEventType,
+			org.AccessPolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgAccessPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	rules domain.AccessRules,
+) (*org.AccessPolicyChangedEvent, bool) {
+	changes := make([]policy.AccessPolicyChanges, 0)
+	if wm.rulesChanged(rules) {
+		changes = append(changes, policy.ChangeAccessRules(rules))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewAccessPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
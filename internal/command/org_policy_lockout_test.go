@@ -62,7 +62,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -92,7 +95,10 @@ func TestCommandSide_AddPasswordLockoutPolicy(t *testing.T) {
 								org.NewLockoutPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									10,
+									0,
+									0,
 									true,
+									0,
 								),
 							),
 						},
@@ -205,7 +211,10 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -233,7 +242,10 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
+								0,
 								true,
+								0,
 							),
 						),
 					),
@@ -343,7 +355,10 @@ func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 							org.NewLockoutPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								10,
+								0,
+								0,
 								true,
+								0,
 							),
 						),
 					),
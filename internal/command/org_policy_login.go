@@ -40,6 +40,7 @@ func (c *Commands) AddLoginPolicy(ctx context.Context, resourceOwner string, pol
 			policy.ForceMFA,
 			policy.HidePasswordReset,
 			policy.IgnoreUnknownUsernames,
+			policy.AllowMagicLink,
 			policy.PasswordlessType,
 			policy.DefaultRedirectURI,
 			policy.PasswordCheckLifetime,
@@ -103,6 +104,7 @@ func (c *Commands) ChangeLoginPolicy(ctx context.Context, resourceOwner string,
 		policy.ForceMFA,
 		policy.HidePasswordReset,
 		policy.IgnoreUnknownUsernames,
+		policy.AllowMagicLink,
 		policy.PasswordlessType,
 		policy.DefaultRedirectURI,
 		policy.PasswordCheckLifetime,
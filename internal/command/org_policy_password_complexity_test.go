@@ -55,6 +55,52 @@ func TestCommandSide_AddPasswordComplexityPolicy(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "history depth too high, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.PasswordComplexityPolicy{
+					MinLength:    8,
+					HasUppercase: true,
+					HasLowercase: true,
+					HasNumber:    true,
+					HasSymbol:    true,
+					HistoryDepth: 25,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "breached check without breached passwords, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.PasswordComplexityPolicy{
+					MinLength:     8,
+					HasUppercase:  true,
+					HasLowercase:  true,
+					HasNumber:     true,
+					HasSymbol:     true,
+					CheckBreached: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
 		{
 			name: "policy already existing, already exists error",
 			fields: fields{
@@ -65,7 +111,7 @@ func TestCommandSide_AddPasswordComplexityPolicy(t *testing.T) {
 							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -98,7 +144,7 @@ func TestCommandSide_AddPasswordComplexityPolicy(t *testing.T) {
 								org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 									&org.NewAggregate("org1").Aggregate,
 									8,
-									true, true, true, true,
+									true, true, true, true, 0, false,
 								),
 							),
 						},
@@ -223,7 +269,7 @@ func TestCommandSide_ChangePasswordComplexityPolicy(t *testing.T) {
 							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -254,7 +300,7 @@ func TestCommandSide_ChangePasswordComplexityPolicy(t *testing.T) {
 							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
@@ -370,7 +416,7 @@ func TestCommandSide_RemovePasswordComplexityPolicy(t *testing.T) {
 							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
 								&org.NewAggregate("org1").Aggregate,
 								8,
-								true, true, true, true,
+								true, true, true, true, 0, false,
 							),
 						),
 					),
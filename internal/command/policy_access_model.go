@@ -0,0 +1,46 @@
+package command
+
+import (
+	"reflect"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type AccessPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	Rules domain.AccessRules
+	State domain.PolicyState
+}
+
+func (wm *AccessPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.AccessPolicyAddedEvent:
+			wm.Rules = e.Rules
+			wm.State = domain.PolicyStateActive
+		case *policy.AccessPolicyChangedEvent:
+			if e.Rules != nil {
+				wm.Rules = *e.Rules
+			}
+		case *policy.AccessPolicyRemovedEvent:
+			wm.Rules = nil
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *AccessPolicyWriteModel) rulesChanged(rules domain.AccessRules) bool {
+	if len(wm.Rules) != len(rules) {
+		return true
+	}
+	for i, rule := range rules {
+		if !reflect.DeepEqual(wm.Rules[i], rule) {
+			return true
+		}
+	}
+	return false
+}
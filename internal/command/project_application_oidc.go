@@ -34,6 +34,11 @@ type addOIDCApp struct {
 	IDTokenUserinfoAssertion bool
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
+	RequirePushedAuthRequest bool
+	RequireRequestObject     bool
+	BackChannelLogoutURI     string
+	FrontChannelLogoutURI    string
+	MinLevelOfAssurance      domain.LevelOfAssurance
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -61,6 +66,14 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			}
 		}
 
+		if !domain.IsLogoutURI(app.BackChannelLogoutURI) || !domain.IsLogoutURI(app.FrontChannelLogoutURI) {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Ohs4i", "Errors.Invalid.Argument")
+		}
+
+		if !app.MinLevelOfAssurance.Valid() {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-aiZ2e", "Errors.Invalid.Argument")
+		}
+
 		if !domain.ContainsRequiredGrantTypes(app.ResponseTypes, app.GrantTypes) {
 			return nil, errors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
@@ -110,6 +123,11 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.IDTokenUserinfoAssertion,
 					app.ClockSkew,
 					app.AdditionalOrigins,
+					app.RequirePushedAuthRequest,
+					app.RequireRequestObject,
+					app.BackChannelLogoutURI,
+					app.FrontChannelLogoutURI,
+					app.MinLevelOfAssurance,
 				),
 			}, nil
 		}, nil
@@ -185,7 +203,12 @@ func (c *Commands) addOIDCApplication(ctx context.Context, projectAgg *eventstor
 		oidcApp.IDTokenRoleAssertion,
 		oidcApp.IDTokenUserinfoAssertion,
 		oidcApp.ClockSkew,
-		oidcApp.AdditionalOrigins))
+		oidcApp.AdditionalOrigins,
+		oidcApp.RequirePushedAuthRequest,
+		oidcApp.RequireRequestObject,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.FrontChannelLogoutURI,
+		oidcApp.MinLevelOfAssurance))
 
 	return events, stringPw, nil
 }
@@ -223,7 +246,12 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.IDTokenRoleAssertion,
 		oidc.IDTokenUserinfoAssertion,
 		oidc.ClockSkew,
-		oidc.AdditionalOrigins)
+		oidc.AdditionalOrigins,
+		oidc.RequirePushedAuthRequest,
+		oidc.RequireRequestObject,
+		oidc.BackChannelLogoutURI,
+		oidc.FrontChannelLogoutURI,
+		oidc.MinLevelOfAssurance)
 	if err != nil {
 		return nil, err
 	}
@@ -35,6 +35,13 @@ type OIDCApplicationWriteModel struct {
 	ClockSkew                time.Duration
 	State                    domain.AppState
 	AdditionalOrigins        []string
+	RequirePushedAuthRequest bool
+	RequireRequestObject     bool
+	BackChannelLogoutURI     string
+	FrontChannelLogoutURI    string
+	MinLevelOfAssurance      domain.LevelOfAssurance
+	RegistrationTokenID      string
+	RegistrationTokenHash    string
 	oidc                     bool
 }
 
@@ -99,6 +106,11 @@ func (wm *OIDCApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigRegistrationAccessTokenSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -131,6 +143,9 @@ func (wm *OIDCApplicationWriteModel) Reduce() error {
 			wm.appendChangeOIDCEvent(e)
 		case *project.OIDCConfigSecretChangedEvent:
 			wm.ClientSecret = e.ClientSecret
+		case *project.OIDCConfigRegistrationAccessTokenSetEvent:
+			wm.RegistrationTokenID = e.TokenID
+			wm.RegistrationTokenHash = e.TokenHash
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.AppStateRemoved
 		}
@@ -156,6 +171,11 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.IDTokenUserinfoAssertion = e.IDTokenUserinfoAssertion
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
+	wm.RequirePushedAuthRequest = e.RequirePushedAuthRequest
+	wm.RequireRequestObject = e.RequireRequestObject
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.FrontChannelLogoutURI = e.FrontChannelLogoutURI
+	wm.MinLevelOfAssurance = e.MinLevelOfAssurance
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -201,6 +221,21 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.AdditionalOrigins != nil {
 		wm.AdditionalOrigins = *e.AdditionalOrigins
 	}
+	if e.RequirePushedAuthRequest != nil {
+		wm.RequirePushedAuthRequest = *e.RequirePushedAuthRequest
+	}
+	if e.RequireRequestObject != nil {
+		wm.RequireRequestObject = *e.RequireRequestObject
+	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		wm.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
+	if e.MinLevelOfAssurance != nil {
+		wm.MinLevelOfAssurance = *e.MinLevelOfAssurance
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -218,6 +253,7 @@ func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.OIDCConfigAddedType,
 			project.OIDCConfigChangedType,
 			project.OIDCConfigSecretChangedType,
+			project.OIDCConfigRegistrationAccessTokenSetType,
 			project.ProjectRemovedType).
 		Builder()
 }
@@ -240,6 +276,11 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
+	requirePushedAuthRequest,
+	requireRequestObject bool,
+	backChannelLogoutURI,
+	frontChannelLogoutURI string,
+	minLevelOfAssurance domain.LevelOfAssurance,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -286,6 +327,21 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if !reflect.DeepEqual(wm.AdditionalOrigins, additionalOrigins) {
 		changes = append(changes, project.ChangeAdditionalOrigins(additionalOrigins))
 	}
+	if wm.RequirePushedAuthRequest != requirePushedAuthRequest {
+		changes = append(changes, project.ChangeRequirePushedAuthRequest(requirePushedAuthRequest))
+	}
+	if wm.RequireRequestObject != requireRequestObject {
+		changes = append(changes, project.ChangeRequireRequestObject(requireRequestObject))
+	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.FrontChannelLogoutURI != frontChannelLogoutURI {
+		changes = append(changes, project.ChangeFrontChannelLogoutURI(frontChannelLogoutURI))
+	}
+	if wm.MinLevelOfAssurance != minLevelOfAssurance {
+		changes = append(changes, project.ChangeMinLevelOfAssurance(minLevelOfAssurance))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -169,6 +169,80 @@ func TestAddOIDCApp(t *testing.T) {
 						false,
 						0,
 						nil,
+						false,
+						false,
+						"",
+						"",
+						domain.LevelOfAssuranceNone,
+					),
+				},
+			},
+		},
+		{
+			name: "correct with device code grant",
+			fields: fields{
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "clientID"),
+			},
+			args: args{
+				app: &addOIDCApp{
+					AddApp: AddApp{
+						Aggregate: *agg,
+						ID:        "id",
+						Name:      "name",
+					},
+					GrantTypes:    []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeDeviceCode},
+					ResponseTypes: []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					Version:       domain.OIDCVersionV1,
+
+					ApplicationType: domain.OIDCApplicationTypeNative,
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+				},
+				filter: NewMultiFilter().
+					Append(func(ctx context.Context, queryFactory *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+						return []eventstore.Event{
+							project.NewProjectAddedEvent(
+								ctx,
+								&agg.Aggregate,
+								"project",
+								false,
+								false,
+								false,
+								domain.PrivateLabelingSettingUnspecified,
+							),
+						}, nil
+					}).
+					Filter(),
+			},
+			want: Want{
+				Commands: []eventstore.Command{
+					project.NewApplicationAddedEvent(ctx, &agg.Aggregate,
+						"id",
+						"name",
+					),
+					project.NewOIDCConfigAddedEvent(ctx, &agg.Aggregate,
+						domain.OIDCVersionV1,
+						"id",
+						"clientID@project",
+						nil,
+						nil,
+						[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+						[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeDeviceCode},
+						domain.OIDCApplicationTypeNative,
+						domain.OIDCAuthMethodTypeNone,
+						nil,
+						false,
+						domain.OIDCTokenTypeBearer,
+						false,
+						false,
+						false,
+						0,
+						nil,
+						false,
+						false,
+						"",
+						"",
+						domain.LevelOfAssuranceNone,
 					),
 				},
 			},
@@ -324,7 +398,12 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 									true,
 									true,
 									time.Second*1,
-									[]string{"https://sub.test.ch"}),
+									[]string{"https://sub.test.ch"},
+									false,
+									false,
+									"",
+									"",
+									domain.LevelOfAssuranceNone),
 							),
 						},
 						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
@@ -557,7 +636,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
 						),
 					),
 				),
@@ -628,7 +712,12 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
 						),
 					),
 					expectPush(
@@ -665,6 +754,10 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					IDTokenUserinfoAssertion: false,
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
+					RequirePushedAuthRequest: true,
+					RequireRequestObject:     true,
+					BackChannelLogoutURI:     "https://test-change.ch/logout/backchannel",
+					FrontChannelLogoutURI:    "https://test-change.ch/logout/frontchannel",
 				},
 				resourceOwner: "org1",
 			},
@@ -691,6 +784,10 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					IDTokenUserinfoAssertion: false,
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
+					RequirePushedAuthRequest: true,
+					RequireRequestObject:     true,
+					BackChannelLogoutURI:     "https://test-change.ch/logout/backchannel",
+					FrontChannelLogoutURI:    "https://test-change.ch/logout/frontchannel",
 					Compliance:               &domain.Compliance{},
 					State:                    domain.AppStateActive,
 				},
@@ -825,7 +922,12 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								true,
 								true,
 								time.Second*1,
-								[]string{"https://sub.test.ch"}),
+								[]string{"https://sub.test.ch"},
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
 						),
 					),
 					expectPush(
@@ -910,6 +1012,10 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeRequirePushedAuthRequest(true),
+		project.ChangeRequireRequestObject(true),
+		project.ChangeBackChannelLogoutURI("https://test-change.ch/logout/backchannel"),
+		project.ChangeFrontChannelLogoutURI("https://test-change.ch/logout/frontchannel"),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
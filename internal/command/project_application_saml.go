@@ -0,0 +1,185 @@
+package command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/saml/schema"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	project_repo "github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const samlMetadataTimeout = 10 * time.Second
+
+func (c *Commands) AddSAMLApplication(ctx context.Context, application *domain.SAMLApp, resourceOwner string) (_ *domain.SAMLApp, err error) {
+	if application == nil || application.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-35Fn0", "Errors.Application.Invalid")
+	}
+	project, err := c.getProjectByID(ctx, application.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, errors.ThrowPreconditionFailed(err, "PROJECT-3p9ss", "Errors.Project.NotFound")
+	}
+	addedApplication := NewSAMLApplicationWriteModel(application.AggregateID, resourceOwner)
+	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
+	events, err := c.addSAMLApplication(ctx, projectAgg, project, application)
+	if err != nil {
+		return nil, err
+	}
+	addedApplication.AppID = application.AppID
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedApplication, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return samlWriteModelToSAMLConfig(addedApplication), nil
+}
+
+func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstore.Aggregate, proj *domain.Project, samlApp *domain.SAMLApp) (events []eventstore.Command, err error) {
+	if !samlApp.IsValid() {
+		return nil, errors.ThrowInvalidArgument(nil, "PROJECT-1n9df", "Errors.Application.Invalid")
+	}
+	entityID, metadata, err := c.samlMetadata(ctx, samlApp.Metadata, samlApp.MetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	samlApp.AppID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	return []eventstore.Command{
+		project_repo.NewApplicationAddedEvent(ctx, projectAgg, samlApp.AppID, samlApp.AppName),
+		project_repo.NewSAMLConfigAddedEvent(ctx,
+			projectAgg,
+			samlApp.AppID,
+			entityID,
+			metadata,
+			samlApp.MetadataURL),
+	}, nil
+}
+
+func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SAMLApp, resourceOwner string) (*domain.SAMLApp, error) {
+	if samlApp.AppID == "" || samlApp.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-5n9fs", "Errors.Project.App.SAMLConfigInvalid")
+	}
+	if len(samlApp.Metadata) == 0 && samlApp.MetadataURL == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-3m9fg", "Errors.Project.App.SAMLMetadataMissing")
+	}
+
+	existingSAML, err := c.getSAMLAppWriteModel(ctx, samlApp.AggregateID, samlApp.AppID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingSAML.State == domain.AppStateUnspecified || existingSAML.State == domain.AppStateRemoved {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-2n9fs", "Errors.Project.App.NotExisting")
+	}
+	if !existingSAML.IsSAML() {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-fms43", "Errors.Project.App.IsNotSAML")
+	}
+	entityID, metadata, err := c.samlMetadata(ctx, samlApp.Metadata, samlApp.MetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	projectAgg := ProjectAggregateFromWriteModel(&existingSAML.WriteModel)
+	changedEvent, hasChanged, err := existingSAML.NewChangedEvent(
+		ctx,
+		projectAgg,
+		samlApp.AppID,
+		entityID,
+		metadata,
+		samlApp.MetadataURL)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-1m88i", "Errors.NoChangesFound")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingSAML, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return samlWriteModelToSAMLConfig(existingSAML), nil
+}
+
+func (c *Commands) getSAMLAppWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*SAMLApplicationWriteModel, error) {
+	appWriteModel := NewSAMLApplicationWriteModelWithAppID(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, appWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return appWriteModel, nil
+}
+
+// samlMetadata returns the entityID and the metadata of the service provider,
+// if no metadata is provided, it will be loaded from the url
+func (c *Commands) samlMetadata(ctx context.Context, metadata []byte, metadataURL string) (string, []byte, error) {
+	if len(metadata) == 0 {
+		var err error
+		metadata, err = fetchSAMLMetadata(ctx, metadataURL)
+		if err != nil {
+			return "", nil, errors.ThrowInvalidArgument(err, "SAML-4n0fs", "Errors.Project.App.SAMLMetadataMissing")
+		}
+	}
+	entityDescriptor, err := schema.ParseMetadata(metadata)
+	if err != nil {
+		return "", nil, errors.ThrowInvalidArgument(err, "SAML-bs9fe", "Errors.Project.App.SAMLMetadataFormat")
+	}
+	return entityDescriptor.EntityID, metadata, nil
+}
+
+// samlIDPMetadata returns the entityID and the metadata of an external identity provider,
+// if no metadata is provided, it will be loaded from the url
+func samlIDPMetadata(ctx context.Context, config *domain.SAMLIDPConfig) (string, []byte, error) {
+	if config == nil || !config.IsValid() {
+		return "", nil, errors.ThrowInvalidArgument(nil, "SAML-Ghw2s", "Errors.IDPConfig.SAMLMetadataMissing")
+	}
+	metadata := config.Metadata
+	if len(metadata) == 0 {
+		var err error
+		metadata, err = fetchSAMLMetadata(ctx, config.MetadataURL)
+		if err != nil {
+			return "", nil, errors.ThrowInvalidArgument(err, "SAML-Jd3n2", "Errors.IDPConfig.SAMLMetadataMissing")
+		}
+	}
+	entityDescriptor, err := schema.ParseIDPMetadata(metadata)
+	if err != nil {
+		return "", nil, errors.ThrowInvalidArgument(err, "SAML-Wf2qg", "Errors.IDPConfig.SAMLMetadataFormat")
+	}
+	// responses of the identity provider can only be verified with a signing certificate
+	certificates, err := entityDescriptor.IDPSigningCertificates()
+	if err != nil || len(certificates) == 0 {
+		return "", nil, errors.ThrowInvalidArgument(err, "SAML-Pw2bd", "Errors.IDPConfig.SAMLMetadataFormat")
+	}
+	return entityDescriptor.EntityID, metadata, nil
+}
+
+func fetchSAMLMetadata(ctx context.Context, metadataURL string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, samlMetadataTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.ThrowInvalidArgumentf(nil, "SAML-8hwe2", "unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	project_repo "github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddInitialAccessToken creates a token, which allows clients to register themselves
+// as OIDC applications of the project (RFC 7591, section 3)
+func (c *Commands) AddInitialAccessToken(ctx context.Context, projectID, resourceOwner string, expirationDate time.Time) (*domain.InitialAccessToken, string, error) {
+	if projectID == "" {
+		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eez8o", "Errors.Project.ProjectIDMissing")
+	}
+	_, err := c.getProjectByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, "", err
+	}
+	expirationDate, err = domain.ValidateExpirationDate(expirationDate)
+	if err != nil {
+		return nil, "", err
+	}
+	tokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	token, tokenHash, err := c.clientRegistrationToken(projectID, tokenID)
+	if err != nil {
+		return nil, "", err
+	}
+	tokenWriteModel := NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		project_repo.NewInitialAccessTokenAddedEvent(ctx, ProjectAggregateFromWriteModel(&tokenWriteModel.WriteModel), tokenID, tokenHash, expirationDate))
+	if err != nil {
+		return nil, "", err
+	}
+	err = AppendAndReduce(tokenWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, "", err
+	}
+	return &domain.InitialAccessToken{
+		ObjectRoot: writeModelToObjectRoot(tokenWriteModel.WriteModel),
+		TokenID:    tokenID,
+		Expiration: tokenWriteModel.Expiration,
+	}, token, nil
+}
+
+func (c *Commands) RemoveInitialAccessToken(ctx context.Context, projectID, tokenID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || tokenID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Fai5s", "Errors.IDMissing")
+	}
+	tokenWriteModel, err := c.initialAccessTokenWriteModelByID(ctx, projectID, tokenID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !tokenWriteModel.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Quai2", "Errors.Project.InitialAccessToken.NotFound")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		project_repo.NewInitialAccessTokenRemovedEvent(ctx, ProjectAggregateFromWriteModel(&tokenWriteModel.WriteModel), tokenID))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(tokenWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&tokenWriteModel.WriteModel), nil
+}
+
+// RegisterOIDCApplication adds the OIDC application to the project of the initial access token (RFC 7591, section 3.1).
+// It returns the registration access token, which is needed to manage the application
+// on the client configuration endpoint (RFC 7592)
+func (c *Commands) RegisterOIDCApplication(ctx context.Context, initialAccessToken string, application *domain.OIDCApp) (_ *domain.OIDCApp, _ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if application == nil {
+		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kah2u", "Errors.Application.Invalid")
+	}
+	projectID, tokenID, tokenHash, err := domain.FromInitialAccessToken(initialAccessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	tokenWriteModel, err := c.initialAccessTokenWriteModelByID(ctx, projectID, tokenID, "")
+	if err != nil {
+		return nil, "", err
+	}
+	if !tokenWriteModel.Exists() || tokenWriteModel.Expiration.Before(time.Now()) ||
+		!domain.ClientRegistrationTokenHashEqual(tokenHash, tokenWriteModel.TokenHash) {
+		return nil, "", caos_errs.ThrowPermissionDenied(nil, "COMMAND-Shoo5", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	resourceOwner := tokenWriteModel.ResourceOwner
+	project, err := c.getProjectByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, "", caos_errs.ThrowPreconditionFailed(err, "COMMAND-Ohl6e", "Errors.Project.NotFound")
+	}
+	appSecretGenerator, err := c.newHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	application.AggregateID = projectID
+	addedApplication := NewOIDCApplicationWriteModel(projectID, resourceOwner)
+	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
+	events, stringPw, err := c.addOIDCApplication(ctx, projectAgg, project, application, resourceOwner, appSecretGenerator)
+	if err != nil {
+		return nil, "", err
+	}
+	registrationTokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationAccessToken, registrationTokenHash, err := c.clientRegistrationToken(projectID, application.AppID, registrationTokenID)
+	if err != nil {
+		return nil, "", err
+	}
+	events = append(events, project_repo.NewOIDCConfigRegistrationAccessTokenSetEvent(ctx, projectAgg, application.AppID, registrationTokenID, registrationTokenHash))
+	addedApplication.AppID = application.AppID
+
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, "", err
+	}
+	err = AppendAndReduce(addedApplication, pushedEvents...)
+	if err != nil {
+		return nil, "", err
+	}
+	result := oidcWriteModelToOIDCConfig(addedApplication)
+	result.ClientSecretString = stringPw
+	result.FillCompliance()
+	return result, registrationAccessToken, nil
+}
+
+// VerifyOIDCRegistrationAccessToken checks that the registration access token was issued
+// for the application and wasn't replaced since (RFC 7592, section 2)
+func (c *Commands) VerifyOIDCRegistrationAccessToken(ctx context.Context, projectID, appID, registrationAccessToken string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenProjectID, tokenAppID, tokenID, tokenHash, err := domain.FromRegistrationAccessToken(registrationAccessToken)
+	if err != nil {
+		return err
+	}
+	if tokenProjectID != projectID || tokenAppID != appID {
+		return caos_errs.ThrowPermissionDenied(nil, "COMMAND-Eeb4e", "Errors.Project.App.RegistrationAccessTokenInvalid")
+	}
+	app, err := c.getOIDCAppWriteModel(ctx, projectID, appID, "")
+	if err != nil {
+		return err
+	}
+	if !app.State.Exists() || !app.IsOIDC() || app.RegistrationTokenID == "" || app.RegistrationTokenID != tokenID ||
+		!domain.ClientRegistrationTokenHashEqual(tokenHash, app.RegistrationTokenHash) {
+		return caos_errs.ThrowPermissionDenied(nil, "COMMAND-ahC0u", "Errors.Project.App.RegistrationAccessTokenInvalid")
+	}
+	return nil
+}
+
+func (c *Commands) initialAccessTokenWriteModelByID(ctx context.Context, projectID, tokenID, resourceOwner string) (writeModel *InitialAccessTokenWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) newHashGenerator(ctx context.Context, typ domain.SecretGeneratorType) (crypto.Generator, error) {
+	config, err := secretGeneratorConfig(ctx, c.eventstore.Filter, typ)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.NewHashGenerator(*config, c.userPasswordAlg), nil
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type InitialAccessTokenWriteModel struct {
+	eventstore.WriteModel
+
+	TokenID    string
+	TokenHash  string
+	Expiration time.Time
+
+	State domain.InitialAccessTokenState
+}
+
+func NewInitialAccessTokenWriteModel(projectID, tokenID, resourceOwner string) *InitialAccessTokenWriteModel {
+	return &InitialAccessTokenWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		TokenID: tokenID,
+	}
+}
+
+func (wm *InitialAccessTokenWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.InitialAccessTokenAddedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.InitialAccessTokenRemovedEvent:
+			if wm.TokenID != e.TokenID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InitialAccessTokenWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.InitialAccessTokenAddedEvent:
+			wm.TokenHash = e.TokenHash
+			wm.Expiration = e.Expiration
+			wm.State = domain.InitialAccessTokenStateActive
+		case *project.InitialAccessTokenRemovedEvent:
+			wm.State = domain.InitialAccessTokenStateRemoved
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.InitialAccessTokenStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *InitialAccessTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.InitialAccessTokenAddedType,
+			project.InitialAccessTokenRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *InitialAccessTokenWriteModel) Exists() bool {
+	return wm.State != domain.InitialAccessTokenStateUnspecified && wm.State != domain.InitialAccessTokenStateRemoved
+}
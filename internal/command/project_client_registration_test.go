@@ -0,0 +1,860 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommands_AddInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore              *eventstore.Eventstore
+		idGenerator             id.Generator
+		clientRegistrationToken func(ids ...string) (string, string, error)
+	}
+	type args struct {
+		ctx            context.Context
+		projectID      string
+		resourceOwner  string
+		expirationDate time.Time
+	}
+	type res struct {
+		want  *domain.InitialAccessToken
+		token string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"project id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+			},
+			res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"project does not exist, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			"invalid expiration date, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args{
+				ctx:            context.Background(),
+				projectID:      "project1",
+				resourceOwner:  "org1",
+				expirationDate: time.Now().Add(-24 * time.Hour),
+			},
+			res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"token added",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewInitialAccessTokenAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+									"hash",
+									time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+								),
+							),
+						},
+					),
+				),
+				idGenerator:             id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+				clientRegistrationToken: mockClientRegistrationToken("token", "hash"),
+			},
+			args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				resourceOwner: "org1",
+			},
+			res{
+				want: &domain.InitialAccessToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					TokenID:    "token1",
+					Expiration: time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+				},
+				token: "token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:              tt.fields.eventstore,
+				idGenerator:             tt.fields.idGenerator,
+				clientRegistrationToken: tt.fields.clientRegistrationToken,
+			}
+			got, token, err := c.AddInitialAccessToken(tt.args.ctx, tt.args.projectID, tt.args.resourceOwner, tt.args.expirationDate)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.token, token)
+			}
+		})
+	}
+}
+
+func TestCommands_RemoveInitialAccessToken(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		projectID     string
+		tokenID       string
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"token does not exist, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			"token already removed, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								"hash",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+						eventFromEventPusher(
+							project.NewInitialAccessTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			"token removed",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								"hash",
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewInitialAccessTokenRemovedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				projectID:     "project1",
+				tokenID:       "token1",
+				resourceOwner: "org1",
+			},
+			res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.RemoveInitialAccessToken(tt.args.ctx, tt.args.projectID, tt.args.tokenID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommands_RegisterOIDCApplication(t *testing.T) {
+	initialAccessToken, initialAccessTokenHash := testClientRegistrationToken(t, "project1", "token1")
+	otherInitialAccessToken, _ := testClientRegistrationToken(t, "project1", "token1")
+	type fields struct {
+		eventstore              *eventstore.Eventstore
+		idGenerator             id.Generator
+		clientRegistrationToken func(ids ...string) (string, string, error)
+	}
+	type args struct {
+		ctx                context.Context
+		initialAccessToken string
+		oidcApp            *domain.OIDCApp
+	}
+	type res struct {
+		want  *domain.OIDCApp
+		token string
+		err   func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid token, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: "invalid",
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"wrong secret, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								initialAccessTokenHash,
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: otherInitialAccessToken,
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"token removed, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								initialAccessTokenHash,
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+						eventFromEventPusher(
+							project.NewInitialAccessTokenRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"token expired, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								initialAccessTokenHash,
+								time.Now().Add(-time.Hour),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				oidcApp:            &domain.OIDCApp{},
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"invalid app, invalid argument error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								initialAccessTokenHash,
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				oidcApp: &domain.OIDCApp{
+					AppName: "app",
+				},
+			},
+			res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"app registered",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewInitialAccessTokenAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"token1",
+								initialAccessTokenHash,
+								time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC),
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								project.NewApplicationAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"app",
+								),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigAddedEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									domain.OIDCVersionV1,
+									"app1",
+									"client1@project",
+									nil,
+									[]string{"https://test.ch"},
+									[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+									[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+									domain.OIDCApplicationTypeUserAgent,
+									domain.OIDCAuthMethodTypeNone,
+									nil,
+									false,
+									domain.OIDCTokenTypeBearer,
+									false,
+									false,
+									false,
+									0,
+									nil,
+									false,
+									false,
+									"",
+									"",
+									domain.LevelOfAssuranceNone),
+							),
+							eventFromEventPusher(
+								project.NewOIDCConfigRegistrationAccessTokenSetEvent(context.Background(),
+									&project.NewAggregate("project1", "org1").Aggregate,
+									"app1",
+									"registration1",
+									"hash",
+								),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(project.NewAddApplicationUniqueConstraint("app", "project1")),
+					),
+				),
+				idGenerator:             id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1", "registration1"),
+				clientRegistrationToken: mockClientRegistrationToken("token", "hash"),
+			},
+			args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				oidcApp: &domain.OIDCApp{
+					AppName:         "app",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeUserAgent,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+				},
+			},
+			res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:           "app1",
+					AppName:         "app",
+					ClientID:        "client1@project",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					OIDCVersion:     domain.OIDCVersionV1,
+					RedirectUris:    []string{"https://test.ch"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeUserAgent,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+					State:           domain.AppStateActive,
+					Compliance:      &domain.Compliance{},
+				},
+				token: "token",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:              tt.fields.eventstore,
+				idGenerator:             tt.fields.idGenerator,
+				clientRegistrationToken: tt.fields.clientRegistrationToken,
+			}
+			got, token, err := c.RegisterOIDCApplication(tt.args.ctx, tt.args.initialAccessToken, tt.args.oidcApp)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.token, token)
+			}
+		})
+	}
+}
+
+func TestCommands_VerifyOIDCRegistrationAccessToken(t *testing.T) {
+	registrationAccessToken, registrationAccessTokenHash := testClientRegistrationToken(t, "project1", "app1", "registration1")
+	otherRegistrationAccessToken, _ := testClientRegistrationToken(t, "project1", "app1", "registration1")
+	otherAppToken, _ := testClientRegistrationToken(t, "project1", "app2", "registration1")
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx       context.Context
+		projectID string
+		appID     string
+		token     string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"token of other app, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				token:     otherAppToken,
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"app removed, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigRegistrationAccessTokenSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"registration1",
+								registrationAccessTokenHash,
+							),
+						),
+						eventFromEventPusher(
+							project.NewApplicationRemovedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				token:     registrationAccessToken,
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"token replaced, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								nil,
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								nil,
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigRegistrationAccessTokenSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"registration2",
+								"hash",
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				token:     registrationAccessToken,
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"wrong secret, permission denied error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								nil,
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								nil,
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigRegistrationAccessTokenSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"registration1",
+								registrationAccessTokenHash,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				token:     otherRegistrationAccessToken,
+			},
+			res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			"token valid",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								nil,
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								nil,
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								false,
+								"",
+								"",
+								domain.LevelOfAssuranceNone),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigRegistrationAccessTokenSetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"registration1",
+								registrationAccessTokenHash,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:       context.Background(),
+				projectID: "project1",
+				appID:     "app1",
+				token:     registrationAccessToken,
+			},
+			res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := c.VerifyOIDCRegistrationAccessToken(tt.args.ctx, tt.args.projectID, tt.args.appID, tt.args.token)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func mockClientRegistrationToken(token, hash string) func(ids ...string) (string, string, error) {
+	return func(...string) (string, string, error) {
+		return token, hash, nil
+	}
+}
+
+func testClientRegistrationToken(t *testing.T, ids ...string) (token, hash string) {
+	t.Helper()
+	token, hash, err := domain.NewClientRegistrationToken(ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token, hash
+}
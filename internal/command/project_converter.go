@@ -54,6 +54,11 @@ func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.O
 		IDTokenUserinfoAssertion: writeModel.IDTokenUserinfoAssertion,
 		ClockSkew:                writeModel.ClockSkew,
 		AdditionalOrigins:        writeModel.AdditionalOrigins,
+		RequirePushedAuthRequest: writeModel.RequirePushedAuthRequest,
+		RequireRequestObject:     writeModel.RequireRequestObject,
+		BackChannelLogoutURI:     writeModel.BackChannelLogoutURI,
+		FrontChannelLogoutURI:    writeModel.FrontChannelLogoutURI,
+		MinLevelOfAssurance:      writeModel.MinLevelOfAssurance,
 	}
 }
 
@@ -68,6 +73,18 @@ func apiWriteModelToAPIConfig(writeModel *APIApplicationWriteModel) *domain.APIA
 	}
 }
 
+func samlWriteModelToSAMLConfig(writeModel *SAMLApplicationWriteModel) *domain.SAMLApp {
+	return &domain.SAMLApp{
+		ObjectRoot:  writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:       writeModel.AppID,
+		AppName:     writeModel.AppName,
+		State:       writeModel.State,
+		EntityID:    writeModel.EntityID,
+		Metadata:    writeModel.Metadata,
+		MetadataURL: writeModel.MetadataURL,
+	}
+}
+
 func roleWriteModelToRole(writeModel *ProjectRoleWriteModel) *domain.ProjectRole {
 	return &domain.ProjectRole{
 		ObjectRoot:  writeModelToObjectRoot(writeModel.WriteModel),
@@ -0,0 +1,56 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+)
+
+type SAMLConfigWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID string
+	EntityID    string
+	Metadata    []byte
+	MetadataURL string
+	State       domain.IDPConfigState
+}
+
+func (wm *SAMLConfigWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idpconfig.SAMLConfigAddedEvent:
+			wm.reduceConfigAddedEvent(e)
+		case *idpconfig.SAMLConfigChangedEvent:
+			wm.reduceConfigChangedEvent(e)
+		case *idpconfig.IDPConfigDeactivatedEvent:
+			wm.State = domain.IDPConfigStateInactive
+		case *idpconfig.IDPConfigReactivatedEvent:
+			wm.State = domain.IDPConfigStateActive
+		case *idpconfig.IDPConfigRemovedEvent:
+			wm.State = domain.IDPConfigStateRemoved
+		}
+	}
+
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLConfigWriteModel) reduceConfigAddedEvent(e *idpconfig.SAMLConfigAddedEvent) {
+	wm.IDPConfigID = e.IDPConfigID
+	wm.EntityID = e.EntityID
+	wm.Metadata = e.Metadata
+	wm.MetadataURL = e.MetadataURL
+	wm.State = domain.IDPConfigStateActive
+}
+
+func (wm *SAMLConfigWriteModel) reduceConfigChangedEvent(e *idpconfig.SAMLConfigChangedEvent) {
+	if e.EntityID != nil {
+		wm.EntityID = *e.EntityID
+	}
+	if e.Metadata != nil {
+		wm.Metadata = e.Metadata
+	}
+	if e.MetadataURL != nil {
+		wm.MetadataURL = *e.MetadataURL
+	}
+}
@@ -546,7 +546,9 @@ func createRegisterHumanEvent(ctx context.Context, aggregate *eventstore.Aggrega
 	return addEvent
 }
 
-func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []string) error {
+// HumansSignOut ends the sessions of the users on the user agent
+// and requests the delivery of the logout tokens to the back-channel logout uris of the OIDC applications
+func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []string, backChannelLogouts []*domain.BackChannelLogout) error {
 	if agentID == "" {
 		return errors.ThrowInvalidArgument(nil, "COMMAND-2M0ds", "Errors.User.UserIDMissing")
 	}
@@ -566,6 +568,20 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 			ctx,
 			UserAggregateFromWriteModel(&existingUser.WriteModel),
 			agentID))
+		for _, logout := range backChannelLogouts {
+			if logout.UserID != userID {
+				continue
+			}
+			events = append(events, user.NewHumanBackChannelLogoutRequestedEvent(
+				ctx,
+				UserAggregateFromWriteModel(&existingUser.WriteModel),
+				logout.ID,
+				agentID,
+				logout.ClientID,
+				logout.LogoutURI,
+				logout.LogoutToken,
+				logout.Expiry))
+		}
 	}
 	if len(events) == 0 {
 		return nil
@@ -574,6 +590,54 @@ func (c *Commands) HumansSignOut(ctx context.Context, agentID string, userIDs []
 	return err
 }
 
+// TerminateHumanSessions ends the sessions of the user on the user agents
+// and revokes the refresh tokens which were issued on them
+func (c *Commands) TerminateHumanSessions(ctx context.Context, userID, resourceOwner string, agentIDs []string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Oow4e", "Errors.User.UserIDMissing")
+	}
+	if len(agentIDs) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ahc3E", "Errors.User.Session.AgentIDMissing")
+	}
+	existingUser, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-eeM3i", "Errors.User.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	events := make([]eventstore.Command, 0, len(agentIDs))
+	for _, agentID := range agentIDs {
+		events = append(events, user.NewHumanSignedOutEvent(ctx, userAgg, agentID))
+	}
+	refreshTokenEvents, err := c.removeUserAgentsRefreshTokens(ctx, userAgg, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, append(events, refreshTokenEvents...)...)
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+// HumanBackChannelLogoutSent marks the logout token as delivered to the back-channel logout uri
+func (c *Commands) HumanBackChannelLogoutSent(ctx context.Context, userID, resourceOwner, logoutID string) error {
+	if userID == "" || logoutID == "" {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Ahgh8", "Errors.IDMissing")
+	}
+	existingUser, err := c.getHumanWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-eiG5k", "Errors.User.NotFound")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanBackChannelLogoutSentEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel), logoutID))
+	return err
+}
+
 func (c *Commands) getHumanWriteModelByID(ctx context.Context, userID, resourceowner string) (*HumanWriteModel, error) {
 	humanWriteModel := NewHumanWriteModel(userID, resourceowner)
 	err := c.eventstore.FilterToQueryReducer(ctx, humanWriteModel)
@@ -571,6 +571,8 @@ func TestCommandSide_VerifyInitCode(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
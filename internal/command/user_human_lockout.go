@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// UnlockUserAfterLockout unlocks the user if it was locked because of too many failed checks
+// and the auto unlock duration of the lockout policy has passed.
+// It returns if the user was unlocked.
+func (c *Commands) UnlockUserAfterLockout(ctx context.Context, userID, resourceOwner string, lockoutPolicy *domain.LockoutPolicy) (bool, error) {
+	if userID == "" {
+		return false, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eeb8u", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.lockoutWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return false, err
+	}
+	if writeModel.UserState != domain.UserStateLocked || !writeModel.LockedByLockout || !lockoutPolicy.LockoutExpired(writeModel.LockedAt) {
+		return false, nil
+	}
+	_, err = c.eventstore.Push(ctx, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&writeModel.WriteModel)))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func maxOTPAttempts(lockoutPolicy *domain.LockoutPolicy) uint64 {
+	if lockoutPolicy == nil {
+		return 0
+	}
+	return lockoutPolicy.MaxOTPAttempts
+}
+
+func maxU2FAttempts(lockoutPolicy *domain.LockoutPolicy) uint64 {
+	if lockoutPolicy == nil {
+		return 0
+	}
+	return lockoutPolicy.MaxU2FAttempts
+}
+
+func otpCheckFailedCount(wm *HumanLockoutWriteModel) uint64 {
+	return wm.OTPCheckFailedCount
+}
+
+func u2fCheckFailedCount(wm *HumanLockoutWriteModel) uint64 {
+	return wm.U2FCheckFailedCount
+}
+
+// mfaCheckFailedEvents adds the user locked event to the failed check
+// if the failed attempts of the second factor reach the maximum of the lockout policy
+// if the lockout state can't be read, the error is returned, so the check fails without a decision
+func (c *Commands) mfaCheckFailedEvents(ctx context.Context, userAgg *eventstore.Aggregate, checkFailed eventstore.Command, maxAttempts uint64, failedCount func(*HumanLockoutWriteModel) uint64) ([]eventstore.Command, error) {
+	events := []eventstore.Command{checkFailed}
+	if maxAttempts == 0 {
+		return events, nil
+	}
+	writeModel, err := c.lockoutWriteModelByID(ctx, userAgg.ID, userAgg.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.UserState != domain.UserStateLocked && failedCount(writeModel)+1 >= maxAttempts {
+		events = append(events, user.NewUserLockedByLockoutEvent(ctx, userAgg))
+	}
+	return events, nil
+}
+
+func (c *Commands) lockoutWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanLockoutWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanLockoutWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// HumanLockoutWriteModel counts the failed second factor checks since the last successful check of the same kind
+// and keeps track of locks caused by the lockout policy
+type HumanLockoutWriteModel struct {
+	eventstore.WriteModel
+
+	UserState           domain.UserState
+	LockedAt            time.Time
+	LockedByLockout     bool
+	OTPCheckFailedCount uint64
+	U2FCheckFailedCount uint64
+}
+
+func NewHumanLockoutWriteModel(userID, resourceOwner string) *HumanLockoutWriteModel {
+	return &HumanLockoutWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanLockoutWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent,
+			*user.HumanRegisteredEvent,
+			*user.UserReactivatedEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserDeactivatedEvent:
+			wm.UserState = domain.UserStateInactive
+		case *user.UserLockedEvent:
+			wm.UserState = domain.UserStateLocked
+			wm.LockedAt = e.CreationDate()
+			wm.LockedByLockout = e.Lockout
+		case *user.UserUnlockedEvent:
+			wm.UserState = domain.UserStateActive
+			wm.LockedByLockout = false
+			wm.OTPCheckFailedCount = 0
+			wm.U2FCheckFailedCount = 0
+		case *user.HumanOTPCheckFailedEvent,
+			*user.HumanOTPSMSCheckFailedEvent,
+			*user.HumanOTPEmailCheckFailedEvent,
+			*user.HumanRecoveryCodeCheckFailedEvent:
+			wm.OTPCheckFailedCount++
+		case *user.HumanOTPCheckSucceededEvent,
+			*user.HumanOTPSMSCheckSucceededEvent,
+			*user.HumanOTPEmailCheckSucceededEvent,
+			*user.HumanRecoveryCodeCheckSucceededEvent:
+			wm.OTPCheckFailedCount = 0
+		case *user.HumanU2FCheckFailedEvent:
+			wm.U2FCheckFailedCount++
+		case *user.HumanU2FCheckSucceededEvent:
+			wm.U2FCheckFailedCount = 0
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanLockoutWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.HumanMFAOTPCheckFailedType,
+			user.HumanMFAOTPCheckSucceededType,
+			user.HumanMFAOTPSMSCheckFailedType,
+			user.HumanMFAOTPSMSCheckSucceededType,
+			user.HumanMFAOTPEmailCheckFailedType,
+			user.HumanMFAOTPEmailCheckSucceededType,
+			user.HumanMFARecoveryCodeCheckFailedType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.HumanU2FTokenCheckFailedType,
+			user.HumanU2FTokenCheckSucceededType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// HumanRequestMagicLink creates a new single-use code bound to the auth request,
+// which will be sent as login link to the verified email of the user by the notification handler
+func (c *Commands) HumanRequestMagicLink(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-ieR2o", "Errors.User.UserIDMissing")
+	}
+	if authRequest == nil || authRequest.ID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Oot4a", "Errors.AuthRequest.NotFound")
+	}
+	magicLinkWriteModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(magicLinkWriteModel.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Mah7i", "Errors.User.NotFound")
+	}
+	if !magicLinkWriteModel.EmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Yoh3e", "Errors.User.MagicLink.EmailNotVerified")
+	}
+	code, expiry, err := newCryptoCodeWithExpiry(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeMagicLink, c.userEncryption)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&magicLinkWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanMagicLinkCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kah5e", "Errors.User.UserIDMissing")
+	}
+	magicLinkWriteModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if magicLinkWriteModel.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ung8i", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&magicLinkWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+// HumanCheckMagicLink verifies the code of the login link.
+// The code can only be used once and only for the auth request it was created for,
+// so every check (successful or not) invalidates it.
+func (c *Commands) HumanCheckMagicLink(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eex1u", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-zo9Ai", "Errors.User.Code.Empty")
+	}
+	if authRequest == nil {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-ooD1u", "Errors.AuthRequest.NotFound")
+	}
+	magicLinkWriteModel, err := c.magicLinkWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(magicLinkWriteModel.UserState) {
+		return caos_errs.ThrowNotFound(nil, "COMMAND-Jei7o", "Errors.User.NotFound")
+	}
+	if magicLinkWriteModel.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Iek4i", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&magicLinkWriteModel.WriteModel)
+	err = crypto.VerifyCode(magicLinkWriteModel.CodeCreationDate, magicLinkWriteModel.CodeExpiry, magicLinkWriteModel.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil && magicLinkWriteModel.AuthRequestID != authRequest.ID {
+		err = caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ae6ch", "Errors.User.MagicLink.AuthRequestMismatch")
+	}
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	_, pushErr := c.eventstore.Push(ctx, user.NewHumanMagicLinkCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("magic link check failed event push failed")
+	return err
+}
+
+func (c *Commands) magicLinkWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanMagicLinkWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanMagicLinkWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
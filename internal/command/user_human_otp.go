@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zitadel/logging"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -91,7 +92,7 @@ func (c *Commands) HumanCheckMFAOTPSetup(ctx context.Context, userID, code, user
 	return writeModelToObjectDetails(&existingOTP.WriteModel), nil
 }
 
-func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest) error {
+func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceowner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
 	if userID == "" {
 		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-8N9ds", "Errors.User.UserIDMissing")
 	}
@@ -108,7 +109,13 @@ func (c *Commands) HumanCheckMFAOTP(ctx context.Context, userID, code, resourceo
 		_, err = c.eventstore.Push(ctx, user.NewHumanOTPCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 		return err
 	}
-	_, pushErr := c.eventstore.Push(ctx, user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+	events, lockoutErr := c.mfaCheckFailedEvents(ctx, userAgg,
+		user.NewHumanOTPCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+		maxOTPAttempts(lockoutPolicy), otpCheckFailedCount)
+	if lockoutErr != nil {
+		return lockoutErr
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
 	logging.Log("COMMAND-9fj7s").OnError(pushErr).Error("error create password check failed event")
 	return err
 }
@@ -148,3 +155,273 @@ func (c *Commands) otpWriteModelByID(ctx context.Context, userID, resourceOwner
 	}
 	return writeModel, nil
 }
+
+func (c *Commands) AddHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Sie7o", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !otpWriteModel.UserState.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ahl2u", "Errors.User.NotFound")
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Quu3e", "Errors.User.MFA.OTPSMS.AlreadyReady")
+	}
+	if !otpWriteModel.PhoneVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Chae4", "Errors.User.MFA.OTPSMS.PhoneNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPSMS(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Oqu0o", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Fie8u", "Errors.User.MFA.OTPSMS.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPSMSRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPSMSCode creates a new one-time code, which will be sent to the verified phone of the user by the notification handler
+func (c *Commands) HumanSendOTPSMSCode(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Poh5i", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady || !otpWriteModel.PhoneVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ye6ai", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	code, expiry, err := newCryptoCodeWithExpiry(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPSMS, c.userEncryption)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPSMSCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ahx8e", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohb1x", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPSMS(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Kuo9p", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Wa2ni", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpSMSWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ij5oo", "Errors.User.MFA.OTPSMS.NotReady")
+	}
+	if otpWriteModel.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Uu8ie", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	err = crypto.VerifyCode(otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPSMSCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	events, lockoutErr := c.mfaCheckFailedEvents(ctx, userAgg,
+		user.NewHumanOTPSMSCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+		maxOTPAttempts(lockoutPolicy), otpCheckFailedCount)
+	if lockoutErr != nil {
+		return lockoutErr
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("otp sms check failed event push failed")
+	return err
+}
+
+func (c *Commands) otpSMSWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPSMSWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPSMSWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func (c *Commands) AddHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ohr3o", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !otpWriteModel.UserState.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ko0ah", "Errors.User.NotFound")
+	}
+	if otpWriteModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-Ooh9a", "Errors.User.MFA.OTPEmail.AlreadyReady")
+	}
+	if !otpWriteModel.EmailVerified {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Nei3u", "Errors.User.MFA.OTPEmail.EmailNotVerified")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailAddedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveHumanOTPEmail(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Eup3i", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Aeg2o", "Errors.User.MFA.OTPEmail.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanOTPEmailRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(otpWriteModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&otpWriteModel.WriteModel), nil
+}
+
+// HumanSendOTPEmailCode creates a new one-time code, which will be sent to the verified email of the user by the notification handler
+func (c *Commands) HumanSendOTPEmailCode(ctx context.Context, userID, resourceOwner string, authRequest *domain.AuthRequest) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ri8ee", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady || !otpWriteModel.EmailVerified {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Leo0a", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	code, expiry, err := newCryptoCodeWithExpiry(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeOTPEmail, c.userEncryption)
+	if err != nil {
+		return err
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeAddedEvent(ctx, userAgg, code, expiry, authRequestDomainToAuthRequestInfo(authRequest)))
+	return err
+}
+
+func (c *Commands) HumanOTPEmailCodeSent(ctx context.Context, userID, resourceOwner string) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aif7o", "Errors.User.UserIDMissing")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Vah9u", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCodeSentEvent(ctx, userAgg))
+	return err
+}
+
+func (c *Commands) HumanCheckOTPEmail(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ene0i", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Ahs7r", "Errors.User.Code.Empty")
+	}
+	otpWriteModel, err := c.otpEmailWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if otpWriteModel.State != domain.MFAStateReady {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Xe3ee", "Errors.User.MFA.OTPEmail.NotReady")
+	}
+	if otpWriteModel.Code == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Phu5a", "Errors.User.Code.NotFound")
+	}
+	userAgg := UserAggregateFromWriteModel(&otpWriteModel.WriteModel)
+	err = crypto.VerifyCode(otpWriteModel.CodeCreationDate, otpWriteModel.CodeExpiry, otpWriteModel.Code, code, crypto.NewEncryptionGenerator(crypto.GeneratorConfig{}, c.userEncryption))
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanOTPEmailCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	events, lockoutErr := c.mfaCheckFailedEvents(ctx, userAgg,
+		user.NewHumanOTPEmailCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+		maxOTPAttempts(lockoutPolicy), otpCheckFailedCount)
+	if lockoutErr != nil {
+		return lockoutErr
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("otp email check failed event push failed")
+	return err
+}
+
+func (c *Commands) otpEmailWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanOTPEmailWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanOTPEmailWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -145,9 +145,61 @@ func (c *Commands) changePassword(ctx context.Context, userAgentID string, passw
 	if err := password.HashPasswordIfExisting(pwPolicy, c.userPasswordAlg); err != nil {
 		return nil, err
 	}
+	if password.SecretString != "" {
+		if err := c.checkBreachedPassword(pwPolicy, password.SecretString); err != nil {
+			return nil, err
+		}
+		if err := c.checkPasswordHistory(ctx, pwPolicy, password.SecretString, existingPassword.PasswordHistory); err != nil {
+			return nil, err
+		}
+	}
 	return user.NewHumanPasswordChangedEvent(ctx, userAgg, password.SecretCrypto, password.ChangeRequired, userAgentID), nil
 }
 
+func (c *Commands) checkBreachedPassword(policy *domain.PasswordComplexityPolicy, password string) error {
+	if !policy.CheckBreached {
+		return nil
+	}
+	if c.breachedPasswords == nil {
+		logging.Log("COMMAND-Iequ0").Warn("password complexity policy requires breached password check, but no list is configured")
+		return nil
+	}
+	breached, err := c.breachedPasswords.IsBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-eiM5a", "Errors.User.PasswordComplexityPolicy.Breached")
+	}
+	return nil
+}
+
+// checkPasswordHistory compares the password with the last (policy.HistoryDepth) secrets of the user
+func (c *Commands) checkPasswordHistory(ctx context.Context, policy *domain.PasswordComplexityPolicy, password string, history []*crypto.CryptoValue) (err error) {
+	if policy.HistoryDepth == 0 || len(history) == 0 {
+		return nil
+	}
+	_, span := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	defer func() { span.EndWithError(err) }()
+
+	if uint64(len(history)) > policy.HistoryDepth {
+		history = history[uint64(len(history))-policy.HistoryDepth:]
+	}
+	for _, secret := range history {
+		if crypto.CompareHash(secret, []byte(password), c.userPasswordAlg) == nil {
+			return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aes8o", "Errors.User.PasswordComplexityPolicy.PasswordReused")
+		}
+	}
+	return nil
+}
+
+func (c *Commands) checkBreachedPasswordsConfigured(checkBreached bool) error {
+	if checkBreached && c.breachedPasswords == nil {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ooL1i", "Errors.User.PasswordComplexityPolicy.BreachedListNotConfigured")
+	}
+	return nil
+}
+
 func (c *Commands) RequestSetPassword(ctx context.Context, userID, resourceOwner string, notifyType domain.NotificationType, passwordVerificationCode crypto.Generator) (objectDetails *domain.ObjectDetails, err error) {
 	if userID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-M00oL", "Errors.User.UserIDMissing")
@@ -196,7 +248,10 @@ func (c *Commands) PasswordCodeSent(ctx context.Context, orgID, userID string) (
 	return err
 }
 
-func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, password string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) (err error) {
+// HumanCheckPassword checks the password of the user
+// postAuthentication (e.g. actions) is called after the password matched and before the check is recorded as succeeded,
+// if it returns an error the check is not recorded and the error is returned
+func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, password string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy, postAuthentication func() error) (err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -232,14 +287,19 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	err = crypto.CompareHash(existingPassword.Secret, []byte(password), c.userPasswordAlg)
 	spanPasswordComparison.EndWithError(err)
 	if err == nil {
-		_, err = c.eventstore.Push(ctx, user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
+		if postAuthentication != nil {
+			if err = postAuthentication(); err != nil {
+				return err
+			}
+		}
+		_, err = c.eventstore.Push(ctx, c.passwordCheckSucceededEvents(ctx, userAgg, existingPassword.Secret, password, authRequest)...)
 		return err
 	}
 	events := make([]eventstore.Command, 0)
 	events = append(events, user.NewHumanPasswordCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)))
 	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 {
 		if existingPassword.PasswordCheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
-			events = append(events, user.NewUserLockedEvent(ctx, userAgg))
+			events = append(events, user.NewUserLockedByLockoutEvent(ctx, userAgg))
 		}
 
 	}
@@ -248,6 +308,23 @@ func (c *Commands) HumanCheckPassword(ctx context.Context, orgID, userID, passwo
 	return caos_errs.ThrowInvalidArgument(nil, "COMMAND-452ad", "Errors.User.Password.Invalid")
 }
 
+// passwordCheckSucceededEvents replaces the hash of the checked password,
+// if it wasn't created with the configured algorithm and parameters (e.g. of an imported user)
+func (c *Commands) passwordCheckSucceededEvents(ctx context.Context, userAgg *eventstore.Aggregate, existingSecret *crypto.CryptoValue, password string, authRequest *domain.AuthRequest) []eventstore.Command {
+	events := []eventstore.Command{
+		user.NewHumanPasswordCheckSucceededEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+	}
+	if !crypto.NeedsRehash(existingSecret, c.userPasswordAlg) {
+		return events
+	}
+	secret, err := crypto.Hash([]byte(password), c.userPasswordAlg)
+	if err != nil {
+		logging.Log("COMMAND-Aep2u").WithError(err).Warn("unable to rehash password")
+		return events
+	}
+	return append(events, user.NewHumanPasswordHashUpdatedEvent(ctx, userAgg, secret))
+}
+
 func (c *Commands) passwordWriteModel(ctx context.Context, userID, resourceOwner string) (writeModel *HumanPasswordWriteModel, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
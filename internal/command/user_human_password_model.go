@@ -15,6 +15,8 @@ type HumanPasswordWriteModel struct {
 
 	Secret               *crypto.CryptoValue
 	SecretChangeRequired bool
+	// PasswordHistory contains the most recent secrets (including the current one), oldest first
+	PasswordHistory []*crypto.CryptoValue
 
 	Code                     *crypto.CryptoValue
 	CodeCreationDate         time.Time
@@ -40,10 +42,12 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
+			wm.appendPasswordHistory(e.Secret)
 		case *user.HumanRegisteredEvent:
 			wm.Secret = e.Secret
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.UserState = domain.UserStateActive
+			wm.appendPasswordHistory(e.Secret)
 		case *user.HumanInitialCodeAddedEvent:
 			wm.UserState = domain.UserStateInitial
 		case *user.HumanInitializedCheckSucceededEvent:
@@ -53,6 +57,12 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 			wm.SecretChangeRequired = e.ChangeRequired
 			wm.Code = nil
 			wm.PasswordCheckFailedCount = 0
+			wm.appendPasswordHistory(e.Secret)
+		case *user.HumanPasswordHashUpdatedEvent:
+			wm.Secret = e.Secret
+			if len(wm.PasswordHistory) > 0 {
+				wm.PasswordHistory[len(wm.PasswordHistory)-1] = e.Secret
+			}
 		case *user.HumanPasswordCodeAddedEvent:
 			wm.Code = e.Code
 			wm.CodeCreationDate = e.CreationDate()
@@ -74,6 +84,17 @@ func (wm *HumanPasswordWriteModel) Reduce() error {
 	return wm.WriteModel.Reduce()
 }
 
+// appendPasswordHistory keeps at most domain.MaxPasswordHistoryDepth secrets
+func (wm *HumanPasswordWriteModel) appendPasswordHistory(secret *crypto.CryptoValue) {
+	if secret == nil {
+		return
+	}
+	wm.PasswordHistory = append(wm.PasswordHistory, secret)
+	if len(wm.PasswordHistory) > domain.MaxPasswordHistoryDepth {
+		wm.PasswordHistory = wm.PasswordHistory[len(wm.PasswordHistory)-domain.MaxPasswordHistoryDepth:]
+	}
+}
+
 func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
 		AddQuery().
@@ -84,6 +105,7 @@ func (wm *HumanPasswordWriteModel) Query() *eventstore.SearchQueryBuilder {
 			user.HumanInitialCodeAddedType,
 			user.HumanInitializedCheckSucceededType,
 			user.HumanPasswordChangedType,
+			user.HumanPasswordHashUpdatedType,
 			user.HumanPasswordCodeAddedType,
 			user.HumanEmailVerifiedType,
 			user.HumanPasswordCheckFailedType,
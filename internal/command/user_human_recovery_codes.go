@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddHumanRecoveryCodes generates the recovery codes of the user,
+// the plain codes are only returned once and can't be retrieved afterwards
+func (c *Commands) AddHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ieR4e", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.UserState.Exists() {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Oor5a", "Errors.User.NotFound")
+	}
+	if writeModel.State == domain.MFAStateReady {
+		return nil, caos_errs.ThrowAlreadyExists(nil, "COMMAND-ooJ2u", "Errors.User.MFA.RecoveryCodes.AlreadyReady")
+	}
+	return c.pushRecoveryCodes(ctx, writeModel)
+}
+
+// RegenerateHumanRecoveryCodes replaces all (used and unused) recovery codes of the user with new ones
+func (c *Commands) RegenerateHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.RecoveryCodes, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Xei9a", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Mah3o", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	return c.pushRecoveryCodes(ctx, writeModel)
+}
+
+func (c *Commands) pushRecoveryCodes(ctx context.Context, writeModel *HumanRecoveryCodesWriteModel) (*domain.RecoveryCodes, error) {
+	codes, hashedCodes, err := domain.NewRecoveryCodes(c.userPasswordAlg)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodesAddedEvent(ctx, userAgg, hashedCodes))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RecoveryCodes{
+		ObjectRoot: writeModelToObjectRoot(writeModel.WriteModel),
+		Codes:      codes,
+	}, nil
+}
+
+func (c *Commands) RemoveHumanRecoveryCodes(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-aeR0e", "Errors.User.UserIDMissing")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.MFAStateReady {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ix8ei", "Errors.User.MFA.RecoveryCodes.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewHumanRecoveryCodesRemovedEvent(ctx, userAgg))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// HumanCheckRecoveryCode verifies the code against the unused recovery codes of the user
+// and invalidates it on success
+func (c *Commands) HumanCheckRecoveryCode(ctx context.Context, userID, code, resourceOwner string, authRequest *domain.AuthRequest, lockoutPolicy *domain.LockoutPolicy) error {
+	if userID == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-Gai4i", "Errors.User.UserIDMissing")
+	}
+	if code == "" {
+		return caos_errs.ThrowInvalidArgument(nil, "COMMAND-nae1E", "Errors.User.Code.Empty")
+	}
+	writeModel, err := c.recoveryCodesWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if writeModel.State != domain.MFAStateReady || writeModel.RemainingCodes() == 0 {
+		return caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Zie6u", "Errors.User.MFA.RecoveryCodes.NotReady")
+	}
+	userAgg := UserAggregateFromWriteModel(&writeModel.WriteModel)
+	index, err := domain.VerifyRecoveryCode(code, writeModel.Codes, c.userPasswordAlg)
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewHumanRecoveryCodeCheckSucceededEvent(ctx, userAgg, index, authRequestDomainToAuthRequestInfo(authRequest)))
+		return err
+	}
+	events, lockoutErr := c.mfaCheckFailedEvents(ctx, userAgg,
+		user.NewHumanRecoveryCodeCheckFailedEvent(ctx, userAgg, authRequestDomainToAuthRequestInfo(authRequest)),
+		maxOTPAttempts(lockoutPolicy), otpCheckFailedCount)
+	if lockoutErr != nil {
+		return lockoutErr
+	}
+	_, pushErr := c.eventstore.Push(ctx, events...)
+	logging.WithFields("userID", userAgg.ID).OnError(pushErr).Error("recovery code check failed event push failed")
+	return err
+}
+
+func (c *Commands) recoveryCodesWriteModelByID(ctx context.Context, userID, resourceOwner string) (writeModel *HumanRecoveryCodesWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewHumanRecoveryCodesWriteModel(userID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
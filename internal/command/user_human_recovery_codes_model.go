@@ -0,0 +1,81 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type HumanRecoveryCodesWriteModel struct {
+	eventstore.WriteModel
+
+	State     domain.MFAState
+	UserState domain.UserState
+
+	// Codes contains the hashed codes of the last generation, used codes are set to nil
+	Codes []*crypto.CryptoValue
+}
+
+func NewHumanRecoveryCodesWriteModel(userID, resourceOwner string) *HumanRecoveryCodesWriteModel {
+	return &HumanRecoveryCodesWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent,
+			*user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.HumanRecoveryCodesAddedEvent:
+			wm.State = domain.MFAStateReady
+			wm.Codes = e.Codes
+		case *user.HumanRecoveryCodeCheckSucceededEvent:
+			if e.CodeIndex >= 0 && e.CodeIndex < len(wm.Codes) {
+				wm.Codes[e.CodeIndex] = nil
+			}
+		case *user.HumanRecoveryCodesRemovedEvent:
+			wm.State = domain.MFAStateRemoved
+			wm.Codes = nil
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+			wm.State = domain.MFAStateRemoved
+			wm.Codes = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRecoveryCodesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.HumanAddedType,
+			user.HumanRegisteredType,
+			user.HumanMFARecoveryCodesAddedType,
+			user.HumanMFARecoveryCodeCheckSucceededType,
+			user.HumanMFARecoveryCodesRemovedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *HumanRecoveryCodesWriteModel) RemainingCodes() int {
+	remaining := 0
+	for _, code := range wm.Codes {
+		if code != nil {
+			remaining++
+		}
+	}
+	return remaining
+}
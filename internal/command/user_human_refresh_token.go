@@ -24,11 +24,12 @@ func (c *Commands) AddAccessAndRefreshToken(
 	refreshIdleExpiration,
 	refreshExpiration time.Duration,
 	authTime time.Time,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, confirmation)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, confirmation)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,6 +45,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || agentID == "" || clientID == "" {
 		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
@@ -53,7 +55,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, nil, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -79,13 +81,14 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
+	confirmation *domain.TokenConfirmation,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, nil, confirmation)
 	if err != nil {
 		return nil, "", err
 	}
@@ -193,3 +196,19 @@ func (c *Commands) removeRefreshToken(ctx context.Context, userID, orgID, tokenI
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID), refreshTokenWriteModel, nil
 }
+
+// removeUserAgentsRefreshTokens returns the events to revoke all refresh tokens of the user,
+// which were issued on one of the user agents
+func (c *Commands) removeUserAgentsRefreshTokens(ctx context.Context, userAgg *eventstore.Aggregate, agentIDs []string) ([]eventstore.Command, error) {
+	refreshTokensWriteModel := NewHumanRefreshTokensWriteModel(userAgg.ID, userAgg.ResourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, refreshTokensWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	tokenIDs := refreshTokensWriteModel.TokenIDsByUserAgents(agentIDs)
+	events := make([]eventstore.Command, len(tokenIDs))
+	for i, tokenID := range tokenIDs {
+		events[i] = user.NewHumanRefreshTokenRemovedEvent(ctx, userAgg, tokenID)
+	}
+	return events, nil
+}
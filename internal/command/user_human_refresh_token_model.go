@@ -98,3 +98,81 @@ func (wm *HumanRefreshTokenWriteModel) Query() *eventstore.SearchQueryBuilder {
 	}
 	return query
 }
+
+// HumanRefreshTokensWriteModel holds the active refresh tokens of a user
+// and the user agents they were issued on
+type HumanRefreshTokensWriteModel struct {
+	eventstore.WriteModel
+
+	Tokens []*userAgentRefreshToken
+}
+
+type userAgentRefreshToken struct {
+	TokenID     string
+	UserAgentID string
+}
+
+func NewHumanRefreshTokensWriteModel(userID, resourceOwner string) *HumanRefreshTokensWriteModel {
+	return &HumanRefreshTokensWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *HumanRefreshTokensWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanRefreshTokenAddedEvent:
+			wm.Tokens = append(wm.Tokens, &userAgentRefreshToken{
+				TokenID:     e.TokenID,
+				UserAgentID: e.UserAgentID,
+			})
+		case *user.HumanRefreshTokenRemovedEvent:
+			for i, token := range wm.Tokens {
+				if token.TokenID == e.TokenID {
+					wm.Tokens = append(wm.Tokens[:i], wm.Tokens[i+1:]...)
+					break
+				}
+			}
+		case *user.UserLockedEvent,
+			*user.UserDeactivatedEvent,
+			*user.UserRemovedEvent:
+			wm.Tokens = nil
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *HumanRefreshTokensWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.HumanRefreshTokenAddedType,
+			user.HumanRefreshTokenRemovedType,
+			user.UserLockedType,
+			user.UserDeactivatedType,
+			user.UserRemovedType).
+		Builder()
+
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+func (wm *HumanRefreshTokensWriteModel) TokenIDsByUserAgents(agentIDs []string) []string {
+	tokenIDs := make([]string, 0)
+	for _, token := range wm.Tokens {
+		for _, agentID := range agentIDs {
+			if token.UserAgentID == agentID {
+				tokenIDs = append(tokenIDs, token.TokenID)
+				break
+			}
+		}
+	}
+	return tokenIDs
+}
@@ -273,6 +273,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -364,6 +366,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -434,6 +438,8 @@ func TestCommandSide_AddHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -760,6 +766,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -803,6 +811,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -894,6 +904,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -955,6 +967,157 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add human with invalid password hash, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+								0,
+								false,
+							),
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				human: &domain.Human{
+					Username: "username",
+					Password: &domain.Password{
+						EncodedHash: "md5$password",
+					},
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						PreferredLanguage: language.English,
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+				},
+				secretGenerator: GetMockSecretGenerator(t),
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add human email verified with password hash, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewDomainPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								true,
+								true,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								1,
+								false,
+								false,
+								false,
+								false,
+								0,
+								false,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								func() *user.HumanAddedEvent {
+									event := newAddHumanEvent("", false, "")
+									event.AddPasswordData(&crypto.CryptoValue{
+										CryptoType: crypto.TypeHash,
+										Algorithm:  "pbkdf2",
+										Crypted:    []byte("$pbkdf2-sha256$i=1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c"),
+									}, false)
+									return event
+								}(),
+							),
+							eventFromEventPusher(
+								user.NewHumanEmailVerifiedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate),
+							),
+						},
+						uniqueConstraintsFromEventConstraint(user.NewAddUsernameUniqueConstraint("username", "org1", true)),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+				userPasswordAlg: crypto.CreateMockHashAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				human: &domain.Human{
+					Username: "username",
+					Password: &domain.Password{
+						EncodedHash: "pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+					},
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						PreferredLanguage: language.English,
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+				},
+				secretGenerator: GetMockSecretGenerator(t),
+			},
+			res: res{
+				wantHuman: &domain.Human{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "user1",
+						ResourceOwner: "org1",
+					},
+					Username: "username",
+					Profile: &domain.Profile{
+						FirstName:         "firstname",
+						LastName:          "lastname",
+						DisplayName:       "firstname lastname",
+						PreferredLanguage: language.English,
+					},
+					Email: &domain.Email{
+						EmailAddress:    "email@test.ch",
+						IsEmailVerified: true,
+					},
+					State: domain.UserStateActive,
+				},
+			},
+		},
 		{
 			name: "add human email verified passwordless only, ok",
 			fields: fields{
@@ -979,6 +1142,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1086,6 +1251,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1197,6 +1364,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1304,6 +1473,8 @@ func TestCommandSide_ImportHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1548,6 +1719,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1596,6 +1769,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1609,6 +1784,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1662,6 +1838,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1675,6 +1853,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1728,6 +1907,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1741,6 +1922,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1811,6 +1993,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1824,6 +2008,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -1952,6 +2137,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -1965,6 +2152,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2061,6 +2249,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2074,6 +2264,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2164,6 +2355,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2177,6 +2370,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2289,6 +2483,8 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								0,
+								false,
 							),
 						),
 					),
@@ -2302,6 +2498,7 @@ func TestCommandSide_RegisterHuman(t *testing.T) {
 								false,
 								false,
 								false,
+								false,
 								domain.PasswordlessTypeNotAllowed,
 								"",
 								time.Hour*1,
@@ -2527,9 +2724,10 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 	}
 	type (
 		args struct {
-			ctx     context.Context
-			agentID string
-			userIDs []string
+			ctx                context.Context
+			agentID            string
+			userIDs            []string
+			backChannelLogouts []*domain.BackChannelLogout
 		}
 	)
 	type res struct {
@@ -2699,13 +2897,86 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "human sign out with back-channel logout, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanBackChannelLogoutRequestedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"logout1",
+									"agent1",
+									"client1",
+									"https://app.ch/logout/backchannel",
+									"logout-token",
+									10*time.Minute,
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:     context.Background(),
+				agentID: "agent1",
+				userIDs: []string{"user1"},
+				backChannelLogouts: []*domain.BackChannelLogout{
+					{
+						ID:          "logout1",
+						UserID:      "user1",
+						ClientID:    "client1",
+						LogoutURI:   "https://app.ch/logout/backchannel",
+						LogoutToken: "logout-token",
+						Expiry:      10 * time.Minute,
+					},
+					{
+						ID:          "logout2",
+						UserID:      "user2",
+						ClientID:    "client1",
+						LogoutURI:   "https://app.ch/logout/backchannel",
+						LogoutToken: "logout-token",
+						Expiry:      10 * time.Minute,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &Commands{
 				eventstore: tt.fields.eventstore,
 			}
-			err := r.HumansSignOut(tt.args.ctx, tt.args.agentID, tt.args.userIDs)
+			err := r.HumansSignOut(tt.args.ctx, tt.args.agentID, tt.args.userIDs, tt.args.backChannelLogouts)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -2716,23 +2987,425 @@ func TestCommandSide_HumanSignOut(t *testing.T) {
 	}
 }
 
-func newAddHumanEvent(password string, changeRequired bool, phone string) *user.HumanAddedEvent {
-	event := user.NewHumanAddedEvent(context.Background(),
-		&user.NewAggregate("user1", "org1").Aggregate,
-		"username",
-		"firstname",
-		"lastname",
-		"",
-		"firstname lastname",
-		language.English,
-		domain.GenderUnspecified,
-		"email@test.ch",
-		true,
+func TestCommandSide_TerminateHumanSessions(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type (
+		args struct {
+			ctx           context.Context
+			userID        string
+			resourceOwner string
+			agentIDs      []string
+		}
 	)
-	if password != "" {
-		event.AddPasswordData(&crypto.CryptoValue{
-			CryptoType: crypto.TypeHash,
-			Algorithm:  "hash",
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "userid missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				agentIDs:      []string{"agent1"},
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "agentids missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				agentIDs:      []string{"agent1"},
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "terminate session, refresh tokens of user agent revoked",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token1",
+								"client1",
+								"agent1",
+								"de",
+								[]string{"client1"},
+								[]string{"openid"},
+								[]string{"password"},
+								time.Now(),
+								time.Hour,
+								24*time.Hour,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token2",
+								"client1",
+								"agent2",
+								"de",
+								[]string{"client1"},
+								[]string{"openid"},
+								[]string{"password"},
+								time.Now(),
+								time.Hour,
+								24*time.Hour,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token3",
+								"client1",
+								"agent1",
+								"de",
+								[]string{"client1"},
+								[]string{"openid"},
+								[]string{"password"},
+								time.Now(),
+								time.Hour,
+								24*time.Hour,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token3",
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				agentIDs:      []string{"agent1"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "terminate multiple sessions, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token1",
+								"client1",
+								"agent1",
+								"de",
+								[]string{"client1"},
+								[]string{"openid"},
+								[]string{"password"},
+								time.Now(),
+								time.Hour,
+								24*time.Hour,
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanRefreshTokenAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"token2",
+								"client1",
+								"agent2",
+								"de",
+								[]string{"client1"},
+								[]string{"openid"},
+								[]string{"password"},
+								time.Now(),
+								time.Hour,
+								24*time.Hour,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanSignedOutEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"agent2",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token1",
+								),
+							),
+							eventFromEventPusher(
+								user.NewHumanRefreshTokenRemovedEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"token2",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				agentIDs:      []string{"agent1", "agent2"},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.TerminateHumanSessions(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.agentIDs)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_HumanBackChannelLogoutSent(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		userID        string
+		resourceOwner string
+		logoutID      string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "logout id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				logoutID:      "logout1",
+			},
+			res: res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			name: "back-channel logout sent, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username",
+								"firstname",
+								"lastname",
+								"nickname",
+								"displayname",
+								language.German,
+								domain.GenderUnspecified,
+								"email@test.ch",
+								true,
+							),
+						),
+					),
+					expectPush(
+						[]*repository.Event{
+							eventFromEventPusher(
+								user.NewHumanBackChannelLogoutSentEvent(context.Background(),
+									&user.NewAggregate("user1", "org1").Aggregate,
+									"logout1",
+								),
+							),
+						},
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				userID:        "user1",
+				resourceOwner: "org1",
+				logoutID:      "logout1",
+			},
+			res: res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			err := r.HumanBackChannelLogoutSent(tt.args.ctx, tt.args.userID, tt.args.resourceOwner, tt.args.logoutID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func newAddHumanEvent(password string, changeRequired bool, phone string) *user.HumanAddedEvent {
+	event := user.NewHumanAddedEvent(context.Background(),
+		&user.NewAggregate("user1", "org1").Aggregate,
+		"username",
+		"firstname",
+		"lastname",
+		"",
+		"firstname lastname",
+		language.English,
+		domain.GenderUnspecified,
+		"email@test.ch",
+		true,
+	)
+	if password != "" {
+		event.AddPasswordData(&crypto.CryptoValue{
+			CryptoType: crypto.TypeHash,
+			Algorithm:  "hash",
 			KeyID:      "",
 			Crypted:    []byte(password),
 		},
@@ -2866,6 +3539,8 @@ func TestAddHumanCommand(t *testing.T) {
 									true,
 									true,
 									true,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -2912,6 +3587,8 @@ func TestAddHumanCommand(t *testing.T) {
 									false,
 									false,
 									false,
+									0,
+									false,
 								),
 							}, nil
 						}).
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -17,6 +18,8 @@ type MachineWriteModel struct {
 	Name        string
 	Description string
 	UserState   domain.UserState
+
+	ClientSecret *crypto.CryptoValue
 }
 
 func NewMachineWriteModel(userID, resourceOwner string) *MachineWriteModel {
@@ -45,6 +48,10 @@ func (wm *MachineWriteModel) Reduce() error {
 			if e.Description != nil {
 				wm.Description = *e.Description
 			}
+		case *user.MachineSecretSetEvent:
+			wm.ClientSecret = e.ClientSecret
+		case *user.MachineSecretRemovedEvent:
+			wm.ClientSecret = nil
 		case *user.UserLockedEvent:
 			if wm.UserState != domain.UserStateDeleted {
 				wm.UserState = domain.UserStateLocked
@@ -77,6 +84,8 @@ func (wm *MachineWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(user.MachineAddedEventType,
 			user.UserUserNameChangedType,
 			user.MachineChangedEventType,
+			user.MachineSecretSetType,
+			user.MachineSecretRemovedType,
 			user.UserLockedType,
 			user.UserUnlockedType,
 			user.UserDeactivatedType,
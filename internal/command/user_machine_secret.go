@@ -0,0 +1,97 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// GenerateMachineSecret generates a new client secret for the client credentials grant,
+// an existing secret is replaced (rotated)
+func (c *Commands) GenerateMachineSecret(ctx context.Context, userID, resourceOwner string, generator crypto.Generator) (*domain.ObjectDetails, string, error) {
+	machine, err := c.machineWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isUserStateExists(machine.UserState) {
+		return nil, "", errors.ThrowNotFound(nil, "COMMAND-Bz8Gh", "Errors.User.NotFound")
+	}
+	clientSecret, secretString, err := domain.NewClientSecret(generator)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewMachineSecretSetEvent(ctx, UserAggregateFromWriteModel(&machine.WriteModel), clientSecret))
+	if err != nil {
+		return nil, "", err
+	}
+	err = AppendAndReduce(machine, pushedEvents...)
+	if err != nil {
+		return nil, "", err
+	}
+	return writeModelToObjectDetails(&machine.WriteModel), secretString, nil
+}
+
+func (c *Commands) RemoveMachineSecret(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error) {
+	machine, err := c.machineWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(machine.UserState) {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Kie4o", "Errors.User.NotFound")
+	}
+	if machine.ClientSecret == nil {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Boo6a", "Errors.User.Machine.Secret.NotExisting")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx,
+		user.NewMachineSecretRemovedEvent(ctx, UserAggregateFromWriteModel(&machine.WriteModel)))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(machine, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&machine.WriteModel), nil
+}
+
+// VerifyMachineSecret checks the client secret of the machine user (client credentials grant)
+// the result of the check is recorded on the user
+func (c *Commands) VerifyMachineSecret(ctx context.Context, userID, resourceOwner, secret string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	machine, err := c.machineWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !isUserStateExists(machine.UserState) {
+		return errors.ThrowNotFound(nil, "COMMAND-Nei5u", "Errors.User.NotFound")
+	}
+	if machine.ClientSecret == nil {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Wae3o", "Errors.User.Machine.Secret.NotExisting")
+	}
+	if machine.UserState != domain.UserStateActive {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Yoo3e", "Errors.User.NotActive")
+	}
+
+	userAgg := UserAggregateFromWriteModel(&machine.WriteModel)
+	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "crypto.CompareHash")
+	err = crypto.CompareHash(machine.ClientSecret, []byte(secret), c.userPasswordAlg)
+	spanPasswordComparison.EndWithError(err)
+	if err == nil {
+		_, err = c.eventstore.Push(ctx, user.NewMachineSecretCheckSucceededEvent(ctx, userAgg))
+		return err
+	}
+	_, err = c.eventstore.Push(ctx, user.NewMachineSecretCheckFailedEvent(ctx, userAgg))
+	logging.New().OnError(err).Error("could not push event MachineSecretCheckFailed")
+	return errors.ThrowInvalidArgument(nil, "COMMAND-Ahw1o", "Errors.User.Machine.Secret.Invalid")
+}
@@ -57,6 +57,8 @@ func Test_customPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							0,
+							false,
 						),
 					}, nil
 				},
@@ -134,6 +136,8 @@ func Test_defaultPasswordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							0,
+							false,
 						),
 					}, nil
 				},
@@ -201,6 +205,8 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 							true,
 							true,
 							true,
+							0,
+							false,
 						),
 					}, nil
 				},
@@ -252,6 +258,8 @@ func Test_passwordComplexityPolicy(t *testing.T) {
 								true,
 								true,
 								true,
+								0,
+								false,
 							),
 						}, nil
 					}).
@@ -1281,14 +1281,15 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 	}
 	type (
 		args struct {
-			ctx      context.Context
-			orgID    string
-			agentID  string
-			clientID string
-			userID   string
-			audience []string
-			scopes   []string
-			lifetime time.Duration
+			ctx          context.Context
+			orgID        string
+			agentID      string
+			clientID     string
+			userID       string
+			audience     []string
+			scopes       []string
+			lifetime     time.Duration
+			confirmation *domain.TokenConfirmation
 		}
 	)
 	type res struct {
@@ -1341,7 +1342,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime, tt.args.confirmation)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1407,6 +1408,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now(),
+								nil,
+								nil,
 							),
 						),
 					),
@@ -1439,6 +1442,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now().Add(5*time.Hour),
+								nil,
+								nil,
 							),
 						),
 					),
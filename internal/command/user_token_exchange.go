@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// ExchangeUserToken issues an access token of the user in exchange of another token (RFC 8693)
+// the exchange is recorded on the user, in case of impersonation the acting user is required
+func (c *Commands) ExchangeUserToken(ctx context.Context, orgID, agentID, clientID, userID string, audience, scopes []string, lifetime time.Duration, actor *domain.TokenActor, impersonation bool, confirmation *domain.TokenConfirmation) (*domain.Token, error) {
+	if userID == "" || clientID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aiz6a", "Errors.IDMissing")
+	}
+	if impersonation && (actor == nil || actor.UserID == "") {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-eeW4u", "Errors.User.Impersonation.ActorMissing")
+	}
+	userWriteModel := NewUserWriteModel(userID, orgID)
+	tokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, "", audience, scopes, lifetime, actor, confirmation)
+	if err != nil {
+		return nil, err
+	}
+	if userWriteModel.UserState != domain.UserStateActive {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ooy5i", "Errors.User.NotActive")
+	}
+	userAgg := UserAggregateFromWriteModel(&userWriteModel.WriteModel)
+	var exchangeEvent eventstore.Command
+	if impersonation {
+		exchangeEvent = user.NewUserImpersonatedEvent(ctx, userAgg, accessToken.TokenID, clientID, actor)
+	} else {
+		exchangeEvent = user.NewUserTokenExchangedEvent(ctx, userAgg, accessToken.TokenID, clientID, actor)
+	}
+	_, err = c.eventstore.Push(ctx, tokenEvent, exchangeEvent)
+	if err != nil {
+		return nil, err
+	}
+	return accessToken, nil
+}
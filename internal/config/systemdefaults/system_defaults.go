@@ -8,6 +8,8 @@ import (
 
 type SystemDefaults struct {
 	SecretGenerators   SecretGenerators
+	PasswordHasher     crypto.PasswordHashConfig
+	BreachedPasswords  crypto.BreachedPasswordsConfig
 	Multifactors       MultifactorConfig
 	DomainVerification DomainVerification
 	Notifications      Notifications
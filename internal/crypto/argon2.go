@@ -0,0 +1,125 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Argon2)(nil)
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+
+	Argon2id = "argon2id"
+	Argon2i  = "argon2i"
+
+	// argon2MaxMemory (in KiB), argon2MaxTime and argon2MaxThreads limit the cost of comparing a value with a hash
+	argon2MaxMemory  = 256 * 1024
+	argon2MaxTime    = 16
+	argon2MaxThreads = 16
+)
+
+// Argon2 hashes values with argon2id or argon2i and stores them as PHC string
+// ($argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>)
+type Argon2 struct {
+	variant string
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+func NewArgon2(variant string, time, memory uint32, threads uint8) *Argon2 {
+	return &Argon2{
+		variant: variant,
+		time:    time,
+		memory:  memory,
+		threads: threads,
+	}
+}
+
+func (a *Argon2) Algorithm() string {
+	return "argon2"
+}
+
+func (a *Argon2) Hash(value []byte) ([]byte, error) {
+	salt, err := generateSalt(argon2SaltLength)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := argon2Key(a.variant, value, salt, a.time, a.memory, a.threads, argon2KeyLength)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(formatArgon2(a.variant, a.memory, a.time, a.threads, salt, hash)), nil
+}
+
+func (a *Argon2) CompareHash(hashed, comparer []byte) error {
+	phc, params, err := parseArgon2(string(hashed))
+	if err != nil {
+		return err
+	}
+	hash, err := argon2Key(phc.id, comparer, phc.salt, params.time, params.memory, params.threads, uint32(len(phc.hash)))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(hash, phc.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-Ohk9e", "hash does not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the hash was created with a different variant or parameters
+func (a *Argon2) NeedsRehash(hashed []byte) bool {
+	phc, params, err := parseArgon2(string(hashed))
+	if err != nil {
+		return true
+	}
+	return phc.id != a.variant || params.time != a.time || params.memory != a.memory || params.threads != a.threads
+}
+
+func parseArgon2(encoded string) (*phcHash, *Argon2, error) {
+	phc, err := parsePHC(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	if phc.id != Argon2id && phc.id != Argon2i {
+		return nil, nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-ahR0e", "unsupported argon2 variant %s", phc.id)
+	}
+	if phc.version != argon2.Version {
+		return nil, nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-ooG2e", "unsupported argon2 version %d", phc.version)
+	}
+	memory, err := phc.boundedIntParam("m", argon2MaxMemory)
+	if err != nil {
+		return nil, nil, err
+	}
+	time, err := phc.boundedIntParam("t", argon2MaxTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	threads, err := phc.boundedIntParam("p", argon2MaxThreads)
+	if err != nil {
+		return nil, nil, err
+	}
+	return phc, NewArgon2(phc.id, uint32(time), uint32(memory), uint8(threads)), nil
+}
+
+func argon2Key(variant string, value, salt []byte, time, memory uint32, threads uint8, keyLength uint32) ([]byte, error) {
+	switch variant {
+	case Argon2id:
+		return argon2.IDKey(value, salt, time, memory, threads, keyLength), nil
+	case Argon2i:
+		return argon2.Key(value, salt, time, memory, threads, keyLength), nil
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-iej2E", "unsupported argon2 variant %s", variant)
+	}
+}
+
+func formatArgon2(variant string, memory, time uint32, threads uint8, salt, hash []byte) string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		variant, argon2.Version, memory, time, threads, encodeHashBase64(salt), encodeHashBase64(hash))
+}
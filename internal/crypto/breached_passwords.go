@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const breachedPasswordsPrefixLength = 5
+
+type BreachedPasswordsConfig struct {
+	// Path to a directory containing the k-anonymity range files of the breached passwords
+	// (as provided by the Pwned Passwords range API / downloader).
+	// Every file is named after the first 5 characters of the upper case hex SHA-1 hash (optionally with a .txt suffix)
+	// and contains one `SUFFIX:COUNT` line per breached password.
+	Path string
+	// MinOccurrences a password must have in the list to be treated as breached
+	MinOccurrences uint64
+}
+
+// BreachedPasswords checks if a password is known to be part of a data breach
+type BreachedPasswords interface {
+	IsBreached(password string) (bool, error)
+}
+
+// NewBreachedPasswords returns nil if no Path is configured
+func (c *BreachedPasswordsConfig) NewBreachedPasswords() (BreachedPasswords, error) {
+	if c.Path == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(c.Path)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-ahV4u", "unable to read breached passwords")
+	}
+	if !info.IsDir() {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-eeB5i", "breached passwords path must be a directory")
+	}
+	return &hashRangeFiles{
+		path:           c.Path,
+		minOccurrences: c.MinOccurrences,
+	}, nil
+}
+
+type hashRangeFiles struct {
+	path           string
+	minOccurrences uint64
+}
+
+func (h *hashRangeFiles) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPasswordsPrefixLength], hash[breachedPasswordsPrefixLength:]
+
+	file, err := h.openRange(prefix)
+	if err != nil {
+		return false, err
+	}
+	if file == nil {
+		return false, nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		separator := strings.IndexByte(line, ':')
+		if separator < 0 || !strings.EqualFold(line[:separator], suffix) {
+			continue
+		}
+		count, err := strconv.ParseUint(line[separator+1:], 10, 64)
+		if err != nil {
+			return false, errors.ThrowInternal(err, "CRYPT-Zoo4e", "invalid breached passwords entry")
+		}
+		return count >= h.minOccurrences, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.ThrowInternal(err, "CRYPT-Ahm8i", "unable to read breached passwords")
+	}
+	return false, nil
+}
+
+// openRange returns nil if there's no file for the prefix, which means no password of the range was breached
+func (h *hashRangeFiles) openRange(prefix string) (*os.File, error) {
+	for _, name := range []string{prefix, prefix + ".txt"} {
+		file, err := os.Open(filepath.Join(h.path, name))
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, errors.ThrowInternal(err, "CRYPT-Oog5d", "unable to read breached passwords")
+		}
+	}
+	return nil, nil
+}
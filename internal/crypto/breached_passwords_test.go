@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBreachedPasswords_IsBreached(t *testing.T) {
+	dir := t.TempDir()
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	rangeFile := "003D68EB55068C33ACE09247EE4C639306B:3\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"
+	if err := os.WriteFile(filepath.Join(dir, "5BAA6.txt"), []byte(rangeFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		minOccurrences uint64
+		password       string
+		want           bool
+	}{
+		{
+			"breached",
+			0,
+			"password",
+			true,
+		},
+		{
+			"breached below min occurrences",
+			10000000,
+			"password",
+			false,
+		},
+		{
+			"not in range",
+			0,
+			"Password1!",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := BreachedPasswordsConfig{Path: dir, MinOccurrences: tt.minOccurrences}
+			breached, err := config.NewBreachedPasswords()
+			if err != nil {
+				t.Fatalf("NewBreachedPasswords() error = %v", err)
+			}
+			got, err := breached.IsBreached(tt.password)
+			if err != nil {
+				t.Fatalf("IsBreached() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBreached() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreachedPasswordsConfig_NewBreachedPasswords(t *testing.T) {
+	config := BreachedPasswordsConfig{}
+	breached, err := config.NewBreachedPasswords()
+	if err != nil || breached != nil {
+		t.Errorf("NewBreachedPasswords() without path = %v, %v, want nil", breached, err)
+	}
+	config.Path = filepath.Join(t.TempDir(), "missing")
+	if _, err = config.NewBreachedPasswords(); err == nil {
+		t.Error("NewBreachedPasswords() with missing path must fail")
+	}
+}
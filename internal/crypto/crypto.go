@@ -31,6 +31,16 @@ type HashAlgorithm interface {
 	CompareHash(hashed, comparer []byte) error
 }
 
+// hashVerifier is implemented by hash algorithms, which are able to compare hashes of other algorithms
+type hashVerifier interface {
+	Verifier(algorithm string) HashAlgorithm
+}
+
+// hashRehasher is implemented by hash algorithms, which are able to detect hashes created with outdated parameters
+type hashRehasher interface {
+	NeedsRehash(hashed []byte) bool
+}
+
 type CryptoValue struct {
 	CryptoType CryptoType
 	Algorithm  string
@@ -120,7 +130,20 @@ func Hash(value []byte, alg HashAlgorithm) (*CryptoValue, error) {
 
 func CompareHash(value *CryptoValue, comparer []byte, alg HashAlgorithm) error {
 	if value.Algorithm != alg.Algorithm() {
-		return errors.ThrowInvalidArgument(nil, "CRYPT-HF32f", "value was hashed with a different algorithm")
+		verifier, ok := alg.(hashVerifier)
+		if !ok || verifier.Verifier(value.Algorithm) == nil {
+			return errors.ThrowInvalidArgument(nil, "CRYPT-HF32f", "value was hashed with a different algorithm")
+		}
+		return verifier.Verifier(value.Algorithm).CompareHash(value.Crypted, comparer)
 	}
 	return alg.CompareHash(value.Crypted, comparer)
 }
+
+// NeedsRehash returns true if the value was not hashed with the algorithm or its current parameters
+func NeedsRehash(value *CryptoValue, alg HashAlgorithm) bool {
+	if value.Algorithm != alg.Algorithm() {
+		return true
+	}
+	rehasher, ok := alg.(hashRehasher)
+	return ok && rehasher.NeedsRehash(value.Crypted)
+}
@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PasswordHasher)(nil)
+
+const (
+	PasswordHashAlgorithmBCrypt   = "bcrypt"
+	PasswordHashAlgorithmArgon2id = Argon2id
+	PasswordHashAlgorithmArgon2i  = Argon2i
+	PasswordHashAlgorithmScrypt   = "scrypt"
+	PasswordHashAlgorithmPBKDF2   = "pbkdf2"
+)
+
+type PasswordHashConfig struct {
+	// Algorithm used to hash new passwords: bcrypt, argon2id, argon2i, scrypt or pbkdf2
+	Algorithm string
+	Argon2    Argon2Config
+	Scrypt    ScryptConfig
+	PBKDF2    PBKDF2Config
+}
+
+type Argon2Config struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+type ScryptConfig struct {
+	Cost        int
+	BlockSize   int
+	Parallelism int
+}
+
+type PBKDF2Config struct {
+	Hash       string
+	Iterations int
+}
+
+// NewHasher creates a PasswordHasher, which hashes with the configured algorithm
+// and verifies hashes of all supported algorithms.
+// bcrypt isn't configured in PasswordHashConfig to stay compatible with the former PasswordSaltCost
+func (c *PasswordHashConfig) NewHasher(bcryptCost int) (*PasswordHasher, error) {
+	bcrypt := NewBCrypt(bcryptCost)
+	argon2 := NewArgon2(Argon2id, c.Argon2.Time, c.Argon2.Memory, c.Argon2.Threads)
+	scrypt := NewScrypt(c.Scrypt.Cost, c.Scrypt.BlockSize, c.Scrypt.Parallelism)
+	pbkdf2 := NewPBKDF2(c.PBKDF2.Hash, c.PBKDF2.Iterations)
+
+	var target HashAlgorithm
+	switch c.Algorithm {
+	case PasswordHashAlgorithmBCrypt, "":
+		target = bcrypt
+	case PasswordHashAlgorithmArgon2id, PasswordHashAlgorithmArgon2i:
+		argon2.variant = c.Algorithm
+		if argon2.time == 0 || argon2.memory == 0 || argon2.threads == 0 {
+			return nil, errors.ThrowInvalidArgument(nil, "CRYPT-ieX6e", "argon2 time, memory and threads must be set")
+		}
+		if argon2.time > argon2MaxTime || argon2.memory > argon2MaxMemory || argon2.threads > argon2MaxThreads {
+			return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Quie4", "argon2 time, memory and threads must not exceed %d, %d and %d", argon2MaxTime, argon2MaxMemory, argon2MaxThreads)
+		}
+		target = argon2
+	case PasswordHashAlgorithmScrypt:
+		if !isPowerOfTwo(scrypt.cost) || scrypt.blockSize <= 0 || scrypt.parallelism <= 0 {
+			return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ohqu4", "scrypt cost must be a power of two, block size and parallelism must be set")
+		}
+		if scrypt.cost > 1<<scryptMaxLogCost || scrypt.blockSize > scryptMaxBlockSize || scrypt.parallelism > scryptMaxParallelism || scrypt.cost > scryptMaxMemory/128/scrypt.blockSize {
+			return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-iu5Ee", "scrypt cost, block size and parallelism must not exceed %d, %d and %d and use at most %d bytes", 1<<scryptMaxLogCost, scryptMaxBlockSize, scryptMaxParallelism, scryptMaxMemory)
+		}
+		target = scrypt
+	case PasswordHashAlgorithmPBKDF2:
+		if _, _, err := pbkdf2HashFunc(pbkdf2.hash); err != nil {
+			return nil, err
+		}
+		if pbkdf2.iterations <= 0 {
+			return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Iev3a", "pbkdf2 iterations must be set")
+		}
+		if pbkdf2.iterations > pbkdf2MaxIterations {
+			return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Ohb4a", "pbkdf2 iterations must not exceed %d", pbkdf2MaxIterations)
+		}
+		target = pbkdf2
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-ahg7E", "unsupported password hash algorithm %s", c.Algorithm)
+	}
+	return NewPasswordHasher(target, bcrypt, argon2, scrypt, pbkdf2), nil
+}
+
+// PasswordHasher hashes values with its target algorithm,
+// but is able to verify hashes of all its verifiers (e.g. hashes of imported users or of a previously configured algorithm)
+type PasswordHasher struct {
+	HashAlgorithm
+	verifiers map[string]HashAlgorithm
+}
+
+func NewPasswordHasher(target HashAlgorithm, verifiers ...HashAlgorithm) *PasswordHasher {
+	hasher := &PasswordHasher{
+		HashAlgorithm: target,
+		verifiers:     make(map[string]HashAlgorithm, len(verifiers)+1),
+	}
+	for _, verifier := range verifiers {
+		hasher.verifiers[verifier.Algorithm()] = verifier
+	}
+	hasher.verifiers[target.Algorithm()] = target
+	return hasher
+}
+
+// Verifier returns the algorithm able to compare values hashed with the passed algorithm
+func (h *PasswordHasher) Verifier(algorithm string) HashAlgorithm {
+	return h.verifiers[algorithm]
+}
+
+// NeedsRehash returns true if the target algorithm would hash differently (e.g. because of changed parameters).
+// Hashes of other algorithms are already handled by NeedsRehash of the package
+func (h *PasswordHasher) NeedsRehash(hashed []byte) bool {
+	rehasher, ok := h.HashAlgorithm.(hashRehasher)
+	return ok && rehasher.NeedsRehash(hashed)
+}
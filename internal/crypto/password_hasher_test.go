@@ -0,0 +1,245 @@
+package crypto
+
+import (
+	"testing"
+)
+
+func TestHashAlgorithms(t *testing.T) {
+	tests := []struct {
+		name string
+		alg  HashAlgorithm
+	}{
+		{
+			"bcrypt",
+			NewBCrypt(4),
+		},
+		{
+			"argon2id",
+			NewArgon2(Argon2id, 1, 64, 1),
+		},
+		{
+			"argon2i",
+			NewArgon2(Argon2i, 1, 64, 1),
+		},
+		{
+			"scrypt",
+			NewScrypt(16, 8, 1),
+		},
+		{
+			"pbkdf2 sha1",
+			NewPBKDF2(PBKDF2SHA1, 10),
+		},
+		{
+			"pbkdf2 sha256",
+			NewPBKDF2(PBKDF2SHA256, 10),
+		},
+		{
+			"pbkdf2 sha512",
+			NewPBKDF2(PBKDF2SHA512, 10),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashed, err := Hash([]byte("password"), tt.alg)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if err = CompareHash(hashed, []byte("password"), tt.alg); err != nil {
+				t.Errorf("CompareHash() error = %v", err)
+			}
+			if err = CompareHash(hashed, []byte("wrong"), tt.alg); err == nil {
+				t.Error("CompareHash() of wrong password must fail")
+			}
+			if NeedsRehash(hashed, tt.alg) {
+				t.Error("NeedsRehash() of hash with same parameters must be false")
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	tests := []struct {
+		name   string
+		hashed HashAlgorithm
+		alg    HashAlgorithm
+		want   bool
+	}{
+		{
+			"bcrypt cost changed",
+			NewBCrypt(4),
+			NewBCrypt(5),
+			true,
+		},
+		{
+			"argon2 variant changed",
+			NewArgon2(Argon2i, 1, 64, 1),
+			NewArgon2(Argon2id, 1, 64, 1),
+			true,
+		},
+		{
+			"argon2 memory changed",
+			NewArgon2(Argon2id, 1, 64, 1),
+			NewArgon2(Argon2id, 1, 128, 1),
+			true,
+		},
+		{
+			"scrypt cost changed",
+			NewScrypt(16, 8, 1),
+			NewScrypt(32, 8, 1),
+			true,
+		},
+		{
+			"pbkdf2 iterations changed",
+			NewPBKDF2(PBKDF2SHA256, 10),
+			NewPBKDF2(PBKDF2SHA256, 20),
+			true,
+		},
+		{
+			"algorithm changed",
+			NewBCrypt(4),
+			NewPasswordHasher(NewPBKDF2(PBKDF2SHA256, 10), NewBCrypt(4)),
+			true,
+		},
+		{
+			"password hasher unchanged",
+			NewPBKDF2(PBKDF2SHA256, 10),
+			NewPasswordHasher(NewPBKDF2(PBKDF2SHA256, 10), NewBCrypt(4)),
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashed, err := Hash([]byte("password"), tt.hashed)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if got := NeedsRehash(hashed, tt.alg); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordHasher_CompareHash(t *testing.T) {
+	hasher := NewPasswordHasher(NewArgon2(Argon2id, 1, 64, 1), NewBCrypt(4), NewPBKDF2(PBKDF2SHA256, 10))
+	tests := []struct {
+		name    string
+		hashed  HashAlgorithm
+		wantErr bool
+	}{
+		{
+			"target",
+			NewArgon2(Argon2id, 1, 64, 1),
+			false,
+		},
+		{
+			"verifier",
+			NewBCrypt(4),
+			false,
+		},
+		{
+			"verifier with other parameters",
+			NewPBKDF2(PBKDF2SHA512, 5),
+			false,
+		},
+		{
+			"unknown algorithm",
+			NewScrypt(16, 8, 1),
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hashed, err := Hash([]byte("password"), tt.hashed)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+			if err = CompareHash(hashed, []byte("password"), hasher); (err != nil) != tt.wantErr {
+				t.Errorf("CompareHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPasswordHashConfig_NewHasher(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        PasswordHashConfig
+		wantAlgorithm string
+		wantErr       bool
+	}{
+		{
+			"default bcrypt",
+			PasswordHashConfig{},
+			"bcrypt",
+			false,
+		},
+		{
+			"argon2id",
+			PasswordHashConfig{Algorithm: "argon2id", Argon2: Argon2Config{Time: 1, Memory: 64, Threads: 1}},
+			"argon2",
+			false,
+		},
+		{
+			"argon2id missing parameters",
+			PasswordHashConfig{Algorithm: "argon2id"},
+			"",
+			true,
+		},
+		{
+			"argon2id memory too high",
+			PasswordHashConfig{Algorithm: "argon2id", Argon2: Argon2Config{Time: 1, Memory: 1 << 22, Threads: 1}},
+			"",
+			true,
+		},
+		{
+			"scrypt memory too high",
+			PasswordHashConfig{Algorithm: "scrypt", Scrypt: ScryptConfig{Cost: 1 << 20, BlockSize: 8, Parallelism: 1}},
+			"",
+			true,
+		},
+		{
+			"pbkdf2 iterations too high",
+			PasswordHashConfig{Algorithm: "pbkdf2", PBKDF2: PBKDF2Config{Hash: "sha256", Iterations: 100000000}},
+			"",
+			true,
+		},
+		{
+			"scrypt cost no power of two",
+			PasswordHashConfig{Algorithm: "scrypt", Scrypt: ScryptConfig{Cost: 15, BlockSize: 8, Parallelism: 1}},
+			"",
+			true,
+		},
+		{
+			"pbkdf2 unknown hash",
+			PasswordHashConfig{Algorithm: "pbkdf2", PBKDF2: PBKDF2Config{Hash: "md5", Iterations: 10}},
+			"",
+			true,
+		},
+		{
+			"unknown algorithm",
+			PasswordHashConfig{Algorithm: "md5"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.NewHasher(4)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewHasher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Algorithm() != tt.wantAlgorithm {
+				t.Errorf("NewHasher() algorithm = %s, want %s", got.Algorithm(), tt.wantAlgorithm)
+			}
+			for _, verifier := range []string{"bcrypt", "argon2", "scrypt", "pbkdf2"} {
+				if got.Verifier(verifier) == nil {
+					t.Errorf("NewHasher() verifier %s missing", verifier)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// bcryptMaxCost limits the cost of comparing a value with an imported bcrypt hash
+const bcryptMaxCost = 16
+
+// ParsePasswordHash parses a password hash exported from another system
+// and returns it as CryptoValue in the format of the matching HashAlgorithm.
+// Supported are:
+//   - bcrypt ($2a$, $2b$, $2y$)
+//   - PHC strings of argon2id, argon2i, scrypt and pbkdf2 (including the passlib variants)
+//   - Django hashes (pbkdf2_sha256, pbkdf2_sha1, argon2, scrypt, bcrypt)
+//   - Keycloak pbkdf2 credentials (JSON containing value, salt, hashIterations and algorithm
+//     either directly or as secretData and credentialData)
+//
+// Hashes with cost parameters exceeding the maximums of the algorithms are rejected,
+// as they would be compared on the first login of the user.
+func ParsePasswordHash(encoded string) (*CryptoValue, error) {
+	encoded = strings.TrimSpace(encoded)
+	var (
+		algorithm string
+		hashed    string
+		err       error
+	)
+	switch {
+	case encoded == "":
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ahp4u", "hash is empty")
+	case strings.HasPrefix(encoded, "{"):
+		algorithm, hashed, err = parseKeycloakHash(encoded)
+	case strings.HasPrefix(encoded, "$"):
+		algorithm, hashed, err = parseModularCryptHash(encoded)
+	default:
+		algorithm, hashed, err = parseDjangoHash(encoded)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &CryptoValue{
+		CryptoType: TypeHash,
+		Algorithm:  algorithm,
+		Crypted:    []byte(hashed),
+	}, nil
+}
+
+func parseModularCryptHash(encoded string) (algorithm, hashed string, err error) {
+	id := strings.SplitN(strings.TrimPrefix(encoded, "$"), "$", 2)[0]
+	switch {
+	case id == "2a" || id == "2b" || id == "2y":
+		cost, err := bcrypt.Cost([]byte(encoded))
+		if err != nil {
+			return "", "", errors.ThrowInvalidArgument(err, "CRYPT-Va7ae", "invalid bcrypt hash")
+		}
+		if cost > bcryptMaxCost {
+			return "", "", errors.ThrowInvalidArgumentf(nil, "CRYPT-Ooz4i", "bcrypt cost exceeds %d", bcryptMaxCost)
+		}
+		return PasswordHashAlgorithmBCrypt, encoded, nil
+	case id == Argon2id || id == Argon2i:
+		phc, params, err := parseArgon2(encoded)
+		if err != nil {
+			return "", "", err
+		}
+		return params.Algorithm(), formatArgon2(params.variant, params.memory, params.time, params.threads, phc.salt, phc.hash), nil
+	case id == "scrypt":
+		phc, params, err := parseScrypt(encoded)
+		if err != nil {
+			return "", "", err
+		}
+		return params.Algorithm(), formatScrypt(params.cost, params.blockSize, params.parallelism, phc.salt, phc.hash), nil
+	case strings.HasPrefix(id, "pbkdf2"):
+		return parsePBKDF2Hash(encoded)
+	default:
+		return "", "", errors.ThrowInvalidArgumentf(nil, "CRYPT-Ug1ie", "unsupported hash format %s", id)
+	}
+}
+
+// parsePBKDF2Hash normalizes PHC and passlib ($pbkdf2-sha256$<iterations>$<salt>$<hash>) formats
+func parsePBKDF2Hash(encoded string) (algorithm, hashed string, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) == 5 && !strings.Contains(parts[2], "=") {
+		parts[2] = "i=" + parts[2]
+		encoded = strings.Join(parts, "$")
+	}
+	phc, params, err := parsePBKDF2(encoded)
+	if err != nil {
+		return "", "", err
+	}
+	return params.Algorithm(), formatPBKDF2(params.hash, params.iterations, phc.salt, phc.hash), nil
+}
+
+// parseDjangoHash parses hashes of the form <algorithm>$<hash>,
+// see https://docs.djangoproject.com/en/stable/topics/auth/passwords/#how-django-stores-passwords
+func parseDjangoHash(encoded string) (algorithm, hashed string, err error) {
+	parts := strings.Split(encoded, "$")
+	switch parts[0] {
+	case "pbkdf2_sha256", "pbkdf2_sha1":
+		if len(parts) != 4 {
+			return "", "", errors.ThrowInvalidArgument(nil, "CRYPT-ea4Ee", "invalid django pbkdf2 hash")
+		}
+		iterations, err := strconv.Atoi(parts[1])
+		if err != nil || iterations <= 0 {
+			return "", "", errors.ThrowInvalidArgument(err, "CRYPT-ZieV3", "invalid django pbkdf2 iterations")
+		}
+		key, err := decodeHashBase64(parts[3])
+		if err != nil {
+			return "", "", err
+		}
+		// django uses the salt as is and doesn't encode it,
+		// the formatted hash is parsed again to check the parameters
+		return parsePBKDF2Hash(formatPBKDF2(strings.TrimPrefix(parts[0], "pbkdf2_"), iterations, []byte(parts[2]), key))
+	case "argon2", "bcrypt":
+		return parseModularCryptHash(strings.TrimPrefix(encoded, parts[0]))
+	case "scrypt":
+		if len(parts) != 6 {
+			return "", "", errors.ThrowInvalidArgument(nil, "CRYPT-ooN6e", "invalid django scrypt hash")
+		}
+		params := make([]int, 3)
+		for i, param := range parts[2:5] {
+			params[i], err = strconv.Atoi(param)
+			if err != nil || params[i] <= 0 {
+				return "", "", errors.ThrowInvalidArgument(err, "CRYPT-Xah1u", "invalid django scrypt parameters")
+			}
+		}
+		if !isPowerOfTwo(params[0]) {
+			return "", "", errors.ThrowInvalidArgument(nil, "CRYPT-Uo4ei", "scrypt cost must be a power of two")
+		}
+		key, err := decodeHashBase64(parts[5])
+		if err != nil {
+			return "", "", err
+		}
+		// django uses the salt as is and doesn't encode it,
+		// the formatted hash is parsed again to check the parameters
+		return parseModularCryptHash(formatScrypt(params[0], params[1], params[2], []byte(parts[1]), key))
+	default:
+		return "", "", errors.ThrowInvalidArgumentf(nil, "CRYPT-Cha5o", "unsupported hash format %s", parts[0])
+	}
+}
+
+type keycloakCredential struct {
+	keycloakSecretData
+	keycloakCredentialData
+	SecretData     string `json:"secretData"`
+	CredentialData string `json:"credentialData"`
+}
+
+type keycloakSecretData struct {
+	Value string `json:"value"`
+	Salt  string `json:"salt"`
+}
+
+type keycloakCredentialData struct {
+	HashIterations int    `json:"hashIterations"`
+	Algorithm      string `json:"algorithm"`
+}
+
+// parseKeycloakHash parses the password credential of a Keycloak user export
+func parseKeycloakHash(encoded string) (algorithm, hashed string, err error) {
+	credential := new(keycloakCredential)
+	if err := json.Unmarshal([]byte(encoded), credential); err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "CRYPT-Iefi7", "invalid keycloak credential")
+	}
+	if credential.SecretData != "" {
+		if err := json.Unmarshal([]byte(credential.SecretData), &credential.keycloakSecretData); err != nil {
+			return "", "", errors.ThrowInvalidArgument(err, "CRYPT-ohX3a", "invalid keycloak secret data")
+		}
+	}
+	if credential.CredentialData != "" {
+		if err := json.Unmarshal([]byte(credential.CredentialData), &credential.keycloakCredentialData); err != nil {
+			return "", "", errors.ThrowInvalidArgument(err, "CRYPT-Ahb5i", "invalid keycloak credential data")
+		}
+	}
+	var hash string
+	switch credential.Algorithm {
+	case "pbkdf2":
+		hash = PBKDF2SHA1
+	case "pbkdf2-sha256":
+		hash = PBKDF2SHA256
+	case "pbkdf2-sha512":
+		hash = PBKDF2SHA512
+	default:
+		return "", "", errors.ThrowInvalidArgumentf(nil, "CRYPT-ie5Ah", "unsupported keycloak algorithm %s", credential.Algorithm)
+	}
+	if credential.HashIterations <= 0 {
+		return "", "", errors.ThrowInvalidArgument(nil, "CRYPT-Chu9o", "invalid keycloak hash iterations")
+	}
+	salt, err := base64.StdEncoding.DecodeString(credential.Salt)
+	if err != nil {
+		return "", "", errors.ThrowInvalidArgument(err, "CRYPT-Oow4a", "invalid keycloak salt")
+	}
+	key, err := base64.StdEncoding.DecodeString(credential.Value)
+	if err != nil || len(key) == 0 {
+		return "", "", errors.ThrowInvalidArgument(err, "CRYPT-ahY9e", "invalid keycloak hash value")
+	}
+	// the formatted hash is parsed again to check the parameters
+	return parsePBKDF2Hash(formatPBKDF2(hash, credential.HashIterations, salt, key))
+}
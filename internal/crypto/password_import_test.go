@@ -0,0 +1,229 @@
+package crypto
+
+import (
+	"testing"
+)
+
+func TestParsePasswordHash(t *testing.T) {
+	type want struct {
+		algorithm string
+		crypted   string
+	}
+	tests := []struct {
+		name    string
+		encoded string
+		want    want
+		wantErr bool
+	}{
+		{
+			"empty",
+			" ",
+			want{},
+			true,
+		},
+		{
+			"unsupported format",
+			"md5$password",
+			want{},
+			true,
+		},
+		{
+			"bcrypt",
+			"$2a$04$DrxjpfwyliDcna.HUoAaM.Zu/wybZ7IwIepg1WT1hiapDtX0KHv.u",
+			want{
+				algorithm: "bcrypt",
+				crypted:   "$2a$04$DrxjpfwyliDcna.HUoAaM.Zu/wybZ7IwIepg1WT1hiapDtX0KHv.u",
+			},
+			false,
+		},
+		{
+			"bcrypt invalid",
+			"$2a$04$DrxjpfwyliDcna",
+			want{},
+			true,
+		},
+		{
+			"argon2i phc",
+			"$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{
+				algorithm: "argon2",
+				crypted:   "$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			},
+			false,
+		},
+		{
+			"argon2 unsupported version",
+			"$argon2i$v=16$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{},
+			true,
+		},
+		{
+			"argon2 django",
+			"argon2$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{
+				algorithm: "argon2",
+				crypted:   "$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			},
+			false,
+		},
+		{
+			"scrypt phc",
+			"$scrypt$ln=10,r=8,p=1$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			want{
+				algorithm: "scrypt",
+				crypted:   "$scrypt$ln=10,r=8,p=1$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			},
+			false,
+		},
+		{
+			"scrypt django",
+			"scrypt$seasalt$1024$8$1$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8=",
+			want{
+				algorithm: "scrypt",
+				crypted:   "$scrypt$ln=10,r=8,p=1$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			},
+			false,
+		},
+		{
+			"pbkdf2 phc",
+			"$pbkdf2-sha256$i=1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c",
+			want{
+				algorithm: "pbkdf2",
+				crypted:   "$pbkdf2-sha256$i=1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c",
+			},
+			false,
+		},
+		{
+			"pbkdf2 passlib",
+			"$pbkdf2-sha256$1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y.i7c",
+			want{
+				algorithm: "pbkdf2",
+				crypted:   "$pbkdf2-sha256$i=1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c",
+			},
+			false,
+		},
+		{
+			"pbkdf2 django",
+			"pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+			want{
+				algorithm: "pbkdf2",
+				crypted:   "$pbkdf2-sha256$i=1000$c2Vhc2FsdA$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c",
+			},
+			false,
+		},
+		{
+			"pbkdf2 django invalid iterations",
+			"pbkdf2_sha256$many$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+			want{},
+			true,
+		},
+		{
+			"keycloak",
+			`{"type":"password","secretData":"{\"value\":\"PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA==\",\"salt\":\"MDEyMzQ1Njc4OWFiY2RlZg==\",\"additionalParameters\":{}}","credentialData":"{\"hashIterations\":27500,\"algorithm\":\"pbkdf2-sha512\",\"additionalParameters\":{}}"}`,
+			want{
+				algorithm: "pbkdf2",
+				crypted:   "$pbkdf2-sha512$i=27500$MDEyMzQ1Njc4OWFiY2RlZg$PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA",
+			},
+			false,
+		},
+		{
+			"keycloak legacy",
+			`{"value":"PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA==","salt":"MDEyMzQ1Njc4OWFiY2RlZg==","hashIterations":27500,"algorithm":"pbkdf2-sha512"}`,
+			want{
+				algorithm: "pbkdf2",
+				crypted:   "$pbkdf2-sha512$i=27500$MDEyMzQ1Njc4OWFiY2RlZg$PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA",
+			},
+			false,
+		},
+		{
+			"bcrypt cost too high",
+			"$2a$17$DrxjpfwyliDcna.HUoAaM.Zu/wybZ7IwIepg1WT1hiapDtX0KHv.u",
+			want{},
+			true,
+		},
+		{
+			"argon2 memory too high",
+			"$argon2i$v=19$m=4194304,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{},
+			true,
+		},
+		{
+			"argon2 memory overflows uint32",
+			"$argon2i$v=19$m=4294967297,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{},
+			true,
+		},
+		{
+			"argon2 time too high",
+			"$argon2i$v=19$m=65536,t=1000,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG",
+			want{},
+			true,
+		},
+		{
+			"scrypt cost too high",
+			"$scrypt$ln=31,r=8,p=1$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			want{},
+			true,
+		},
+		{
+			"scrypt memory too high",
+			"$scrypt$ln=20,r=32,p=1$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			want{},
+			true,
+		},
+		{
+			"scrypt parallelism too high",
+			"$scrypt$ln=10,r=8,p=1000$c2Vhc2FsdA$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8",
+			want{},
+			true,
+		},
+		{
+			"django scrypt cost too high",
+			"scrypt$seasalt$2147483648$8$1$31PFhAHfMCdqX/BGQIxXuAjBaIcgP0CgVuIps6DSp+8=",
+			want{},
+			true,
+		},
+		{
+			"pbkdf2 iterations too high",
+			"$pbkdf2-sha512$i=100000000$MDEyMzQ1Njc4OWFiY2RlZg$PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA",
+			want{},
+			true,
+		},
+		{
+			"django pbkdf2 iterations too high",
+			"pbkdf2_sha256$100000000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+			want{},
+			true,
+		},
+		{
+			"keycloak iterations too high",
+			`{"value":"PL2Z/DA4/gip9BOhizlkMcLEOP+fHrPCbSP9Tb149DU0JE7pSjQFrpJLTWzsBxrYv3zFxhbkKMKewklKCsDUqA==","salt":"MDEyMzQ1Njc4OWFiY2RlZg==","hashIterations":100000000,"algorithm":"pbkdf2-sha512"}`,
+			want{},
+			true,
+		},
+		{
+			"keycloak unsupported algorithm",
+			`{"value":"PL2Z","salt":"MDEy","hashIterations":27500,"algorithm":"argon2"}`,
+			want{},
+			true,
+		},
+	}
+	hasher := NewPasswordHasher(NewBCrypt(4), NewArgon2(Argon2id, 1, 64, 1), NewScrypt(16, 8, 1), NewPBKDF2(PBKDF2SHA256, 10))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePasswordHash(tt.encoded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePasswordHash() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.CryptoType != TypeHash || got.Algorithm != tt.want.algorithm || string(got.Crypted) != tt.want.crypted {
+				t.Errorf("ParsePasswordHash() = %s %s, want %s %s", got.Algorithm, got.Crypted, tt.want.algorithm, tt.want.crypted)
+			}
+			if err = CompareHash(got, []byte("password"), hasher); err != nil {
+				t.Errorf("CompareHash() error = %v", err)
+			}
+		})
+	}
+}
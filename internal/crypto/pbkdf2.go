@@ -0,0 +1,122 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*PBKDF2)(nil)
+
+const (
+	pbkdf2SaltLength = 16
+	// pbkdf2MaxIterations limits the cost of comparing a value with a hash
+	pbkdf2MaxIterations = 5000000
+
+	PBKDF2SHA1   = "sha1"
+	PBKDF2SHA256 = "sha256"
+	PBKDF2SHA512 = "sha512"
+)
+
+// PBKDF2 hashes values with PBKDF2 and stores them as PHC string
+// ($pbkdf2-<hash>$i=<iterations>$<salt>$<hash>)
+type PBKDF2 struct {
+	hash       string
+	iterations int
+}
+
+func NewPBKDF2(hash string, iterations int) *PBKDF2 {
+	return &PBKDF2{
+		hash:       hash,
+		iterations: iterations,
+	}
+}
+
+func (p *PBKDF2) Algorithm() string {
+	return "pbkdf2"
+}
+
+func (p *PBKDF2) Hash(value []byte) ([]byte, error) {
+	hashFunc, keyLength, err := pbkdf2HashFunc(p.hash)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := generateSalt(pbkdf2SaltLength)
+	if err != nil {
+		return nil, err
+	}
+	hash := pbkdf2.Key(value, salt, p.iterations, keyLength, hashFunc)
+	return []byte(formatPBKDF2(p.hash, p.iterations, salt, hash)), nil
+}
+
+func (p *PBKDF2) CompareHash(hashed, comparer []byte) error {
+	phc, params, err := parsePBKDF2(string(hashed))
+	if err != nil {
+		return err
+	}
+	hashFunc, _, err := pbkdf2HashFunc(params.hash)
+	if err != nil {
+		return err
+	}
+	hash := pbkdf2.Key(comparer, phc.salt, params.iterations, len(phc.hash), hashFunc)
+	if subtle.ConstantTimeCompare(hash, phc.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-ieT0e", "hash does not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the hash was created with a different hash function or iterations
+func (p *PBKDF2) NeedsRehash(hashed []byte) bool {
+	_, params, err := parsePBKDF2(string(hashed))
+	if err != nil {
+		return true
+	}
+	return params.hash != p.hash || params.iterations != p.iterations
+}
+
+func parsePBKDF2(encoded string) (*phcHash, *PBKDF2, error) {
+	phc, err := parsePHC(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	var hashName string
+	switch phc.id {
+	case "pbkdf2", "pbkdf2-sha1":
+		hashName = PBKDF2SHA1
+	case "pbkdf2-sha256":
+		hashName = PBKDF2SHA256
+	case "pbkdf2-sha512":
+		hashName = PBKDF2SHA512
+	default:
+		return nil, nil, errors.ThrowInvalidArgumentf(nil, "CRYPT-Chei6", "unsupported pbkdf2 variant %s", phc.id)
+	}
+	iterations, err := phc.boundedIntParam("i", pbkdf2MaxIterations)
+	if err != nil {
+		return nil, nil, err
+	}
+	return phc, NewPBKDF2(hashName, iterations), nil
+}
+
+func formatPBKDF2(hash string, iterations int, salt, key []byte) string {
+	return fmt.Sprintf("$pbkdf2-%s$i=%d$%s$%s", hash, iterations, encodeHashBase64(salt), encodeHashBase64(key))
+}
+
+func pbkdf2HashFunc(name string) (func() hash.Hash, int, error) {
+	switch name {
+	case PBKDF2SHA1:
+		return sha1.New, sha1.Size, nil
+	case PBKDF2SHA256:
+		return sha256.New, sha256.Size, nil
+	case PBKDF2SHA512:
+		return sha512.New, sha512.Size, nil
+	default:
+		return nil, 0, errors.ThrowInvalidArgumentf(nil, "CRYPT-Aih4o", "unsupported pbkdf2 hash function %s", name)
+	}
+}
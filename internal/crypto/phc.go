@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// phcHash represents a hash in the PHC string format:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*][$<salt>[$<hash>]]
+type phcHash struct {
+	id      string
+	version int
+	params  map[string]string
+	salt    []byte
+	hash    []byte
+}
+
+func parsePHC(encoded string) (*phcHash, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) < 2 || parts[0] != "" || parts[1] == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ub3ei", "invalid phc string")
+	}
+	phc := &phcHash{
+		id:     parts[1],
+		params: make(map[string]string),
+	}
+	parts = parts[2:]
+	if len(parts) > 0 && strings.HasPrefix(parts[0], "v=") {
+		version, err := strconv.Atoi(strings.TrimPrefix(parts[0], "v="))
+		if err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "CRYPT-ooJ5a", "invalid phc version")
+		}
+		phc.version = version
+		parts = parts[1:]
+	}
+	if len(parts) > 0 && strings.Contains(parts[0], "=") {
+		for _, param := range strings.Split(parts[0], ",") {
+			kv := strings.SplitN(param, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Thae8", "invalid phc parameter")
+			}
+			phc.params[kv[0]] = kv[1]
+		}
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ieg4o", "phc string must contain salt and hash")
+	}
+	var err error
+	if phc.salt, err = decodeHashBase64(parts[0]); err != nil {
+		return nil, err
+	}
+	if phc.hash, err = decodeHashBase64(parts[1]); err != nil {
+		return nil, err
+	}
+	if len(phc.hash) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Aec2i", "phc string must contain a hash")
+	}
+	return phc, nil
+}
+
+func (p *phcHash) intParam(name string) (int, error) {
+	value, ok := p.params[name]
+	if !ok {
+		return 0, errors.ThrowInvalidArgumentf(nil, "CRYPT-Ohng3", "phc parameter %s missing", name)
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		return 0, errors.ThrowInvalidArgumentf(err, "CRYPT-uW4ae", "phc parameter %s invalid", name)
+	}
+	return i, nil
+}
+
+// boundedIntParam returns the parameter if it doesn't exceed max,
+// as the parameters of (imported) hashes define the cost of comparing a value with them
+func (p *phcHash) boundedIntParam(name string, max int) (int, error) {
+	i, err := p.intParam(name)
+	if err != nil {
+		return 0, err
+	}
+	if i > max {
+		return 0, errors.ThrowInvalidArgumentf(nil, "CRYPT-ahY7e", "phc parameter %s exceeds %d", name, max)
+	}
+	return i, nil
+}
+
+func encodeHashBase64(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+// decodeHashBase64 decodes standard base64 with or without padding
+// as well as the adapted base64 alphabet of passlib (using . instead of +)
+func decodeHashBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(strings.ReplaceAll(value, ".", "+"), "=")
+	decoded, err := base64.RawStdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "CRYPT-Pie2u", "invalid base64 encoding")
+	}
+	return decoded, nil
+}
+
+func generateSalt(length int) ([]byte, error) {
+	salt := make([]byte, length)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-Waek4", "unable to generate salt")
+	}
+	return salt, nil
+}
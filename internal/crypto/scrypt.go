@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"math/bits"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+var _ HashAlgorithm = (*Scrypt)(nil)
+
+const (
+	scryptSaltLength = 16
+	scryptKeyLength  = 32
+
+	// scryptMaxLogCost, scryptMaxBlockSize, scryptMaxParallelism and scryptMaxMemory (in bytes)
+	// limit the cost of comparing a value with a hash
+	scryptMaxLogCost     = 20
+	scryptMaxBlockSize   = 32
+	scryptMaxParallelism = 16
+	scryptMaxMemory      = 256 << 20
+)
+
+// Scrypt hashes values with scrypt and stores them as PHC string
+// ($scrypt$ln=<log2(cost)>,r=<block size>,p=<parallelism>$<salt>$<hash>)
+type Scrypt struct {
+	cost        int
+	blockSize   int
+	parallelism int
+}
+
+func NewScrypt(cost, blockSize, parallelism int) *Scrypt {
+	return &Scrypt{
+		cost:        cost,
+		blockSize:   blockSize,
+		parallelism: parallelism,
+	}
+}
+
+func (s *Scrypt) Algorithm() string {
+	return "scrypt"
+}
+
+func (s *Scrypt) Hash(value []byte) ([]byte, error) {
+	if !isPowerOfTwo(s.cost) {
+		return nil, errors.ThrowInternal(nil, "CRYPT-Feib3", "scrypt cost must be a power of two")
+	}
+	salt, err := generateSalt(scryptSaltLength)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := scrypt.Key(value, salt, s.cost, s.blockSize, s.parallelism, scryptKeyLength)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "CRYPT-ooS8i", "unable to hash with scrypt")
+	}
+	return []byte(formatScrypt(s.cost, s.blockSize, s.parallelism, salt, hash)), nil
+}
+
+func (s *Scrypt) CompareHash(hashed, comparer []byte) error {
+	phc, params, err := parseScrypt(string(hashed))
+	if err != nil {
+		return err
+	}
+	hash, err := scrypt.Key(comparer, phc.salt, params.cost, params.blockSize, params.parallelism, len(phc.hash))
+	if err != nil {
+		return errors.ThrowInvalidArgument(err, "CRYPT-Eev5a", "unable to hash with scrypt")
+	}
+	if subtle.ConstantTimeCompare(hash, phc.hash) != 1 {
+		return errors.ThrowInvalidArgument(nil, "CRYPT-fai0E", "hash does not match")
+	}
+	return nil
+}
+
+// NeedsRehash returns true if the hash was created with different parameters
+func (s *Scrypt) NeedsRehash(hashed []byte) bool {
+	_, params, err := parseScrypt(string(hashed))
+	if err != nil {
+		return true
+	}
+	return params.cost != s.cost || params.blockSize != s.blockSize || params.parallelism != s.parallelism
+}
+
+func parseScrypt(encoded string) (*phcHash, *Scrypt, error) {
+	phc, err := parsePHC(encoded)
+	if err != nil {
+		return nil, nil, err
+	}
+	if phc.id != "scrypt" {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ood9u", "not a scrypt hash")
+	}
+	logCost, err := phc.boundedIntParam("ln", scryptMaxLogCost)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockSize, err := phc.boundedIntParam("r", scryptMaxBlockSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	parallelism, err := phc.boundedIntParam("p", scryptMaxParallelism)
+	if err != nil {
+		return nil, nil, err
+	}
+	if 1<<logCost > scryptMaxMemory/128/blockSize {
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-yee6E", "scrypt memory too high")
+	}
+	return phc, NewScrypt(1<<logCost, blockSize, parallelism), nil
+}
+
+func formatScrypt(cost, blockSize, parallelism int, salt, hash []byte) string {
+	return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		bits.TrailingZeros(uint(cost)), blockSize, parallelism, encodeHashBase64(salt), encodeHashBase64(hash))
+}
+
+func isPowerOfTwo(n int) bool {
+	return n > 1 && n&(n-1) == 0
+}
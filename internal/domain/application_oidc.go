@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -45,6 +46,11 @@ type OIDCApp struct {
 	IDTokenUserinfoAssertion bool
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
+	RequirePushedAuthRequest bool
+	RequireRequestObject     bool
+	BackChannelLogoutURI     string
+	FrontChannelLogoutURI    string
+	MinLevelOfAssurance      LevelOfAssurance
 
 	State AppState
 }
@@ -89,6 +95,8 @@ const (
 	OIDCGrantTypeAuthorizationCode OIDCGrantType = iota
 	OIDCGrantTypeImplicit
 	OIDCGrantTypeRefreshToken
+	OIDCGrantTypeDeviceCode
+	OIDCGrantTypeTokenExchange
 )
 
 type OIDCApplicationType int32
@@ -121,7 +129,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.LogoutURIsValid() || !a.MinLevelOfAssurance.Valid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -146,6 +154,23 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+func (a *OIDCApp) LogoutURIsValid() bool {
+	return IsLogoutURI(a.BackChannelLogoutURI) && IsLogoutURI(a.FrontChannelLogoutURI)
+}
+
+// IsLogoutURI checks if the back- or front-channel logout uri is either empty
+// or an absolute http(s) url without fragment
+func IsLogoutURI(logoutURI string) bool {
+	if logoutURI == "" {
+		return true
+	}
+	parsed, err := url.Parse(logoutURI)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" && parsed.Fragment == ""
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
@@ -220,7 +245,9 @@ func GetOIDCV1Compliance(appType OIDCApplicationType, grantTypes []OIDCGrantType
 }
 
 func checkGrantTypesCombination(compliance *Compliance, grantTypes []OIDCGrantType) {
-	if containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) && !containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) {
+	if containsOIDCGrantType(grantTypes, OIDCGrantTypeRefreshToken) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeAuthorizationCode) &&
+		!containsOIDCGrantType(grantTypes, OIDCGrantTypeDeviceCode) {
 		compliance.NoneCompliant = true
 		compliance.Problems = append(compliance.Problems, "Application.OIDC.V1.GrantType.Refresh.NoAuthCode")
 	}
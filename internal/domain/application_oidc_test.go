@@ -177,6 +177,49 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "invalid oidc application: relative back-channel logout uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "Name",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI: "/logout/backchannel",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid oidc application: front-channel logout uri with fragment",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                 "AppID",
+					AppName:               "Name",
+					ResponseTypes:         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:            []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					FrontChannelLogoutURI: "https://test.com/logout#fragment",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid oidc application: logout uris",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                 "AppID",
+					AppName:               "Name",
+					ResponseTypes:         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:            []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI:  "https://test.com/logout/backchannel",
+					FrontChannelLogoutURI: "https://test.com/logout/frontchannel?iss=zitadel",
+				},
+			},
+			result: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -241,6 +284,11 @@ func Test_checkGrantTypesCombination(t *testing.T) {
 			want:       &Compliance{},
 			grantTypes: []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeRefreshToken},
 		},
+		{
+			name:       "refresh token and device code",
+			want:       &Compliance{},
+			grantTypes: []OIDCGrantType{OIDCGrantTypeImplicit, OIDCGrantTypeDeviceCode, OIDCGrantTypeRefreshToken},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
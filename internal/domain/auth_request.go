@@ -26,7 +26,7 @@ type AuthRequest struct {
 	InstanceID    string
 	Request       Request
 
-	levelOfAssurance         LevelOfAssurance
+	MinLevelOfAssurance      LevelOfAssurance
 	UserID                   string
 	UserName                 string
 	LoginName                string
@@ -43,6 +43,7 @@ type AuthRequest struct {
 	LinkingUsers             []*ExternalUser
 	PossibleSteps            []NextStep
 	PasswordVerified         bool
+	MagicLinkVerified        bool
 	MFAsVerified             []MFAType
 	Audience                 []string
 	AuthTime                 time.Time
@@ -52,6 +53,9 @@ type AuthRequest struct {
 	LabelPolicy              *LabelPolicy
 	PrivacyPolicy            *PrivacyPolicy
 	LockoutPolicy            *LockoutPolicy
+	PasswordAgePolicy        *PasswordAgePolicy
+	AccessPolicy             *AccessPolicy
+	PasswordExpirySkipped    bool
 	DefaultTranslations      []*CustomText
 	OrgTranslations          []*CustomText
 }
@@ -96,16 +100,33 @@ type LevelOfAssurance int
 
 const (
 	LevelOfAssuranceNone LevelOfAssurance = iota
+	LevelOfAssurancePassword
+	LevelOfAssuranceMFA
+	LevelOfAssurancePhishingResistant
+
+	levelOfAssuranceCount
 )
 
+func (l LevelOfAssurance) Valid() bool {
+	return l >= LevelOfAssuranceNone && l < levelOfAssuranceCount
+}
+
 type MFAType int
 
 const (
 	MFATypeOTP MFAType = iota
 	MFATypeU2F
 	MFATypeU2FUserVerification
+	MFATypeOTPSMS
+	MFATypeOTPEmail
+	MFATypeRecoveryCode
 )
 
+// IsPhishingResistant returns true for factors bound to the origin of the login (WebAuthN)
+func (m MFAType) IsPhishingResistant() bool {
+	return m == MFATypeU2F || m == MFATypeU2FUserVerification
+}
+
 type MFALevel int
 
 const (
@@ -119,6 +140,10 @@ func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 	switch requestType {
 	case AuthRequestTypeOIDC:
 		return &AuthRequest{Request: &AuthRequestOIDC{}}, nil
+	case AuthRequestTypeSAML:
+		return &AuthRequest{Request: &AuthRequestSAML{}}, nil
+	case AuthRequestTypeDevice:
+		return &AuthRequest{Request: &AuthRequestDevice{}}, nil
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-ds2kl", "invalid request type")
 }
@@ -137,11 +162,52 @@ func (a *AuthRequest) SetUserInfo(userID, userName, loginName, displayName, avat
 	a.UserOrgID = userOrgID
 }
 
+// LDAPIDPs returns the allowed external identity providers verifying the password against an LDAP directory
+func (a *AuthRequest) LDAPIDPs() []*IDPProvider {
+	idps := make([]*IDPProvider, 0)
+	for _, idp := range a.AllowedExternalIDPs {
+		if idp.IDPConfigType == IDPConfigTypeLDAP {
+			idps = append(idps, idp)
+		}
+	}
+	return idps
+}
+
 func (a *AuthRequest) MFALevel() MFALevel {
 	return -1
 	//PLANNED: check a.PossibleLOAs (and Prompt Login?)
 }
 
+// LevelOfAssurance returns the level the login has to reach:
+// the lowest level requested by the client (acr_values), but at least the minimum of the application
+func (a *AuthRequest) LevelOfAssurance() LevelOfAssurance {
+	level := LevelOfAssuranceNone
+	for i, requested := range a.PossibleLOAs {
+		if i == 0 || requested < level {
+			level = requested
+		}
+	}
+	if level < a.MinLevelOfAssurance {
+		return a.MinLevelOfAssurance
+	}
+	return level
+}
+
+// AchievedLevelOfAssurance returns the level reached by the factors verified during the login
+func (a *AuthRequest) AchievedLevelOfAssurance() LevelOfAssurance {
+	level := LevelOfAssuranceNone
+	if a.PasswordVerified || a.MagicLinkVerified || a.SelectedIDPConfigID != "" {
+		level = LevelOfAssurancePassword
+	}
+	for _, mfa := range a.MFAsVerified {
+		if mfa.IsPhishingResistant() {
+			return LevelOfAssurancePhishingResistant
+		}
+		level = LevelOfAssuranceMFA
+	}
+	return level
+}
+
 func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
 	for _, a := range a.Audience {
 		if a == aud {
@@ -153,25 +219,29 @@ func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
 
 func (a *AuthRequest) GetScopeProjectIDsForAud() []string {
 	projectIDs := make([]string, 0)
-	switch request := a.Request.(type) {
-	case *AuthRequestOIDC:
-		for _, scope := range request.Scopes {
-			if strings.HasPrefix(scope, ProjectIDScope) && strings.HasSuffix(scope, AudSuffix) {
-				projectIDs = append(projectIDs, strings.TrimSuffix(strings.TrimPrefix(scope, ProjectIDScope), AudSuffix))
-			}
+	for _, scope := range a.scopes() {
+		if strings.HasPrefix(scope, ProjectIDScope) && strings.HasSuffix(scope, AudSuffix) {
+			projectIDs = append(projectIDs, strings.TrimSuffix(strings.TrimPrefix(scope, ProjectIDScope), AudSuffix))
 		}
 	}
 	return projectIDs
 }
 
 func (a *AuthRequest) GetScopeOrgPrimaryDomain() string {
-	switch request := a.Request.(type) {
-	case *AuthRequestOIDC:
-		for _, scope := range request.Scopes {
-			if strings.HasPrefix(scope, OrgDomainPrimaryScope) {
-				return strings.TrimPrefix(scope, OrgDomainPrimaryScope)
-			}
+	for _, scope := range a.scopes() {
+		if strings.HasPrefix(scope, OrgDomainPrimaryScope) {
+			return strings.TrimPrefix(scope, OrgDomainPrimaryScope)
 		}
 	}
 	return ""
 }
+
+func (a *AuthRequest) scopes() []string {
+	switch request := a.Request.(type) {
+	case *AuthRequestOIDC:
+		return request.Scopes
+	case *AuthRequestDevice:
+		return request.Scopes
+	}
+	return nil
+}
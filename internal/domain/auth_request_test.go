@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestAuthRequest_LevelOfAssurance(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *AuthRequest
+		want    LevelOfAssurance
+	}{
+		{
+			"nothing requested, none",
+			&AuthRequest{},
+			LevelOfAssuranceNone,
+		},
+		{
+			"requested by client",
+			&AuthRequest{PossibleLOAs: []LevelOfAssurance{LevelOfAssuranceMFA}},
+			LevelOfAssuranceMFA,
+		},
+		{
+			"multiple requested, lowest",
+			&AuthRequest{PossibleLOAs: []LevelOfAssurance{LevelOfAssurancePhishingResistant, LevelOfAssuranceMFA}},
+			LevelOfAssuranceMFA,
+		},
+		{
+			"application minimum",
+			&AuthRequest{MinLevelOfAssurance: LevelOfAssuranceMFA},
+			LevelOfAssuranceMFA,
+		},
+		{
+			"requested below application minimum, minimum",
+			&AuthRequest{PossibleLOAs: []LevelOfAssurance{LevelOfAssurancePassword}, MinLevelOfAssurance: LevelOfAssuranceMFA},
+			LevelOfAssuranceMFA,
+		},
+		{
+			"requested above application minimum, requested",
+			&AuthRequest{PossibleLOAs: []LevelOfAssurance{LevelOfAssurancePhishingResistant}, MinLevelOfAssurance: LevelOfAssuranceMFA},
+			LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.LevelOfAssurance(); got != tt.want {
+				t.Errorf("LevelOfAssurance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthRequest_AchievedLevelOfAssurance(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *AuthRequest
+		want    LevelOfAssurance
+	}{
+		{
+			"nothing verified, none",
+			&AuthRequest{},
+			LevelOfAssuranceNone,
+		},
+		{
+			"password verified",
+			&AuthRequest{PasswordVerified: true},
+			LevelOfAssurancePassword,
+		},
+		{
+			"magic link verified",
+			&AuthRequest{MagicLinkVerified: true},
+			LevelOfAssurancePassword,
+		},
+		{
+			"external login",
+			&AuthRequest{SelectedIDPConfigID: "idpConfigID"},
+			LevelOfAssurancePassword,
+		},
+		{
+			"password and otp verified, mfa",
+			&AuthRequest{PasswordVerified: true, MFAsVerified: []MFAType{MFATypeOTP}},
+			LevelOfAssuranceMFA,
+		},
+		{
+			"password and u2f verified, phishing resistant",
+			&AuthRequest{PasswordVerified: true, MFAsVerified: []MFAType{MFATypeOTP, MFATypeU2F}},
+			LevelOfAssurancePhishingResistant,
+		},
+		{
+			"passwordless verified, phishing resistant",
+			&AuthRequest{MFAsVerified: []MFAType{MFATypeU2FUserVerification}},
+			LevelOfAssurancePhishingResistant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.AchievedLevelOfAssurance(); got != tt.want {
+				t.Errorf("AchievedLevelOfAssurance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+)
+
+// BackChannelLogout is the signed logout token of a user,
+// which will be sent to the back-channel logout uri of an OIDC application
+type BackChannelLogout struct {
+	// ID is the unique identifier (jti) of the logout token
+	ID          string
+	UserID      string
+	ClientID    string
+	LogoutURI   string
+	LogoutToken string
+	Expiry      time.Duration
+}
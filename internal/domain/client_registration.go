@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const clientRegistrationSecretLength = 32
+
+// InitialAccessToken authorizes the dynamic registration of clients (RFC 7591) in a project
+type InitialAccessToken struct {
+	models.ObjectRoot
+
+	TokenID    string
+	Expiration time.Time
+}
+
+type InitialAccessTokenState int32
+
+const (
+	InitialAccessTokenStateUnspecified InitialAccessTokenState = iota
+	InitialAccessTokenStateActive
+	InitialAccessTokenStateRemoved
+)
+
+// FromInitialAccessToken returns the ids of the token and the hash of its secret,
+// which has to be compared to the stored hash
+func FromInitialAccessToken(token string) (projectID, tokenID, hash string, err error) {
+	ids, hash, err := parseClientRegistrationToken(token, 2)
+	if err != nil {
+		return "", "", "", caos_errors.ThrowPermissionDenied(err, "DOMAIN-Ahb3u", "Errors.Project.InitialAccessToken.Invalid")
+	}
+	return ids[0], ids[1], hash, nil
+}
+
+// FromRegistrationAccessToken returns the ids of the token and the hash of its secret,
+// which has to be compared to the stored hash
+func FromRegistrationAccessToken(token string) (projectID, appID, tokenID, hash string, err error) {
+	ids, hash, err := parseClientRegistrationToken(token, 3)
+	if err != nil {
+		return "", "", "", "", caos_errors.ThrowPermissionDenied(err, "DOMAIN-Oov4a", "Errors.Project.App.RegistrationAccessTokenInvalid")
+	}
+	return ids[0], ids[1], ids[2], hash, nil
+}
+
+// ClientRegistrationTokenHashEqual compares the hash of a presented token with the stored one in constant time
+func ClientRegistrationTokenHashEqual(hash, storedHash string) bool {
+	return storedHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(storedHash)) == 1
+}
+
+// NewClientRegistrationToken creates an initial access token (project id, token id)
+// or a registration access token (project id, app id, token id) of the form <ids>.<secret>.
+// The ids are only used to look up the stored hash, the random secret is what authorizes the bearer,
+// so only the returned hash of it must be stored
+func NewClientRegistrationToken(ids ...string) (token, hash string, err error) {
+	secret := make([]byte, clientRegistrationSecretLength)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	token = base64.RawURLEncoding.EncodeToString([]byte(strings.Join(ids, ":"))) + "." + encodedSecret
+	return token, hashClientRegistrationSecret(encodedSecret), nil
+}
+
+func parseClientRegistrationToken(token string, idCount int) (ids []string, hash string, err error) {
+	split := strings.Split(token, ".")
+	if len(split) != 2 || split[1] == "" {
+		return nil, "", caos_errors.ThrowInvalidArgument(nil, "DOMAIN-Wah6e", "invalid token format")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(split[0])
+	if err != nil {
+		return nil, "", err
+	}
+	ids = strings.Split(string(decoded), ":")
+	if len(ids) != idCount {
+		return nil, "", caos_errors.ThrowInvalidArgument(nil, "DOMAIN-ieN3a", "invalid token format")
+	}
+	return ids, hashClientRegistrationSecret(split[1]), nil
+}
+
+func hashClientRegistrationSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
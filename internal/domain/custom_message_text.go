@@ -13,6 +13,9 @@ const (
 	VerifyPhoneMessageType              = "VerifyPhone"
 	DomainClaimedMessageType            = "DomainClaimed"
 	PasswordlessRegistrationMessageType = "PasswordlessRegistration"
+	VerifySMSOTPMessageType             = "VerifySMSOTP"
+	VerifyEmailOTPMessageType           = "VerifyEmailOTP"
+	MagicLinkMessageType                = "MagicLink"
 	MessageTitle                        = "Title"
 	MessagePreHeader                    = "PreHeader"
 	MessageSubject                      = "Subject"
@@ -29,6 +32,9 @@ type MessageTexts struct {
 	VerifyPhone              CustomMessageText
 	DomainClaimed            CustomMessageText
 	PasswordlessRegistration CustomMessageText
+	VerifySMSOTP             CustomMessageText
+	VerifyEmailOTP           CustomMessageText
+	MagicLink                CustomMessageText
 }
 
 type CustomMessageText struct {
@@ -65,6 +71,12 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *CustomMessageText {
 		return &m.DomainClaimed
 	case PasswordlessRegistrationMessageType:
 		return &m.PasswordlessRegistration
+	case VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
+	case MagicLinkMessageType:
+		return &m.MagicLink
 	}
 	return nil
 }
@@ -75,5 +87,8 @@ func IsMessageTextType(textType string) bool {
 		textType == VerifyEmailMessageType ||
 		textType == VerifyPhoneMessageType ||
 		textType == DomainClaimedMessageType ||
-		textType == PasswordlessRegistrationMessageType
+		textType == PasswordlessRegistrationMessageType ||
+		textType == VerifySMSOTPMessageType ||
+		textType == VerifyEmailOTPMessageType ||
+		textType == MagicLinkMessageType
 }
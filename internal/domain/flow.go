@@ -17,6 +17,9 @@ type FlowType int32
 const (
 	FlowTypeUnspecified FlowType = iota
 	FlowTypeExternalAuthentication
+	FlowTypeCustomiseToken
+	FlowTypeInternalAuthentication
+	FlowTypeEvent
 	flowTypeCount
 )
 
@@ -27,11 +30,22 @@ func (s FlowType) Valid() bool {
 func (s FlowType) HasTrigger(triggerType TriggerType) bool {
 	switch triggerType {
 	case TriggerTypePostAuthentication:
-		return s == FlowTypeExternalAuthentication
+		return s == FlowTypeExternalAuthentication || s == FlowTypeInternalAuthentication
 	case TriggerTypePreCreation:
-		return s == FlowTypeExternalAuthentication
+		return s == FlowTypeExternalAuthentication || s == FlowTypeInternalAuthentication
 	case TriggerTypePostCreation:
-		return s == FlowTypeExternalAuthentication
+		return s == FlowTypeExternalAuthentication || s == FlowTypeInternalAuthentication
+	case TriggerTypePreUserinfoCreation:
+		return s == FlowTypeCustomiseToken
+	case TriggerTypePreAccessTokenCreation:
+		return s == FlowTypeCustomiseToken
+	case TriggerTypeHumanAdded,
+		TriggerTypeHumanRegistered,
+		TriggerTypeUserRemoved,
+		TriggerTypeUserGrantAdded,
+		TriggerTypeUserGrantChanged,
+		TriggerTypeUserGrantRemoved:
+		return s == FlowTypeEvent
 	default:
 		return false
 	}
@@ -44,6 +58,14 @@ const (
 	TriggerTypePostAuthentication
 	TriggerTypePreCreation
 	TriggerTypePostCreation
+	TriggerTypePreUserinfoCreation
+	TriggerTypePreAccessTokenCreation
+	TriggerTypeHumanAdded
+	TriggerTypeHumanRegistered
+	TriggerTypeUserRemoved
+	TriggerTypeUserGrantAdded
+	TriggerTypeUserGrantChanged
+	TriggerTypeUserGrantRemoved
 	triggerTypeCount
 )
 
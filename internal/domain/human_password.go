@@ -14,6 +14,8 @@ type Password struct {
 	SecretString   string
 	SecretCrypto   *crypto.CryptoValue
 	ChangeRequired bool
+	// EncodedHash is the hash of a password created by another system (e.g. on import of users)
+	EncodedHash string
 }
 
 func NewPassword(password string) *Password {
@@ -32,7 +34,7 @@ type PasswordCode struct {
 
 func (p *Password) HashPasswordIfExisting(policy *PasswordComplexityPolicy, passwordAlg crypto.HashAlgorithm) error {
 	if p.SecretString == "" {
-		return nil
+		return p.parseEncodedHashIfExisting()
 	}
 	if policy == nil {
 		return caos_errs.ThrowPreconditionFailed(nil, "DOMAIN-s8ifS", "Errors.User.PasswordComplexityPolicy.NotFound")
@@ -48,6 +50,24 @@ func (p *Password) HashPasswordIfExisting(policy *PasswordComplexityPolicy, pass
 	return nil
 }
 
+// parseEncodedHashIfExisting takes over the hash as is,
+// the password complexity can't be checked and the hash will be replaced on the first successful login
+func (p *Password) parseEncodedHashIfExisting() error {
+	if p.EncodedHash == "" {
+		return nil
+	}
+	secret, err := crypto.ParsePasswordHash(p.EncodedHash)
+	if err != nil {
+		return caos_errs.ThrowInvalidArgument(err, "DOMAIN-Eeph6", "Errors.User.Password.HashInvalid")
+	}
+	p.SecretCrypto = secret
+	return nil
+}
+
+func (p *Password) IsSet() bool {
+	return p != nil && (p.SecretString != "" || p.EncodedHash != "")
+}
+
 func NewPasswordCode(passwordGenerator crypto.Generator) (*PasswordCode, error) {
 	passwordCodeCrypto, _, err := crypto.NewCode(passwordGenerator)
 	if err != nil {
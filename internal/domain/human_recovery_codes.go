@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const (
+	RecoveryCodesCount = 10
+	recoveryCodeLength = 10
+)
+
+// recoveryCodeRunes omits characters which are easily confused when written down (0/o, 1/l)
+var recoveryCodeRunes = []rune("abcdefghijkmnpqrstuvwxyz23456789")
+
+type RecoveryCodes struct {
+	es_models.ObjectRoot
+
+	// Codes are only returned in plain text on generation
+	Codes []string
+}
+
+// NewRecoveryCodes generates the single use recovery codes
+// and returns them in their display format (xxxxx-xxxxx) and hashed
+func NewRecoveryCodes(hashAlg crypto.HashAlgorithm) ([]string, []*crypto.CryptoValue, error) {
+	codes := make([]string, RecoveryCodesCount)
+	hashedCodes := make([]*crypto.CryptoValue, RecoveryCodesCount)
+	for i := range codes {
+		code, err := crypto.GenerateRandomString(recoveryCodeLength, recoveryCodeRunes)
+		if err != nil {
+			return nil, nil, err
+		}
+		hashedCodes[i], err = crypto.Hash([]byte(code), hashAlg)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+	}
+	return codes, hashedCodes, nil
+}
+
+// VerifyRecoveryCode returns the index of the matching code,
+// used codes must be passed as nil
+func VerifyRecoveryCode(code string, hashedCodes []*crypto.CryptoValue, hashAlg crypto.HashAlgorithm) (int, error) {
+	code = normalizeRecoveryCode(code)
+	if len(code) != recoveryCodeLength {
+		return -1, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-ohG3a", "Errors.User.MFA.RecoveryCodes.InvalidCode")
+	}
+	for i, hashedCode := range hashedCodes {
+		if hashedCode == nil {
+			continue
+		}
+		if err := crypto.CompareHash(hashedCode, []byte(code), hashAlg); err == nil {
+			return i, nil
+		}
+	}
+	return -1, caos_errs.ThrowInvalidArgument(nil, "DOMAIN-Gie8o", "Errors.User.MFA.RecoveryCodes.InvalidCode")
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+func TestVerifyRecoveryCode(t *testing.T) {
+	hashAlg := crypto.NewBCrypt(4)
+	codes, hashedCodes, err := NewRecoveryCodes(hashAlg)
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != RecoveryCodesCount || len(hashedCodes) != RecoveryCodesCount {
+		t.Fatalf("NewRecoveryCodes() returned %d codes, want %d", len(codes), RecoveryCodesCount)
+	}
+	usedCodes := make([]*crypto.CryptoValue, len(hashedCodes))
+	copy(usedCodes, hashedCodes)
+	usedCodes[2] = nil
+
+	tests := []struct {
+		name        string
+		code        string
+		hashedCodes []*crypto.CryptoValue
+		want        int
+		wantErr     bool
+	}{
+		{
+			"display format",
+			codes[1],
+			hashedCodes,
+			1,
+			false,
+		},
+		{
+			"upper case without separator",
+			strings.ToUpper(strings.Replace(codes[3], "-", "", 1)),
+			hashedCodes,
+			3,
+			false,
+		},
+		{
+			"used code",
+			codes[2],
+			usedCodes,
+			-1,
+			true,
+		},
+		{
+			"invalid length",
+			"abc",
+			hashedCodes,
+			-1,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyRecoveryCode(tt.code, tt.hashedCodes, hashAlg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyRecoveryCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifyRecoveryCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
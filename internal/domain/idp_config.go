@@ -5,6 +5,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/crypto"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/ldap"
 )
 
 type IDPConfig struct {
@@ -16,6 +17,8 @@ type IDPConfig struct {
 	State        IDPConfigState
 	OIDCConfig   *OIDCIDPConfig
 	JWTConfig    *JWTIDPConfig
+	SAMLConfig   *SAMLIDPConfig
+	LDAPConfig   *LDAPIDPConfig
 	AutoRegister bool
 }
 
@@ -44,6 +47,19 @@ type IDPConfigView struct {
 	JWTEndpoint     string
 	JWTIssuer       string
 	JWTKeysEndpoint string
+
+	SAMLEntityID    string
+	SAMLMetadata    []byte
+	SAMLMetadataURL string
+
+	IsLDAP           bool
+	LDAPURL          string
+	LDAPBaseDN       string
+	LDAPBindDN       string
+	LDAPBindPassword *crypto.CryptoValue
+	LDAPObjectClass  string
+	LDAPUserFilters  []string
+	LDAPAttributes   LDAPAttributes
 }
 
 type OIDCIDPConfig struct {
@@ -69,12 +85,79 @@ type JWTIDPConfig struct {
 	HeaderName   string
 }
 
+type SAMLIDPConfig struct {
+	es_models.ObjectRoot
+	IDPConfigID string
+	EntityID    string
+	Metadata    []byte
+	MetadataURL string
+}
+
+func (c *SAMLIDPConfig) IsValid() bool {
+	return len(c.Metadata) > 0 || c.MetadataURL != ""
+}
+
+type LDAPIDPConfig struct {
+	es_models.ObjectRoot
+	IDPConfigID        string
+	URL                string
+	StartTLS           bool
+	RootCA             string
+	BaseDN             string
+	BindDN             string
+	BindPassword       *crypto.CryptoValue
+	BindPasswordString string
+	UserObjectClass    string
+	UserFilters        []string
+	Attributes         LDAPAttributes
+}
+
+// LDAPAttributes are the names of the directory attributes mapped to the external user
+type LDAPAttributes struct {
+	IDAttribute                string
+	PreferredUsernameAttribute string
+	FirstNameAttribute         string
+	LastNameAttribute          string
+	DisplayNameAttribute       string
+	EmailAttribute             string
+	PhoneAttribute             string
+}
+
+// Names returns all configured attribute names
+func (a LDAPAttributes) Names() []string {
+	names := make([]string, 0, 7)
+	for _, name := range []string{
+		a.IDAttribute,
+		a.PreferredUsernameAttribute,
+		a.FirstNameAttribute,
+		a.LastNameAttribute,
+		a.DisplayNameAttribute,
+		a.EmailAttribute,
+		a.PhoneAttribute,
+	} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c *LDAPIDPConfig) IsValid() bool {
+	return ldap.ValidateURL(c.URL) == nil &&
+		ldap.ValidateRootCA(c.RootCA) == nil &&
+		c.BaseDN != "" &&
+		c.UserObjectClass != "" &&
+		len(c.UserFilters) > 0 &&
+		c.Attributes.IDAttribute != ""
+}
+
 type IDPConfigType int32
 
 const (
 	IDPConfigTypeOIDC IDPConfigType = iota
 	IDPConfigTypeSAML
 	IDPConfigTypeJWT
+	IDPConfigTypeLDAP
 
 	//count is for validation
 	idpConfigTypeCount
@@ -1,5 +1,9 @@
 package domain
 
+const (
+	PermissionUserImpersonation = "user.impersonation"
+)
+
 type Permissions struct {
 	Permissions []string
 }
@@ -21,3 +25,12 @@ func (p *Permissions) appendPermission(ctxID, permission string) {
 	}
 	p.Permissions = append(p.Permissions, permission)
 }
+
+func (p *Permissions) Contains(permission string) bool {
+	for _, existingPermission := range p.Permissions {
+		if existingPermission == permission {
+			return true
+		}
+	}
+	return false
+}
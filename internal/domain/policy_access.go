@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+type AccessPolicy struct {
+	models.ObjectRoot
+
+	Default bool
+	Rules   AccessRules
+}
+
+type AccessRuleAction int32
+
+const (
+	AccessRuleActionAllow AccessRuleAction = iota
+	AccessRuleActionDeny
+	AccessRuleActionRequireMFA
+	AccessRuleActionRequirePasswordless
+
+	accessRuleActionCount
+)
+
+func (a AccessRuleAction) Valid() bool {
+	return a >= 0 && a < accessRuleActionCount
+}
+
+// AccessRule applies its action to a login if all of its conditions match.
+// Conditions which are not set match every login.
+type AccessRule struct {
+	Name   string           `json:"name,omitempty"`
+	Action AccessRuleAction `json:"action,omitempty"`
+	// IPRanges are networks in CIDR notation (e.g. 10.0.0.0/8)
+	IPRanges []string `json:"ipRanges,omitempty"`
+	// Countries are ISO 3166-1 alpha-2 codes (e.g. CH) resolved from the remote IP
+	Countries []string `json:"countries,omitempty"`
+	// NewDevice matches logins from user agents the user has not been logged in with before
+	NewDevice bool `json:"newDevice,omitempty"`
+	// ApplicationIDs are the client ids (OIDC) or entity ids (SAML) of the requesting applications
+	ApplicationIDs []string `json:"applicationIds,omitempty"`
+	// UserRoles match if the user is granted at least one of the roles on the project of the application
+	UserRoles []string `json:"userRoles,omitempty"`
+}
+
+func (r *AccessRule) IsValid() bool {
+	if r == nil || !r.Action.Valid() {
+		return false
+	}
+	for _, ipRange := range r.IPRanges {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			return false
+		}
+	}
+	for _, country := range r.Countries {
+		if len(country) != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches checks if all conditions of the rule match the access context
+func (r *AccessRule) Matches(access *AccessContext) bool {
+	if len(r.IPRanges) > 0 && !ipInRanges(access.IP, r.IPRanges) {
+		return false
+	}
+	if len(r.Countries) > 0 && !containsFold(r.Countries, access.Country) {
+		return false
+	}
+	if r.NewDevice && !access.NewDevice {
+		return false
+	}
+	if len(r.ApplicationIDs) > 0 && !containsFold(r.ApplicationIDs, access.ApplicationID) {
+		return false
+	}
+	if len(r.UserRoles) > 0 && !containsAny(r.UserRoles, access.UserRoles) {
+		return false
+	}
+	return true
+}
+
+type AccessRules []*AccessRule
+
+func (r AccessRules) IsValid() bool {
+	for _, rule := range r {
+		if !rule.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate returns the action of the first matching rule,
+// logins which don't match any rule are allowed
+func (r AccessRules) Evaluate(access *AccessContext) AccessRuleAction {
+	for _, rule := range r {
+		if rule.Matches(access) {
+			return rule.Action
+		}
+	}
+	return AccessRuleActionAllow
+}
+
+// RequiresUserRoles checks if any rule has a condition on the roles of the user
+func (r AccessRules) RequiresUserRoles() bool {
+	for _, rule := range r {
+		if len(rule.UserRoles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresCountry checks if any rule has a condition on the country of the remote IP
+func (r AccessRules) RequiresCountry() bool {
+	for _, rule := range r {
+		if len(rule.Countries) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (r AccessRules) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
+
+func (r *AccessRules) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, r)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), r)
+	}
+	return nil
+}
+
+// AccessContext contains the signals of a login the access rules are evaluated against
+type AccessContext struct {
+	IP            net.IP
+	Country       string
+	NewDevice     bool
+	ApplicationID string
+	UserRoles     []string
+}
+
+func ipInRanges(ip net.IP, ipRanges []string) bool {
+	if ip == nil {
+		return false
+	}
+	for _, ipRange := range ipRanges {
+		_, network, err := net.ParseCIDR(ipRange)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, entry := range list {
+		if strings.EqualFold(entry, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list, values []string) bool {
+	for _, value := range values {
+		for _, entry := range list {
+			if entry == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAccessRules_Evaluate(t *testing.T) {
+	type args struct {
+		access *AccessContext
+	}
+	tests := []struct {
+		name  string
+		rules AccessRules
+		args  args
+		want  AccessRuleAction
+	}{
+		{
+			"no rules, allow",
+			nil,
+			args{&AccessContext{IP: net.ParseIP("10.0.0.1")}},
+			AccessRuleActionAllow,
+		},
+		{
+			"rule without conditions, matches",
+			AccessRules{{Action: AccessRuleActionRequireMFA}},
+			args{&AccessContext{IP: net.ParseIP("10.0.0.1")}},
+			AccessRuleActionRequireMFA,
+		},
+		{
+			"ip in range, deny",
+			AccessRules{{Action: AccessRuleActionDeny, IPRanges: []string{"192.168.0.0/16", "10.0.0.0/8"}}},
+			args{&AccessContext{IP: net.ParseIP("10.0.0.1")}},
+			AccessRuleActionDeny,
+		},
+		{
+			"ip not in range, allow",
+			AccessRules{{Action: AccessRuleActionDeny, IPRanges: []string{"10.0.0.0/8"}}},
+			args{&AccessContext{IP: net.ParseIP("172.16.0.1")}},
+			AccessRuleActionAllow,
+		},
+		{
+			"unknown ip, range doesn't match",
+			AccessRules{{Action: AccessRuleActionDeny, IPRanges: []string{"10.0.0.0/8"}}},
+			args{&AccessContext{}},
+			AccessRuleActionAllow,
+		},
+		{
+			"country matches case insensitive, require mfa",
+			AccessRules{{Action: AccessRuleActionRequireMFA, Countries: []string{"ch", "DE"}}},
+			args{&AccessContext{Country: "CH"}},
+			AccessRuleActionRequireMFA,
+		},
+		{
+			"unknown country, allow",
+			AccessRules{{Action: AccessRuleActionDeny, Countries: []string{"CH"}}},
+			args{&AccessContext{}},
+			AccessRuleActionAllow,
+		},
+		{
+			"new device only, known device, allow",
+			AccessRules{{Action: AccessRuleActionRequireMFA, NewDevice: true}},
+			args{&AccessContext{NewDevice: false}},
+			AccessRuleActionAllow,
+		},
+		{
+			"new device only, new device, require mfa",
+			AccessRules{{Action: AccessRuleActionRequireMFA, NewDevice: true}},
+			args{&AccessContext{NewDevice: true}},
+			AccessRuleActionRequireMFA,
+		},
+		{
+			"all conditions must match",
+			AccessRules{{Action: AccessRuleActionDeny, ApplicationIDs: []string{"app"}, UserRoles: []string{"admin"}}},
+			args{&AccessContext{ApplicationID: "app", UserRoles: []string{"user"}}},
+			AccessRuleActionAllow,
+		},
+		{
+			"application and role match, require passwordless",
+			AccessRules{{Action: AccessRuleActionRequirePasswordless, ApplicationIDs: []string{"app"}, UserRoles: []string{"admin"}}},
+			args{&AccessContext{ApplicationID: "app", UserRoles: []string{"user", "admin"}}},
+			AccessRuleActionRequirePasswordless,
+		},
+		{
+			"first matching rule wins",
+			AccessRules{
+				{Action: AccessRuleActionAllow, IPRanges: []string{"10.0.0.0/8"}},
+				{Action: AccessRuleActionDeny},
+			},
+			args{&AccessContext{IP: net.ParseIP("10.0.0.1")}},
+			AccessRuleActionAllow,
+		},
+		{
+			"first rule doesn't match, second rule applies",
+			AccessRules{
+				{Action: AccessRuleActionAllow, IPRanges: []string{"10.0.0.0/8"}},
+				{Action: AccessRuleActionDeny},
+			},
+			args{&AccessContext{IP: net.ParseIP("172.16.0.1")}},
+			AccessRuleActionDeny,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Evaluate(tt.args.access); got != tt.want {
+				t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessRule_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule *AccessRule
+		want bool
+	}{
+		{
+			"nil, invalid",
+			nil,
+			false,
+		},
+		{
+			"unknown action, invalid",
+			&AccessRule{Action: accessRuleActionCount},
+			false,
+		},
+		{
+			"invalid ip range, invalid",
+			&AccessRule{Action: AccessRuleActionDeny, IPRanges: []string{"10.0.0.1"}},
+			false,
+		},
+		{
+			"invalid country, invalid",
+			&AccessRule{Action: AccessRuleActionDeny, Countries: []string{"CHE"}},
+			false,
+		},
+		{
+			"valid",
+			&AccessRule{Action: AccessRuleActionDeny, IPRanges: []string{"10.0.0.0/8", "2001:db8::/32"}, Countries: []string{"CH"}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
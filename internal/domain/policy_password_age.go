@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -10,3 +12,28 @@ type PasswordAgePolicy struct {
 	MaxAgeDays     uint64
 	ExpireWarnDays uint64
 }
+
+// PasswordExpiry returns the point in time a password changed at passwordChanged expires.
+// The zero time is returned if passwords don't expire.
+func (p *PasswordAgePolicy) PasswordExpiry(passwordChanged time.Time) time.Time {
+	if p == nil || p.MaxAgeDays == 0 || passwordChanged.IsZero() {
+		return time.Time{}
+	}
+	return passwordChanged.AddDate(0, 0, int(p.MaxAgeDays))
+}
+
+// IsPasswordExpired returns true if the password changed at passwordChanged must be changed
+func (p *PasswordAgePolicy) IsPasswordExpired(passwordChanged, now time.Time) bool {
+	expiry := p.PasswordExpiry(passwordChanged)
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// IsPasswordExpiryWarning returns true if the password changed at passwordChanged is about to expire
+// within the ExpireWarnDays
+func (p *PasswordAgePolicy) IsPasswordExpiryWarning(passwordChanged, now time.Time) bool {
+	expiry := p.PasswordExpiry(passwordChanged)
+	if expiry.IsZero() || p.ExpireWarnDays == 0 || !now.Before(expiry) {
+		return false
+	}
+	return !now.Before(expiry.AddDate(0, 0, -int(p.ExpireWarnDays)))
+}
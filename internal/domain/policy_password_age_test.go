@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordAgePolicy_IsPasswordExpired(t *testing.T) {
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		passwordChanged time.Time
+	}
+	tests := []struct {
+		name        string
+		policy      *PasswordAgePolicy
+		args        args
+		wantExpired bool
+		wantWarning bool
+	}{
+		{
+			"no policy, not expired",
+			nil,
+			args{now.AddDate(-1, 0, 0)},
+			false,
+			false,
+		},
+		{
+			"no max age, not expired",
+			&PasswordAgePolicy{ExpireWarnDays: 10},
+			args{now.AddDate(-1, 0, 0)},
+			false,
+			false,
+		},
+		{
+			"unknown change date, not expired",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{time.Time{}},
+			false,
+			false,
+		},
+		{
+			"within max age, not expired",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{now.AddDate(0, 0, -10)},
+			false,
+			false,
+		},
+		{
+			"within warn days, warning",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{now.AddDate(0, 0, -25)},
+			false,
+			true,
+		},
+		{
+			"within warn days without warn days, not expired",
+			&PasswordAgePolicy{MaxAgeDays: 30},
+			args{now.AddDate(0, 0, -25)},
+			false,
+			false,
+		},
+		{
+			"max age reached, expired",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{now.AddDate(0, 0, -30)},
+			true,
+			false,
+		},
+		{
+			"max age exceeded, expired",
+			&PasswordAgePolicy{MaxAgeDays: 30, ExpireWarnDays: 10},
+			args{now.AddDate(0, -2, 0)},
+			true,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsPasswordExpired(tt.args.passwordChanged, now); got != tt.wantExpired {
+				t.Errorf("IsPasswordExpired() = %v, want %v", got, tt.wantExpired)
+			}
+			if got := tt.policy.IsPasswordExpiryWarning(tt.args.passwordChanged, now); got != tt.wantWarning {
+				t.Errorf("IsPasswordExpiryWarning() = %v, want %v", got, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -14,6 +14,9 @@ var (
 	hasSymbol          = regexp.MustCompile(`[^A-Za-z0-9]`).MatchString
 )
 
+// MaxPasswordHistoryDepth limits the previous passwords to compare, as every comparison requires a (costly) hash
+const MaxPasswordHistoryDepth = 24
+
 type PasswordComplexityPolicy struct {
 	models.ObjectRoot
 
@@ -22,6 +25,10 @@ type PasswordComplexityPolicy struct {
 	HasUppercase bool
 	HasNumber    bool
 	HasSymbol    bool
+	// HistoryDepth is the amount of previous passwords (including the current one), which must not be reused
+	HistoryDepth uint64
+	// CheckBreached rejects passwords found in the configured list of breached passwords
+	CheckBreached bool
 
 	Default bool
 }
@@ -30,6 +37,9 @@ func (p *PasswordComplexityPolicy) IsValid() error {
 	if p.MinLength == 0 || p.MinLength > 72 {
 		return caos_errs.ThrowInvalidArgument(nil, "MODEL-Lsp0e", "Errors.User.PasswordComplexityPolicy.MinLengthNotAllowed")
 	}
+	if p.HistoryDepth > MaxPasswordHistoryDepth {
+		return caos_errs.ThrowInvalidArgument(nil, "MODEL-Ahch7", "Errors.User.PasswordComplexityPolicy.HistoryDepthNotAllowed")
+	}
 	return nil
 }
 
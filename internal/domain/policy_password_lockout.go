@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -9,5 +11,16 @@ type LockoutPolicy struct {
 
 	Default             bool
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
+	MaxU2FAttempts      uint64
 	ShowLockOutFailures bool
+	AutoUnlockDuration  time.Duration
+}
+
+// LockoutExpired checks if a user locked by the lockout policy at lockedAt can be unlocked automatically
+func (p *LockoutPolicy) LockoutExpired(lockedAt time.Time) bool {
+	if p == nil || p.AutoUnlockDuration == 0 {
+		return false
+	}
+	return !lockedAt.Add(p.AutoUnlockDuration).After(time.Now())
 }
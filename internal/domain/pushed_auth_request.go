@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// PushedAuthRequestURIPrefix is the prefix of the request_uri returned by the pushed authorization request endpoint (RFC 9126, section 2.2)
+const PushedAuthRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// PushedAuthRequest holds the parameters of an authorization request,
+// which the client pushed to the authorization server before redirecting the user agent (RFC 9126)
+// the request can only be used once by the client it was pushed by
+type PushedAuthRequest struct {
+	ID           string
+	InstanceID   string
+	ClientID     string
+	Parameters   map[string][]string
+	CreationDate time.Time
+	Expiration   time.Time
+}
+
+func (r *PushedAuthRequest) RequestURI() string {
+	return PushedAuthRequestURIPrefix + r.ID
+}
+
+func (r *PushedAuthRequest) IsExpired(now time.Time) bool {
+	return !now.Before(r.Expiration)
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"strings"
+	"time"
+)
+
 const (
 	OrgDomainPrimaryScope = "urn:zitadel:iam:org:domain:primary:"
 	OrgDomainPrimaryClaim = "urn:zitadel:iam:org:domain:primary"
@@ -19,6 +24,7 @@ type AuthRequestType int32
 const (
 	AuthRequestTypeOIDC AuthRequestType = iota
 	AuthRequestTypeSAML
+	AuthRequestTypeDevice
 )
 
 type AuthRequestOIDC struct {
@@ -38,6 +44,11 @@ func (a *AuthRequestOIDC) IsValid() bool {
 }
 
 type AuthRequestSAML struct {
+	RequestID    string
+	BindingType  string
+	Issuer       string
+	Destination  string
+	NameIDFormat string
 }
 
 func (a *AuthRequestSAML) Type() AuthRequestType {
@@ -45,5 +56,89 @@ func (a *AuthRequestSAML) Type() AuthRequestType {
 }
 
 func (a *AuthRequestSAML) IsValid() bool {
-	return true
+	return a.RequestID != "" && a.Issuer != ""
+}
+
+type DeviceAuthState int32
+
+const (
+	DeviceAuthStateInitiated DeviceAuthState = iota
+	DeviceAuthStateApproved
+	DeviceAuthStateDenied
+)
+
+type DeviceAuthPollResult int32
+
+const (
+	DeviceAuthPollResultPending DeviceAuthPollResult = iota
+	DeviceAuthPollResultSlowDown
+	DeviceAuthPollResultExpired
+	DeviceAuthPollResultDenied
+	DeviceAuthPollResultApproved
+)
+
+// DeviceAuthSlowDownInterval is added to the polling interval of a device,
+// each time it polls faster than allowed (RFC 8628, section 3.5)
+const DeviceAuthSlowDownInterval = 5 * time.Second
+
+// AuthRequestDevice is the request of the device authorization grant (RFC 8628)
+// the device polls with the DeviceCode while the user authenticates on another device using the UserCode
+type AuthRequestDevice struct {
+	Scopes     []string
+	DeviceCode string
+	UserCode   string
+	Expiration time.Time
+	Interval   time.Duration
+	LastPoll   time.Time
+	State      DeviceAuthState
+}
+
+func (a *AuthRequestDevice) Type() AuthRequestType {
+	return AuthRequestTypeDevice
+}
+
+func (a *AuthRequestDevice) IsValid() bool {
+	return len(a.Scopes) > 0 &&
+		a.DeviceCode != "" &&
+		a.UserCode != "" &&
+		!a.Expiration.IsZero()
+}
+
+// NormalizeDeviceUserCode removes the separators and whitespaces of a user code entered by the user
+func NormalizeDeviceUserCode(userCode string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(userCode))
+}
+
+// FormatDeviceUserCode splits the user code into two halves for better readability (e.g. BCDF-GHJK)
+func FormatDeviceUserCode(userCode string) string {
+	if len(userCode) < 2 {
+		return userCode
+	}
+	half := len(userCode) / 2
+	return userCode[:half] + "-" + userCode[half:]
+}
+
+func (a *AuthRequestDevice) IsExpired(now time.Time) bool {
+	return !now.Before(a.Expiration)
+}
+
+// Poll records the poll of the device at the given time and returns its result
+// if the device polls faster than the interval, the interval is increased
+func (a *AuthRequestDevice) Poll(now time.Time) DeviceAuthPollResult {
+	lastPoll := a.LastPoll
+	a.LastPoll = now
+	if a.IsExpired(now) {
+		return DeviceAuthPollResultExpired
+	}
+	switch a.State {
+	case DeviceAuthStateDenied:
+		return DeviceAuthPollResultDenied
+	case DeviceAuthStateApproved:
+		return DeviceAuthPollResultApproved
+	}
+	if !lastPoll.IsZero() && now.Sub(lastPoll) < a.Interval {
+		a.Interval += DeviceAuthSlowDownInterval
+		return DeviceAuthPollResultSlowDown
+	}
+	return DeviceAuthPollResultPending
 }
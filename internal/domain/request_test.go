@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuthRequestDevice_Poll(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		Expiration time.Time
+		Interval   time.Duration
+		LastPoll   time.Time
+		State      DeviceAuthState
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		want         DeviceAuthPollResult
+		wantInterval time.Duration
+	}{
+		{
+			name: "first poll, pending",
+			fields: fields{
+				Expiration: now.Add(time.Minute),
+				Interval:   5 * time.Second,
+			},
+			want:         DeviceAuthPollResultPending,
+			wantInterval: 5 * time.Second,
+		},
+		{
+			name: "poll after interval, pending",
+			fields: fields{
+				Expiration: now.Add(time.Minute),
+				Interval:   5 * time.Second,
+				LastPoll:   now.Add(-5 * time.Second),
+			},
+			want:         DeviceAuthPollResultPending,
+			wantInterval: 5 * time.Second,
+		},
+		{
+			name: "poll within interval, slow down",
+			fields: fields{
+				Expiration: now.Add(time.Minute),
+				Interval:   5 * time.Second,
+				LastPoll:   now.Add(-2 * time.Second),
+			},
+			want:         DeviceAuthPollResultSlowDown,
+			wantInterval: 10 * time.Second,
+		},
+		{
+			name: "expired, expired",
+			fields: fields{
+				Expiration: now,
+				Interval:   5 * time.Second,
+				State:      DeviceAuthStateApproved,
+			},
+			want:         DeviceAuthPollResultExpired,
+			wantInterval: 5 * time.Second,
+		},
+		{
+			name: "denied, denied",
+			fields: fields{
+				Expiration: now.Add(time.Minute),
+				Interval:   5 * time.Second,
+				LastPoll:   now.Add(-time.Second),
+				State:      DeviceAuthStateDenied,
+			},
+			want:         DeviceAuthPollResultDenied,
+			wantInterval: 5 * time.Second,
+		},
+		{
+			name: "approved, approved",
+			fields: fields{
+				Expiration: now.Add(time.Minute),
+				Interval:   5 * time.Second,
+				LastPoll:   now.Add(-time.Second),
+				State:      DeviceAuthStateApproved,
+			},
+			want:         DeviceAuthPollResultApproved,
+			wantInterval: 5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &AuthRequestDevice{
+				Expiration: tt.fields.Expiration,
+				Interval:   tt.fields.Interval,
+				LastPoll:   tt.fields.LastPoll,
+				State:      tt.fields.State,
+			}
+			if got := a.Poll(now); got != tt.want {
+				t.Errorf("Poll() = %v, want %v", got, tt.want)
+			}
+			if a.Interval != tt.wantInterval {
+				t.Errorf("Poll() interval = %v, want %v", a.Interval, tt.wantInterval)
+			}
+			if !a.LastPoll.Equal(now) {
+				t.Errorf("Poll() last poll = %v, want %v", a.LastPoll, now)
+			}
+		})
+	}
+}
+
+func TestNormalizeDeviceUserCode(t *testing.T) {
+	tests := []struct {
+		userCode string
+		want     string
+	}{
+		{"BCDF-GHJK", "BCDFGHJK"},
+		{"bcdf-ghjk", "BCDFGHJK"},
+		{" bcdf ghjk ", "BCDFGHJK"},
+		{"BCDFGHJK", "BCDFGHJK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userCode, func(t *testing.T) {
+			if got := NormalizeDeviceUserCode(tt.userCode); got != tt.want {
+				t.Errorf("NormalizeDeviceUserCode() = %v, want %v", got, tt.want)
+			}
+			if got := NormalizeDeviceUserCode(FormatDeviceUserCode(tt.want)); got != tt.want {
+				t.Errorf("NormalizeDeviceUserCode(FormatDeviceUserCode()) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
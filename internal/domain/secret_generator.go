@@ -11,6 +11,9 @@ const (
 	SecretGeneratorTypePasswordResetCode
 	SecretGeneratorTypePasswordlessInitCode
 	SecretGeneratorTypeAppSecret
+	SecretGeneratorTypeOTPSMS
+	SecretGeneratorTypeOTPEmail
+	SecretGeneratorTypeMagicLink
 
 	secretGeneratorTypeCount
 )
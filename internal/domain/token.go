@@ -18,6 +18,31 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	Actor             *TokenActor
+	Confirmation      *TokenConfirmation
+}
+
+// TokenActor is the user acting on behalf of the subject of a token,
+// it's returned as act claim (RFC 8693, section 4.1)
+// the actor might itself act on behalf of another user (delegation chain)
+type TokenActor struct {
+	Actor         *TokenActor `json:"actor,omitempty"`
+	UserID        string      `json:"userId,omitempty"`
+	Issuer        string      `json:"issuer,omitempty"`
+	ResourceOwner string      `json:"resourceOwner,omitempty"`
+	InstanceID    string      `json:"instanceId,omitempty"`
+}
+
+// TokenConfirmation binds a token to a key of the client (sender-constrained token),
+// it's returned as cnf claim (RFC 7800)
+// either the thumbprint of the DPoP key (RFC 9449) or of the client certificate (RFC 8705) is set
+type TokenConfirmation struct {
+	JKT                   string `json:"jkt,omitempty"`
+	CertificateThumbprint string `json:"x5t#S256,omitempty"`
+}
+
+func (c *TokenConfirmation) IsEmpty() bool {
+	return c == nil || (c.JKT == "" && c.CertificateThumbprint == "")
 }
 
 func AddAudScopeToAudience(audience, scopes []string) []string {
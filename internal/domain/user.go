@@ -47,6 +47,9 @@ const (
 	UserAuthMethodTypeOTP
 	UserAuthMethodTypeU2F
 	UserAuthMethodTypePasswordless
+	UserAuthMethodTypeOTPSMS
+	UserAuthMethodTypeOTPEmail
+	UserAuthMethodTypeRecoveryCodes
 	userAuthMethodTypeCount
 )
 
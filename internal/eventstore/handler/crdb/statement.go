@@ -25,6 +25,14 @@ func WithTableSuffix(name string) func(*execConfig) {
 	}
 }
 
+//WithTableName executes the statement on the given table instead of the table of the projection
+//it's used by handlers which queue side effects for events (e.g. actions)
+func WithTableName(name string) func(*execConfig) {
+	return func(o *execConfig) {
+		o.tableName = name
+	}
+}
+
 func NewCreateStatement(event eventstore.Event, values []handler.Column, opts ...execOption) *handler.Statement {
 	cols, params, args := columnsToQuery(values)
 	columnNames := strings.Join(cols, ", ")
@@ -209,6 +217,16 @@ func NewArrayRemoveCol(column string, value interface{}) handler.Column {
 	}
 }
 
+func NewDecrementCol(column string, value interface{}) handler.Column {
+	return handler.Column{
+		Name:  column,
+		Value: value,
+		ParameterOpt: func(placeholder string) string {
+			return column + " - " + placeholder
+		},
+	}
+}
+
 func NewArrayIntersectCol(column string, value interface{}) handler.Column {
 	var arrayType string
 	switch value.(type) {
@@ -629,6 +629,28 @@ func TestNewNoOpStatement(t *testing.T) {
 	}
 }
 
+func TestWithTableName(t *testing.T) {
+	executer := &wantExecuter{
+		params: []params{
+			{
+				query: "INSERT INTO other_table (col1) VALUES ($1)",
+				args:  []interface{}{"val"},
+			},
+		},
+		shouldExecute: true,
+		t:             t,
+	}
+	stmt := NewCreateStatement(
+		&testEvent{aggregateType: "agg", sequence: 1},
+		[]handler.Column{handler.NewCol("col1", "val")},
+		WithTableName("other_table"),
+	)
+	if err := stmt.Execute(executer, "my_projection"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	executer.check(t)
+}
+
 func TestNewMultiStatement(t *testing.T) {
 	type args struct {
 		table string
@@ -1226,6 +1248,16 @@ func TestParameterOpts(t *testing.T) {
 			constructor: NewArrayRemoveCol,
 			want:        "array_remove(testCol, $1)",
 		},
+		{
+			name: "NewDecrementCol",
+			args: args{
+				column:      "testCol",
+				value:       1,
+				placeholder: "$1",
+			},
+			constructor: NewDecrementCol,
+			want:        "testCol - $1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,137 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type Config struct {
+	// Path to an offline country database in CSV format.
+	// Each line either contains a network and a country (e.g. 10.0.0.0/8,CH)
+	// or the first and the last IP of a range and a country (e.g. 10.0.0.0,10.255.255.255,CH).
+	// No countries are resolved if the path is empty.
+	Path string
+}
+
+// DB resolves the country of an IP from an offline database
+type DB struct {
+	ranges []ipRange
+}
+
+type ipRange struct {
+	first   net.IP
+	last    net.IP
+	country string
+}
+
+// Start loads the database configured in config,
+// it returns an empty database if no path is configured
+func Start(config Config) (*DB, error) {
+	if config.Path == "" {
+		return new(DB), nil
+	}
+	file, err := os.Open(config.Path)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "GEOIP-Ee4ch", "unable to open geoip database")
+	}
+	defer file.Close()
+	return New(file)
+}
+
+// New reads a database in CSV format from r, see Config.Path for the format
+func New(r io.Reader) (*DB, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	db := new(DB)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.ThrowInternal(err, "GEOIP-Ohd7a", "unable to read geoip database")
+		}
+		entry, err := parseRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			db.ranges = append(db.ranges, *entry)
+		}
+	}
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return bytes.Compare(db.ranges[i].first, db.ranges[j].first) < 0
+	})
+	return db, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country of ip
+// or an empty string if it is unknown
+func (db *DB) Country(ip net.IP) string {
+	if db == nil || ip == nil {
+		return ""
+	}
+	ip = ip.To16()
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return bytes.Compare(db.ranges[i].first, ip) > 0
+	})
+	if i == 0 {
+		return ""
+	}
+	entry := db.ranges[i-1]
+	if bytes.Compare(ip, entry.last) > 0 {
+		return ""
+	}
+	return entry.country
+}
+
+func parseRecord(record []string) (*ipRange, error) {
+	switch len(record) {
+	case 2:
+		_, network, err := net.ParseCIDR(strings.TrimSpace(record[0]))
+		if err != nil {
+			// header lines are skipped
+			return nil, nil
+		}
+		return &ipRange{
+			first:   network.IP.To16(),
+			last:    lastIP(network),
+			country: strings.ToUpper(strings.TrimSpace(record[1])),
+		}, nil
+	case 3:
+		first := net.ParseIP(strings.TrimSpace(record[0]))
+		last := net.ParseIP(strings.TrimSpace(record[1]))
+		if first == nil || last == nil {
+			// header lines are skipped
+			return nil, nil
+		}
+		return &ipRange{
+			first:   first.To16(),
+			last:    last.To16(),
+			country: strings.ToUpper(strings.TrimSpace(record[2])),
+		}, nil
+	default:
+		return nil, errors.ThrowInternal(nil, "GEOIP-eeX0u", "invalid geoip database record")
+	}
+}
+
+func lastIP(network *net.IPNet) net.IP {
+	ip := network.IP.To16()
+	mask := network.Mask
+	if len(mask) == net.IPv4len {
+		mask = append(net.CIDRMask(96, 128)[:12], mask...)
+	}
+	last := make(net.IP, net.IPv6len)
+	for i := range ip {
+		last[i] = ip[i] | ^mask[i]
+	}
+	return last
+}
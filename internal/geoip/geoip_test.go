@@ -0,0 +1,91 @@
+package geoip
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+const testDB = `network,country
+# comments are ignored
+10.0.0.0/8,ch
+192.168.1.0,192.168.1.255,DE
+2001:db8::/32,IT
+`
+
+func TestDB_Country(t *testing.T) {
+	db, err := New(strings.NewReader(testDB))
+	if err != nil {
+		t.Fatalf("unable to read db: %v", err)
+	}
+	tests := []struct {
+		name string
+		ip   net.IP
+		want string
+	}{
+		{
+			"nil ip, unknown",
+			nil,
+			"",
+		},
+		{
+			"network start",
+			net.ParseIP("10.0.0.0"),
+			"CH",
+		},
+		{
+			"network end",
+			net.ParseIP("10.255.255.255"),
+			"CH",
+		},
+		{
+			"ip range",
+			net.ParseIP("192.168.1.42"),
+			"DE",
+		},
+		{
+			"after ip range, unknown",
+			net.ParseIP("192.168.2.1"),
+			"",
+		},
+		{
+			"before first range, unknown",
+			net.ParseIP("1.1.1.1"),
+			"",
+		},
+		{
+			"ipv6",
+			net.ParseIP("2001:db8::1"),
+			"IT",
+		},
+		{
+			"ipv6 outside of network, unknown",
+			net.ParseIP("2001:db9::1"),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := db.Country(tt.ip); got != tt.want {
+				t.Errorf("Country() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_invalidRecord(t *testing.T) {
+	_, err := New(strings.NewReader("10.0.0.0/8,CH,too,many\n"))
+	if err == nil {
+		t.Error("error expected")
+	}
+}
+
+func TestStart_noPath(t *testing.T) {
+	db, err := Start(Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := db.Country(net.ParseIP("10.0.0.1")); got != "" {
+		t.Errorf("Country() = %v, want empty", got)
+	}
+}
@@ -42,6 +42,7 @@ const (
 	IDPConfigTypeOIDC IdpConfigType = iota
 	IDPConfigTypeSAML
 	IDPConfigTypeJWT
+	IDPConfigTypeLDAP
 )
 
 type IDPConfigState int32
@@ -33,6 +33,20 @@ type IDPConfigView struct {
 	JWTIssuer                  string
 	JWTKeysEndpoint            string
 	JWTHeaderName              string
+	IsSAML                     bool
+	SAMLEntityID               string
+	SAMLMetadata               []byte
+	SAMLMetadataURL            string
+	IsLDAP                     bool
+	LDAPURL                    string
+	LDAPStartTLS               bool
+	LDAPRootCA                 string
+	LDAPBaseDN                 string
+	LDAPBindDN                 string
+	LDAPBindPassword           *crypto.CryptoValue
+	LDAPUserObjectClass        string
+	LDAPUserFilters            []string
+	LDAPAttributes             domain.LDAPAttributes
 }
 
 type IDPConfigSearchRequest struct {
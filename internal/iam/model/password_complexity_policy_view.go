@@ -7,13 +7,15 @@ import (
 )
 
 type PasswordComplexityPolicyView struct {
-	AggregateID  string
-	MinLength    uint64
-	HasLowercase bool
-	HasUppercase bool
-	HasNumber    bool
-	HasSymbol    bool
-	Default      bool
+	AggregateID   string
+	MinLength     uint64
+	HasLowercase  bool
+	HasUppercase  bool
+	HasNumber     bool
+	HasSymbol     bool
+	HistoryDepth  uint64
+	CheckBreached bool
+	Default       bool
 
 	CreationDate time.Time
 	ChangeDate   time.Time
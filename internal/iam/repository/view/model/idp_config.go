@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 	"github.com/zitadel/zitadel/internal/repository/org"
@@ -48,6 +49,26 @@ type IDPConfigView struct {
 	JWTEndpoint                string              `json:"jwtEndpoint" gorm:"jwt_endpoint"`
 	JWTKeysEndpoint            string              `json:"keysEndpoint" gorm:"jwt_keys_endpoint"`
 	JWTHeaderName              string              `json:"headerName" gorm:"jwt_header_name"`
+	IsSAML                     bool                `json:"-" gorm:"column:is_saml"`
+	SAMLEntityID               string              `json:"entityId" gorm:"column:saml_entity_id"`
+	SAMLMetadata               []byte              `json:"metadata" gorm:"column:saml_metadata"`
+	SAMLMetadataURL            string              `json:"metadataUrl" gorm:"column:saml_metadata_url"`
+	IsLDAP                     bool                `json:"-" gorm:"column:is_ldap"`
+	LDAPURL                    string              `json:"ldapUrl" gorm:"column:ldap_url"`
+	LDAPStartTLS               bool                `json:"startTls" gorm:"column:ldap_start_tls"`
+	LDAPRootCA                 string              `json:"rootCa" gorm:"column:ldap_root_ca"`
+	LDAPBaseDN                 string              `json:"baseDn" gorm:"column:ldap_base_dn"`
+	LDAPBindDN                 string              `json:"bindDn" gorm:"column:ldap_bind_dn"`
+	LDAPBindPassword           *crypto.CryptoValue `json:"bindPassword" gorm:"column:ldap_bind_password"`
+	LDAPUserObjectClass        string              `json:"userObjectClass" gorm:"column:ldap_user_object_class"`
+	LDAPUserFilters            pq.StringArray      `json:"userFilters" gorm:"column:ldap_user_filters"`
+	LDAPIDAttribute            string              `json:"idAttribute" gorm:"column:ldap_id_attribute"`
+	LDAPPreferredUsername      string              `json:"preferredUsernameAttribute" gorm:"column:ldap_preferred_username_attribute"`
+	LDAPFirstNameAttribute     string              `json:"firstNameAttribute" gorm:"column:ldap_first_name_attribute"`
+	LDAPLastNameAttribute      string              `json:"lastNameAttribute" gorm:"column:ldap_last_name_attribute"`
+	LDAPDisplayNameAttribute   string              `json:"displayNameAttribute" gorm:"column:ldap_display_name_attribute"`
+	LDAPEmailAttribute         string              `json:"emailAttribute" gorm:"column:ldap_email_attribute"`
+	LDAPPhoneAttribute         string              `json:"phoneAttribute" gorm:"column:ldap_phone_attribute"`
 
 	Sequence   uint64 `json:"-" gorm:"column:sequence"`
 	InstanceID string `json:"instanceID" gorm:"column:instance_id;primary_key"`
@@ -78,6 +99,34 @@ func IDPConfigViewToModel(idp *IDPConfigView) *model.IDPConfigView {
 		view.OIDCIssuer = idp.OIDCIssuer
 		return view
 	}
+	if idp.IsSAML {
+		view.IsSAML = true
+		view.SAMLEntityID = idp.SAMLEntityID
+		view.SAMLMetadata = idp.SAMLMetadata
+		view.SAMLMetadataURL = idp.SAMLMetadataURL
+		return view
+	}
+	if idp.IsLDAP {
+		view.IsLDAP = true
+		view.LDAPURL = idp.LDAPURL
+		view.LDAPStartTLS = idp.LDAPStartTLS
+		view.LDAPRootCA = idp.LDAPRootCA
+		view.LDAPBaseDN = idp.LDAPBaseDN
+		view.LDAPBindDN = idp.LDAPBindDN
+		view.LDAPBindPassword = idp.LDAPBindPassword
+		view.LDAPUserObjectClass = idp.LDAPUserObjectClass
+		view.LDAPUserFilters = idp.LDAPUserFilters
+		view.LDAPAttributes = domain.LDAPAttributes{
+			IDAttribute:                idp.LDAPIDAttribute,
+			PreferredUsernameAttribute: idp.LDAPPreferredUsername,
+			FirstNameAttribute:         idp.LDAPFirstNameAttribute,
+			LastNameAttribute:          idp.LDAPLastNameAttribute,
+			DisplayNameAttribute:       idp.LDAPDisplayNameAttribute,
+			EmailAttribute:             idp.LDAPEmailAttribute,
+			PhoneAttribute:             idp.LDAPPhoneAttribute,
+		}
+		return view
+	}
 	view.JWTEndpoint = idp.JWTEndpoint
 	view.JWTIssuer = idp.OIDCIssuer
 	view.JWTKeysEndpoint = idp.JWTKeysEndpoint
@@ -97,10 +146,18 @@ func (i *IDPConfigView) AppendEvent(providerType model.IDPProviderType, event *m
 	case instance.IDPOIDCConfigAddedEventType, org.IDPOIDCConfigAddedEventType:
 		i.IsOIDC = true
 		err = i.SetData(event)
+	case instance.IDPSAMLConfigAddedEventType, org.IDPSAMLConfigAddedEventType:
+		i.IsSAML = true
+		err = i.SetData(event)
+	case instance.IDPLDAPConfigAddedEventType, org.IDPLDAPConfigAddedEventType:
+		i.IsLDAP = true
+		err = i.SetData(event)
 	case instance.IDPOIDCConfigChangedEventType, org.IDPOIDCConfigChangedEventType,
 		instance.IDPConfigChangedEventType, org.IDPConfigChangedEventType,
 		org.IDPJWTConfigAddedEventType, instance.IDPJWTConfigAddedEventType,
-		org.IDPJWTConfigChangedEventType, instance.IDPJWTConfigChangedEventType:
+		org.IDPJWTConfigChangedEventType, instance.IDPJWTConfigChangedEventType,
+		org.IDPSAMLConfigChangedEventType, instance.IDPSAMLConfigChangedEventType,
+		org.IDPLDAPConfigChangedEventType, instance.IDPLDAPConfigChangedEventType:
 		err = i.SetData(event)
 	case instance.IDPConfigDeactivatedEventType, org.IDPConfigDeactivatedEventType:
 		i.IDPState = int32(model.IDPConfigStateInactive)
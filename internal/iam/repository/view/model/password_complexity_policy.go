@@ -37,16 +37,18 @@ type PasswordComplexityPolicyView struct {
 
 func PasswordComplexityViewToModel(policy *query.PasswordComplexityPolicy) *model.PasswordComplexityPolicyView {
 	return &model.PasswordComplexityPolicyView{
-		AggregateID:  policy.ID,
-		Sequence:     policy.Sequence,
-		CreationDate: policy.CreationDate,
-		ChangeDate:   policy.ChangeDate,
-		MinLength:    policy.MinLength,
-		HasLowercase: policy.HasLowercase,
-		HasUppercase: policy.HasUppercase,
-		HasSymbol:    policy.HasSymbol,
-		HasNumber:    policy.HasNumber,
-		Default:      policy.IsDefault,
+		AggregateID:   policy.ID,
+		Sequence:      policy.Sequence,
+		CreationDate:  policy.CreationDate,
+		ChangeDate:    policy.ChangeDate,
+		MinLength:     policy.MinLength,
+		HasLowercase:  policy.HasLowercase,
+		HasUppercase:  policy.HasUppercase,
+		HasSymbol:     policy.HasSymbol,
+		HasNumber:     policy.HasNumber,
+		HistoryDepth:  policy.HistoryDepth,
+		CheckBreached: policy.CheckBreached,
+		Default:       policy.IsDefault,
 	}
 }
 
@@ -0,0 +1,77 @@
+package ldap
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Config describes how users are looked up in the directory
+type Config struct {
+	URL string
+	// StartTLS upgrades the connection of an ldap:// url to TLS before binding
+	StartTLS bool
+	// RootCA is the PEM encoded certificate (chain) the directory is verified with instead of the system pool
+	RootCA       string
+	BaseDN       string
+	BindDN       string
+	BindPassword string
+	// UserObjectClass restricts the search to entries of the object class (e.g. user or inetOrgPerson)
+	UserObjectClass string
+	// UserFilters are the attributes compared with the login name (e.g. uid, sAMAccountName, mail)
+	UserFilters []string
+	// Attributes are returned on the entry of the authenticated user
+	Attributes []string
+}
+
+// Authenticate searches the user by the login name using the service account of the config
+// and verifies the password by binding as the found user
+func Authenticate(ctx context.Context, config *Config, username, password string) (*Entry, error) {
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+	conn, err := dial(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if config.BindDN != "" {
+		if err = bind(conn, config.BindDN, config.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+	entries, err := search(conn, config.BaseDN, userFilter(config, username), config.Attributes...)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, ErrUserNotFound
+	}
+	if err = bind(conn, entries[0].DN, password); err != nil {
+		return nil, err
+	}
+	return entries[0], nil
+}
+
+// userFilter matches the object class and any of the filter attributes with the username
+// for login names containing a domain (user@domain) the local part is compared as well
+func userFilter(config *Config, username string) string {
+	names := []string{username}
+	if at := strings.LastIndex(username, "@"); at > 0 {
+		names = append(names, username[:at])
+	}
+	var alternatives strings.Builder
+	for _, attribute := range config.UserFilters {
+		for _, name := range names {
+			alternatives.WriteString(equalityFilter(attribute, name))
+		}
+	}
+	return "(&" + equalityFilter("objectClass", config.UserObjectClass) + "(|" + alternatives.String() + "))"
+}
+
+// equalityFilter matches entries with the attribute value, the value is escaped
+func equalityFilter(attribute, value string) string {
+	return "(" + attribute + "=" + ldap.EscapeFilter(value) + ")"
+}
@@ -0,0 +1,499 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+type testUser struct {
+	dn         string
+	password   string
+	attributes map[string][]string
+	// truncated answers binds of the user without a result code
+	truncated bool
+}
+
+// testServer is an in-process stand-in of a directory supporting simple binds and searches with
+// and, or and equality filters
+// if tls is set, connections can be upgraded using StartTLS
+type testServer struct {
+	listener net.Listener
+	scheme   string
+	tls      *tls.Config
+	users    []*testUser
+}
+
+func newTestServer(t *testing.T, users ...*testUser) *testServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &testServer{listener: listener, scheme: SchemeLDAP, users: users}
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server
+}
+
+// newTLSTestServer returns a server supporting StartTLS (ldap://) or using implicit TLS (ldaps://)
+// and the PEM encoded certificate it's verified with
+func newTLSTestServer(t *testing.T, scheme string, users ...*testUser) (*testServer, string) {
+	certificate, rootCA := testCertificate(t)
+	server := &testServer{scheme: scheme, tls: &tls.Config{Certificates: []tls.Certificate{certificate}}, users: users}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheme == SchemeLDAPS {
+		listener = tls.NewListener(listener, server.tls)
+	}
+	server.listener = listener
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server, rootCA
+}
+
+// testCertificate creates a self-signed certificate for 127.0.0.1
+func testCertificate(t *testing.T) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ldap.test"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return certificate, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func (s *testServer) url() string {
+	return s.scheme + "://" + s.listener.Addr().String()
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	reader := bufio.NewReader(conn)
+	for {
+		message, err := ber.ReadPacket(reader)
+		if err != nil || len(message.Children) < 2 {
+			return
+		}
+		id := message.Children[0].Value.(int64)
+		op := message.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			user := s.user(op.Children[1].Value.(string))
+			if user != nil && user.truncated {
+				s.respond(conn, id, ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindResponse, nil, ""))
+				continue
+			}
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			if user != nil && user.password == op.Children[2].Data.String() {
+				code = ldap.LDAPResultSuccess
+			}
+			s.respond(conn, id, resultPacket(ldap.ApplicationBindResponse, code))
+		case ldap.ApplicationSearchRequest:
+			for _, user := range s.users {
+				if strings.HasSuffix(user.dn, op.Children[0].Value.(string)) && matches(op.Children[6], user.attributes) {
+					s.respond(conn, id, entryPacket(user, op.Children[7]))
+				}
+			}
+			s.respond(conn, id, resultPacket(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+		case ldap.ApplicationExtendedRequest:
+			if s.tls == nil || s.scheme == SchemeLDAPS {
+				s.respond(conn, id, resultPacket(ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+				continue
+			}
+			s.respond(conn, id, resultPacket(ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+			conn = tls.Server(conn, s.tls)
+			reader = bufio.NewReader(conn)
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func (s *testServer) user(dn string) *testUser {
+	for _, user := range s.users {
+		if user.dn == dn {
+			return user
+		}
+	}
+	return nil
+}
+
+func (s *testServer) respond(conn net.Conn, id int64, op *ber.Packet) {
+	message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	message.AppendChild(op)
+	conn.Write(message.Bytes())
+}
+
+func resultPacket(tag ber.Tag, code int64) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return result
+}
+
+func entryPacket(user *testUser, requested *ber.Packet) *ber.Packet {
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for _, name := range requested.Children {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name.Value.(string), ""))
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, value := range user.attributes[name.Value.(string)] {
+			values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+		}
+		attribute.AppendChild(values)
+		attributes.AppendChild(attribute)
+	}
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, user.dn, ""))
+	entry.AppendChild(attributes)
+	return entry
+}
+
+func matches(filter *ber.Packet, attributes map[string][]string) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(child, attributes) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		for _, value := range attributes[filter.Children[0].Value.(string)] {
+			if strings.EqualFold(value, filter.Children[1].Value.(string)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newTestServer(t,
+		&testUser{
+			dn:       "cn=service,dc=example,dc=com",
+			password: "service-secret",
+		},
+		&testUser{
+			dn:       "cn=gigi,ou=users,dc=example,dc=com",
+			password: "password",
+			attributes: map[string][]string{
+				"objectClass":    {"top", "user"},
+				"sAMAccountName": {"gigi"},
+				"mail":           {"gigi@example.com"},
+				"givenName":      {"Gigi"},
+				"sn":             {"Giraffe"},
+			},
+		},
+		&testUser{
+			dn:       "cn=truncated,ou=users,dc=example,dc=com",
+			password: "password",
+			attributes: map[string][]string{
+				"objectClass":    {"user"},
+				"sAMAccountName": {"truncated"},
+			},
+			truncated: true,
+		},
+		&testUser{
+			dn:       "cn=duplicate1,ou=users,dc=example,dc=com",
+			password: "password",
+			attributes: map[string][]string{
+				"objectClass":    {"user"},
+				"sAMAccountName": {"duplicate"},
+			},
+		},
+		&testUser{
+			dn:       "cn=duplicate2,ou=users,dc=example,dc=com",
+			password: "password",
+			attributes: map[string][]string{
+				"objectClass":    {"user"},
+				"sAMAccountName": {"duplicate"},
+			},
+		},
+	)
+	config := func(modify ...func(*Config)) *Config {
+		c := &Config{
+			URL:             server.url(),
+			BaseDN:          "dc=example,dc=com",
+			BindDN:          "cn=service,dc=example,dc=com",
+			BindPassword:    "service-secret",
+			UserObjectClass: "user",
+			UserFilters:     []string{"sAMAccountName", "mail"},
+			Attributes:      []string{"mail", "givenName", "sn"},
+		}
+		for _, m := range modify {
+			m(c)
+		}
+		return c
+	}
+	type args struct {
+		config   *Config
+		username string
+		password string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantDN     string
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name: "invalid service account, error",
+			args: args{
+				config: config(func(c *Config) {
+					c.BindPassword = "wrong"
+				}),
+				username: "gigi",
+				password: "password",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "unknown user, error",
+			args: args{
+				config:   config(),
+				username: "unknown",
+				password: "password",
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "ambiguous user, error",
+			args: args{
+				config:   config(),
+				username: "duplicate",
+				password: "password",
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "wrong password, error",
+			args: args{
+				config:   config(),
+				username: "gigi",
+				password: "wrong",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "bind response without result code, error",
+			args: args{
+				config:   config(),
+				username: "truncated",
+				password: "wrong",
+			},
+			wantAnyErr: true,
+		},
+		{
+			name: "empty password, error",
+			args: args{
+				config:   config(),
+				username: "gigi",
+				password: "",
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		{
+			name: "wrong object class, error",
+			args: args{
+				config: config(func(c *Config) {
+					c.UserObjectClass = "inetOrgPerson"
+				}),
+				username: "gigi",
+				password: "password",
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "username, ok",
+			args: args{
+				config:   config(),
+				username: "gigi",
+				password: "password",
+			},
+			wantDN: "cn=gigi,ou=users,dc=example,dc=com",
+		},
+		{
+			name: "email, ok",
+			args: args{
+				config:   config(),
+				username: "gigi@example.com",
+				password: "password",
+			},
+			wantDN: "cn=gigi,ou=users,dc=example,dc=com",
+		},
+		{
+			name: "login name with domain, ok",
+			args: args{
+				config:   config(),
+				username: "gigi@example.zitadel.ch",
+				password: "password",
+			},
+			wantDN: "cn=gigi,ou=users,dc=example,dc=com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := Authenticate(context.Background(), tt.args.config, tt.args.username, tt.args.password)
+			if tt.wantAnyErr {
+				if err == nil {
+					t.Fatal("Authenticate() expected error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if entry.DN != tt.wantDN {
+				t.Errorf("Authenticate() dn = %s, want %s", entry.DN, tt.wantDN)
+			}
+			if got := entry.Attribute("GivenName"); got != "Gigi" {
+				t.Errorf("Authenticate() givenName = %s, want Gigi", got)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_TLS(t *testing.T) {
+	user := &testUser{
+		dn:       "cn=gigi,ou=users,dc=example,dc=com",
+		password: "password",
+		attributes: map[string][]string{
+			"objectClass":    {"user"},
+			"sAMAccountName": {"gigi"},
+		},
+	}
+	startTLSServer, startTLSRootCA := newTLSTestServer(t, SchemeLDAP, user)
+	ldapsServer, ldapsRootCA := newTLSTestServer(t, SchemeLDAPS, user)
+	config := func(url, rootCA string, startTLS bool) *Config {
+		return &Config{
+			URL:             url,
+			StartTLS:        startTLS,
+			RootCA:          rootCA,
+			BaseDN:          "dc=example,dc=com",
+			UserObjectClass: "user",
+			UserFilters:     []string{"sAMAccountName"},
+		}
+	}
+	tests := []struct {
+		name       string
+		config     *Config
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name:   "start tls, root ca, ok",
+			config: config(startTLSServer.url(), startTLSRootCA, true),
+		},
+		{
+			name:       "start tls, untrusted certificate, error",
+			config:     config(startTLSServer.url(), "", true),
+			wantAnyErr: true,
+		},
+		{
+			name:    "start tls, invalid root ca, error",
+			config:  config(startTLSServer.url(), "invalid", true),
+			wantErr: ErrInvalidRootCA,
+		},
+		{
+			name:   "ldaps, root ca, ok",
+			config: config(ldapsServer.url(), ldapsRootCA, false),
+		},
+		{
+			name:       "ldaps, other root ca, error",
+			config:     config(ldapsServer.url(), startTLSRootCA, false),
+			wantAnyErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := Authenticate(context.Background(), tt.config, "gigi", "password")
+			if tt.wantAnyErr {
+				if err == nil {
+					t.Fatal("Authenticate() expected error")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && entry.DN != user.dn {
+				t.Errorf("Authenticate() dn = %s, want %s", entry.DN, user.dn)
+			}
+		})
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"ldap://localhost", false},
+		{"ldaps://ad.example.com:3269", false},
+		{"https://ad.example.com", true},
+		{"ldap://", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if err := ValidateURL(tt.url); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	SchemeLDAP  = "ldap"
+	SchemeLDAPS = "ldaps"
+
+	defaultTimeout = 10 * time.Second
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserNotFound       = errors.New("user not found or not unique")
+	ErrInvalidURL         = errors.New("invalid ldap url")
+	ErrInvalidRootCA      = errors.New("invalid root ca certificate")
+)
+
+// Entry is an object returned by a search
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the attribute (case insensitive) or an empty string
+func (e *Entry) Attribute(name string) string {
+	for attribute, values := range e.Attributes {
+		if strings.EqualFold(attribute, name) && len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// ValidateURL checks the url to be an ldap:// or ldaps:// url with a host
+func ValidateURL(rawURL string) error {
+	_, err := parseURL(rawURL)
+	return err
+}
+
+// ValidateRootCA checks the PEM encoded root CA to contain at least one certificate, an empty one is valid
+func ValidateRootCA(rootCA string) error {
+	_, err := certPool(rootCA)
+	return err
+}
+
+// certPool returns the pool of the root CA or nil (system pool) if it's empty
+func certPool(rootCA string) (*x509.CertPool, error) {
+	if rootCA == "" {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(rootCA)) {
+		return nil, ErrInvalidRootCA
+	}
+	return pool, nil
+}
+
+func parseURL(rawURL string) (*url.URL, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return nil, ErrInvalidURL
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case SchemeLDAP, SchemeLDAPS:
+		return parsed, nil
+	default:
+		return nil, ErrInvalidURL
+	}
+}
+
+// dial connects to the directory of the url, ldaps:// urls use implicit TLS
+// ldap:// urls are upgraded using StartTLS if configured
+// the timeout applies to the connection and every request on it
+func dial(ctx context.Context, config *Config) (*ldap.Conn, error) {
+	parsed, err := parseURL(config.URL)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs, err := certPool(config.RootCA)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: parsed.Hostname(), RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	timeout := defaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	conn, err := ldap.DialURL(config.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+	if config.StartTLS && strings.ToLower(parsed.Scheme) == SchemeLDAP {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// bind authenticates the connection using a simple bind
+// an empty password is rejected, because the directory would treat it as an unauthenticated bind
+func bind(conn *ldap.Conn, dn, password string) error {
+	if password == "" {
+		return ErrInvalidCredentials
+	}
+	err := conn.Bind(dn, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return ErrInvalidCredentials
+	}
+	return err
+}
+
+// search returns all entries below the base dn matching the filter including the requested attributes
+// referrals to other directories are not followed
+func search(conn *ldap.Conn, baseDN, filter string, attributes ...string) ([]*Entry, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		baseDN,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		filter,
+		attributes,
+		nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*Entry, len(result.Entries))
+	for i, entry := range result.Entries {
+		entries[i] = &Entry{
+			DN:         entry.DN,
+			Attributes: make(map[string][]string, len(entry.Attributes)),
+		}
+		for _, attribute := range entry.Attributes {
+			entries[i].Attributes[attribute.Name] = attribute.Values
+		}
+	}
+	return entries, nil
+}
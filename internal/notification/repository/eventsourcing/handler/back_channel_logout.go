@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	backChannelLogoutTimeout = 10 * time.Second
+)
+
+var backChannelLogoutClient = &http.Client{
+	Timeout: backChannelLogoutTimeout,
+	// the logout uri must respond directly, redirects are not followed (OpenID Connect Back-Channel Logout, section 2.8)
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// sendBackChannelLogout posts the logout token to the back-channel logout uri of the application
+// (OpenID Connect Back-Channel Logout, section 2.5)
+func sendBackChannelLogout(ctx context.Context, logoutURI, logoutToken string) error {
+	form := url.Values{"logout_token": {logoutToken}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, logoutURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.ThrowInternal(err, "HANDLE-Aiph3", "unable to create back-channel logout request")
+	}
+	req.Header.Set(http_utils.ContentType, "application/x-www-form-urlencoded")
+	resp, err := backChannelLogoutClient.Do(req)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "HANDLE-Quu7e", "back-channel logout request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnavailablef(nil, "HANDLE-Eeph9", "back-channel logout request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
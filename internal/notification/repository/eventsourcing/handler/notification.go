@@ -157,6 +157,14 @@ func (n *Notification) Reduce(event *models.Event) (err error) {
 		err = n.handleDomainClaimed(event)
 	case user_repo.HumanPasswordlessInitCodeRequestedType:
 		err = n.handlePasswordlessRegistrationLink(event)
+	case user_repo.HumanBackChannelLogoutRequestedType:
+		err = n.handleBackChannelLogout(event)
+	case user_repo.HumanMFAOTPSMSCodeAddedType:
+		err = n.handleOTPSMSCode(event)
+	case user_repo.HumanMFAOTPEmailCodeAddedType:
+		err = n.handleOTPEmailCode(event)
+	case user_repo.HumanMagicLinkCodeAddedType:
+		err = n.handleMagicLink(event)
 	}
 	if err != nil {
 		return err
@@ -320,6 +328,116 @@ func (n *Notification) handlePhoneVerificationCode(event *models.Event) (err err
 	return n.command.HumanPhoneVerificationCodeSent(ctx, event.ResourceOwner, event.AggregateID)
 }
 
+func (n *Notification) handleOTPSMSCode(event *models.Event) (err error) {
+	codeAdded := new(user_repo.HumanOTPSMSCodeAddedEvent)
+	if err := json.Unmarshal(event.Data, codeAdded); err != nil {
+		return err
+	}
+	ctx := getSetNotifyContextData(event.InstanceID, event.ResourceOwner)
+	alreadyHandled, err := n.checkIfCodeAlreadyHandledOrExpired(ctx, event, codeAdded.Expiry,
+		user_repo.HumanMFAOTPSMSCodeAddedType, user_repo.HumanMFAOTPSMSCodeSentType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	user, err := n.getUserByID(event.AggregateID, event.InstanceID)
+	if err != nil {
+		return err
+	}
+	translator, err := n.getTranslatorWithOrgTexts(ctx, user.ResourceOwner, domain.VerifySMSOTPMessageType)
+	if err != nil {
+		return err
+	}
+	err = types.SendOTPSMSCode(ctx, translator, user, codeAdded, n.getTwilioConfig, n.getFileSystemProvider, n.getLogProvider, n.userDataCrypto)
+	if err != nil {
+		return err
+	}
+	return n.command.HumanOTPSMSCodeSent(ctx, event.AggregateID, event.ResourceOwner)
+}
+
+func (n *Notification) handleOTPEmailCode(event *models.Event) (err error) {
+	codeAdded := new(user_repo.HumanOTPEmailCodeAddedEvent)
+	if err := json.Unmarshal(event.Data, codeAdded); err != nil {
+		return err
+	}
+	ctx := getSetNotifyContextData(event.InstanceID, event.ResourceOwner)
+	alreadyHandled, err := n.checkIfCodeAlreadyHandledOrExpired(ctx, event, codeAdded.Expiry,
+		user_repo.HumanMFAOTPEmailCodeAddedType, user_repo.HumanMFAOTPEmailCodeSentType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	colors, err := n.getLabelPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	template, err := n.getMailTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := n.getUserByID(event.AggregateID, event.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	translator, err := n.getTranslatorWithOrgTexts(ctx, user.ResourceOwner, domain.VerifyEmailOTPMessageType)
+	if err != nil {
+		return err
+	}
+
+	origin, err := n.origin(ctx)
+	if err != nil {
+		return err
+	}
+	err = types.SendOTPEmailCode(ctx, string(template.Template), translator, user, codeAdded, n.getSMTPConfig, n.getFileSystemProvider, n.getLogProvider, n.userDataCrypto, colors, n.assetsPrefix, origin)
+	if err != nil {
+		return err
+	}
+	return n.command.HumanOTPEmailCodeSent(ctx, event.AggregateID, event.ResourceOwner)
+}
+
+func (n *Notification) handleMagicLink(event *models.Event) (err error) {
+	codeAdded := new(user_repo.HumanMagicLinkCodeAddedEvent)
+	if err := json.Unmarshal(event.Data, codeAdded); err != nil {
+		return err
+	}
+	ctx := getSetNotifyContextData(event.InstanceID, event.ResourceOwner)
+	alreadyHandled, err := n.checkIfCodeAlreadyHandledOrExpired(ctx, event, codeAdded.Expiry,
+		user_repo.HumanMagicLinkCodeAddedType, user_repo.HumanMagicLinkCodeSentType)
+	if err != nil || alreadyHandled {
+		return err
+	}
+	colors, err := n.getLabelPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	template, err := n.getMailTemplate(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, err := n.getUserByID(event.AggregateID, event.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	translator, err := n.getTranslatorWithOrgTexts(ctx, user.ResourceOwner, domain.MagicLinkMessageType)
+	if err != nil {
+		return err
+	}
+
+	origin, err := n.origin(ctx)
+	if err != nil {
+		return err
+	}
+	err = types.SendMagicLink(ctx, string(template.Template), translator, user, codeAdded, n.getSMTPConfig, n.getFileSystemProvider, n.getLogProvider, n.userDataCrypto, colors, n.assetsPrefix, origin)
+	if err != nil {
+		return err
+	}
+	return n.command.HumanMagicLinkCodeSent(ctx, event.AggregateID, event.ResourceOwner)
+}
+
 func (n *Notification) handleDomainClaimed(event *models.Event) (err error) {
 	ctx := getSetNotifyContextData(event.InstanceID, event.ResourceOwner)
 	alreadyHandled, err := n.checkIfAlreadyHandled(ctx, event.AggregateID, event.InstanceID, event.Sequence, user_repo.UserDomainClaimedType, user_repo.UserDomainClaimedSentType)
@@ -415,6 +533,39 @@ func (n *Notification) handlePasswordlessRegistrationLink(event *models.Event) (
 	return n.command.HumanPasswordlessInitCodeSent(ctx, event.AggregateID, event.ResourceOwner, addedEvent.ID)
 }
 
+// handleBackChannelLogout delivers the logout token to the back-channel logout uri of the application,
+// if the delivery fails, the event will be retried by the spooler and listed as failed event after too many errors
+func (n *Notification) handleBackChannelLogout(event *models.Event) (err error) {
+	requestedEvent := new(user_repo.HumanBackChannelLogoutRequestedEvent)
+	if err := json.Unmarshal(event.Data, requestedEvent); err != nil {
+		return err
+	}
+	if event.CreationDate.Add(requestedEvent.Expiry).Before(time.Now().UTC()) {
+		return nil
+	}
+	ctx := getSetNotifyContextData(event.InstanceID, event.ResourceOwner)
+	events, err := n.getUserEvents(ctx, event.AggregateID, event.InstanceID, event.Sequence)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if eventstore.EventType(e.Type) == user_repo.HumanBackChannelLogoutSentType {
+			sentEvent := new(user_repo.HumanBackChannelLogoutSentEvent)
+			if err := json.Unmarshal(e.Data, sentEvent); err != nil {
+				return err
+			}
+			if sentEvent.ID == requestedEvent.ID {
+				return nil
+			}
+		}
+	}
+	err = sendBackChannelLogout(ctx, requestedEvent.LogoutURI, requestedEvent.LogoutToken)
+	if err != nil {
+		return err
+	}
+	return n.command.HumanBackChannelLogoutSent(ctx, event.AggregateID, event.ResourceOwner, requestedEvent.ID)
+}
+
 func (n *Notification) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event *models.Event, expiry time.Duration, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreationDate.Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/smtp"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+)
+
+type MagicLinkData struct {
+	templates.TemplateData
+	URL string
+}
+
+func SendMagicLink(ctx context.Context, mailhtml string, translator *i18n.Translator, user *view_model.NotifyUser, code *user.HumanMagicLinkCodeAddedEvent, smtpConfig func(ctx context.Context) (*smtp.EmailConfig, error), getFileSystemProvider func(ctx context.Context) (*fs.FSConfig, error), getLogProvider func(ctx context.Context) (*log.LogConfig, error), alg crypto.EncryptionAlgorithm, colors *query.LabelPolicy, assetsPrefix string, origin string) error {
+	codeString, err := crypto.DecryptString(code.Code, alg)
+	if err != nil {
+		return err
+	}
+	var authRequestID string
+	if code.AuthRequestInfo != nil {
+		authRequestID = code.AuthRequestInfo.ID
+	}
+	url := login.MagicLinkLink(origin, authRequestID, user.ID, codeString, user.ResourceOwner)
+	var args = mapNotifyUserToArgs(user)
+
+	magicLinkData := &MagicLinkData{
+		TemplateData: GetTemplateData(translator, args, assetsPrefix, url, domain.MagicLinkMessageType, user.PreferredLanguage, colors),
+		URL:          url,
+	}
+
+	template, err := templates.GetParsedTemplate(mailhtml, magicLinkData)
+	if err != nil {
+		return err
+	}
+	return generateEmail(ctx, user, magicLinkData.Subject, template, smtpConfig, getFileSystemProvider, getLogProvider, false)
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/i18n"
+	"github.com/zitadel/zitadel/internal/notification/channels/fs"
+	"github.com/zitadel/zitadel/internal/notification/channels/log"
+	"github.com/zitadel/zitadel/internal/notification/channels/twilio"
+	"github.com/zitadel/zitadel/internal/notification/templates"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	view_model "github.com/zitadel/zitadel/internal/user/repository/view/model"
+)
+
+type OTPSMSCodeData struct {
+	UserID string
+}
+
+func SendOTPSMSCode(ctx context.Context, translator *i18n.Translator, user *view_model.NotifyUser, code *user.HumanOTPSMSCodeAddedEvent, getTwilioConfig func(ctx context.Context) (*twilio.TwilioConfig, error), getFileSystemProvider func(ctx context.Context) (*fs.FSConfig, error), getLogProvider func(ctx context.Context) (*log.LogConfig, error), alg crypto.EncryptionAlgorithm) error {
+	codeString, err := crypto.DecryptString(code.Code, alg)
+	if err != nil {
+		return err
+	}
+	var args = mapNotifyUserToArgs(user)
+	args["Code"] = codeString
+
+	text := translator.Localize(fmt.Sprintf("%s.%s", domain.VerifySMSOTPMessageType, domain.MessageText), args, user.PreferredLanguage)
+
+	codeData := &OTPSMSCodeData{UserID: user.ID}
+	template, err := templates.ParseTemplateText(text, codeData)
+	if err != nil {
+		return err
+	}
+	return generateSms(ctx, user, template, getTwilioConfig, getFileSystemProvider, getLogProvider, false)
+}
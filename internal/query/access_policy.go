@@ -0,0 +1,146 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+type AccessPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	Rules domain.AccessRules
+
+	IsDefault bool
+}
+
+var (
+	accessTable = table{
+		name: projection.AccessPolicyTable,
+	}
+	AccessColID = Column{
+		name:  projection.AccessPolicyIDCol,
+		table: accessTable,
+	}
+	AccessColInstanceID = Column{
+		name:  projection.AccessPolicyInstanceIDCol,
+		table: accessTable,
+	}
+	AccessColSequence = Column{
+		name:  projection.AccessPolicySequenceCol,
+		table: accessTable,
+	}
+	AccessColCreationDate = Column{
+		name:  projection.AccessPolicyCreationDateCol,
+		table: accessTable,
+	}
+	AccessColChangeDate = Column{
+		name:  projection.AccessPolicyChangeDateCol,
+		table: accessTable,
+	}
+	AccessColResourceOwner = Column{
+		name:  projection.AccessPolicyResourceOwnerCol,
+		table: accessTable,
+	}
+	AccessColRules = Column{
+		name:  projection.AccessPolicyRulesCol,
+		table: accessTable,
+	}
+	AccessColIsDefault = Column{
+		name:  projection.AccessPolicyIsDefaultCol,
+		table: accessTable,
+	}
+	AccessColState = Column{
+		name:  projection.AccessPolicyStateCol,
+		table: accessTable,
+	}
+)
+
+func (q *Queries) AccessPolicyByOrg(ctx context.Context, orgID string) (*AccessPolicy, error) {
+	stmt, scan := prepareAccessPolicyQuery()
+	query, args, err := stmt.Where(
+		sq.And{
+			sq.Eq{
+				AccessColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+			},
+			sq.Or{
+				sq.Eq{
+					AccessColID.identifier(): orgID,
+				},
+				sq.Eq{
+					AccessColID.identifier(): authz.GetInstance(ctx).InstanceID(),
+				},
+			},
+		}).
+		OrderBy(AccessColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ooqu3", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) DefaultAccessPolicy(ctx context.Context) (*AccessPolicy, error) {
+	stmt, scan := prepareAccessPolicyQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		AccessColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		AccessColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(AccessColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-jai4U", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareAccessPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*AccessPolicy, error)) {
+	return sq.Select(
+			AccessColID.identifier(),
+			AccessColSequence.identifier(),
+			AccessColCreationDate.identifier(),
+			AccessColChangeDate.identifier(),
+			AccessColResourceOwner.identifier(),
+			AccessColRules.identifier(),
+			AccessColIsDefault.identifier(),
+			AccessColState.identifier(),
+		).
+			From(accessTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*AccessPolicy, error) {
+			policy := new(AccessPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.Rules,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Uu9ai", "Errors.Org.AccessPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-ieC2u", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
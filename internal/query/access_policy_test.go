@@ -0,0 +1,136 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+func Test_AccessPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAccessPolicyQuery no result",
+			prepare: prepareAccessPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(`SELECT projections.access_policies.id,`+
+						` projections.access_policies.sequence,`+
+						` projections.access_policies.creation_date,`+
+						` projections.access_policies.change_date,`+
+						` projections.access_policies.resource_owner,`+
+						` projections.access_policies.rules,`+
+						` projections.access_policies.is_default,`+
+						` projections.access_policies.state`+
+						` FROM projections.access_policies`),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AccessPolicy)(nil),
+		},
+		{
+			name:    "prepareAccessPolicyQuery found",
+			prepare: prepareAccessPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.access_policies.id,`+
+						` projections.access_policies.sequence,`+
+						` projections.access_policies.creation_date,`+
+						` projections.access_policies.change_date,`+
+						` projections.access_policies.resource_owner,`+
+						` projections.access_policies.rules,`+
+						` projections.access_policies.is_default,`+
+						` projections.access_policies.state`+
+						` FROM projections.access_policies`),
+					[]string{
+						"id",
+						"sequence",
+						"creation_date",
+						"change_date",
+						"resource_owner",
+						"rules",
+						"is_default",
+						"state",
+					},
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						[]byte(`[{"name":"office","action":2,"ipRanges":["10.0.0.0/8"]}]`),
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &AccessPolicy{
+				ID:            "pol-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro",
+				State:         domain.PolicyStateActive,
+				Rules: domain.AccessRules{
+					{
+						Name:     "office",
+						Action:   domain.AccessRuleActionRequireMFA,
+						IPRanges: []string{"10.0.0.0/8"},
+					},
+				},
+				IsDefault: true,
+			},
+		},
+		{
+			name:    "prepareAccessPolicyQuery sql err",
+			prepare: prepareAccessPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(`SELECT projections.access_policies.id,`+
+						` projections.access_policies.sequence,`+
+						` projections.access_policies.creation_date,`+
+						` projections.access_policies.change_date,`+
+						` projections.access_policies.resource_owner,`+
+						` projections.access_policies.rules,`+
+						` projections.access_policies.is_default,`+
+						` projections.access_policies.state`+
+						` FROM projections.access_policies`),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
@@ -103,6 +103,41 @@ func (q *Queries) GetActiveActionsByFlowAndTriggerType(ctx context.Context, flow
 	return scan(rows)
 }
 
+// GetActiveActionsByFlowAndTriggerTypeOfUser returns the active actions of the organisation of the user,
+// so the user doesn't have to be queried if there are no actions
+func (q *Queries) GetActiveActionsByFlowAndTriggerTypeOfUser(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, userID string) ([]*Action, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	userOrgQuery, userOrgArgs, err := sq.Select(UserResourceOwnerCol.identifier()).
+		From(userTable.identifier()).
+		Where(sq.Eq{
+			UserIDCol.identifier():         userID,
+			UserInstanceIDCol.identifier(): instanceID,
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Shie4", "Errors.Query.SQLStatement")
+	}
+	stmt, scan := prepareTriggerActionsQuery()
+	query, args, err := stmt.Where(
+		sq.Eq{
+			FlowsTriggersColumnFlowType.identifier():    flowType,
+			FlowsTriggersColumnTriggerType.identifier(): triggerType,
+			FlowsTriggersColumnInstanceID.identifier():  instanceID,
+			ActionColumnState.identifier():              domain.ActionStateActive,
+		},
+	).Where(
+		sq.Expr(FlowsTriggersColumnResourceOwner.identifier()+" = ("+userOrgQuery+")", userOrgArgs...),
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-aiX0o", "Errors.Query.SQLStatement")
+	}
+
+	rows, err := q.client.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ohp3e", "Errors.Internal")
+	}
+	return scan(rows)
+}
+
 func (q *Queries) GetFlowTypesOfActionID(ctx context.Context, actionID string) ([]domain.FlowType, error) {
 	stmt, scan := prepareFlowTypesQuery()
 	query, args, err := stmt.Where(
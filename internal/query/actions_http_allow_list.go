@@ -0,0 +1,91 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+var (
+	actionsHTTPAllowListTable = table{
+		name: projection.ActionsHTTPAllowListTable,
+	}
+	ActionsHTTPAllowListColumnAggregateID = Column{
+		name:  projection.ActionsHTTPAllowListAggregateIDCol,
+		table: actionsHTTPAllowListTable,
+	}
+	ActionsHTTPAllowListColumnChangeDate = Column{
+		name:  projection.ActionsHTTPAllowListChangeDateCol,
+		table: actionsHTTPAllowListTable,
+	}
+	ActionsHTTPAllowListColumnSequence = Column{
+		name:  projection.ActionsHTTPAllowListSequenceCol,
+		table: actionsHTTPAllowListTable,
+	}
+	ActionsHTTPAllowListColumnInstanceID = Column{
+		name:  projection.ActionsHTTPAllowListInstanceIDCol,
+		table: actionsHTTPAllowListTable,
+	}
+	ActionsHTTPAllowListColumnHosts = Column{
+		name:  projection.ActionsHTTPAllowListHostsCol,
+		table: actionsHTTPAllowListTable,
+	}
+)
+
+type ActionsHTTPAllowList struct {
+	AggregateID string
+	ChangeDate  time.Time
+	Sequence    uint64
+
+	Hosts []string
+}
+
+// ActionsHTTPAllowList returns the hosts actions of the instance are allowed to send HTTP requests to.
+// If no allow list was set, the returned list is empty.
+func (q *Queries) ActionsHTTPAllowList(ctx context.Context) (*ActionsHTTPAllowList, error) {
+	stmt, scan := prepareActionsHTTPAllowListQuery()
+	query, args, err := stmt.Where(sq.Eq{
+		ActionsHTTPAllowListColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Iek4a", "Errors.Query.SQLStatment")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func prepareActionsHTTPAllowListQuery() (sq.SelectBuilder, func(*sql.Row) (*ActionsHTTPAllowList, error)) {
+	return sq.Select(
+			ActionsHTTPAllowListColumnAggregateID.identifier(),
+			ActionsHTTPAllowListColumnChangeDate.identifier(),
+			ActionsHTTPAllowListColumnSequence.identifier(),
+			ActionsHTTPAllowListColumnHosts.identifier()).
+			From(actionsHTTPAllowListTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ActionsHTTPAllowList, error) {
+			allowList := new(ActionsHTTPAllowList)
+			hosts := pq.StringArray{}
+			err := row.Scan(
+				&allowList.AggregateID,
+				&allowList.ChangeDate,
+				&allowList.Sequence,
+				&hosts,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return &ActionsHTTPAllowList{Hosts: []string{}}, nil
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Lu6ah", "Errors.Internal")
+			}
+			allowList.Hosts = hosts
+			return allowList, nil
+		}
+}
@@ -0,0 +1,98 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+var (
+	actionsHTTPAllowListStmt = regexp.QuoteMeta(`SELECT projections.actions_http_allow_lists.aggregate_id,` +
+		` projections.actions_http_allow_lists.change_date,` +
+		` projections.actions_http_allow_lists.sequence,` +
+		` projections.actions_http_allow_lists.hosts` +
+		` FROM projections.actions_http_allow_lists`)
+	actionsHTTPAllowListCols = []string{
+		"aggregate_id",
+		"change_date",
+		"sequence",
+		"hosts",
+	}
+)
+
+func Test_ActionsHTTPAllowListPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionsHTTPAllowListQuery no result",
+			prepare: prepareActionsHTTPAllowListQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					actionsHTTPAllowListStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &ActionsHTTPAllowList{
+				Hosts: []string{},
+			},
+		},
+		{
+			name:    "prepareActionsHTTPAllowListQuery found",
+			prepare: prepareActionsHTTPAllowListQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					actionsHTTPAllowListStmt,
+					actionsHTTPAllowListCols,
+					[]driver.Value{
+						"agg-id",
+						testNow,
+						uint64(20211108),
+						pq.StringArray{"entitlements.example.com", "localhost:8080"},
+					},
+				),
+			},
+			object: &ActionsHTTPAllowList{
+				AggregateID: "agg-id",
+				ChangeDate:  testNow,
+				Sequence:    20211108,
+				Hosts:       []string{"entitlements.example.com", "localhost:8080"},
+			},
+		},
+		{
+			name:    "prepareActionsHTTPAllowListQuery sql err",
+			prepare: prepareActionsHTTPAllowListQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					actionsHTTPAllowListStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
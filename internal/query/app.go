@@ -36,26 +36,32 @@ type App struct {
 
 	OIDCConfig *OIDCApp
 	APIConfig  *APIApp
+	SAMLConfig *SAMLApp
 }
 
 type OIDCApp struct {
-	RedirectURIs           []string
-	ResponseTypes          []domain.OIDCResponseType
-	GrantTypes             []domain.OIDCGrantType
-	AppType                domain.OIDCApplicationType
-	ClientID               string
-	AuthMethodType         domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs []string
-	Version                domain.OIDCVersion
-	ComplianceProblems     []string
-	IsDevMode              bool
-	AccessTokenType        domain.OIDCTokenType
-	AssertAccessTokenRole  bool
-	AssertIDTokenRole      bool
-	AssertIDTokenUserinfo  bool
-	ClockSkew              time.Duration
-	AdditionalOrigins      []string
-	AllowedOrigins         []string
+	RedirectURIs             []string
+	ResponseTypes            []domain.OIDCResponseType
+	GrantTypes               []domain.OIDCGrantType
+	AppType                  domain.OIDCApplicationType
+	ClientID                 string
+	AuthMethodType           domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs   []string
+	Version                  domain.OIDCVersion
+	ComplianceProblems       []string
+	IsDevMode                bool
+	AccessTokenType          domain.OIDCTokenType
+	AssertAccessTokenRole    bool
+	AssertIDTokenRole        bool
+	AssertIDTokenUserinfo    bool
+	ClockSkew                time.Duration
+	AdditionalOrigins        []string
+	AllowedOrigins           []string
+	RequirePushedAuthRequest bool
+	RequireRequestObject     bool
+	BackChannelLogoutURI     string
+	FrontChannelLogoutURI    string
+	MinLevelOfAssurance      domain.LevelOfAssurance
 }
 
 type APIApp struct {
@@ -63,6 +69,12 @@ type APIApp struct {
 	AuthMethodType domain.APIAuthMethodType
 }
 
+type SAMLApp struct {
+	EntityID    string
+	Metadata    []byte
+	MetadataURL string
+}
+
 type AppSearchQueries struct {
 	SearchRequest
 	Queries []SearchQuery
@@ -204,6 +216,48 @@ var (
 		name:  projection.AppOIDCConfigColumnAdditionalOrigins,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRequirePushedAuthRequest = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthRequest,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireRequestObject = Column{
+		name:  projection.AppOIDCConfigColumnRequireRequestObject,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnFrontChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnFrontChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnMinLevelOfAssurance = Column{
+		name:  projection.AppOIDCConfigColumnMinLevelOfAssurance,
+		table: appOIDCConfigsTable,
+	}
+)
+
+var (
+	appSAMLConfigsTable = table{
+		name: projection.AppSAMLTable,
+	}
+	AppSAMLConfigColumnAppID = Column{
+		name:  projection.AppSAMLConfigColumnAppID,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnEntityID = Column{
+		name:  projection.AppSAMLConfigColumnEntityID,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnMetadata = Column{
+		name:  projection.AppSAMLConfigColumnMetadata,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnMetadataURL = Column{
+		name:  projection.AppSAMLConfigColumnMetadataURL,
+		table: appSAMLConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, projectID, appID string) (*App, error) {
@@ -306,6 +360,38 @@ func (q *Queries) AppByOIDCClientID(ctx context.Context, clientID string) (*App,
 	return scan(row)
 }
 
+func (q *Queries) ProjectBySAMLEntityID(ctx context.Context, entityID string) (*Project, error) {
+	stmt, scan := prepareProjectByAppQuery()
+	query, args, err := stmt.Where(
+		sq.Eq{
+			AppSAMLConfigColumnEntityID.identifier(): entityID,
+			AppColumnInstanceID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-JgUop", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
+func (q *Queries) AppBySAMLEntityID(ctx context.Context, entityID string) (*App, error) {
+	stmt, scan := prepareAppQuery()
+	query, args, err := stmt.Where(
+		sq.Eq{
+			AppSAMLConfigColumnEntityID.identifier(): entityID,
+			AppColumnInstanceID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		},
+	).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-JgUoi", "Errors.Query.SQLStatement")
+	}
+
+	row := q.client.QueryRowContext(ctx, query, args...)
+	return scan(row)
+}
+
 func (q *Queries) AppByClientID(ctx context.Context, clientID string) (*App, error) {
 	stmt, scan := prepareAppQuery()
 	query, args, err := stmt.Where(
@@ -403,15 +489,27 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnIDTokenUserinfoAssertion.identifier(),
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnRequireRequestObject.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnMinLevelOfAssurance.identifier(),
+
+			AppSAMLConfigColumnAppID.identifier(),
+			AppSAMLConfigColumnEntityID.identifier(),
+			AppSAMLConfigColumnMetadata.identifier(),
+			AppSAMLConfigColumnMetadataURL.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*App, error) {
 			app := new(App)
 
 			var (
 				apiConfig  = sqlAPIConfig{}
 				oidcConfig = sqlOIDCConfig{}
+				samlConfig = sqlSAMLConfig{}
 			)
 
 			err := row.Scan(
@@ -444,6 +542,16 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.iDTokenUserinfoAssertion,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
+				&oidcConfig.requirePushedAuthRequest,
+				&oidcConfig.requireRequestObject,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.frontChannelLogoutURI,
+				&oidcConfig.minLevelOfAssurance,
+
+				&samlConfig.appID,
+				&samlConfig.entityID,
+				&samlConfig.metadata,
+				&samlConfig.metadataURL,
 			)
 
 			if err != nil {
@@ -455,6 +563,7 @@ func prepareAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 
 			apiConfig.set(app)
 			oidcConfig.set(app)
+			samlConfig.set(app)
 
 			return app, nil
 		}
@@ -499,6 +608,7 @@ func prepareProjectByAppQuery() (sq.SelectBuilder, func(*sql.Row) (*Project, err
 			Join(join(AppColumnProjectID, ProjectColumnID)).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Project, error) {
 			p := new(Project)
@@ -556,10 +666,21 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppOIDCConfigColumnIDTokenUserinfoAssertion.identifier(),
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnRequireRequestObject.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnMinLevelOfAssurance.identifier(),
+
+			AppSAMLConfigColumnAppID.identifier(),
+			AppSAMLConfigColumnEntityID.identifier(),
+			AppSAMLConfigColumnMetadata.identifier(),
+			AppSAMLConfigColumnMetadataURL.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*Apps, error) {
 			apps := &Apps{Apps: []*App{}}
 
@@ -568,6 +689,7 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 				var (
 					apiConfig  = sqlAPIConfig{}
 					oidcConfig = sqlOIDCConfig{}
+					samlConfig = sqlSAMLConfig{}
 				)
 
 				err := row.Scan(
@@ -600,6 +722,16 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&oidcConfig.iDTokenUserinfoAssertion,
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
+					&oidcConfig.requirePushedAuthRequest,
+					&oidcConfig.requireRequestObject,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.frontChannelLogoutURI,
+					&oidcConfig.minLevelOfAssurance,
+
+					&samlConfig.appID,
+					&samlConfig.entityID,
+					&samlConfig.metadata,
+					&samlConfig.metadataURL,
 					&apps.Count,
 				)
 
@@ -609,6 +741,7 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 
 				apiConfig.set(app)
 				oidcConfig.set(app)
+				samlConfig.set(app)
 
 				apps.Apps = append(apps.Apps, app)
 			}
@@ -662,6 +795,11 @@ type sqlOIDCConfig struct {
 	iDTokenUserinfoAssertion sql.NullBool
 	clockSkew                sql.NullInt64
 	additionalOrigins        pq.StringArray
+	requirePushedAuthRequest sql.NullBool
+	requireRequestObject     sql.NullBool
+	backChannelLogoutURI     sql.NullString
+	frontChannelLogoutURI    sql.NullString
+	minLevelOfAssurance      sql.NullInt16
 	responseTypes            pq.Int32Array
 	grantTypes               pq.Int32Array
 }
@@ -671,21 +809,26 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                domain.OIDCVersion(c.version.Int32),
-		ClientID:               c.clientID.String,
-		RedirectURIs:           c.redirectUris,
-		AppType:                domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:         domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs: c.postLogoutRedirectUris,
-		IsDevMode:              c.devMode.Bool,
-		AccessTokenType:        domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:  c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:      c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:  c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:              time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:      c.additionalOrigins,
-		ResponseTypes:          oidcResponseTypesToDomain(c.responseTypes),
-		GrantTypes:             oidcGrantTypesToDomain(c.grantTypes),
+		Version:                  domain.OIDCVersion(c.version.Int32),
+		ClientID:                 c.clientID.String,
+		RedirectURIs:             c.redirectUris,
+		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
+		IsDevMode:                c.devMode.Bool,
+		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:        c.additionalOrigins,
+		RequirePushedAuthRequest: c.requirePushedAuthRequest.Bool,
+		RequireRequestObject:     c.requireRequestObject.Bool,
+		BackChannelLogoutURI:     c.backChannelLogoutURI.String,
+		FrontChannelLogoutURI:    c.frontChannelLogoutURI.String,
+		MinLevelOfAssurance:      domain.LevelOfAssurance(c.minLevelOfAssurance.Int16),
+		ResponseTypes:            oidcResponseTypesToDomain(c.responseTypes),
+		GrantTypes:               oidcGrantTypesToDomain(c.grantTypes),
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -711,6 +854,24 @@ func (c sqlAPIConfig) set(app *App) {
 	}
 }
 
+type sqlSAMLConfig struct {
+	appID       sql.NullString
+	entityID    sql.NullString
+	metadataURL sql.NullString
+	metadata    []byte
+}
+
+func (c sqlSAMLConfig) set(app *App) {
+	if !c.appID.Valid {
+		return
+	}
+	app.SAMLConfig = &SAMLApp{
+		EntityID:    c.entityID.String,
+		MetadataURL: c.metadataURL.String,
+		Metadata:    c.metadata,
+	}
+}
+
 func oidcResponseTypesToDomain(t pq.Int32Array) []domain.OIDCResponseType {
 	types := make([]domain.OIDCResponseType, len(t))
 	for i, typ := range t {
@@ -44,10 +44,21 @@ var (
 		` projections.apps_oidc_configs.id_token_role_assertion,` +
 		` projections.apps_oidc_configs.id_token_userinfo_assertion,` +
 		` projections.apps_oidc_configs.clock_skew,` +
-		` projections.apps_oidc_configs.additional_origins` +
+		` projections.apps_oidc_configs.additional_origins,` +
+		` projections.apps_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps_oidc_configs.require_request_object,` +
+		` projections.apps_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps_oidc_configs.min_level_of_assurance,` +
+		// saml config
+		` projections.apps_saml_configs.app_id,` +
+		` projections.apps_saml_configs.entity_id,` +
+		` projections.apps_saml_configs.metadata,` +
+		` projections.apps_saml_configs.metadata_url` +
 		` FROM projections.apps` +
 		` LEFT JOIN projections.apps_api_configs ON projections.apps.id = projections.apps_api_configs.app_id` +
-		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id`)
+		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id` +
+		` LEFT JOIN projections.apps_saml_configs ON projections.apps.id = projections.apps_saml_configs.app_id`)
 	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps.id,` +
 		` projections.apps.name,` +
 		` projections.apps.project_id,` +
@@ -77,10 +88,21 @@ var (
 		` projections.apps_oidc_configs.id_token_userinfo_assertion,` +
 		` projections.apps_oidc_configs.clock_skew,` +
 		` projections.apps_oidc_configs.additional_origins,` +
+		` projections.apps_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps_oidc_configs.require_request_object,` +
+		` projections.apps_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps_oidc_configs.min_level_of_assurance,` +
+		// saml config
+		` projections.apps_saml_configs.app_id,` +
+		` projections.apps_saml_configs.entity_id,` +
+		` projections.apps_saml_configs.metadata,` +
+		` projections.apps_saml_configs.metadata_url,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.apps` +
 		` LEFT JOIN projections.apps_api_configs ON projections.apps.id = projections.apps_api_configs.app_id` +
-		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id`)
+		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id` +
+		` LEFT JOIN projections.apps_saml_configs ON projections.apps.id = projections.apps_saml_configs.app_id`)
 	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps_api_configs.client_id,` +
 		` projections.apps_oidc_configs.client_id` +
 		` FROM projections.apps` +
@@ -104,7 +126,8 @@ var (
 		` FROM projections.projects` +
 		` JOIN projections.apps ON projections.projects.id = projections.apps.project_id` +
 		` LEFT JOIN projections.apps_api_configs ON projections.apps.id = projections.apps_api_configs.app_id` +
-		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id`)
+		` LEFT JOIN projections.apps_oidc_configs ON projections.apps.id = projections.apps_oidc_configs.app_id` +
+		` LEFT JOIN projections.apps_saml_configs ON projections.apps.id = projections.apps_saml_configs.app_id`)
 
 	appCols = []string{
 		"id",
@@ -136,6 +159,16 @@ var (
 		"id_token_userinfo_assertion",
 		"clock_skew",
 		"additional_origins",
+		"require_pushed_auth_request",
+		"require_request_object",
+		"back_channel_logout_uri",
+		"front_channel_logout_uri",
+		"min_level_of_assurance",
+		// saml config
+		"app_id",
+		"entity_id",
+		"metadata",
+		"metadata_url",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -201,6 +234,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -261,6 +304,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -325,6 +378,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -404,6 +467,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -483,6 +556,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -562,6 +645,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -641,6 +734,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -720,6 +823,16 @@ func Test_AppsPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -751,6 +864,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -897,6 +1020,16 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -949,6 +1082,16 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -968,6 +1111,74 @@ func Test_AppPrepare(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareAppQuery saml app",
+			prepare: prepareAppQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppQuery,
+					appCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							"app-id",
+							"https://sp.example.com/metadata",
+							[]byte("<xml>"),
+							"https://sp.example.com/metadata",
+						},
+					},
+				),
+			},
+			object: &App{
+				ID:            "app-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.AppStateActive,
+				Sequence:      20211109,
+				Name:          "app-name",
+				ProjectID:     "project-id",
+				SAMLConfig: &SAMLApp{
+					EntityID:    "https://sp.example.com/metadata",
+					Metadata:    []byte("<xml>"),
+					MetadataURL: "https://sp.example.com/metadata",
+				},
+			},
+		},
 		{
 			name:    "prepareAppQuery oidc app",
 			prepare: prepareAppQuery,
@@ -1006,6 +1217,16 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1078,6 +1299,16 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1150,6 +1381,16 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1222,6 +1463,16 @@ func Test_AppPrepare(t *testing.T) {
 							true,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1294,6 +1545,16 @@ func Test_AppPrepare(t *testing.T) {
 							false,
 							1 * time.Second,
 							pq.StringArray{"additional.origin"},
+							false,
+							false,
+							"",
+							"",
+							domain.LevelOfAssuranceNone,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -30,6 +30,8 @@ type IDP struct {
 	AutoRegister  bool
 	*OIDCIDP
 	*JWTIDP
+	*SAMLIDP
+	*LDAPIDP
 }
 
 type IDPs struct {
@@ -57,6 +59,25 @@ type JWTIDP struct {
 	Endpoint     string
 }
 
+type SAMLIDP struct {
+	IDPID       string
+	EntityID    string
+	Metadata    []byte
+	MetadataURL string
+}
+
+type LDAPIDP struct {
+	IDPID           string
+	URL             string
+	StartTLS        bool
+	RootCA          string
+	BaseDN          string
+	BindDN          string
+	UserObjectClass string
+	UserFilters     []string
+	Attributes      domain.LDAPAttributes
+}
+
 var (
 	idpTable = table{
 		name: projection.IDPTable,
@@ -179,6 +200,94 @@ var (
 	}
 )
 
+var (
+	samlIDPTable = table{
+		name: projection.IDPSAMLTable,
+	}
+	SAMLIDPColIDPID = Column{
+		name:  projection.SAMLConfigIDPIDCol,
+		table: samlIDPTable,
+	}
+	SAMLIDPColEntityID = Column{
+		name:  projection.SAMLConfigEntityIDCol,
+		table: samlIDPTable,
+	}
+	SAMLIDPColMetadata = Column{
+		name:  projection.SAMLConfigMetadataCol,
+		table: samlIDPTable,
+	}
+	SAMLIDPColMetadataURL = Column{
+		name:  projection.SAMLConfigMetadataURLCol,
+		table: samlIDPTable,
+	}
+)
+
+var (
+	ldapIDPTable = table{
+		name: projection.IDPLDAPTable,
+	}
+	LDAPIDPColIDPID = Column{
+		name:  projection.LDAPConfigIDPIDCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColURL = Column{
+		name:  projection.LDAPConfigURLCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColStartTLS = Column{
+		name:  projection.LDAPConfigStartTLSCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColRootCA = Column{
+		name:  projection.LDAPConfigRootCACol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColBaseDN = Column{
+		name:  projection.LDAPConfigBaseDNCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColBindDN = Column{
+		name:  projection.LDAPConfigBindDNCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColUserObjectClass = Column{
+		name:  projection.LDAPConfigUserObjectClassCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColUserFilters = Column{
+		name:  projection.LDAPConfigUserFiltersCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColIDAttribute = Column{
+		name:  projection.LDAPConfigIDAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColPreferredUsernameAttribute = Column{
+		name:  projection.LDAPConfigPreferredUsernameAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColFirstNameAttribute = Column{
+		name:  projection.LDAPConfigFirstNameAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColLastNameAttribute = Column{
+		name:  projection.LDAPConfigLastNameAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColDisplayNameAttribute = Column{
+		name:  projection.LDAPConfigDisplayNameAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColEmailAttribute = Column{
+		name:  projection.LDAPConfigEmailAttributeCol,
+		table: ldapIDPTable,
+	}
+	LDAPIDPColPhoneAttribute = Column{
+		name:  projection.LDAPConfigPhoneAttributeCol,
+		table: ldapIDPTable,
+	}
+)
+
 //IDPByIDAndResourceOwner searches for the requested id in the context of the resource owner and IAM
 func (q *Queries) IDPByIDAndResourceOwner(ctx context.Context, id, resourceOwner string) (*IDP, error) {
 	stmt, scan := prepareIDPByIDQuery()
@@ -292,9 +401,30 @@ func prepareIDPByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDP, error)) {
 			JWTIDPColKeysEndpoint.identifier(),
 			JWTIDPColHeaderName.identifier(),
 			JWTIDPColEndpoint.identifier(),
+			SAMLIDPColIDPID.identifier(),
+			SAMLIDPColEntityID.identifier(),
+			SAMLIDPColMetadata.identifier(),
+			SAMLIDPColMetadataURL.identifier(),
+			LDAPIDPColIDPID.identifier(),
+			LDAPIDPColURL.identifier(),
+			LDAPIDPColStartTLS.identifier(),
+			LDAPIDPColRootCA.identifier(),
+			LDAPIDPColBaseDN.identifier(),
+			LDAPIDPColBindDN.identifier(),
+			LDAPIDPColUserObjectClass.identifier(),
+			LDAPIDPColUserFilters.identifier(),
+			LDAPIDPColIDAttribute.identifier(),
+			LDAPIDPColPreferredUsernameAttribute.identifier(),
+			LDAPIDPColFirstNameAttribute.identifier(),
+			LDAPIDPColLastNameAttribute.identifier(),
+			LDAPIDPColDisplayNameAttribute.identifier(),
+			LDAPIDPColEmailAttribute.identifier(),
+			LDAPIDPColPhoneAttribute.identifier(),
 		).From(idpTable.identifier()).
 			LeftJoin(join(OIDCIDPColIDPID, IDPIDCol)).
 			LeftJoin(join(JWTIDPColIDPID, IDPIDCol)).
+			LeftJoin(join(SAMLIDPColIDPID, IDPIDCol)).
+			LeftJoin(join(LDAPIDPColIDPID, IDPIDCol)).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDP, error) {
 			idp := new(IDP)
@@ -315,6 +445,27 @@ func prepareIDPByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDP, error)) {
 			jwtHeaderName := sql.NullString{}
 			jwtEndpoint := sql.NullString{}
 
+			samlIDPID := sql.NullString{}
+			samlEntityID := sql.NullString{}
+			var samlMetadata []byte
+			samlMetadataURL := sql.NullString{}
+
+			ldapIDPID := sql.NullString{}
+			ldapURL := sql.NullString{}
+			ldapStartTLS := sql.NullBool{}
+			ldapRootCA := sql.NullString{}
+			ldapBaseDN := sql.NullString{}
+			ldapBindDN := sql.NullString{}
+			ldapUserObjectClass := sql.NullString{}
+			ldapUserFilters := pq.StringArray{}
+			ldapIDAttribute := sql.NullString{}
+			ldapPreferredUsernameAttribute := sql.NullString{}
+			ldapFirstNameAttribute := sql.NullString{}
+			ldapLastNameAttribute := sql.NullString{}
+			ldapDisplayNameAttribute := sql.NullString{}
+			ldapEmailAttribute := sql.NullString{}
+			ldapPhoneAttribute := sql.NullString{}
+
 			err := row.Scan(
 				&idp.ID,
 				&idp.ResourceOwner,
@@ -340,6 +491,25 @@ func prepareIDPByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDP, error)) {
 				&jwtKeysEndpoint,
 				&jwtHeaderName,
 				&jwtEndpoint,
+				&samlIDPID,
+				&samlEntityID,
+				&samlMetadata,
+				&samlMetadataURL,
+				&ldapIDPID,
+				&ldapURL,
+				&ldapStartTLS,
+				&ldapRootCA,
+				&ldapBaseDN,
+				&ldapBindDN,
+				&ldapUserObjectClass,
+				&ldapUserFilters,
+				&ldapIDAttribute,
+				&ldapPreferredUsernameAttribute,
+				&ldapFirstNameAttribute,
+				&ldapLastNameAttribute,
+				&ldapDisplayNameAttribute,
+				&ldapEmailAttribute,
+				&ldapPhoneAttribute,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -368,6 +538,33 @@ func prepareIDPByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDP, error)) {
 					HeaderName:   jwtHeaderName.String,
 					Endpoint:     jwtEndpoint.String,
 				}
+			} else if samlIDPID.Valid {
+				idp.SAMLIDP = &SAMLIDP{
+					IDPID:       samlIDPID.String,
+					EntityID:    samlEntityID.String,
+					Metadata:    samlMetadata,
+					MetadataURL: samlMetadataURL.String,
+				}
+			} else if ldapIDPID.Valid {
+				idp.LDAPIDP = &LDAPIDP{
+					IDPID:           ldapIDPID.String,
+					URL:             ldapURL.String,
+					StartTLS:        ldapStartTLS.Bool,
+					RootCA:          ldapRootCA.String,
+					BaseDN:          ldapBaseDN.String,
+					BindDN:          ldapBindDN.String,
+					UserObjectClass: ldapUserObjectClass.String,
+					UserFilters:     ldapUserFilters,
+					Attributes: domain.LDAPAttributes{
+						IDAttribute:                ldapIDAttribute.String,
+						PreferredUsernameAttribute: ldapPreferredUsernameAttribute.String,
+						FirstNameAttribute:         ldapFirstNameAttribute.String,
+						LastNameAttribute:          ldapLastNameAttribute.String,
+						DisplayNameAttribute:       ldapDisplayNameAttribute.String,
+						EmailAttribute:             ldapEmailAttribute.String,
+						PhoneAttribute:             ldapPhoneAttribute.String,
+					},
+				}
 			}
 
 			return idp, nil
@@ -400,10 +597,31 @@ func prepareIDPsQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPs, error)) {
 			JWTIDPColKeysEndpoint.identifier(),
 			JWTIDPColHeaderName.identifier(),
 			JWTIDPColEndpoint.identifier(),
+			SAMLIDPColIDPID.identifier(),
+			SAMLIDPColEntityID.identifier(),
+			SAMLIDPColMetadata.identifier(),
+			SAMLIDPColMetadataURL.identifier(),
+			LDAPIDPColIDPID.identifier(),
+			LDAPIDPColURL.identifier(),
+			LDAPIDPColStartTLS.identifier(),
+			LDAPIDPColRootCA.identifier(),
+			LDAPIDPColBaseDN.identifier(),
+			LDAPIDPColBindDN.identifier(),
+			LDAPIDPColUserObjectClass.identifier(),
+			LDAPIDPColUserFilters.identifier(),
+			LDAPIDPColIDAttribute.identifier(),
+			LDAPIDPColPreferredUsernameAttribute.identifier(),
+			LDAPIDPColFirstNameAttribute.identifier(),
+			LDAPIDPColLastNameAttribute.identifier(),
+			LDAPIDPColDisplayNameAttribute.identifier(),
+			LDAPIDPColEmailAttribute.identifier(),
+			LDAPIDPColPhoneAttribute.identifier(),
 			countColumn.identifier(),
 		).From(idpTable.identifier()).
 			LeftJoin(join(OIDCIDPColIDPID, IDPIDCol)).
 			LeftJoin(join(JWTIDPColIDPID, IDPIDCol)).
+			LeftJoin(join(SAMLIDPColIDPID, IDPIDCol)).
+			LeftJoin(join(LDAPIDPColIDPID, IDPIDCol)).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPs, error) {
 			idps := make([]*IDP, 0)
@@ -427,6 +645,27 @@ func prepareIDPsQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPs, error)) {
 				jwtHeaderName := sql.NullString{}
 				jwtEndpoint := sql.NullString{}
 
+				samlIDPID := sql.NullString{}
+				samlEntityID := sql.NullString{}
+				var samlMetadata []byte
+				samlMetadataURL := sql.NullString{}
+
+				ldapIDPID := sql.NullString{}
+				ldapURL := sql.NullString{}
+				ldapStartTLS := sql.NullBool{}
+				ldapRootCA := sql.NullString{}
+				ldapBaseDN := sql.NullString{}
+				ldapBindDN := sql.NullString{}
+				ldapUserObjectClass := sql.NullString{}
+				ldapUserFilters := pq.StringArray{}
+				ldapIDAttribute := sql.NullString{}
+				ldapPreferredUsernameAttribute := sql.NullString{}
+				ldapFirstNameAttribute := sql.NullString{}
+				ldapLastNameAttribute := sql.NullString{}
+				ldapDisplayNameAttribute := sql.NullString{}
+				ldapEmailAttribute := sql.NullString{}
+				ldapPhoneAttribute := sql.NullString{}
+
 				err := rows.Scan(
 					&idp.ID,
 					&idp.ResourceOwner,
@@ -454,6 +693,27 @@ func prepareIDPsQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPs, error)) {
 					&jwtKeysEndpoint,
 					&jwtHeaderName,
 					&jwtEndpoint,
+					// saml config
+					&samlIDPID,
+					&samlEntityID,
+					&samlMetadata,
+					&samlMetadataURL,
+					// ldap config
+					&ldapIDPID,
+					&ldapURL,
+					&ldapStartTLS,
+					&ldapRootCA,
+					&ldapBaseDN,
+					&ldapBindDN,
+					&ldapUserObjectClass,
+					&ldapUserFilters,
+					&ldapIDAttribute,
+					&ldapPreferredUsernameAttribute,
+					&ldapFirstNameAttribute,
+					&ldapLastNameAttribute,
+					&ldapDisplayNameAttribute,
+					&ldapEmailAttribute,
+					&ldapPhoneAttribute,
 					&count,
 				)
 
@@ -481,6 +741,33 @@ func prepareIDPsQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPs, error)) {
 						HeaderName:   jwtHeaderName.String,
 						Endpoint:     jwtEndpoint.String,
 					}
+				} else if samlIDPID.Valid {
+					idp.SAMLIDP = &SAMLIDP{
+						IDPID:       samlIDPID.String,
+						EntityID:    samlEntityID.String,
+						Metadata:    samlMetadata,
+						MetadataURL: samlMetadataURL.String,
+					}
+				} else if ldapIDPID.Valid {
+					idp.LDAPIDP = &LDAPIDP{
+						IDPID:           ldapIDPID.String,
+						URL:             ldapURL.String,
+						StartTLS:        ldapStartTLS.Bool,
+						RootCA:          ldapRootCA.String,
+						BaseDN:          ldapBaseDN.String,
+						BindDN:          ldapBindDN.String,
+						UserObjectClass: ldapUserObjectClass.String,
+						UserFilters:     ldapUserFilters,
+						Attributes: domain.LDAPAttributes{
+							IDAttribute:                ldapIDAttribute.String,
+							PreferredUsernameAttribute: ldapPreferredUsernameAttribute.String,
+							FirstNameAttribute:         ldapFirstNameAttribute.String,
+							LastNameAttribute:          ldapLastNameAttribute.String,
+							DisplayNameAttribute:       ldapDisplayNameAttribute.String,
+							EmailAttribute:             ldapEmailAttribute.String,
+							PhoneAttribute:             ldapPhoneAttribute.String,
+						},
+					}
 				}
 
 				idps = append(idps, idp)
@@ -54,10 +54,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.issuer,`+
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
-						` projections.idps_jwt_config.endpoint`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					nil,
 					nil,
 				),
@@ -98,10 +119,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.issuer,`+
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
-						` projections.idps_jwt_config.endpoint`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -129,6 +171,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 					},
 					[]driver.Value{
 						"idp-id",
@@ -157,6 +220,27 @@ func Test_IDPPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -212,10 +296,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.issuer,`+
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
-						` projections.idps_jwt_config.endpoint`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -243,6 +348,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 					},
 					[]driver.Value{
 						"idp-id",
@@ -271,6 +397,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"key.ch",
 						"x-header-name",
 						"jwt.endpoint.ch",
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -294,6 +441,363 @@ func Test_IDPPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareIDPByIDQuery saml config",
+			prepare: prepareIDPByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.idps.id,`+
+						` projections.idps.resource_owner,`+
+						` projections.idps.creation_date,`+
+						` projections.idps.change_date,`+
+						` projections.idps.sequence,`+
+						` projections.idps.state,`+
+						` projections.idps.name,`+
+						` projections.idps.styling_type,`+
+						` projections.idps.owner_type,`+
+						` projections.idps.auto_register,`+
+						` projections.idps_oidc_config.idp_id,`+
+						` projections.idps_oidc_config.client_id,`+
+						` projections.idps_oidc_config.client_secret,`+
+						` projections.idps_oidc_config.issuer,`+
+						` projections.idps_oidc_config.scopes,`+
+						` projections.idps_oidc_config.display_name_mapping,`+
+						` projections.idps_oidc_config.username_mapping,`+
+						` projections.idps_oidc_config.authorization_endpoint,`+
+						` projections.idps_oidc_config.token_endpoint,`+
+						` projections.idps_jwt_config.idp_id,`+
+						` projections.idps_jwt_config.issuer,`+
+						` projections.idps_jwt_config.keys_endpoint,`+
+						` projections.idps_jwt_config.header_name,`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
+						` FROM projections.idps`+
+						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
+					[]string{
+						"id",
+						"resource_owner",
+						"creation_date",
+						"change_date",
+						"sequence",
+						"state",
+						"name",
+						"styling_type",
+						"owner_type",
+						"auto_register",
+						// oidc config
+						"idp_id",
+						"client_id",
+						"client_secret",
+						"issuer",
+						"scopes",
+						"display_name_mapping",
+						"username_mapping",
+						"authorization_endpoint",
+						"token_endpoint",
+						// jwt config
+						"idp_id",
+						"issuer",
+						"keys_endpoint",
+						"header_name",
+						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
+					},
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPConfigStylingTypeGoogle,
+						domain.IdentityProviderTypeOrg,
+						true,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						"idp-id",
+						"saml-entity-id",
+						[]byte("<metadata/>"),
+						"saml.metadata.ch",
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+					},
+				),
+			},
+			object: &IDP{
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro",
+				ID:            "idp-id",
+				State:         domain.IDPConfigStateActive,
+				Name:          "idp-name",
+				StylingType:   domain.IDPConfigStylingTypeGoogle,
+				OwnerType:     domain.IdentityProviderTypeOrg,
+				AutoRegister:  true,
+				SAMLIDP: &SAMLIDP{
+					IDPID:       "idp-id",
+					EntityID:    "saml-entity-id",
+					Metadata:    []byte("<metadata/>"),
+					MetadataURL: "saml.metadata.ch",
+				},
+			},
+		},
+		{
+			name:    "prepareIDPByIDQuery ldap config",
+			prepare: prepareIDPByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(`SELECT projections.idps.id,`+
+						` projections.idps.resource_owner,`+
+						` projections.idps.creation_date,`+
+						` projections.idps.change_date,`+
+						` projections.idps.sequence,`+
+						` projections.idps.state,`+
+						` projections.idps.name,`+
+						` projections.idps.styling_type,`+
+						` projections.idps.owner_type,`+
+						` projections.idps.auto_register,`+
+						` projections.idps_oidc_config.idp_id,`+
+						` projections.idps_oidc_config.client_id,`+
+						` projections.idps_oidc_config.client_secret,`+
+						` projections.idps_oidc_config.issuer,`+
+						` projections.idps_oidc_config.scopes,`+
+						` projections.idps_oidc_config.display_name_mapping,`+
+						` projections.idps_oidc_config.username_mapping,`+
+						` projections.idps_oidc_config.authorization_endpoint,`+
+						` projections.idps_oidc_config.token_endpoint,`+
+						` projections.idps_jwt_config.idp_id,`+
+						` projections.idps_jwt_config.issuer,`+
+						` projections.idps_jwt_config.keys_endpoint,`+
+						` projections.idps_jwt_config.header_name,`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
+						` FROM projections.idps`+
+						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
+					[]string{
+						"id",
+						"resource_owner",
+						"creation_date",
+						"change_date",
+						"sequence",
+						"state",
+						"name",
+						"styling_type",
+						"owner_type",
+						"auto_register",
+						// oidc config
+						"idp_id",
+						"client_id",
+						"client_secret",
+						"issuer",
+						"scopes",
+						"display_name_mapping",
+						"username_mapping",
+						"authorization_endpoint",
+						"token_endpoint",
+						// jwt config
+						"idp_id",
+						"issuer",
+						"keys_endpoint",
+						"header_name",
+						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
+					},
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPConfigStylingTypeGoogle,
+						domain.IdentityProviderTypeOrg,
+						true,
+						// oidc config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						"idp-id",
+						"ldaps://ad.example.com",
+						true,
+						"root-ca",
+						"dc=example,dc=com",
+						"cn=service,dc=example,dc=com",
+						"user",
+						pq.StringArray{"sAMAccountName"},
+						"objectGUID",
+						"sAMAccountName",
+						"givenName",
+						"sn",
+						"displayName",
+						"mail",
+						"mobile",
+					},
+				),
+			},
+			object: &IDP{
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				ResourceOwner: "ro",
+				ID:            "idp-id",
+				State:         domain.IDPConfigStateActive,
+				Name:          "idp-name",
+				StylingType:   domain.IDPConfigStylingTypeGoogle,
+				OwnerType:     domain.IdentityProviderTypeOrg,
+				AutoRegister:  true,
+				LDAPIDP: &LDAPIDP{
+					IDPID:           "idp-id",
+					URL:             "ldaps://ad.example.com",
+					StartTLS:        true,
+					RootCA:          "root-ca",
+					BaseDN:          "dc=example,dc=com",
+					BindDN:          "cn=service,dc=example,dc=com",
+					UserObjectClass: "user",
+					UserFilters:     []string{"sAMAccountName"},
+					Attributes: domain.LDAPAttributes{
+						IDAttribute:                "objectGUID",
+						PreferredUsernameAttribute: "sAMAccountName",
+						FirstNameAttribute:         "givenName",
+						LastNameAttribute:          "sn",
+						DisplayNameAttribute:       "displayName",
+						EmailAttribute:             "mail",
+						PhoneAttribute:             "mobile",
+					},
+				},
+			},
+		},
 		{
 			name:    "prepareIDPByIDQuery no config",
 			prepare: prepareIDPByIDQuery,
@@ -322,10 +826,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.issuer,`+
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
-						` projections.idps_jwt_config.endpoint`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -353,6 +878,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 					},
 					[]driver.Value{
 						"idp-id",
@@ -381,6 +927,27 @@ func Test_IDPPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// saml config
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -425,10 +992,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.issuer,`+
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
-						` projections.idps_jwt_config.endpoint`+
+						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -469,10 +1057,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					nil,
 					nil,
 				),
@@ -514,10 +1123,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -545,6 +1175,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 						"count",
 					},
 					[][]driver.Value{
@@ -575,6 +1226,27 @@ func Test_IDPPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -639,10 +1311,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -669,6 +1362,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 						"count",
 					},
 					[][]driver.Value{
@@ -699,6 +1413,27 @@ func Test_IDPPrepares(t *testing.T) {
 							"key.ch",
 							"x-header-name",
 							"jwt.endpoint.ch",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -759,10 +1494,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -789,6 +1545,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 						"count",
 					},
 					[][]driver.Value{
@@ -819,6 +1596,27 @@ func Test_IDPPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -872,10 +1670,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					[]string{
 						"id",
 						"resource_owner",
@@ -902,6 +1721,27 @@ func Test_IDPPrepares(t *testing.T) {
 						"keys_endpoint",
 						"header_name",
 						"endpoint",
+						// saml config
+						"idp_id",
+						"entity_id",
+						"metadata",
+						"metadata_url",
+						// ldap config
+						"idp_id",
+						"url",
+						"start_tls",
+						"root_ca",
+						"base_dn",
+						"bind_dn",
+						"user_object_class",
+						"user_filters",
+						"id_attribute",
+						"preferred_username_attribute",
+						"first_name_attribute",
+						"last_name_attribute",
+						"display_name_attribute",
+						"email_attribute",
+						"phone_attribute",
 						"count",
 					},
 					[][]driver.Value{
@@ -932,6 +1772,27 @@ func Test_IDPPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-2",
@@ -960,6 +1821,27 @@ func Test_IDPPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"idp-id-3",
@@ -988,6 +1870,27 @@ func Test_IDPPrepares(t *testing.T) {
 							"key.ch",
 							"x-header-name",
 							"jwt.endpoint.ch",
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							// ldap config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1083,10 +1986,31 @@ func Test_IDPPrepares(t *testing.T) {
 						` projections.idps_jwt_config.keys_endpoint,`+
 						` projections.idps_jwt_config.header_name,`+
 						` projections.idps_jwt_config.endpoint,`+
+						` projections.idps_saml_config.idp_id,`+
+						` projections.idps_saml_config.entity_id,`+
+						` projections.idps_saml_config.metadata,`+
+						` projections.idps_saml_config.metadata_url,`+
+						` projections.idps_ldap_config.idp_id,`+
+						` projections.idps_ldap_config.url,`+
+						` projections.idps_ldap_config.start_tls,`+
+						` projections.idps_ldap_config.root_ca,`+
+						` projections.idps_ldap_config.base_dn,`+
+						` projections.idps_ldap_config.bind_dn,`+
+						` projections.idps_ldap_config.user_object_class,`+
+						` projections.idps_ldap_config.user_filters,`+
+						` projections.idps_ldap_config.id_attribute,`+
+						` projections.idps_ldap_config.preferred_username_attribute,`+
+						` projections.idps_ldap_config.first_name_attribute,`+
+						` projections.idps_ldap_config.last_name_attribute,`+
+						` projections.idps_ldap_config.display_name_attribute,`+
+						` projections.idps_ldap_config.email_attribute,`+
+						` projections.idps_ldap_config.phone_attribute,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.idps`+
 						` LEFT JOIN projections.idps_oidc_config ON projections.idps.id = projections.idps_oidc_config.idp_id`+
-						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`),
+						` LEFT JOIN projections.idps_jwt_config ON projections.idps.id = projections.idps_jwt_config.idp_id`+
+						` LEFT JOIN projections.idps_saml_config ON projections.idps.id = projections.idps_saml_config.idp_id`+
+						` LEFT JOIN projections.idps_ldap_config ON projections.idps.id = projections.idps_ldap_config.idp_id`),
 					sql.ErrConnDone,
 				),
 				err: func(err error) (error, bool) {
@@ -24,7 +24,10 @@ type LockoutPolicy struct {
 	State         domain.PolicyState
 
 	MaxPasswordAttempts uint64
+	MaxOTPAttempts      uint64
+	MaxU2FAttempts      uint64
 	ShowFailures        bool
+	AutoUnlockDuration  time.Duration
 
 	IsDefault bool
 }
@@ -65,6 +68,18 @@ var (
 		name:  projection.LockoutPolicyMaxPasswordAttemptsCol,
 		table: lockoutTable,
 	}
+	LockoutColMaxOTPAttempts = Column{
+		name:  projection.LockoutPolicyMaxOTPAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColMaxU2FAttempts = Column{
+		name:  projection.LockoutPolicyMaxU2FAttemptsCol,
+		table: lockoutTable,
+	}
+	LockoutColAutoUnlockDuration = Column{
+		name:  projection.LockoutPolicyAutoUnlockDurationCol,
+		table: lockoutTable,
+	}
 	LockoutColIsDefault = Column{
 		name:  projection.LockoutPolicyIsDefaultCol,
 		table: lockoutTable,
@@ -126,6 +141,9 @@ func prepareLockoutPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*LockoutPoli
 			LockoutColResourceOwner.identifier(),
 			LockoutColShowFailures.identifier(),
 			LockoutColMaxPasswordAttempts.identifier(),
+			LockoutColMaxOTPAttempts.identifier(),
+			LockoutColMaxU2FAttempts.identifier(),
+			LockoutColAutoUnlockDuration.identifier(),
 			LockoutColIsDefault.identifier(),
 			LockoutColState.identifier(),
 		).
@@ -140,6 +158,9 @@ func prepareLockoutPolicyQuery() (sq.SelectBuilder, func(*sql.Row) (*LockoutPoli
 				&policy.ResourceOwner,
 				&policy.ShowFailures,
 				&policy.MaxPasswordAttempts,
+				&policy.MaxOTPAttempts,
+				&policy.MaxU2FAttempts,
+				&policy.AutoUnlockDuration,
 				&policy.IsDefault,
 				&policy.State,
 			)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
@@ -35,6 +36,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` projections.lockout_policies.resource_owner,`+
 						` projections.lockout_policies.show_failure,`+
 						` projections.lockout_policies.max_password_attempts,`+
+						` projections.lockout_policies.max_otp_attempts,`+
+						` projections.lockout_policies.max_u2f_attempts,`+
+						` projections.lockout_policies.auto_unlock_duration,`+
 						` projections.lockout_policies.is_default,`+
 						` projections.lockout_policies.state`+
 						` FROM projections.lockout_policies`),
@@ -62,6 +66,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` projections.lockout_policies.resource_owner,`+
 						` projections.lockout_policies.show_failure,`+
 						` projections.lockout_policies.max_password_attempts,`+
+						` projections.lockout_policies.max_otp_attempts,`+
+						` projections.lockout_policies.max_u2f_attempts,`+
+						` projections.lockout_policies.auto_unlock_duration,`+
 						` projections.lockout_policies.is_default,`+
 						` projections.lockout_policies.state`+
 						` FROM projections.lockout_policies`),
@@ -73,6 +80,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"resource_owner",
 						"show_failure",
 						"max_password_attempts",
+						"max_otp_attempts",
+						"max_u2f_attempts",
+						"auto_unlock_duration",
 						"is_default",
 						"state",
 					},
@@ -84,6 +94,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						"ro",
 						true,
 						20,
+						5,
+						3,
+						time.Hour,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -98,6 +111,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 				State:               domain.PolicyStateActive,
 				ShowFailures:        true,
 				MaxPasswordAttempts: 20,
+				MaxOTPAttempts:      5,
+				MaxU2FAttempts:      3,
+				AutoUnlockDuration:  time.Hour,
 				IsDefault:           true,
 			},
 		},
@@ -113,6 +129,9 @@ func Test_LockoutPolicyPrepares(t *testing.T) {
 						` projections.lockout_policies.resource_owner,`+
 						` projections.lockout_policies.show_failure,`+
 						` projections.lockout_policies.max_password_attempts,`+
+						` projections.lockout_policies.max_otp_attempts,`+
+						` projections.lockout_policies.max_u2f_attempts,`+
+						` projections.lockout_policies.auto_unlock_duration,`+
 						` projections.lockout_policies.is_default,`+
 						` projections.lockout_policies.state`+
 						` FROM projections.lockout_policies`),
@@ -45,6 +45,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						` projections.login_policies.is_default,`+
 						` projections.login_policies.hide_password_reset,`+
 						` projections.login_policies.ignore_unknown_usernames,`+
+						` projections.login_policies.allow_magic_link,`+
 						` projections.login_policies.default_redirect_uri,`+
 						` projections.login_policies.password_check_lifetime,`+
 						` projections.login_policies.external_login_check_lifetime,`+
@@ -83,6 +84,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						` projections.login_policies.is_default,`+
 						` projections.login_policies.hide_password_reset,`+
 						` projections.login_policies.ignore_unknown_usernames,`+
+						` projections.login_policies.allow_magic_link,`+
 						` projections.login_policies.default_redirect_uri,`+
 						` projections.login_policies.password_check_lifetime,`+
 						` projections.login_policies.external_login_check_lifetime,`+
@@ -105,6 +107,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						"is_default",
 						"hide_password_reset",
 						"ignore_unknown_usernames",
+						"allow_magic_link",
 						"default_redirect_uri",
 						"password_check_lifetime",
 						"external_login_check_lifetime",
@@ -127,6 +130,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						true,
 						"https://example.com/redirect",
 						time.Hour * 2,
 						time.Hour * 2,
@@ -151,6 +155,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 				IsDefault:                  true,
 				HidePasswordReset:          true,
 				IgnoreUnknownUsernames:     true,
+				AllowMagicLink:             true,
 				DefaultRedirectURI:         "https://example.com/redirect",
 				PasswordCheckLifetime:      time.Hour * 2,
 				ExternalLoginCheckLifetime: time.Hour * 2,
@@ -178,6 +183,7 @@ func Test_LoginPolicyPrepares(t *testing.T) {
 						` projections.login_policies.is_default,`+
 						` projections.login_policies.hide_password_reset,`+
 						` projections.login_policies.ignore_unknown_usernames,`+
+						` projections.login_policies.allow_magic_link,`+
 						` projections.login_policies.default_redirect_uri,`+
 						` projections.login_policies.password_check_lifetime,`+
 						` projections.login_policies.external_login_check_lifetime,`+
@@ -29,6 +29,9 @@ type MessageTexts struct {
 	VerifyPhone              MessageText
 	DomainClaimed            MessageText
 	PasswordlessRegistration MessageText
+	VerifySMSOTP             MessageText
+	VerifyEmailOTP           MessageText
+	MagicLink                MessageText
 }
 
 type MessageText struct {
@@ -338,6 +341,12 @@ func (m *MessageTexts) GetMessageTextByType(msgType string) *MessageText {
 		return &m.DomainClaimed
 	case domain.PasswordlessRegistrationMessageType:
 		return &m.PasswordlessRegistration
+	case domain.VerifySMSOTPMessageType:
+		return &m.VerifySMSOTP
+	case domain.VerifyEmailOTPMessageType:
+		return &m.VerifyEmailOTP
+	case domain.MagicLinkMessageType:
+		return &m.MagicLink
 	}
 	return nil
 }
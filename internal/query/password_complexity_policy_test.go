@@ -38,6 +38,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						` projections.password_complexity_policies.has_uppercase,`+
 						` projections.password_complexity_policies.has_number,`+
 						` projections.password_complexity_policies.has_symbol,`+
+						` projections.password_complexity_policies.history_depth,`+
+						` projections.password_complexity_policies.check_breached,`+
 						` projections.password_complexity_policies.is_default,`+
 						` projections.password_complexity_policies.state`+
 						` FROM projections.password_complexity_policies`),
@@ -68,6 +70,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						` projections.password_complexity_policies.has_uppercase,`+
 						` projections.password_complexity_policies.has_number,`+
 						` projections.password_complexity_policies.has_symbol,`+
+						` projections.password_complexity_policies.history_depth,`+
+						` projections.password_complexity_policies.check_breached,`+
 						` projections.password_complexity_policies.is_default,`+
 						` projections.password_complexity_policies.state`+
 						` FROM projections.password_complexity_policies`),
@@ -82,6 +86,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						"has_uppercase",
 						"has_number",
 						"has_symbol",
+						"history_depth",
+						"check_breached",
 						"is_default",
 						"state",
 					},
@@ -96,6 +102,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						true,
 						true,
 						true,
+						5,
+						true,
 						true,
 						domain.PolicyStateActive,
 					},
@@ -113,6 +121,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 				HasUppercase:  true,
 				HasNumber:     true,
 				HasSymbol:     true,
+				HistoryDepth:  5,
+				CheckBreached: true,
 				IsDefault:     true,
 			},
 		},
@@ -131,6 +141,8 @@ func Test_PasswordComplexityPolicyPrepares(t *testing.T) {
 						` projections.password_complexity_policies.has_uppercase,`+
 						` projections.password_complexity_policies.has_number,`+
 						` projections.password_complexity_policies.has_symbol,`+
+						` projections.password_complexity_policies.history_depth,`+
+						` projections.password_complexity_policies.check_breached,`+
 						` projections.password_complexity_policies.is_default,`+
 						` projections.password_complexity_policies.state`+
 						` FROM projections.password_complexity_policies`),
@@ -0,0 +1,155 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	AccessPolicyTable = "projections.access_policies"
+
+	AccessPolicyIDCol            = "id"
+	AccessPolicyCreationDateCol  = "creation_date"
+	AccessPolicyChangeDateCol    = "change_date"
+	AccessPolicySequenceCol      = "sequence"
+	AccessPolicyStateCol         = "state"
+	AccessPolicyIsDefaultCol     = "is_default"
+	AccessPolicyResourceOwnerCol = "resource_owner"
+	AccessPolicyInstanceIDCol    = "instance_id"
+	AccessPolicyRulesCol         = "rules"
+)
+
+type AccessPolicyProjection struct {
+	crdb.StatementHandler
+}
+
+func NewAccessPolicyProjection(ctx context.Context, config crdb.StatementHandlerConfig) *AccessPolicyProjection {
+	p := new(AccessPolicyProjection)
+	config.ProjectionName = AccessPolicyTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(AccessPolicyIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessPolicyCreationDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AccessPolicyChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(AccessPolicySequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(AccessPolicyStateCol, crdb.ColumnTypeEnum),
+			crdb.NewColumn(AccessPolicyIsDefaultCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(AccessPolicyResourceOwnerCol, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessPolicyInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(AccessPolicyRulesCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(AccessPolicyInstanceIDCol, AccessPolicyIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *AccessPolicyProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  org.AccessPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.AccessPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.AccessPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.AccessPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.AccessPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *AccessPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.AccessPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.AccessPolicyAddedEvent:
+		policyEvent = e.AccessPolicyAddedEvent
+		isDefault = false
+	case *instance.AccessPolicyAddedEvent:
+		policyEvent = e.AccessPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-ooP4u", "reduce.wrong.event.type, %v", []eventstore.EventType{org.AccessPolicyAddedEventType, instance.AccessPolicyAddedEventType})
+	}
+	return crdb.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(AccessPolicyCreationDateCol, policyEvent.CreationDate()),
+			handler.NewCol(AccessPolicyChangeDateCol, policyEvent.CreationDate()),
+			handler.NewCol(AccessPolicySequenceCol, policyEvent.Sequence()),
+			handler.NewCol(AccessPolicyIDCol, policyEvent.Aggregate().ID),
+			handler.NewCol(AccessPolicyStateCol, domain.PolicyStateActive),
+			handler.NewCol(AccessPolicyRulesCol, policyEvent.Rules),
+			handler.NewCol(AccessPolicyIsDefaultCol, isDefault),
+			handler.NewCol(AccessPolicyResourceOwnerCol, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(AccessPolicyInstanceIDCol, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *AccessPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.AccessPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.AccessPolicyChangedEvent:
+		policyEvent = e.AccessPolicyChangedEvent
+	case *instance.AccessPolicyChangedEvent:
+		policyEvent = e.AccessPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ea5ah", "reduce.wrong.event.type, %v", []eventstore.EventType{org.AccessPolicyChangedEventType, instance.AccessPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(AccessPolicyChangeDateCol, policyEvent.CreationDate()),
+		handler.NewCol(AccessPolicySequenceCol, policyEvent.Sequence()),
+	}
+	if policyEvent.Rules != nil {
+		cols = append(cols, handler.NewCol(AccessPolicyRulesCol, *policyEvent.Rules))
+	}
+	return crdb.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(AccessPolicyIDCol, policyEvent.Aggregate().ID),
+		}), nil
+}
+
+func (p *AccessPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.AccessPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Xoo6j", "reduce.wrong.event.type %s", org.AccessPolicyRemovedEventType)
+	}
+	return crdb.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(AccessPolicyIDCol, policyEvent.Aggregate().ID),
+		}), nil
+}
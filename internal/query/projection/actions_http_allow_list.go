@@ -0,0 +1,77 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+const (
+	ActionsHTTPAllowListTable = "projections.actions_http_allow_lists"
+
+	ActionsHTTPAllowListAggregateIDCol = "aggregate_id"
+	ActionsHTTPAllowListChangeDateCol  = "change_date"
+	ActionsHTTPAllowListSequenceCol    = "sequence"
+	ActionsHTTPAllowListInstanceIDCol  = "instance_id"
+	ActionsHTTPAllowListHostsCol       = "hosts"
+)
+
+type ActionsHTTPAllowListProjection struct {
+	crdb.StatementHandler
+}
+
+func NewActionsHTTPAllowListProjection(ctx context.Context, config crdb.StatementHandlerConfig) *ActionsHTTPAllowListProjection {
+	p := new(ActionsHTTPAllowListProjection)
+	config.ProjectionName = ActionsHTTPAllowListTable
+	config.Reducers = p.reducers()
+	config.InitCheck = crdb.NewTableCheck(
+		crdb.NewTable([]*crdb.Column{
+			crdb.NewColumn(ActionsHTTPAllowListAggregateIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionsHTTPAllowListChangeDateCol, crdb.ColumnTypeTimestamp),
+			crdb.NewColumn(ActionsHTTPAllowListSequenceCol, crdb.ColumnTypeInt64),
+			crdb.NewColumn(ActionsHTTPAllowListInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(ActionsHTTPAllowListHostsCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(ActionsHTTPAllowListInstanceIDCol, ActionsHTTPAllowListAggregateIDCol),
+		),
+	)
+	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
+	return p
+}
+
+func (p *ActionsHTTPAllowListProjection) reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventRedusers: []handler.EventReducer{
+				{
+					Event:  instance.ActionsHTTPAllowListSetEventType,
+					Reduce: p.reduceSet,
+				},
+			},
+		},
+	}
+}
+
+func (p *ActionsHTTPAllowListProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*instance.ActionsHTTPAllowListSetEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ooR0i", "reduce.wrong.event.type %s", instance.ActionsHTTPAllowListSetEventType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionsHTTPAllowListAggregateIDCol, e.Aggregate().ID),
+			handler.NewCol(ActionsHTTPAllowListChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionsHTTPAllowListSequenceCol, e.Sequence()),
+			handler.NewCol(ActionsHTTPAllowListInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(ActionsHTTPAllowListHostsCol, pq.StringArray(e.Hosts)),
+		},
+	), nil
+}
@@ -0,0 +1,101 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+)
+
+func TestActionsHTTPAllowListProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.ActionsHTTPAllowListSetEventType),
+					instance.AggregateType,
+					[]byte(`{"hosts": ["entitlements.example.com", "localhost:8080"]}`),
+				), instance.ActionsHTTPAllowListSetEventMapper),
+			},
+			reduce: (&ActionsHTTPAllowListProjection{}).reduceSet,
+			want: wantReduce{
+				projection:       ActionsHTTPAllowListTable,
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO projections.actions_http_allow_lists (aggregate_id, change_date, sequence, instance_id, hosts) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								pq.StringArray{"entitlements.example.com", "localhost:8080"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceSet empty",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.ActionsHTTPAllowListSetEventType),
+					instance.AggregateType,
+					[]byte(`{"hosts": []}`),
+				), instance.ActionsHTTPAllowListSetEventMapper),
+			},
+			reduce: (&ActionsHTTPAllowListProjection{}).reduceSet,
+			want: wantReduce{
+				projection:       ActionsHTTPAllowListTable,
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO projections.actions_http_allow_lists (aggregate_id, change_date, sequence, instance_id, hosts) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								pq.StringArray{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -265,6 +265,94 @@ func TestAppProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "project.reduceSAMLConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.SAMLConfigAddedType),
+					project.AggregateType,
+					[]byte(`{
+					"appId": "app-id",
+					"entityId": "https://sp.example.com/metadata",
+					"metadata": "PHhtbD4=",
+					"metadataUrl": "https://sp.example.com/metadata"
+				}`),
+				), project.SAMLConfigAddedEventMapper),
+			},
+			reduce: (&AppProjection{}).reduceSAMLConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       AppProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps_saml_configs (app_id, instance_id, entity_id, metadata, metadata_url) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								"https://sp.example.com/metadata",
+								[]byte("<xml>"),
+								"https://sp.example.com/metadata",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project.reduceSAMLConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.SAMLConfigChangedType),
+					project.AggregateType,
+					[]byte(`{
+					"appId": "app-id",
+					"entityId": "https://sp.example.com/metadata",
+					"metadata": "PHhtbD4="
+				}`),
+				), project.SAMLConfigChangedEventMapper),
+			},
+			reduce: (&AppProjection{}).reduceSAMLConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("project"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       AppProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.apps_saml_configs SET (entity_id, metadata) = ($1, $2) WHERE (app_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"https://sp.example.com/metadata",
+								[]byte("<xml>"),
+								"app-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "project.reduceAPIConfigChanged",
 			args: args{
@@ -395,7 +483,12 @@ func TestAppProjection_reduces(t *testing.T) {
                         "idTokenRoleAssertion": true,
                         "idTokenUserinfoAssertion": true,
                         "clockSkew": 1000,
-                        "additionalOrigins": ["origin.one.ch", "origin.two.ch"]
+                        "additionalOrigins": ["origin.one.ch", "origin.two.ch"],
+                        "requirePushedAuthRequest": true,
+                        "requireRequestObject": true,
+                        "backChannelLogoutURI": "https://app.ch/logout/backchannel",
+                        "frontChannelLogoutURI": "https://app.ch/logout/frontchannel",
+                        "minLevelOfAssurance": 2
 		}`),
 				), project.OIDCConfigAddedEventMapper),
 			},
@@ -408,7 +501,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)",
+							expectedStmt: "INSERT INTO projections.apps_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, require_pushed_auth_request, require_request_object, back_channel_logout_uri, front_channel_logout_uri, min_level_of_assurance) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
@@ -428,6 +521,11 @@ func TestAppProjection_reduces(t *testing.T) {
 								true,
 								1 * time.Microsecond,
 								pq.StringArray{"origin.one.ch", "origin.two.ch"},
+								true,
+								true,
+								"https://app.ch/logout/backchannel",
+								"https://app.ch/logout/frontchannel",
+								domain.LevelOfAssuranceMFA,
 							},
 						},
 						{
@@ -464,7 +562,12 @@ func TestAppProjection_reduces(t *testing.T) {
                         "idTokenRoleAssertion": true,
                         "idTokenUserinfoAssertion": true,
                         "clockSkew": 1000,
-                        "additionalOrigins": ["origin.one.ch", "origin.two.ch"]
+                        "additionalOrigins": ["origin.one.ch", "origin.two.ch"],
+                        "requirePushedAuthRequest": true,
+                        "requireRequestObject": true,
+                        "backChannelLogoutURI": "https://app.ch/logout/backchannel",
+                        "frontChannelLogoutURI": "https://app.ch/logout/frontchannel",
+                        "minLevelOfAssurance": 2
 		}`),
 				), project.OIDCConfigChangedEventMapper),
 			},
@@ -477,7 +580,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.apps_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) WHERE (app_id = $15) AND (instance_id = $16)",
+							expectedStmt: "UPDATE projections.apps_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, require_pushed_auth_request, require_request_object, back_channel_logout_uri, front_channel_logout_uri, min_level_of_assurance) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) WHERE (app_id = $20) AND (instance_id = $21)",
 							expectedArgs: []interface{}{
 								domain.OIDCVersionV1,
 								pq.StringArray{"redirect.one.ch", "redirect.two.ch"},
@@ -493,6 +596,11 @@ func TestAppProjection_reduces(t *testing.T) {
 								true,
 								1 * time.Microsecond,
 								pq.StringArray{"origin.one.ch", "origin.two.ch"},
+								true,
+								true,
+								"https://app.ch/logout/backchannel",
+								"https://app.ch/logout/frontchannel",
+								domain.LevelOfAssuranceMFA,
 								"app-id",
 								"instance-id",
 							},
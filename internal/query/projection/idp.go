@@ -19,9 +19,13 @@ const (
 	IDPTable     = "projections.idps"
 	IDPOIDCTable = IDPTable + "_" + IDPOIDCSuffix
 	IDPJWTTable  = IDPTable + "_" + IDPJWTSuffix
+	IDPSAMLTable = IDPTable + "_" + IDPSAMLSuffix
+	IDPLDAPTable = IDPTable + "_" + IDPLDAPSuffix
 
 	IDPOIDCSuffix = "oidc_config"
 	IDPJWTSuffix  = "jwt_config"
+	IDPSAMLSuffix = "saml_config"
+	IDPLDAPSuffix = "ldap_config"
 
 	IDPIDCol            = "id"
 	IDPCreationDateCol  = "creation_date"
@@ -53,6 +57,30 @@ const (
 	JWTConfigKeysEndpointCol = "keys_endpoint"
 	JWTConfigHeaderNameCol   = "header_name"
 	JWTConfigEndpointCol     = "endpoint"
+
+	SAMLConfigIDPIDCol       = "idp_id"
+	SAMLConfigInstanceIDCol  = "instance_id"
+	SAMLConfigEntityIDCol    = "entity_id"
+	SAMLConfigMetadataCol    = "metadata"
+	SAMLConfigMetadataURLCol = "metadata_url"
+
+	LDAPConfigIDPIDCol                      = "idp_id"
+	LDAPConfigInstanceIDCol                 = "instance_id"
+	LDAPConfigURLCol                        = "url"
+	LDAPConfigStartTLSCol                   = "start_tls"
+	LDAPConfigRootCACol                     = "root_ca"
+	LDAPConfigBaseDNCol                     = "base_dn"
+	LDAPConfigBindDNCol                     = "bind_dn"
+	LDAPConfigBindPasswordCol               = "bind_password"
+	LDAPConfigUserObjectClassCol            = "user_object_class"
+	LDAPConfigUserFiltersCol                = "user_filters"
+	LDAPConfigIDAttributeCol                = "id_attribute"
+	LDAPConfigPreferredUsernameAttributeCol = "preferred_username_attribute"
+	LDAPConfigFirstNameAttributeCol         = "first_name_attribute"
+	LDAPConfigLastNameAttributeCol          = "last_name_attribute"
+	LDAPConfigDisplayNameAttributeCol       = "display_name_attribute"
+	LDAPConfigEmailAttributeCol             = "email_attribute"
+	LDAPConfigPhoneAttributeCol             = "phone_attribute"
 )
 
 type IDPProjection struct {
@@ -110,6 +138,40 @@ func NewIDPProjection(ctx context.Context, config crdb.StatementHandlerConfig) *
 			IDPJWTSuffix,
 			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys("fk_jwt_ref_idp")),
 		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(SAMLConfigIDPIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLConfigInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(SAMLConfigEntityIDCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(SAMLConfigMetadataCol, crdb.ColumnTypeBytes, crdb.Nullable()),
+			crdb.NewColumn(SAMLConfigMetadataURLCol, crdb.ColumnTypeText, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(SAMLConfigIDPIDCol),
+			IDPSAMLSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys("fk_saml_ref_idp")),
+		),
+		crdb.NewSuffixedTable([]*crdb.Column{
+			crdb.NewColumn(LDAPConfigIDPIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(LDAPConfigInstanceIDCol, crdb.ColumnTypeText),
+			crdb.NewColumn(LDAPConfigURLCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigStartTLSCol, crdb.ColumnTypeBool, crdb.Default(false)),
+			crdb.NewColumn(LDAPConfigRootCACol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigBaseDNCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigBindDNCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigBindPasswordCol, crdb.ColumnTypeJSONB, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigUserObjectClassCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigUserFiltersCol, crdb.ColumnTypeTextArray, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigIDAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigPreferredUsernameAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigFirstNameAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigLastNameAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigDisplayNameAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigEmailAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+			crdb.NewColumn(LDAPConfigPhoneAttributeCol, crdb.ColumnTypeText, crdb.Nullable()),
+		},
+			crdb.NewPrimaryKey(LDAPConfigIDPIDCol),
+			IDPLDAPSuffix,
+			crdb.WithForeignKey(crdb.NewForeignKeyOfPublicKeys("fk_ldap_ref_idp")),
+		),
 	)
 	p.StatementHandler = crdb.NewStatementHandler(ctx, config)
 	return p
@@ -156,6 +218,22 @@ func (p *IDPProjection) reducers() []handler.AggregateReducer {
 					Event:  instance.IDPJWTConfigChangedEventType,
 					Reduce: p.reduceJWTConfigChanged,
 				},
+				{
+					Event:  instance.IDPSAMLConfigAddedEventType,
+					Reduce: p.reduceSAMLConfigAdded,
+				},
+				{
+					Event:  instance.IDPSAMLConfigChangedEventType,
+					Reduce: p.reduceSAMLConfigChanged,
+				},
+				{
+					Event:  instance.IDPLDAPConfigAddedEventType,
+					Reduce: p.reduceLDAPConfigAdded,
+				},
+				{
+					Event:  instance.IDPLDAPConfigChangedEventType,
+					Reduce: p.reduceLDAPConfigChanged,
+				},
 			},
 		},
 		{
@@ -197,6 +275,22 @@ func (p *IDPProjection) reducers() []handler.AggregateReducer {
 					Event:  org.IDPJWTConfigChangedEventType,
 					Reduce: p.reduceJWTConfigChanged,
 				},
+				{
+					Event:  org.IDPSAMLConfigAddedEventType,
+					Reduce: p.reduceSAMLConfigAdded,
+				},
+				{
+					Event:  org.IDPSAMLConfigChangedEventType,
+					Reduce: p.reduceSAMLConfigChanged,
+				},
+				{
+					Event:  org.IDPLDAPConfigAddedEventType,
+					Reduce: p.reduceLDAPConfigAdded,
+				},
+				{
+					Event:  org.IDPLDAPConfigChangedEventType,
+					Reduce: p.reduceLDAPConfigChanged,
+				},
 			},
 		},
 	}
@@ -538,3 +632,221 @@ func (p *IDPProjection) reduceJWTConfigChanged(event eventstore.Event) (*handler
 		),
 	), nil
 }
+
+func (p *IDPProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idpconfig.SAMLConfigAddedEvent
+	switch e := event.(type) {
+	case *org.IDPSAMLConfigAddedEvent:
+		idpEvent = e.SAMLConfigAddedEvent
+	case *instance.IDPSAMLConfigAddedEvent:
+		idpEvent = e.SAMLConfigAddedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Hd2gq", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPSAMLConfigAddedEventType, instance.IDPSAMLConfigAddedEventType})
+	}
+
+	return crdb.NewMultiStatement(&idpEvent,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTypeCol, domain.IDPConfigTypeSAML),
+			},
+			[]handler.Condition{
+				handler.NewCond(IDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(IDPInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(SAMLConfigIDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCol(SAMLConfigInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(SAMLConfigEntityIDCol, idpEvent.EntityID),
+				handler.NewCol(SAMLConfigMetadataCol, idpEvent.Metadata),
+				handler.NewCol(SAMLConfigMetadataURLCol, idpEvent.MetadataURL),
+			},
+			crdb.WithTableSuffix(IDPSAMLSuffix),
+		),
+	), nil
+}
+
+func (p *IDPProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idpconfig.SAMLConfigChangedEvent
+	switch e := event.(type) {
+	case *org.IDPSAMLConfigChangedEvent:
+		idpEvent = e.SAMLConfigChangedEvent
+	case *instance.IDPSAMLConfigChangedEvent:
+		idpEvent = e.SAMLConfigChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Sw3fb", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPSAMLConfigChangedEventType, instance.IDPSAMLConfigChangedEventType})
+	}
+
+	cols := make([]handler.Column, 0, 3)
+
+	if idpEvent.EntityID != nil {
+		cols = append(cols, handler.NewCol(SAMLConfigEntityIDCol, *idpEvent.EntityID))
+	}
+	if idpEvent.Metadata != nil {
+		cols = append(cols, handler.NewCol(SAMLConfigMetadataCol, idpEvent.Metadata))
+	}
+	if idpEvent.MetadataURL != nil {
+		cols = append(cols, handler.NewCol(SAMLConfigMetadataURLCol, *idpEvent.MetadataURL))
+	}
+
+	if len(cols) == 0 {
+		return crdb.NewNoOpStatement(&idpEvent), nil
+	}
+
+	return crdb.NewMultiStatement(&idpEvent,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPSequenceCol, idpEvent.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(IDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(IDPInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			cols,
+			[]handler.Condition{
+				handler.NewCond(SAMLConfigIDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(SAMLConfigInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(IDPSAMLSuffix),
+		),
+	), nil
+}
+
+func (p *IDPProjection) reduceLDAPConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idpconfig.LDAPConfigAddedEvent
+	switch e := event.(type) {
+	case *org.IDPLDAPConfigAddedEvent:
+		idpEvent = e.LDAPConfigAddedEvent
+	case *instance.IDPLDAPConfigAddedEvent:
+		idpEvent = e.LDAPConfigAddedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Lq2fd", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPLDAPConfigAddedEventType, instance.IDPLDAPConfigAddedEventType})
+	}
+
+	return crdb.NewMultiStatement(&idpEvent,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTypeCol, domain.IDPConfigTypeLDAP),
+			},
+			[]handler.Condition{
+				handler.NewCond(IDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(IDPInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(LDAPConfigIDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCol(LDAPConfigInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(LDAPConfigURLCol, idpEvent.URL),
+				handler.NewCol(LDAPConfigStartTLSCol, idpEvent.StartTLS),
+				handler.NewCol(LDAPConfigRootCACol, idpEvent.RootCA),
+				handler.NewCol(LDAPConfigBaseDNCol, idpEvent.BaseDN),
+				handler.NewCol(LDAPConfigBindDNCol, idpEvent.BindDN),
+				handler.NewCol(LDAPConfigBindPasswordCol, idpEvent.BindPassword),
+				handler.NewCol(LDAPConfigUserObjectClassCol, idpEvent.UserObjectClass),
+				handler.NewCol(LDAPConfigUserFiltersCol, pq.StringArray(idpEvent.UserFilters)),
+				handler.NewCol(LDAPConfigIDAttributeCol, idpEvent.IDAttribute),
+				handler.NewCol(LDAPConfigPreferredUsernameAttributeCol, idpEvent.PreferredUsernameAttribute),
+				handler.NewCol(LDAPConfigFirstNameAttributeCol, idpEvent.FirstNameAttribute),
+				handler.NewCol(LDAPConfigLastNameAttributeCol, idpEvent.LastNameAttribute),
+				handler.NewCol(LDAPConfigDisplayNameAttributeCol, idpEvent.DisplayNameAttribute),
+				handler.NewCol(LDAPConfigEmailAttributeCol, idpEvent.EmailAttribute),
+				handler.NewCol(LDAPConfigPhoneAttributeCol, idpEvent.PhoneAttribute),
+			},
+			crdb.WithTableSuffix(IDPLDAPSuffix),
+		),
+	), nil
+}
+
+func (p *IDPProjection) reduceLDAPConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idpconfig.LDAPConfigChangedEvent
+	switch e := event.(type) {
+	case *org.IDPLDAPConfigChangedEvent:
+		idpEvent = e.LDAPConfigChangedEvent
+	case *instance.IDPLDAPConfigChangedEvent:
+		idpEvent = e.LDAPConfigChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Tw3gs", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPLDAPConfigChangedEventType, instance.IDPLDAPConfigChangedEventType})
+	}
+
+	cols := make([]handler.Column, 0, 15)
+
+	if idpEvent.URL != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigURLCol, *idpEvent.URL))
+	}
+	if idpEvent.StartTLS != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigStartTLSCol, *idpEvent.StartTLS))
+	}
+	if idpEvent.RootCA != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigRootCACol, *idpEvent.RootCA))
+	}
+	if idpEvent.BaseDN != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigBaseDNCol, *idpEvent.BaseDN))
+	}
+	if idpEvent.BindDN != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigBindDNCol, *idpEvent.BindDN))
+	}
+	if idpEvent.BindPassword != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigBindPasswordCol, idpEvent.BindPassword))
+	}
+	if idpEvent.UserObjectClass != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigUserObjectClassCol, *idpEvent.UserObjectClass))
+	}
+	if idpEvent.UserFilters != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigUserFiltersCol, pq.StringArray(idpEvent.UserFilters)))
+	}
+	if idpEvent.IDAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigIDAttributeCol, *idpEvent.IDAttribute))
+	}
+	if idpEvent.PreferredUsernameAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigPreferredUsernameAttributeCol, *idpEvent.PreferredUsernameAttribute))
+	}
+	if idpEvent.FirstNameAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigFirstNameAttributeCol, *idpEvent.FirstNameAttribute))
+	}
+	if idpEvent.LastNameAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigLastNameAttributeCol, *idpEvent.LastNameAttribute))
+	}
+	if idpEvent.DisplayNameAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigDisplayNameAttributeCol, *idpEvent.DisplayNameAttribute))
+	}
+	if idpEvent.EmailAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigEmailAttributeCol, *idpEvent.EmailAttribute))
+	}
+	if idpEvent.PhoneAttribute != nil {
+		cols = append(cols, handler.NewCol(LDAPConfigPhoneAttributeCol, *idpEvent.PhoneAttribute))
+	}
+
+	if len(cols) == 0 {
+		return crdb.NewNoOpStatement(&idpEvent), nil
+	}
+
+	return crdb.NewMultiStatement(&idpEvent,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPSequenceCol, idpEvent.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(IDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(IDPInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			cols,
+			[]handler.Condition{
+				handler.NewCond(LDAPConfigIDPIDCol, idpEvent.IDPConfigID),
+				handler.NewCond(LDAPConfigInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(IDPLDAPSuffix),
+		),
+	), nil
+}
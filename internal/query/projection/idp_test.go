@@ -886,6 +886,510 @@ func TestIDPProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "instance.reduceSAMLConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPSAMLConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"entityId": "https://idp.example.com/metadata",
+	"metadata": "PG1ldGFkYXRhLz4=",
+	"metadataUrl": "https://idp.example.com/metadata"
+}`),
+				), instance.IDPSAMLConfigAddedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence, type) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.IDPConfigTypeSAML,
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idps_saml_config (idp_id, instance_id, entity_id, metadata, metadata_url) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"idp-config-id",
+								"instance-id",
+								"https://idp.example.com/metadata",
+								[]byte("<metadata/>"),
+								"https://idp.example.com/metadata",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceSAMLConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPSAMLConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"entityId": "https://idp.example.com/metadata",
+	"metadata": "PG1ldGFkYXRhLz4="
+}`),
+				), instance.IDPSAMLConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idps_saml_config SET (entity_id, metadata) = ($1, $2) WHERE (idp_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"https://idp.example.com/metadata",
+								[]byte("<metadata/>"),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceSAMLConfigChanged: no op",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPSAMLConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id"
+}`),
+				), instance.IDPSAMLConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "org.reduceSAMLConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPSAMLConfigAddedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"entityId": "https://idp.example.com/metadata",
+	"metadata": "PG1ldGFkYXRhLz4=",
+	"metadataUrl": "https://idp.example.com/metadata"
+}`),
+				), org.IDPSAMLConfigAddedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence, type) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.IDPConfigTypeSAML,
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idps_saml_config (idp_id, instance_id, entity_id, metadata, metadata_url) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"idp-config-id",
+								"instance-id",
+								"https://idp.example.com/metadata",
+								[]byte("<metadata/>"),
+								"https://idp.example.com/metadata",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceSAMLConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPSAMLConfigChangedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"entityId": "https://idp.example.com/metadata",
+	"metadata": "PG1ldGFkYXRhLz4="
+}`),
+				), org.IDPSAMLConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idps_saml_config SET (entity_id, metadata) = ($1, $2) WHERE (idp_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"https://idp.example.com/metadata",
+								[]byte("<metadata/>"),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceSAMLConfigChanged: no op",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPSAMLConfigChangedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id"
+}`),
+				), org.IDPSAMLConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceSAMLConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "instance.reduceLDAPConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPLDAPConfigAddedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"ldapUrl": "ldap://ad.example.com",
+	"startTls": true,
+	"rootCa": "root-ca",
+	"baseDn": "dc=example,dc=com",
+	"bindDn": "cn=service,dc=example,dc=com",
+	"bindPassword": {
+        "cryptoType": 0,
+        "algorithm": "RSA-265",
+        "keyId": "key-id"
+    },
+	"userObjectClass": "user",
+	"userFilters": ["sAMAccountName"],
+	"idAttribute": "objectGUID",
+	"preferredUsernameAttribute": "sAMAccountName",
+	"firstNameAttribute": "givenName",
+	"lastNameAttribute": "sn",
+	"displayNameAttribute": "displayName",
+	"emailAttribute": "mail",
+	"phoneAttribute": "mobile"
+}`),
+				), instance.IDPLDAPConfigAddedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence, type) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.IDPConfigTypeLDAP,
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idps_ldap_config (idp_id, instance_id, url, start_tls, root_ca, base_dn, bind_dn, bind_password, user_object_class, user_filters, id_attribute, preferred_username_attribute, first_name_attribute, last_name_attribute, display_name_attribute, email_attribute, phone_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"idp-config-id",
+								"instance-id",
+								"ldap://ad.example.com",
+								true,
+								"root-ca",
+								"dc=example,dc=com",
+								"cn=service,dc=example,dc=com",
+								anyArg{},
+								"user",
+								pq.StringArray{"sAMAccountName"},
+								"objectGUID",
+								"sAMAccountName",
+								"givenName",
+								"sn",
+								"displayName",
+								"mail",
+								"mobile",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceLDAPConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPLDAPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"ldapUrl": "ldap://ad2.example.com",
+	"startTls": true,
+	"userFilters": ["sAMAccountName", "mail"],
+	"phoneAttribute": ""
+}`),
+				), instance.IDPLDAPConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idps_ldap_config SET (url, start_tls, user_filters, phone_attribute) = ($1, $2, $3, $4) WHERE (idp_id = $5) AND (instance_id = $6)",
+							expectedArgs: []interface{}{
+								"ldap://ad2.example.com",
+								true,
+								pq.StringArray{"sAMAccountName", "mail"},
+								"",
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance.reduceLDAPConfigChanged: no op",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(instance.IDPLDAPConfigChangedEventType),
+					instance.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id"
+}`),
+				), instance.IDPLDAPConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("instance"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "org.reduceLDAPConfigAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPLDAPConfigAddedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"ldapUrl": "ldaps://ad.example.com",
+	"baseDn": "dc=example,dc=com",
+	"bindDn": "cn=service,dc=example,dc=com",
+	"bindPassword": {
+        "cryptoType": 0,
+        "algorithm": "RSA-265",
+        "keyId": "key-id"
+    },
+	"userObjectClass": "user",
+	"userFilters": ["sAMAccountName"],
+	"idAttribute": "objectGUID",
+	"preferredUsernameAttribute": "sAMAccountName",
+	"firstNameAttribute": "givenName",
+	"lastNameAttribute": "sn",
+	"displayNameAttribute": "displayName",
+	"emailAttribute": "mail",
+	"phoneAttribute": "mobile"
+}`),
+				), org.IDPLDAPConfigAddedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigAdded,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence, type) = ($1, $2, $3) WHERE (id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.IDPConfigTypeLDAP,
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idps_ldap_config (idp_id, instance_id, url, start_tls, root_ca, base_dn, bind_dn, bind_password, user_object_class, user_filters, id_attribute, preferred_username_attribute, first_name_attribute, last_name_attribute, display_name_attribute, email_attribute, phone_attribute) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"idp-config-id",
+								"instance-id",
+								"ldaps://ad.example.com",
+								false,
+								"",
+								"dc=example,dc=com",
+								"cn=service,dc=example,dc=com",
+								anyArg{},
+								"user",
+								pq.StringArray{"sAMAccountName"},
+								"objectGUID",
+								"sAMAccountName",
+								"givenName",
+								"sn",
+								"displayName",
+								"mail",
+								"mobile",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceLDAPConfigChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPLDAPConfigChangedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id",
+	"ldapUrl": "ldaps://ad2.example.com",
+	"userFilters": ["sAMAccountName", "mail"],
+	"phoneAttribute": ""
+}`),
+				), org.IDPLDAPConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idps SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idps_ldap_config SET (url, user_filters, phone_attribute) = ($1, $2, $3) WHERE (idp_id = $4) AND (instance_id = $5)",
+							expectedArgs: []interface{}{
+								"ldaps://ad2.example.com",
+								pq.StringArray{"sAMAccountName", "mail"},
+								"",
+								"idp-config-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.reduceLDAPConfigChanged: no op",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.IDPLDAPConfigChangedEventType),
+					org.AggregateType,
+					[]byte(`{
+	"idpConfigId": "idp-config-id"
+}`),
+				), org.IDPLDAPConfigChangedEventMapper),
+			},
+			reduce: (&IDPProjection{}).reduceLDAPConfigChanged,
+			want: wantReduce{
+				aggregateType:    eventstore.AggregateType("org"),
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IDPTable,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
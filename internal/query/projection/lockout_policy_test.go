@@ -2,6 +2,7 @@ package projection
 
 import (
 	"testing"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
@@ -30,7 +31,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
-						"showLockOutFailures": true
+						"maxOTPAttempts": 5,
+						"maxU2FAttempts": 3,
+						"showLockOutFailures": true,
+						"autoUnlockDuration": 3600000000000
 }`),
 				), org.LockoutPolicyAddedEventMapper),
 			},
@@ -43,7 +47,7 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, show_failure, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, max_otp_attempts, max_u2f_attempts, show_failure, auto_unlock_duration, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -51,7 +55,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								uint64(10),
+								uint64(5),
+								uint64(3),
 								true,
+								time.Hour,
 								false,
 								"ro-id",
 								"instance-id",
@@ -70,7 +77,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					org.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
-						"showLockOutFailures": true
+						"maxOTPAttempts": 5,
+						"maxU2FAttempts": 3,
+						"showLockOutFailures": true,
+						"autoUnlockDuration": 3600000000000
 		}`),
 				), org.LockoutPolicyChangedEventMapper),
 			},
@@ -82,12 +92,15 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.lockout_policies SET (change_date, sequence, max_password_attempts, show_failure) = ($1, $2, $3, $4) WHERE (id = $5)",
+							expectedStmt: "UPDATE projections.lockout_policies SET (change_date, sequence, max_password_attempts, max_otp_attempts, max_u2f_attempts, show_failure, auto_unlock_duration) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
+								uint64(5),
+								uint64(3),
 								true,
+								time.Hour,
 								"agg-id",
 							},
 						},
@@ -131,7 +144,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					instance.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
-						"showLockOutFailures": true
+						"maxOTPAttempts": 5,
+						"maxU2FAttempts": 3,
+						"showLockOutFailures": true,
+						"autoUnlockDuration": 3600000000000
 					}`),
 				), instance.LockoutPolicyAddedEventMapper),
 			},
@@ -143,7 +159,7 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, show_failure, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.lockout_policies (creation_date, change_date, sequence, id, state, max_password_attempts, max_otp_attempts, max_u2f_attempts, show_failure, auto_unlock_duration, is_default, resource_owner, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								anyArg{},
@@ -151,7 +167,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 								"agg-id",
 								domain.PolicyStateActive,
 								uint64(10),
+								uint64(5),
+								uint64(3),
 								true,
+								time.Hour,
 								true,
 								"ro-id",
 								"instance-id",
@@ -170,7 +189,10 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 					instance.AggregateType,
 					[]byte(`{
 						"maxPasswordAttempts": 10,
-						"showLockOutFailures": true
+						"maxOTPAttempts": 5,
+						"maxU2FAttempts": 3,
+						"showLockOutFailures": true,
+						"autoUnlockDuration": 3600000000000
 					}`),
 				), instance.LockoutPolicyChangedEventMapper),
 			},
@@ -182,12 +204,15 @@ func TestLockoutPolicyProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.lockout_policies SET (change_date, sequence, max_password_attempts, show_failure) = ($1, $2, $3, $4) WHERE (id = $5)",
+							expectedStmt: "UPDATE projections.lockout_policies SET (change_date, sequence, max_password_attempts, max_otp_attempts, max_u2f_attempts, show_failure, auto_unlock_duration) = ($1, $2, $3, $4, $5, $6, $7) WHERE (id = $8)",
 							expectedArgs: []interface{}{
 								anyArg{},
 								uint64(15),
 								uint64(10),
+								uint64(5),
+								uint64(3),
 								true,
+								time.Hour,
 								"agg-id",
 							},
 						},
@@ -233,7 +233,10 @@ func isMessageTemplate(template string) bool {
 		template == domain.VerifyEmailMessageType ||
 		template == domain.VerifyPhoneMessageType ||
 		template == domain.DomainClaimedMessageType ||
-		template == domain.PasswordlessRegistrationMessageType
+		template == domain.PasswordlessRegistrationMessageType ||
+		template == domain.VerifySMSOTPMessageType ||
+		template == domain.VerifyEmailOTPMessageType ||
+		template == domain.MagicLinkMessageType
 }
 func isTitle(key string) bool {
 	return key == domain.MessageTitle
@@ -18,21 +18,7 @@ const (
 )
 
 func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, config Config, keyEncryptionAlgorithm crypto.EncryptionAlgorithm) error {
-	projectionConfig := crdb.StatementHandlerConfig{
-		ProjectionHandlerConfig: handler.ProjectionHandlerConfig{
-			HandlerConfig: handler.HandlerConfig{
-				Eventstore: es,
-			},
-			RequeueEvery:     config.RequeueEvery,
-			RetryFailedAfter: config.RetryFailedAfter,
-		},
-		Client:            sqlClient,
-		SequenceTable:     CurrentSeqTable,
-		LockTable:         LocksTable,
-		FailedEventsTable: FailedEventsTable,
-		MaxFailureCount:   config.MaxFailureCount,
-		BulkLimit:         config.BulkLimit,
-	}
+	projectionConfig := statementHandlerConfig(sqlClient, es, config)
 
 	NewOrgProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["orgs"]))
 	NewActionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["actions"]))
@@ -41,6 +27,7 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 	NewPasswordComplexityProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_complexities"]))
 	NewPasswordAgeProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["password_age_policy"]))
 	NewLockoutPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["lockout_policy"]))
+	NewAccessPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["access_policies"]))
 	NewPrivacyPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["privacy_policy"]))
 	NewDomainPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["org_iam_policy"]))
 	NewLabelPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["label_policy"]))
@@ -72,11 +59,36 @@ func Start(ctx context.Context, sqlClient *sql.DB, es *eventstore.Eventstore, co
 	NewSMTPConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["smtp_configs"]))
 	NewSMSConfigProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sms_config"]))
 	NewOIDCSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["oidc_settings"]))
+	NewActionsHTTPAllowListProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["actions_http_allow_lists"]))
 	NewDebugNotificationProviderProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_notification_provider"]))
 	NewKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm)
 	return nil
 }
 
+// NewStatementHandlerConfig returns the config of a statement handler which is not part of the projections (e.g. action event flows)
+// the handler uses the same sequence, lock and failed events tables as the projections
+func NewStatementHandlerConfig(sqlClient *sql.DB, es *eventstore.Eventstore, config Config, handlerName string) crdb.StatementHandlerConfig {
+	return applyCustomConfig(statementHandlerConfig(sqlClient, es, config), config.Customizations[handlerName])
+}
+
+func statementHandlerConfig(sqlClient *sql.DB, es *eventstore.Eventstore, config Config) crdb.StatementHandlerConfig {
+	return crdb.StatementHandlerConfig{
+		ProjectionHandlerConfig: handler.ProjectionHandlerConfig{
+			HandlerConfig: handler.HandlerConfig{
+				Eventstore: es,
+			},
+			RequeueEvery:     config.RequeueEvery,
+			RetryFailedAfter: config.RetryFailedAfter,
+		},
+		Client:            sqlClient,
+		SequenceTable:     CurrentSeqTable,
+		LockTable:         LocksTable,
+		FailedEventsTable: FailedEventsTable,
+		MaxFailureCount:   config.MaxFailureCount,
+		BulkLimit:         config.BulkLimit,
+	}
+}
+
 func applyCustomConfig(config crdb.StatementHandlerConfig, customConfig CustomConfig) crdb.StatementHandlerConfig {
 	if customConfig.BulkLimit != nil {
 		config.BulkLimit = *customConfig.BulkLimit
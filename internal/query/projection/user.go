@@ -52,6 +52,9 @@ const (
 	HumanPhoneCol           = "phone"
 	HumanIsPhoneVerifiedCol = "is_phone_verified"
 
+	// password
+	HumanPasswordChangedCol = "password_changed"
+
 	// machine
 	UserMachineSuffix        = "machines"
 	MachineUserIDCol         = "user_id"
@@ -95,6 +98,7 @@ func NewUserProjection(ctx context.Context, config crdb.StatementHandlerConfig)
 			crdb.NewColumn(HumanIsEmailVerifiedCol, crdb.ColumnTypeBool, crdb.Default(false)),
 			crdb.NewColumn(HumanPhoneCol, crdb.ColumnTypeText, crdb.Nullable()),
 			crdb.NewColumn(HumanIsPhoneVerifiedCol, crdb.ColumnTypeBool, crdb.Nullable()),
+			crdb.NewColumn(HumanPasswordChangedCol, crdb.ColumnTypeTimestamp, crdb.Nullable()),
 		},
 			crdb.NewPrimaryKey(HumanUserIDCol, HumanUserInstanceIDCol),
 			UserHumanSuffix,
@@ -224,6 +228,14 @@ func (p *UserProjection) reducers() []handler.AggregateReducer {
 					Event:  user.UserV1EmailVerifiedType,
 					Reduce: p.reduceHumanEmailVerified,
 				},
+				{
+					Event:  user.HumanPasswordChangedType,
+					Reduce: p.reduceHumanPasswordChanged,
+				},
+				{
+					Event:  user.UserV1PasswordChangedType,
+					Reduce: p.reduceHumanPasswordChanged,
+				},
 				{
 					Event:  user.HumanAvatarAddedType,
 					Reduce: p.reduceHumanAvatarAdded,
@@ -277,6 +289,7 @@ func (p *UserProjection) reduceHumanAdded(event eventstore.Event) (*handler.Stat
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: e.PhoneNumber, Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, &sql.NullTime{Time: e.CreationDate(), Valid: e.Secret != nil}),
 			},
 			crdb.WithTableSuffix(UserHumanSuffix),
 		),
@@ -315,6 +328,7 @@ func (p *UserProjection) reduceHumanRegistered(event eventstore.Event) (*handler
 				handler.NewCol(HumanGenderCol, &sql.NullInt16{Int16: int16(e.Gender), Valid: e.Gender.Specified()}),
 				handler.NewCol(HumanEmailCol, e.EmailAddress),
 				handler.NewCol(HumanPhoneCol, &sql.NullString{String: e.PhoneNumber, Valid: e.PhoneNumber != ""}),
+				handler.NewCol(HumanPasswordChangedCol, &sql.NullTime{Time: e.CreationDate(), Valid: e.Secret != nil}),
 			},
 			crdb.WithTableSuffix(UserHumanSuffix),
 		),
@@ -681,6 +695,37 @@ func (p *UserProjection) reduceHumanEmailVerified(event eventstore.Event) (*hand
 	), nil
 }
 
+func (p *UserProjection) reduceHumanPasswordChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanPasswordChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Oht7e", "reduce.wrong.event.type %s", user.HumanPasswordChangedType)
+	}
+
+	return crdb.NewMultiStatement(
+		e,
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(UserChangeDateCol, e.CreationDate()),
+				handler.NewCol(UserSequenceCol, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(UserIDCol, e.Aggregate().ID),
+				handler.NewCond(UserInstanceIDCol, e.Aggregate().InstanceID),
+			},
+		),
+		crdb.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(HumanPasswordChangedCol, e.CreationDate()),
+			},
+			[]handler.Condition{
+				handler.NewCond(HumanUserIDCol, e.Aggregate().ID),
+				handler.NewCond(HumanUserInstanceIDCol, e.Aggregate().InstanceID),
+			},
+			crdb.WithTableSuffix(UserHumanSuffix),
+		),
+	), nil
+}
+
 func (p *UserProjection) reduceHumanAvatarAdded(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*user.HumanAvatarAddedEvent)
 	if !ok {
@@ -14,16 +14,17 @@ import (
 const (
 	UserAuthMethodTable = "projections.user_auth_methods"
 
-	UserAuthMethodUserIDCol        = "user_id"
-	UserAuthMethodTypeCol          = "method_type"
-	UserAuthMethodTokenIDCol       = "token_id"
-	UserAuthMethodCreationDateCol  = "creation_date"
-	UserAuthMethodChangeDateCol    = "change_date"
-	UserAuthMethodSequenceCol      = "sequence"
-	UserAuthMethodResourceOwnerCol = "resource_owner"
-	UserAuthMethodInstanceIDCol    = "instance_id"
-	UserAuthMethodStateCol         = "state"
-	UserAuthMethodNameCol          = "name"
+	UserAuthMethodUserIDCol         = "user_id"
+	UserAuthMethodTypeCol           = "method_type"
+	UserAuthMethodTokenIDCol        = "token_id"
+	UserAuthMethodCreationDateCol   = "creation_date"
+	UserAuthMethodChangeDateCol     = "change_date"
+	UserAuthMethodSequenceCol       = "sequence"
+	UserAuthMethodResourceOwnerCol  = "resource_owner"
+	UserAuthMethodInstanceIDCol     = "instance_id"
+	UserAuthMethodStateCol          = "state"
+	UserAuthMethodNameCol           = "name"
+	UserAuthMethodRemainingCodesCol = "remaining_codes"
 )
 
 type UserAuthMethodProjection struct {
@@ -46,6 +47,7 @@ func NewUserAuthMethodProjection(ctx context.Context, config crdb.StatementHandl
 			crdb.NewColumn(UserAuthMethodResourceOwnerCol, crdb.ColumnTypeText),
 			crdb.NewColumn(UserAuthMethodInstanceIDCol, crdb.ColumnTypeText),
 			crdb.NewColumn(UserAuthMethodNameCol, crdb.ColumnTypeText),
+			crdb.NewColumn(UserAuthMethodRemainingCodesCol, crdb.ColumnTypeInt64, crdb.Default(0)),
 		},
 			crdb.NewPrimaryKey(UserAuthMethodInstanceIDCol, UserAuthMethodUserIDCol, UserAuthMethodTypeCol, UserAuthMethodTokenIDCol),
 			crdb.WithIndex(crdb.NewIndex("ro_idx", []string{UserAuthMethodResourceOwnerCol})),
@@ -72,6 +74,14 @@ func (p *UserAuthMethodProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPAddedType,
 					Reduce: p.reduceInitAuthMethod,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailAddedType,
+					Reduce: p.reduceInitAuthMethod,
+				},
 				{
 					Event:  user.HumanPasswordlessTokenVerifiedType,
 					Reduce: p.reduceActivateEvent,
@@ -96,6 +106,26 @@ func (p *UserAuthMethodProjection) reducers() []handler.AggregateReducer {
 					Event:  user.HumanMFAOTPRemovedType,
 					Reduce: p.reduceRemoveAuthMethod,
 				},
+				{
+					Event:  user.HumanMFAOTPSMSRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
+				{
+					Event:  user.HumanMFAOTPEmailRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodesAddedType,
+					Reduce: p.reduceRecoveryCodesAdded,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodeCheckSucceededType,
+					Reduce: p.reduceRecoveryCodeUsed,
+				},
+				{
+					Event:  user.HumanMFARecoveryCodesRemovedType,
+					Reduce: p.reduceRemoveAuthMethod,
+				},
 			},
 		},
 	}
@@ -103,6 +133,7 @@ func (p *UserAuthMethodProjection) reducers() []handler.AggregateReducer {
 
 func (p *UserAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event) (*handler.Statement, error) {
 	tokenID := ""
+	state := domain.MFAStateNotReady
 	var methodType domain.UserAuthMethodType
 	switch e := event.(type) {
 	case *user.HumanPasswordlessAddedEvent:
@@ -113,6 +144,13 @@ func (p *UserAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPAddedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSAddedEvent:
+		// sms and email codes don't need a verification step, they're ready as soon as they're added
+		methodType = domain.UserAuthMethodTypeOTPSMS
+		state = domain.MFAStateReady
+	case *user.HumanOTPEmailAddedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
+		state = domain.MFAStateReady
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
 	}
@@ -127,7 +165,7 @@ func (p *UserAuthMethodProjection) reduceInitAuthMethod(event eventstore.Event)
 			handler.NewCol(UserAuthMethodInstanceIDCol, event.Aggregate().InstanceID),
 			handler.NewCol(UserAuthMethodUserIDCol, event.Aggregate().ID),
 			handler.NewCol(UserAuthMethodSequenceCol, event.Sequence()),
-			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateNotReady),
+			handler.NewCol(UserAuthMethodStateCol, state),
 			handler.NewCol(UserAuthMethodTypeCol, methodType),
 			handler.NewCol(UserAuthMethodNameCol, ""),
 		},
@@ -184,6 +222,12 @@ func (p *UserAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		tokenID = e.WebAuthNTokenID
 	case *user.HumanOTPRemovedEvent:
 		methodType = domain.UserAuthMethodTypeOTP
+	case *user.HumanOTPSMSRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPSMS
+	case *user.HumanOTPEmailRemovedEvent:
+		methodType = domain.UserAuthMethodTypeOTPEmail
+	case *user.HumanRecoveryCodesRemovedEvent:
+		methodType = domain.UserAuthMethodTypeRecoveryCodes
 
 	default:
 		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-f92f", "reduce.wrong.event.type %v", []eventstore.EventType{user.HumanPasswordlessTokenAddedType, user.HumanU2FTokenAddedType})
@@ -201,3 +245,46 @@ func (p *UserAuthMethodProjection) reduceRemoveAuthMethod(event eventstore.Event
 		conditions,
 	), nil
 }
+
+func (p *UserAuthMethodProjection) reduceRecoveryCodesAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodesAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iesh5", "reduce.wrong.event.type %s", user.HumanMFARecoveryCodesAddedType)
+	}
+	return crdb.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodTokenIDCol, ""),
+			handler.NewCol(UserAuthMethodCreationDateCol, e.CreationDate()),
+			handler.NewCol(UserAuthMethodChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserAuthMethodResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserAuthMethodInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(UserAuthMethodUserIDCol, e.Aggregate().ID),
+			handler.NewCol(UserAuthMethodSequenceCol, e.Sequence()),
+			handler.NewCol(UserAuthMethodStateCol, domain.MFAStateReady),
+			handler.NewCol(UserAuthMethodTypeCol, domain.UserAuthMethodTypeRecoveryCodes),
+			handler.NewCol(UserAuthMethodNameCol, ""),
+			handler.NewCol(UserAuthMethodRemainingCodesCol, len(e.Codes)),
+		},
+	), nil
+}
+
+func (p *UserAuthMethodProjection) reduceRecoveryCodeUsed(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanRecoveryCodeCheckSucceededEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Aib0o", "reduce.wrong.event.type %s", user.HumanMFARecoveryCodeCheckSucceededType)
+	}
+	return crdb.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserAuthMethodChangeDateCol, e.CreationDate()),
+			handler.NewCol(UserAuthMethodSequenceCol, e.Sequence()),
+			crdb.NewDecrementCol(UserAuthMethodRemainingCodesCol, 1),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserAuthMethodUserIDCol, e.Aggregate().ID),
+			handler.NewCond(UserAuthMethodTypeCol, domain.UserAuthMethodTypeRecoveryCodes),
+			handler.NewCond(UserAuthMethodResourceOwnerCol, e.Aggregate().ResourceOwner),
+		},
+	), nil
+}
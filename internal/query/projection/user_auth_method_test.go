@@ -134,6 +134,146 @@ func TestUserAuthMethodProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceAddedOTPSMS",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanMFAOTPSMSAddedType),
+					user.AggregateType,
+					[]byte(`{
+					}`),
+				), user.HumanOTPSMSAddedEventMapper),
+			},
+			reduce: (&UserAuthMethodProjection{}).reduceInitAuthMethod,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserAuthMethodTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO projections.user_auth_methods (token_id, creation_date, change_date, resource_owner, instance_id, user_id, sequence, state, method_type, name) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								domain.MFAStateReady,
+								domain.UserAuthMethodTypeOTPSMS,
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemovedOTPEmail",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanMFAOTPEmailRemovedType),
+					user.AggregateType,
+					[]byte(`{
+					}`),
+				), user.HumanOTPEmailRemovedEventMapper),
+			},
+			reduce: (&UserAuthMethodProjection{}).reduceRemoveAuthMethod,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserAuthMethodTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_auth_methods WHERE (user_id = $1) AND (method_type = $2) AND (resource_owner = $3)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								domain.UserAuthMethodTypeOTPEmail,
+								"ro-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRecoveryCodesAdded",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanMFARecoveryCodesAddedType),
+					user.AggregateType,
+					[]byte(`{
+						"codes": [{"cryptoType": 1, "algorithm": "hash", "crypted": "Y29kZTE="}, {"cryptoType": 1, "algorithm": "hash", "crypted": "Y29kZTI="}]
+					}`),
+				), user.HumanRecoveryCodesAddedEventMapper),
+			},
+			reduce: (&UserAuthMethodProjection{}).reduceRecoveryCodesAdded,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserAuthMethodTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPSERT INTO projections.user_auth_methods (token_id, creation_date, change_date, resource_owner, instance_id, user_id, sequence, state, method_type, name, remaining_codes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
+							expectedArgs: []interface{}{
+								"",
+								anyArg{},
+								anyArg{},
+								"ro-id",
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								domain.MFAStateReady,
+								domain.UserAuthMethodTypeRecoveryCodes,
+								"",
+								2,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRecoveryCodeUsed",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanMFARecoveryCodeCheckSucceededType),
+					user.AggregateType,
+					[]byte(`{
+						"codeIndex": 1
+					}`),
+				), user.HumanRecoveryCodeCheckSucceededEventMapper),
+			},
+			reduce: (&UserAuthMethodProjection{}).reduceRecoveryCodeUsed,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserAuthMethodTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_auth_methods SET (change_date, sequence, remaining_codes) = ($1, $2, remaining_codes - $3) WHERE (user_id = $4) AND (method_type = $5) AND (resource_owner = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								1,
+								"agg-id",
+								domain.UserAuthMethodTypeRecoveryCodes,
+								"ro-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceVerifiedPasswordless",
 			args: args{
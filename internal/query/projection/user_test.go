@@ -64,7 +64,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -76,6 +76,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.com",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -124,7 +125,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -136,6 +137,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.com",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -179,7 +181,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -191,6 +193,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{},
 								"email@zitadel.com",
 								&sql.NullString{},
+								anyArg{},
 							},
 						},
 					},
@@ -239,7 +242,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -251,6 +254,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.com",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -299,7 +303,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -311,6 +315,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{Int16: int16(domain.GenderFemale), Valid: true},
 								"email@zitadel.com",
 								&sql.NullString{String: "+41 00 000 00 00", Valid: true},
+								anyArg{},
 							},
 						},
 					},
@@ -354,7 +359,7 @@ func TestUserProjection_reduces(t *testing.T) {
 							},
 						},
 						{
-							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedStmt: "INSERT INTO projections.users_humans (user_id, instance_id, first_name, last_name, nick_name, display_name, preferred_language, gender, email, phone, password_changed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)",
 							expectedArgs: []interface{}{
 								"agg-id",
 								"instance-id",
@@ -366,6 +371,7 @@ func TestUserProjection_reduces(t *testing.T) {
 								&sql.NullInt16{},
 								"email@zitadel.com",
 								&sql.NullString{},
+								anyArg{},
 							},
 						},
 					},
@@ -1091,6 +1097,44 @@ func TestUserProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "reduceHumanPasswordChanged",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(user.HumanPasswordChangedType),
+					user.AggregateType,
+					[]byte(`{}`),
+				), user.HumanPasswordChangedEventMapper),
+			},
+			reduce: (&UserProjection{}).reduceHumanPasswordChanged,
+			want: wantReduce{
+				aggregateType:    user.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       UserTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.users SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"agg-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.users_humans SET (password_changed) = ($1) WHERE (user_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								"agg-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "reduceHumanEmailVerified",
 			args: args{
@@ -3,6 +3,7 @@ package query
 import (
 	"errors"
 	"reflect"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
@@ -332,6 +333,58 @@ func (s *BoolQuery) comp() sq.Sqlizer {
 	return sq.Eq{s.Column.identifier(): s.Value}
 }
 
+type TimestampQuery struct {
+	Column    Column
+	Timestamp time.Time
+	Compare   TimestampComparison
+}
+
+func NewTimestampQuery(c Column, value time.Time, compare TimestampComparison) (*TimestampQuery, error) {
+	if compare < 0 || compare >= timestampCompareMax {
+		return nil, ErrInvalidCompare
+	}
+	if c.isZero() {
+		return nil, ErrMissingColumn
+	}
+	return &TimestampQuery{
+		Column:    c,
+		Timestamp: value,
+		Compare:   compare,
+	}, nil
+}
+
+func (q *TimestampQuery) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(q.comp())
+}
+
+func (s *TimestampQuery) comp() sq.Sqlizer {
+	switch s.Compare {
+	case TimestampEquals:
+		return sq.Eq{s.Column.identifier(): s.Timestamp}
+	case TimestampGreater:
+		return sq.Gt{s.Column.identifier(): s.Timestamp}
+	case TimestampGreaterOrEquals:
+		return sq.GtOrEq{s.Column.identifier(): s.Timestamp}
+	case TimestampLess:
+		return sq.Lt{s.Column.identifier(): s.Timestamp}
+	case TimestampLessOrEquals:
+		return sq.LtOrEq{s.Column.identifier(): s.Timestamp}
+	}
+	return nil
+}
+
+type TimestampComparison int
+
+const (
+	TimestampEquals TimestampComparison = iota
+	TimestampGreater
+	TimestampGreaterOrEquals
+	TimestampLess
+	TimestampLessOrEquals
+
+	timestampCompareMax
+)
+
 var (
 	//countColumn represents the default counter for search responses
 	countColumn = Column{
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/lib/pq"
@@ -751,3 +752,139 @@ func TestNumberComparisonFromMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTimestampQuery(t *testing.T) {
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		column  Column
+		value   time.Time
+		compare TimestampComparison
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *TimestampQuery
+		wantErr func(error) bool
+	}{
+		{
+			name: "too low compare",
+			args: args{
+				column:  testCol,
+				value:   timestamp,
+				compare: -1,
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidCompare)
+			},
+		},
+		{
+			name: "too high compare",
+			args: args{
+				column:  testCol,
+				value:   timestamp,
+				compare: timestampCompareMax,
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidCompare)
+			},
+		},
+		{
+			name: "no column",
+			args: args{
+				column:  Column{},
+				value:   timestamp,
+				compare: TimestampEquals,
+			},
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrMissingColumn)
+			},
+		},
+		{
+			name: "correct",
+			args: args{
+				column:  testCol,
+				value:   timestamp,
+				compare: TimestampLess,
+			},
+			want: &TimestampQuery{
+				Column:    testCol,
+				Timestamp: timestamp,
+				Compare:   TimestampLess,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTimestampQuery(tt.args.column, tt.args.value, tt.args.compare)
+			if err != nil && tt.wantErr == nil {
+				t.Errorf("NewTimestampQuery() no error expected got %v", err)
+				return
+			} else if tt.wantErr != nil && !tt.wantErr(err) {
+				t.Errorf("NewTimestampQuery() unexpeted error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTimestampQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampQuery_comp(t *testing.T) {
+	timestamp := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		compare TimestampComparison
+		want    interface{}
+	}{
+		{
+			name:    "equals",
+			compare: TimestampEquals,
+			want:    sq.Eq{"test_table.test_col": timestamp},
+		},
+		{
+			name:    "greater",
+			compare: TimestampGreater,
+			want:    sq.Gt{"test_table.test_col": timestamp},
+		},
+		{
+			name:    "greater or equals",
+			compare: TimestampGreaterOrEquals,
+			want:    sq.GtOrEq{"test_table.test_col": timestamp},
+		},
+		{
+			name:    "less",
+			compare: TimestampLess,
+			want:    sq.Lt{"test_table.test_col": timestamp},
+		},
+		{
+			name:    "less or equals",
+			compare: TimestampLessOrEquals,
+			want:    sq.LtOrEq{"test_table.test_col": timestamp},
+		},
+		{
+			name:    "too high comparison",
+			compare: timestampCompareMax,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TimestampQuery{
+				Column:    testCol,
+				Timestamp: timestamp,
+				Compare:   tt.compare,
+			}
+			query := s.comp()
+			if tt.want == nil {
+				if query != nil {
+					t.Error("query should be nil")
+				}
+				return
+			}
+			if !reflect.DeepEqual(query, tt.want) {
+				t.Errorf("wrong query: want: %v, (%T), got: %v, (%T)", tt.want, tt.want, query, query)
+			}
+		})
+	}
+}
@@ -50,6 +50,7 @@ type Human struct {
 	IsEmailVerified   bool
 	Phone             string
 	IsPhoneVerified   bool
+	PasswordChanged   time.Time
 }
 
 type Profile struct {
@@ -216,6 +217,12 @@ var (
 		name:  projection.HumanIsPhoneVerifiedCol,
 		table: humanTable,
 	}
+
+	// password
+	HumanPasswordChangedCol = Column{
+		name:  projection.HumanPasswordChangedCol,
+		table: humanTable,
+	}
 )
 
 var (
@@ -427,6 +434,10 @@ func NewUserEmailSearchQuery(value string, comparison TextComparison) (SearchQue
 	return NewTextQuery(Column(HumanEmailCol), value, comparison)
 }
 
+func NewUserPasswordChangedSearchQuery(value time.Time, comparison TimestampComparison) (SearchQuery, error) {
+	return NewTimestampQuery(HumanPasswordChangedCol, value, comparison)
+}
+
 func NewUserStateSearchQuery(value int32) (SearchQuery, error) {
 	return NewNumberQuery(UserStateCol, value, NumberEquals)
 }
@@ -489,6 +500,7 @@ func prepareUserQuery(instanceID string) (sq.SelectBuilder, func(*sql.Row) (*Use
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -516,6 +528,7 @@ func prepareUserQuery(instanceID string) (sq.SelectBuilder, func(*sql.Row) (*Use
 			isEmailVerified := sql.NullBool{}
 			phone := sql.NullString{}
 			isPhoneVerified := sql.NullBool{}
+			passwordChanged := sql.NullTime{}
 
 			machineID := sql.NullString{}
 			name := sql.NullString{}
@@ -544,6 +557,7 @@ func prepareUserQuery(instanceID string) (sq.SelectBuilder, func(*sql.Row) (*Use
 				&isEmailVerified,
 				&phone,
 				&isPhoneVerified,
+				&passwordChanged,
 				&machineID,
 				&name,
 				&description,
@@ -573,6 +587,7 @@ func prepareUserQuery(instanceID string) (sq.SelectBuilder, func(*sql.Row) (*Use
 					IsEmailVerified:   isEmailVerified.Bool,
 					Phone:             phone.String,
 					IsPhoneVerified:   isPhoneVerified.Bool,
+					PasswordChanged:   passwordChanged.Time,
 				}
 			} else if machineID.Valid {
 				u.Machine = &Machine{
@@ -825,6 +840,7 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 			HumanIsEmailVerifiedCol.identifier(),
 			HumanPhoneCol.identifier(),
 			HumanIsPhoneVerifiedCol.identifier(),
+			HumanPasswordChangedCol.identifier(),
 			MachineUserIDCol.identifier(),
 			MachineNameCol.identifier(),
 			MachineDescriptionCol.identifier(),
@@ -855,6 +871,7 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 				isEmailVerified := sql.NullBool{}
 				phone := sql.NullString{}
 				isPhoneVerified := sql.NullBool{}
+				passwordChanged := sql.NullTime{}
 
 				machineID := sql.NullString{}
 				name := sql.NullString{}
@@ -883,6 +900,7 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 					&isEmailVerified,
 					&phone,
 					&isPhoneVerified,
+					&passwordChanged,
 					&machineID,
 					&name,
 					&description,
@@ -910,6 +928,7 @@ func prepareUsersQuery() (sq.SelectBuilder, func(*sql.Rows) (*Users, error)) {
 						IsEmailVerified:   isEmailVerified.Bool,
 						Phone:             phone.String,
 						IsPhoneVerified:   isPhoneVerified.Bool,
+						PasswordChanged:   passwordChanged.Time,
 					}
 				} else if machineID.Valid {
 					u.Machine = &Machine{
@@ -60,6 +60,10 @@ var (
 		name:  projection.UserAuthMethodTypeCol,
 		table: userAuthMethodTable,
 	}
+	UserAuthMethodColumnRemainingCodes = Column{
+		name:  projection.UserAuthMethodRemainingCodesCol,
+		table: userAuthMethodTable,
+	}
 )
 
 type AuthMethods struct {
@@ -77,6 +81,8 @@ type AuthMethod struct {
 	TokenID string
 	Name    string
 	Type    domain.UserAuthMethodType
+	// RemainingCodes is only set for recovery codes
+	RemainingCodes uint64
 }
 
 type UserAuthMethodSearchQueries struct {
@@ -223,7 +229,8 @@ func prepareUserAuthMethodQuery() (sq.SelectBuilder, func(*sql.Row) (*AuthMethod
 			UserAuthMethodColumnSequence.identifier(),
 			UserAuthMethodColumnName.identifier(),
 			UserAuthMethodColumnState.identifier(),
-			UserAuthMethodColumnMethodType.identifier()).
+			UserAuthMethodColumnMethodType.identifier(),
+			UserAuthMethodColumnRemainingCodes.identifier()).
 			From(userAuthMethodTable.identifier()).PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*AuthMethod, error) {
 			authMethod := new(AuthMethod)
@@ -237,6 +244,7 @@ func prepareUserAuthMethodQuery() (sq.SelectBuilder, func(*sql.Row) (*AuthMethod
 				&authMethod.Name,
 				&authMethod.State,
 				&authMethod.Type,
+				&authMethod.RemainingCodes,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -259,6 +267,7 @@ func prepareUserAuthMethodsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AuthMeth
 			UserAuthMethodColumnName.identifier(),
 			UserAuthMethodColumnState.identifier(),
 			UserAuthMethodColumnMethodType.identifier(),
+			UserAuthMethodColumnRemainingCodes.identifier(),
 			countColumn.identifier()).
 			From(userAuthMethodTable.identifier()).PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*AuthMethods, error) {
@@ -276,6 +285,7 @@ func prepareUserAuthMethodsQuery() (sq.SelectBuilder, func(*sql.Rows) (*AuthMeth
 					&authMethod.Name,
 					&authMethod.State,
 					&authMethod.Type,
+					&authMethod.RemainingCodes,
 					&count,
 				)
 				if err != nil {
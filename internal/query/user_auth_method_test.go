@@ -37,6 +37,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
 						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.user_auth_methods`),
 					nil,
@@ -59,6 +60,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
 						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.user_auth_methods`),
 					[]string{
@@ -71,6 +73,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						"name",
 						"state",
 						"method_type",
+						"remaining_codes",
 						"count",
 					},
 					[][]driver.Value{
@@ -84,6 +87,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypeU2F,
+							uint64(0),
 						},
 					},
 				),
@@ -121,6 +125,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
 						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.user_auth_methods`),
 					[]string{
@@ -133,6 +138,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						"name",
 						"state",
 						"method_type",
+						"remaining_codes",
 						"count",
 					},
 					[][]driver.Value{
@@ -146,6 +152,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypeU2F,
+							uint64(0),
 						},
 						{
 							"token_id-2",
@@ -157,6 +164,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 							"name-2",
 							domain.MFAStateReady,
 							domain.UserAuthMethodTypePasswordless,
+							uint64(0),
 						},
 					},
 				),
@@ -205,6 +213,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
 						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes,`+
 						` COUNT(*) OVER ()`+
 						` FROM projections.user_auth_methods`),
 					sql.ErrConnDone,
@@ -231,7 +240,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.sequence,`+
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
-						` projections.user_auth_methods.method_type`+
+						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes`+
 						` FROM projections.user_auth_methods`,
 					nil,
 					nil,
@@ -258,7 +268,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.sequence,`+
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
-						` projections.user_auth_methods.method_type`+
+						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes`+
 						` FROM projections.user_auth_methods`),
 					[]string{
 						"token_id",
@@ -270,6 +281,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						"name",
 						"state",
 						"method_type",
+						"remaining_codes",
 					},
 					[]driver.Value{
 						"token_id",
@@ -281,6 +293,7 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						"name",
 						domain.MFAStateReady,
 						domain.UserAuthMethodTypeU2F,
+						uint64(0),
 					},
 				),
 			},
@@ -309,7 +322,8 @@ func Test_UserAuthMethodPrepares(t *testing.T) {
 						` projections.user_auth_methods.sequence,`+
 						` projections.user_auth_methods.name,`+
 						` projections.user_auth_methods.state,`+
-						` projections.user_auth_methods.method_type`+
+						` projections.user_auth_methods.method_type,`+
+						` projections.user_auth_methods.remaining_codes`+
 						` FROM projections.user_auth_methods`),
 					sql.ErrConnDone,
 				),
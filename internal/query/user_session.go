@@ -0,0 +1,425 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// the user sessions and tokens are maintained by the views of the auth api
+var (
+	userSessionsTable = table{
+		name: "auth.user_sessions",
+	}
+	UserSessionColumnUserAgentID = Column{
+		name:  "user_agent_id",
+		table: userSessionsTable,
+	}
+	UserSessionColumnUserID = Column{
+		name:  "user_id",
+		table: userSessionsTable,
+	}
+	UserSessionColumnCreationDate = Column{
+		name:  "creation_date",
+		table: userSessionsTable,
+	}
+	UserSessionColumnChangeDate = Column{
+		name:  "change_date",
+		table: userSessionsTable,
+	}
+	UserSessionColumnResourceOwner = Column{
+		name:  "resource_owner",
+		table: userSessionsTable,
+	}
+	UserSessionColumnInstanceID = Column{
+		name:  "instance_id",
+		table: userSessionsTable,
+	}
+	UserSessionColumnSequence = Column{
+		name:  "sequence",
+		table: userSessionsTable,
+	}
+	UserSessionColumnState = Column{
+		name:  "state",
+		table: userSessionsTable,
+	}
+	UserSessionColumnBrowserUserAgent = Column{
+		name:  "browser_user_agent",
+		table: userSessionsTable,
+	}
+	UserSessionColumnRemoteIP = Column{
+		name:  "remote_ip",
+		table: userSessionsTable,
+	}
+	UserSessionColumnPasswordVerification = Column{
+		name:  "password_verification",
+		table: userSessionsTable,
+	}
+	UserSessionColumnPasswordlessVerification = Column{
+		name:  "passwordless_verification",
+		table: userSessionsTable,
+	}
+	UserSessionColumnMagicLinkVerification = Column{
+		name:  "magic_link_verification",
+		table: userSessionsTable,
+	}
+	UserSessionColumnExternalLoginVerification = Column{
+		name:  "external_login_verification",
+		table: userSessionsTable,
+	}
+	UserSessionColumnSecondFactorVerification = Column{
+		name:  "second_factor_verification",
+		table: userSessionsTable,
+	}
+	UserSessionColumnSecondFactorVerificationType = Column{
+		name:  "second_factor_verification_type",
+		table: userSessionsTable,
+	}
+)
+
+var (
+	userSessionTokensTable = table{
+		name: "auth.tokens",
+	}
+	UserSessionTokenColumnUserID = Column{
+		name:  "user_id",
+		table: userSessionTokensTable,
+	}
+	UserSessionTokenColumnUserAgentID = Column{
+		name:  "user_agent_id",
+		table: userSessionTokensTable,
+	}
+	UserSessionTokenColumnApplicationID = Column{
+		name:  "application_id",
+		table: userSessionTokensTable,
+	}
+	UserSessionTokenColumnExpiration = Column{
+		name:  "expiration",
+		table: userSessionTokensTable,
+	}
+	UserSessionTokenColumnInstanceID = Column{
+		name:  "instance_id",
+		table: userSessionTokensTable,
+	}
+)
+
+var (
+	userSessionRefreshTokensTable = table{
+		name: "auth.refresh_tokens",
+	}
+	UserSessionRefreshTokenColumnUserID = Column{
+		name:  "user_id",
+		table: userSessionRefreshTokensTable,
+	}
+	UserSessionRefreshTokenColumnUserAgentID = Column{
+		name:  "user_agent_id",
+		table: userSessionRefreshTokensTable,
+	}
+	UserSessionRefreshTokenColumnClientID = Column{
+		name:  "client_id",
+		table: userSessionRefreshTokensTable,
+	}
+	UserSessionRefreshTokenColumnExpiration = Column{
+		name:  "expiration",
+		table: userSessionRefreshTokensTable,
+	}
+	UserSessionRefreshTokenColumnInstanceID = Column{
+		name:  "instance_id",
+		table: userSessionRefreshTokensTable,
+	}
+)
+
+type UserSessions struct {
+	SearchResponse
+	UserSessions []*UserSession
+}
+
+// UserSession is the session of a user on a user agent (browser)
+type UserSession struct {
+	UserAgentID   string
+	UserID        string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	Sequence      uint64
+	State         domain.UserSessionState
+
+	BrowserUserAgent string
+	RemoteIP         string
+
+	PasswordVerification         time.Time
+	PasswordlessVerification     time.Time
+	MagicLinkVerification        time.Time
+	ExternalLoginVerification    time.Time
+	SecondFactorVerification     time.Time
+	SecondFactorVerificationType domain.MFAType
+
+	// ApplicationIDs are the client ids of the applications with an active token issued in the session
+	ApplicationIDs []string
+}
+
+type UserSessionSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+type userSessionKey struct {
+	userID      string
+	userAgentID string
+}
+
+type userSessionApplications map[userSessionKey][]string
+
+func (a userSessionApplications) add(userID, userAgentID, applicationID string) {
+	key := userSessionKey{userID: userID, userAgentID: userAgentID}
+	for _, id := range a[key] {
+		if id == applicationID {
+			return
+		}
+	}
+	a[key] = append(a[key], applicationID)
+}
+
+func (q *Queries) SearchUserSessions(ctx context.Context, queries *UserSessionSearchQueries) (sessions *UserSessions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserSessionsQuery()
+	stmt, args, err := queries.toQuery(query).
+		Where(sq.Eq{
+			UserSessionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ohb3e", "Errors.Query.InvalidRequest")
+	}
+
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ooS4u", "Errors.Internal")
+	}
+	sessions, err = scan(rows)
+	if err != nil {
+		return nil, err
+	}
+	err = q.addUserSessionApplications(ctx, sessions.UserSessions)
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// addUserSessionApplications sets the applications with active access or refresh tokens
+// issued on the user agent of the session
+func (q *Queries) addUserSessionApplications(ctx context.Context, sessions []*UserSession) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+	userIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		userIDs = append(userIDs, session.UserID)
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	now := time.Now()
+	applications := make(userSessionApplications)
+
+	tokenQuery, scanTokens := prepareUserSessionTokenApplicationsQuery()
+	stmt, args, err := tokenQuery.Where(sq.And{
+		sq.Eq{
+			UserSessionTokenColumnUserID.identifier():     userIDs,
+			UserSessionTokenColumnInstanceID.identifier(): instanceID,
+		},
+		sq.Gt{UserSessionTokenColumnExpiration.identifier(): now},
+	}).ToSql()
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-ieb4E", "Errors.Query.SQLStatment")
+	}
+	rows, err := q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-Xai5a", "Errors.Internal")
+	}
+	if err = scanTokens(rows, applications); err != nil {
+		return err
+	}
+
+	refreshTokenQuery, scanRefreshTokens := prepareUserSessionRefreshTokenApplicationsQuery()
+	stmt, args, err = refreshTokenQuery.Where(sq.And{
+		sq.Eq{
+			UserSessionRefreshTokenColumnUserID.identifier():     userIDs,
+			UserSessionRefreshTokenColumnInstanceID.identifier(): instanceID,
+		},
+		sq.Gt{UserSessionRefreshTokenColumnExpiration.identifier(): now},
+	}).ToSql()
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-Shu8o", "Errors.Query.SQLStatment")
+	}
+	rows, err = q.client.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return errors.ThrowInternal(err, "QUERY-Eew3o", "Errors.Internal")
+	}
+	if err = scanRefreshTokens(rows, applications); err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		session.ApplicationIDs = applications[userSessionKey{userID: session.UserID, userAgentID: session.UserAgentID}]
+	}
+	return nil
+}
+
+func NewUserSessionUserIDSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserSessionColumnUserID, value, TextEquals)
+}
+
+func NewUserSessionResourceOwnerSearchQuery(value string) (SearchQuery, error) {
+	return NewTextQuery(UserSessionColumnResourceOwner, value, TextEquals)
+}
+
+func NewUserSessionStateSearchQuery(value domain.UserSessionState) (SearchQuery, error) {
+	return NewNumberQuery(UserSessionColumnState, int32(value), NumberEquals)
+}
+
+func (q *UserSessionSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func prepareUserSessionsQuery() (sq.SelectBuilder, func(*sql.Rows) (*UserSessions, error)) {
+	return sq.Select(
+			UserSessionColumnUserAgentID.identifier(),
+			UserSessionColumnUserID.identifier(),
+			UserSessionColumnCreationDate.identifier(),
+			UserSessionColumnChangeDate.identifier(),
+			UserSessionColumnResourceOwner.identifier(),
+			UserSessionColumnSequence.identifier(),
+			UserSessionColumnState.identifier(),
+			UserSessionColumnBrowserUserAgent.identifier(),
+			UserSessionColumnRemoteIP.identifier(),
+			UserSessionColumnPasswordVerification.identifier(),
+			UserSessionColumnPasswordlessVerification.identifier(),
+			UserSessionColumnMagicLinkVerification.identifier(),
+			UserSessionColumnExternalLoginVerification.identifier(),
+			UserSessionColumnSecondFactorVerification.identifier(),
+			UserSessionColumnSecondFactorVerificationType.identifier(),
+			countColumn.identifier()).
+			From(userSessionsTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserSessions, error) {
+			sessions := make([]*UserSession, 0)
+			var count uint64
+			for rows.Next() {
+				session := new(UserSession)
+				var (
+					resourceOwner                sql.NullString
+					sequence                     sql.NullInt64
+					state                        sql.NullInt32
+					creationDate                 sql.NullTime
+					changeDate                   sql.NullTime
+					browserUserAgent             sql.NullString
+					remoteIP                     sql.NullString
+					passwordVerification         sql.NullTime
+					passwordlessVerification     sql.NullTime
+					magicLinkVerification        sql.NullTime
+					externalLoginVerification    sql.NullTime
+					secondFactorVerification     sql.NullTime
+					secondFactorVerificationType sql.NullInt32
+				)
+				err := rows.Scan(
+					&session.UserAgentID,
+					&session.UserID,
+					&creationDate,
+					&changeDate,
+					&resourceOwner,
+					&sequence,
+					&state,
+					&browserUserAgent,
+					&remoteIP,
+					&passwordVerification,
+					&passwordlessVerification,
+					&magicLinkVerification,
+					&externalLoginVerification,
+					&secondFactorVerification,
+					&secondFactorVerificationType,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				session.CreationDate = creationDate.Time
+				session.ChangeDate = changeDate.Time
+				session.ResourceOwner = resourceOwner.String
+				session.Sequence = uint64(sequence.Int64)
+				session.State = domain.UserSessionState(state.Int32)
+				session.BrowserUserAgent = browserUserAgent.String
+				session.RemoteIP = remoteIP.String
+				session.PasswordVerification = passwordVerification.Time
+				session.PasswordlessVerification = passwordlessVerification.Time
+				session.MagicLinkVerification = magicLinkVerification.Time
+				session.ExternalLoginVerification = externalLoginVerification.Time
+				session.SecondFactorVerification = secondFactorVerification.Time
+				session.SecondFactorVerificationType = domain.MFAType(secondFactorVerificationType.Int32)
+				sessions = append(sessions, session)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ahx8u", "Errors.Query.CloseRows")
+			}
+
+			return &UserSessions{
+				UserSessions: sessions,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareUserSessionTokenApplicationsQuery() (sq.SelectBuilder, func(*sql.Rows, userSessionApplications) error) {
+	return sq.Select(
+			UserSessionTokenColumnUserID.identifier(),
+			UserSessionTokenColumnUserAgentID.identifier(),
+			UserSessionTokenColumnApplicationID.identifier()).
+			Distinct().
+			From(userSessionTokensTable.identifier()).PlaceholderFormat(sq.Dollar),
+		scanUserSessionApplications
+}
+
+func prepareUserSessionRefreshTokenApplicationsQuery() (sq.SelectBuilder, func(*sql.Rows, userSessionApplications) error) {
+	return sq.Select(
+			UserSessionRefreshTokenColumnUserID.identifier(),
+			UserSessionRefreshTokenColumnUserAgentID.identifier(),
+			UserSessionRefreshTokenColumnClientID.identifier()).
+			Distinct().
+			From(userSessionRefreshTokensTable.identifier()).PlaceholderFormat(sq.Dollar),
+		scanUserSessionApplications
+}
+
+func scanUserSessionApplications(rows *sql.Rows, applications userSessionApplications) error {
+	for rows.Next() {
+		var userID, userAgentID, applicationID sql.NullString
+		err := rows.Scan(
+			&userID,
+			&userAgentID,
+			&applicationID,
+		)
+		if err != nil {
+			return err
+		}
+		if applicationID.String == "" {
+			continue
+		}
+		applications.add(userID.String, userAgentID.String, applicationID.String)
+	}
+	if err := rows.Close(); err != nil {
+		return errors.ThrowInternal(err, "QUERY-aiL3e", "Errors.Query.CloseRows")
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	userSessionsStmt = regexp.QuoteMeta(
+		"SELECT auth.user_sessions.user_agent_id," +
+			" auth.user_sessions.user_id," +
+			" auth.user_sessions.creation_date," +
+			" auth.user_sessions.change_date," +
+			" auth.user_sessions.resource_owner," +
+			" auth.user_sessions.sequence," +
+			" auth.user_sessions.state," +
+			" auth.user_sessions.browser_user_agent," +
+			" auth.user_sessions.remote_ip," +
+			" auth.user_sessions.password_verification," +
+			" auth.user_sessions.passwordless_verification," +
+			" auth.user_sessions.magic_link_verification," +
+			" auth.user_sessions.external_login_verification," +
+			" auth.user_sessions.second_factor_verification," +
+			" auth.user_sessions.second_factor_verification_type," +
+			" COUNT(*) OVER ()" +
+			" FROM auth.user_sessions")
+	userSessionsCols = []string{
+		"user_agent_id",
+		"user_id",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"sequence",
+		"state",
+		"browser_user_agent",
+		"remote_ip",
+		"password_verification",
+		"passwordless_verification",
+		"magic_link_verification",
+		"external_login_verification",
+		"second_factor_verification",
+		"second_factor_verification_type",
+		"count",
+	}
+)
+
+func Test_UserSessionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserSessionsQuery no result",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSessionsStmt,
+					nil,
+					nil,
+				),
+			},
+			object: &UserSessions{UserSessions: []*UserSession{}},
+		},
+		{
+			name:    "prepareUserSessionsQuery one session",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSessionsStmt,
+					userSessionsCols,
+					[][]driver.Value{
+						{
+							"agent-id",
+							"user-id",
+							testNow,
+							testNow,
+							"ro",
+							int64(20211202),
+							int32(domain.UserSessionStateActive),
+							"browser",
+							"127.0.0.1",
+							testNow,
+							nil,
+							nil,
+							nil,
+							testNow,
+							int32(domain.MFATypeOTP),
+						},
+					},
+				),
+			},
+			object: &UserSessions{
+				SearchResponse: SearchResponse{
+					Count: 1,
+				},
+				UserSessions: []*UserSession{
+					{
+						UserAgentID:                  "agent-id",
+						UserID:                       "user-id",
+						CreationDate:                 testNow,
+						ChangeDate:                   testNow,
+						ResourceOwner:                "ro",
+						Sequence:                     20211202,
+						State:                        domain.UserSessionStateActive,
+						BrowserUserAgent:             "browser",
+						RemoteIP:                     "127.0.0.1",
+						PasswordVerification:         testNow,
+						SecondFactorVerification:     testNow,
+						SecondFactorVerificationType: domain.MFATypeOTP,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserSessionsQuery multiple sessions",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					userSessionsStmt,
+					userSessionsCols,
+					[][]driver.Value{
+						{
+							"agent-id",
+							"user-id",
+							testNow,
+							testNow,
+							"ro",
+							int64(20211202),
+							int32(domain.UserSessionStateActive),
+							nil,
+							nil,
+							nil,
+							nil,
+							testNow,
+							nil,
+							nil,
+							nil,
+						},
+						{
+							"agent-id2",
+							"user-id",
+							testNow,
+							testNow,
+							"ro",
+							int64(20211203),
+							int32(domain.UserSessionStateTerminated),
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &UserSessions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				UserSessions: []*UserSession{
+					{
+						UserAgentID:           "agent-id",
+						UserID:                "user-id",
+						CreationDate:          testNow,
+						ChangeDate:            testNow,
+						ResourceOwner:         "ro",
+						Sequence:              20211202,
+						State:                 domain.UserSessionStateActive,
+						MagicLinkVerification: testNow,
+					},
+					{
+						UserAgentID:   "agent-id2",
+						UserID:        "user-id",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						ResourceOwner: "ro",
+						Sequence:      20211203,
+						State:         domain.UserSessionStateTerminated,
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserSessionsQuery sql err",
+			prepare: prepareUserSessionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					userSessionsStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
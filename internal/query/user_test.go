@@ -39,6 +39,7 @@ var (
 		` projections.users_humans.is_email_verified,` +
 		` projections.users_humans.phone,` +
 		` projections.users_humans.is_phone_verified,` +
+		` projections.users_humans.password_changed,` +
 		` projections.users_machines.user_id,` +
 		` projections.users_machines.name,` +
 		` projections.users_machines.description` +
@@ -78,6 +79,7 @@ var (
 		"is_email_verified",
 		"phone",
 		"is_phone_verified",
+		"password_changed",
 		//machine
 		"user_id",
 		"name",
@@ -192,6 +194,7 @@ var (
 		` projections.users_humans.is_email_verified,` +
 		` projections.users_humans.phone,` +
 		` projections.users_humans.is_phone_verified,` +
+		` projections.users_humans.password_changed,` +
 		` projections.users_machines.user_id,` +
 		` projections.users_machines.name,` +
 		` projections.users_machines.description,` +
@@ -231,6 +234,7 @@ var (
 		"is_email_verified",
 		"phone",
 		"is_phone_verified",
+		"password_changed",
 		//machine
 		"user_id",
 		"name",
@@ -303,6 +307,7 @@ func Test_UserPrepares(t *testing.T) {
 						true,
 						"phone",
 						true,
+						testNow,
 						//machine
 						nil,
 						nil,
@@ -333,6 +338,7 @@ func Test_UserPrepares(t *testing.T) {
 					IsEmailVerified:   true,
 					Phone:             "phone",
 					IsPhoneVerified:   true,
+					PasswordChanged:   testNow,
 				},
 			},
 		},
@@ -369,6 +375,7 @@ func Test_UserPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
 						//machine
 						"id",
 						"name",
@@ -810,6 +817,7 @@ func Test_UserPrepares(t *testing.T) {
 							true,
 							"phone",
 							true,
+							testNow,
 							//machine
 							nil,
 							nil,
@@ -846,6 +854,7 @@ func Test_UserPrepares(t *testing.T) {
 							IsEmailVerified:   true,
 							Phone:             "phone",
 							IsPhoneVerified:   true,
+							PasswordChanged:   testNow,
 						},
 					},
 				},
@@ -883,6 +892,7 @@ func Test_UserPrepares(t *testing.T) {
 							true,
 							"phone",
 							true,
+							testNow,
 							//machine
 							nil,
 							nil,
@@ -912,6 +922,7 @@ func Test_UserPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
 							//machine
 							"id",
 							"name",
@@ -948,6 +959,7 @@ func Test_UserPrepares(t *testing.T) {
 							IsEmailVerified:   true,
 							Phone:             "phone",
 							IsPhoneVerified:   true,
+							PasswordChanged:   testNow,
 						},
 					},
 					{
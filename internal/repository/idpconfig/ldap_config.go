@@ -0,0 +1,248 @@
+package idpconfig
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	LDAPConfigAddedEventType   eventstore.EventType = "ldap.config.added"
+	LDAPConfigChangedEventType eventstore.EventType = "ldap.config.changed"
+)
+
+type LDAPConfigAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID     string              `json:"idpConfigId"`
+	URL             string              `json:"ldapUrl,omitempty"`
+	StartTLS        bool                `json:"startTls,omitempty"`
+	RootCA          string              `json:"rootCa,omitempty"`
+	BaseDN          string              `json:"baseDn,omitempty"`
+	BindDN          string              `json:"bindDn,omitempty"`
+	BindPassword    *crypto.CryptoValue `json:"bindPassword,omitempty"`
+	UserObjectClass string              `json:"userObjectClass,omitempty"`
+	UserFilters     []string            `json:"userFilters,omitempty"`
+
+	IDAttribute                string `json:"idAttribute,omitempty"`
+	PreferredUsernameAttribute string `json:"preferredUsernameAttribute,omitempty"`
+	FirstNameAttribute         string `json:"firstNameAttribute,omitempty"`
+	LastNameAttribute          string `json:"lastNameAttribute,omitempty"`
+	DisplayNameAttribute       string `json:"displayNameAttribute,omitempty"`
+	EmailAttribute             string `json:"emailAttribute,omitempty"`
+	PhoneAttribute             string `json:"phoneAttribute,omitempty"`
+}
+
+func (e *LDAPConfigAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *LDAPConfigAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPConfigAddedEvent(
+	base *eventstore.BaseEvent,
+	idpConfigID,
+	url string,
+	startTLS bool,
+	rootCA,
+	baseDN,
+	bindDN string,
+	bindPassword *crypto.CryptoValue,
+	userObjectClass string,
+	userFilters []string,
+	attributes domain.LDAPAttributes,
+) *LDAPConfigAddedEvent {
+	return &LDAPConfigAddedEvent{
+		BaseEvent:                  *base,
+		IDPConfigID:                idpConfigID,
+		URL:                        url,
+		StartTLS:                   startTLS,
+		RootCA:                     rootCA,
+		BaseDN:                     baseDN,
+		BindDN:                     bindDN,
+		BindPassword:               bindPassword,
+		UserObjectClass:            userObjectClass,
+		UserFilters:                userFilters,
+		IDAttribute:                attributes.IDAttribute,
+		PreferredUsernameAttribute: attributes.PreferredUsernameAttribute,
+		FirstNameAttribute:         attributes.FirstNameAttribute,
+		LastNameAttribute:          attributes.LastNameAttribute,
+		DisplayNameAttribute:       attributes.DisplayNameAttribute,
+		EmailAttribute:             attributes.EmailAttribute,
+		PhoneAttribute:             attributes.PhoneAttribute,
+	}
+}
+
+func LDAPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LDAPConfigAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "LDAP-Hw2fq", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type LDAPConfigChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID string `json:"idpConfigId"`
+
+	URL             *string             `json:"ldapUrl,omitempty"`
+	StartTLS        *bool               `json:"startTls,omitempty"`
+	RootCA          *string             `json:"rootCa,omitempty"`
+	BaseDN          *string             `json:"baseDn,omitempty"`
+	BindDN          *string             `json:"bindDn,omitempty"`
+	BindPassword    *crypto.CryptoValue `json:"bindPassword,omitempty"`
+	UserObjectClass *string             `json:"userObjectClass,omitempty"`
+	UserFilters     []string            `json:"userFilters,omitempty"`
+
+	IDAttribute                *string `json:"idAttribute,omitempty"`
+	PreferredUsernameAttribute *string `json:"preferredUsernameAttribute,omitempty"`
+	FirstNameAttribute         *string `json:"firstNameAttribute,omitempty"`
+	LastNameAttribute          *string `json:"lastNameAttribute,omitempty"`
+	DisplayNameAttribute       *string `json:"displayNameAttribute,omitempty"`
+	EmailAttribute             *string `json:"emailAttribute,omitempty"`
+	PhoneAttribute             *string `json:"phoneAttribute,omitempty"`
+}
+
+func (e *LDAPConfigChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *LDAPConfigChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewLDAPConfigChangedEvent(
+	base *eventstore.BaseEvent,
+	idpConfigID string,
+	changes []LDAPConfigChanges,
+) (*LDAPConfigChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IDPCONFIG-Ld3gw", "Errors.NoChangesFound")
+	}
+	changeEvent := &LDAPConfigChangedEvent{
+		BaseEvent:   *base,
+		IDPConfigID: idpConfigID,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type LDAPConfigChanges func(*LDAPConfigChangedEvent)
+
+func ChangeLDAPURL(url string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.URL = &url
+	}
+}
+
+func ChangeLDAPStartTLS(startTLS bool) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.StartTLS = &startTLS
+	}
+}
+
+func ChangeLDAPRootCA(rootCA string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.RootCA = &rootCA
+	}
+}
+
+func ChangeLDAPBaseDN(baseDN string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.BaseDN = &baseDN
+	}
+}
+
+func ChangeLDAPBindDN(bindDN string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.BindDN = &bindDN
+	}
+}
+
+func ChangeLDAPBindPassword(bindPassword *crypto.CryptoValue) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.BindPassword = bindPassword
+	}
+}
+
+func ChangeLDAPUserObjectClass(userObjectClass string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.UserObjectClass = &userObjectClass
+	}
+}
+
+func ChangeLDAPUserFilters(userFilters []string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.UserFilters = userFilters
+	}
+}
+
+func ChangeLDAPIDAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.IDAttribute = &attribute
+	}
+}
+
+func ChangeLDAPPreferredUsernameAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.PreferredUsernameAttribute = &attribute
+	}
+}
+
+func ChangeLDAPFirstNameAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.FirstNameAttribute = &attribute
+	}
+}
+
+func ChangeLDAPLastNameAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.LastNameAttribute = &attribute
+	}
+}
+
+func ChangeLDAPDisplayNameAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.DisplayNameAttribute = &attribute
+	}
+}
+
+func ChangeLDAPEmailAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.EmailAttribute = &attribute
+	}
+}
+
+func ChangeLDAPPhoneAttribute(attribute string) func(*LDAPConfigChangedEvent) {
+	return func(e *LDAPConfigChangedEvent) {
+		e.PhoneAttribute = &attribute
+	}
+}
+
+func LDAPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &LDAPConfigChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "LDAP-Kd2fw", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
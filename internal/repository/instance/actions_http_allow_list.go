@@ -0,0 +1,56 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	actionsHTTPAllowListPrefix       = "actions.http.allowlist."
+	ActionsHTTPAllowListSetEventType = instanceEventTypePrefix + actionsHTTPAllowListPrefix + "set"
+)
+
+type ActionsHTTPAllowListSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Hosts []string `json:"hosts"`
+}
+
+func NewActionsHTTPAllowListSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	hosts []string,
+) *ActionsHTTPAllowListSetEvent {
+	return &ActionsHTTPAllowListSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ActionsHTTPAllowListSetEventType,
+		),
+		Hosts: hosts,
+	}
+}
+
+func (e *ActionsHTTPAllowListSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *ActionsHTTPAllowListSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func ActionsHTTPAllowListSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &ActionsHTTPAllowListSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "IAM-Ohqu2", "unable to unmarshal actions http allow list set")
+	}
+
+	return e, nil
+}
@@ -0,0 +1,98 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+)
+
+const (
+	IDPLDAPConfigAddedEventType   eventstore.EventType = "iam.idp." + idpconfig.LDAPConfigAddedEventType
+	IDPLDAPConfigChangedEventType eventstore.EventType = "iam.idp." + idpconfig.LDAPConfigChangedEventType
+)
+
+type IDPLDAPConfigAddedEvent struct {
+	idpconfig.LDAPConfigAddedEvent
+}
+
+func NewIDPLDAPConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	url string,
+	startTLS bool,
+	rootCA,
+	baseDN,
+	bindDN string,
+	bindPassword *crypto.CryptoValue,
+	userObjectClass string,
+	userFilters []string,
+	attributes domain.LDAPAttributes,
+) *IDPLDAPConfigAddedEvent {
+	return &IDPLDAPConfigAddedEvent{
+		LDAPConfigAddedEvent: *idpconfig.NewLDAPConfigAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLDAPConfigAddedEventType,
+			),
+			idpConfigID,
+			url,
+			startTLS,
+			rootCA,
+			baseDN,
+			bindDN,
+			bindPassword,
+			userObjectClass,
+			userFilters,
+			attributes,
+		),
+	}
+}
+
+func IDPLDAPConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idpconfig.LDAPConfigAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPConfigAddedEvent{LDAPConfigAddedEvent: *e.(*idpconfig.LDAPConfigAddedEvent)}, nil
+}
+
+type IDPLDAPConfigChangedEvent struct {
+	idpconfig.LDAPConfigChangedEvent
+}
+
+func NewIDPLDAPConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID string,
+	changes []idpconfig.LDAPConfigChanges,
+) (*IDPLDAPConfigChangedEvent, error) {
+	changeEvent, err := idpconfig.NewLDAPConfigChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			IDPLDAPConfigChangedEventType),
+		idpConfigID,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &IDPLDAPConfigChangedEvent{LDAPConfigChangedEvent: *changeEvent}, nil
+}
+
+func IDPLDAPConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idpconfig.LDAPConfigChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPConfigChangedEvent{LDAPConfigChangedEvent: *e.(*idpconfig.LDAPConfigChangedEvent)}, nil
+}
@@ -0,0 +1,84 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+)
+
+const (
+	IDPSAMLConfigAddedEventType   eventstore.EventType = "iam.idp." + idpconfig.SAMLConfigAddedEventType
+	IDPSAMLConfigChangedEventType eventstore.EventType = "iam.idp." + idpconfig.SAMLConfigChangedEventType
+)
+
+type IDPSAMLConfigAddedEvent struct {
+	idpconfig.SAMLConfigAddedEvent
+}
+
+func NewIDPSAMLConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	entityID string,
+	metadata []byte,
+	metadataURL string,
+) *IDPSAMLConfigAddedEvent {
+	return &IDPSAMLConfigAddedEvent{
+		SAMLConfigAddedEvent: *idpconfig.NewSAMLConfigAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPSAMLConfigAddedEventType,
+			),
+			idpConfigID,
+			entityID,
+			metadata,
+			metadataURL,
+		),
+	}
+}
+
+func IDPSAMLConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idpconfig.SAMLConfigAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPSAMLConfigAddedEvent{SAMLConfigAddedEvent: *e.(*idpconfig.SAMLConfigAddedEvent)}, nil
+}
+
+type IDPSAMLConfigChangedEvent struct {
+	idpconfig.SAMLConfigChangedEvent
+}
+
+func NewIDPSAMLConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID string,
+	changes []idpconfig.SAMLConfigChanges,
+) (*IDPSAMLConfigChangedEvent, error) {
+	changeEvent, err := idpconfig.NewSAMLConfigChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			IDPSAMLConfigChangedEventType),
+		idpConfigID,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &IDPSAMLConfigChangedEvent{SAMLConfigChangedEvent: *changeEvent}, nil
+}
+
+func IDPSAMLConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := idpconfig.SAMLConfigChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPSAMLConfigChangedEvent{SAMLConfigChangedEvent: *e.(*idpconfig.SAMLConfigChangedEvent)}, nil
+}
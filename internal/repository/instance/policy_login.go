@@ -28,7 +28,8 @@ func NewLoginPolicyAddedEvent(
 	allowExternalIDP,
 	forceMFA,
 	hidePasswordReset,
-	ignoreUnknownUsernames bool,
+	ignoreUnknownUsernames,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -49,6 +50,7 @@ func NewLoginPolicyAddedEvent(
 			forceMFA,
 			hidePasswordReset,
 			ignoreUnknownUsernames,
+			allowMagicLink,
 			passwordlessType,
 			defaultRedirectURI,
 			passwordCheckLifetime,
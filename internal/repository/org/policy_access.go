@@ -0,0 +1,102 @@
+package org
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+var (
+	AccessPolicyAddedEventType   = orgEventTypePrefix + policy.AccessPolicyAddedEventType
+	AccessPolicyChangedEventType = orgEventTypePrefix + policy.AccessPolicyChangedEventType
+	AccessPolicyRemovedEventType = orgEventTypePrefix + policy.AccessPolicyRemovedEventType
+)
+
+type AccessPolicyAddedEvent struct {
+	policy.AccessPolicyAddedEvent
+}
+
+func NewAccessPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	rules domain.AccessRules,
+) *AccessPolicyAddedEvent {
+	return &AccessPolicyAddedEvent{
+		AccessPolicyAddedEvent: *policy.NewAccessPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				AccessPolicyAddedEventType),
+			rules),
+	}
+}
+
+func AccessPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.AccessPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessPolicyAddedEvent{AccessPolicyAddedEvent: *e.(*policy.AccessPolicyAddedEvent)}, nil
+}
+
+type AccessPolicyChangedEvent struct {
+	policy.AccessPolicyChangedEvent
+}
+
+func NewAccessPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.AccessPolicyChanges,
+) (*AccessPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewAccessPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AccessPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessPolicyChangedEvent{AccessPolicyChangedEvent: *changedEvent}, nil
+}
+
+func AccessPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.AccessPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessPolicyChangedEvent{AccessPolicyChangedEvent: *e.(*policy.AccessPolicyChangedEvent)}, nil
+}
+
+type AccessPolicyRemovedEvent struct {
+	policy.AccessPolicyRemovedEvent
+}
+
+func NewAccessPolicyRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *AccessPolicyRemovedEvent {
+	return &AccessPolicyRemovedEvent{
+		AccessPolicyRemovedEvent: *policy.NewAccessPolicyRemovedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				AccessPolicyRemovedEventType),
+		),
+	}
+}
+
+func AccessPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e, err := policy.AccessPolicyRemovedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessPolicyRemovedEvent{AccessPolicyRemovedEvent: *e.(*policy.AccessPolicyRemovedEvent)}, nil
+}
@@ -29,7 +29,8 @@ func NewLoginPolicyAddedEvent(
 	allowExternalIDP,
 	forceMFA,
 	hidePasswordReset,
-	ignoreUnknownUsernames bool,
+	ignoreUnknownUsernames,
+	allowMagicLink bool,
 	passwordlessType domain.PasswordlessType,
 	defaultRedirectURI string,
 	passwordCheckLifetime,
@@ -50,6 +51,7 @@ func NewLoginPolicyAddedEvent(
 			forceMFA,
 			hidePasswordReset,
 			ignoreUnknownUsernames,
+			allowMagicLink,
 			passwordlessType,
 			defaultRedirectURI,
 			passwordCheckLifetime,
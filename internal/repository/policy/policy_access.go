@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	AccessPolicyAddedEventType   = "policy.access.added"
+	AccessPolicyChangedEventType = "policy.access.changed"
+	AccessPolicyRemovedEventType = "policy.access.removed"
+)
+
+type AccessPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Rules domain.AccessRules `json:"rules,omitempty"`
+}
+
+func (e *AccessPolicyAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AccessPolicyAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAccessPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	rules domain.AccessRules,
+) *AccessPolicyAddedEvent {
+	return &AccessPolicyAddedEvent{
+		BaseEvent: *base,
+		Rules:     rules,
+	}
+}
+
+func AccessPolicyAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AccessPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Aeph4", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type AccessPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Rules *domain.AccessRules `json:"rules,omitempty"`
+}
+
+func (e *AccessPolicyChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *AccessPolicyChangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAccessPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []AccessPolicyChanges,
+) (*AccessPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-Ohgh3", "Errors.NoChangesFound")
+	}
+	changeEvent := &AccessPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type AccessPolicyChanges func(*AccessPolicyChangedEvent)
+
+func ChangeAccessRules(rules domain.AccessRules) func(*AccessPolicyChangedEvent) {
+	return func(e *AccessPolicyChangedEvent) {
+		if rules == nil {
+			rules = domain.AccessRules{}
+		}
+		e.Rules = &rules
+	}
+}
+
+func AccessPolicyChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &AccessPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-io7Ae", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type AccessPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *AccessPolicyRemovedEvent) Data() interface{} {
+	return nil
+}
+
+func (e *AccessPolicyRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewAccessPolicyRemovedEvent(base *eventstore.BaseEvent) *AccessPolicyRemovedEvent {
+	return &AccessPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func AccessPolicyRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	return &AccessPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
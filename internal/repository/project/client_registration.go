@@ -0,0 +1,160 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	initialAccessTokenEventTypePrefix = projectEventTypePrefix + "initial.access.token."
+	InitialAccessTokenAddedType       = initialAccessTokenEventTypePrefix + "added"
+	InitialAccessTokenRemovedType     = initialAccessTokenEventTypePrefix + "removed"
+
+	OIDCConfigRegistrationAccessTokenSetType = applicationEventTypePrefix + "config.oidc.registration.token.set"
+)
+
+type InitialAccessTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID    string    `json:"tokenId"`
+	TokenHash  string    `json:"tokenHash"`
+	Expiration time.Time `json:"expiration"`
+}
+
+func (e *InitialAccessTokenAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenAddedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	tokenHash string,
+	expiration time.Time,
+) *InitialAccessTokenAddedEvent {
+	return &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenAddedType,
+		),
+		TokenID:    tokenID,
+		TokenHash:  tokenHash,
+		Expiration: expiration,
+	}
+}
+
+func InitialAccessTokenAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Eiy7o", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
+
+type InitialAccessTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *InitialAccessTokenRemovedEvent) Data() interface{} {
+	return e
+}
+
+func (e *InitialAccessTokenRemovedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewInitialAccessTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *InitialAccessTokenRemovedEvent {
+	return &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			InitialAccessTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func InitialAccessTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &InitialAccessTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-ooC8u", "unable to unmarshal initial access token")
+	}
+
+	return e, nil
+}
+
+// OIDCConfigRegistrationAccessTokenSetEvent is pushed when an application registers itself (RFC 7591),
+// the registration access token for the client configuration endpoint (RFC 7592) is only valid for the latest token id
+// and only the hash of its secret is stored
+type OIDCConfigRegistrationAccessTokenSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID     string `json:"appId"`
+	TokenID   string `json:"tokenId"`
+	TokenHash string `json:"tokenHash"`
+}
+
+func (e *OIDCConfigRegistrationAccessTokenSetEvent) Data() interface{} {
+	return e
+}
+
+func (e *OIDCConfigRegistrationAccessTokenSetEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewOIDCConfigRegistrationAccessTokenSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	tokenID,
+	tokenHash string,
+) *OIDCConfigRegistrationAccessTokenSetEvent {
+	return &OIDCConfigRegistrationAccessTokenSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			OIDCConfigRegistrationAccessTokenSetType,
+		),
+		AppID:     appID,
+		TokenID:   tokenID,
+		TokenHash: tokenHash,
+	}
+}
+
+func OIDCConfigRegistrationAccessTokenSetEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &OIDCConfigRegistrationAccessTokenSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "OIDC-Ieth0", "unable to unmarshal oidc config")
+	}
+
+	return e, nil
+}
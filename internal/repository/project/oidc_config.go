@@ -40,6 +40,11 @@ type OIDCConfigAddedEvent struct {
 	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
 	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
+	RequirePushedAuthRequest bool                       `json:"requirePushedAuthRequest,omitempty"`
+	RequireRequestObject     bool                       `json:"requireRequestObject,omitempty"`
+	BackChannelLogoutURI     string                     `json:"backChannelLogoutURI,omitempty"`
+	FrontChannelLogoutURI    string                     `json:"frontChannelLogoutURI,omitempty"`
+	MinLevelOfAssurance      domain.LevelOfAssurance    `json:"minLevelOfAssurance,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Data() interface{} {
@@ -70,6 +75,11 @@ func NewOIDCConfigAddedEvent(
 	idTokenUserinfoAssertion bool,
 	clockSkew time.Duration,
 	additionalOrigins []string,
+	requirePushedAuthRequest bool,
+	requireRequestObject bool,
+	backChannelLogoutURI string,
+	frontChannelLogoutURI string,
+	minLevelOfAssurance domain.LevelOfAssurance,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -94,6 +104,11 @@ func NewOIDCConfigAddedEvent(
 		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
 		ClockSkew:                clockSkew,
 		AdditionalOrigins:        additionalOrigins,
+		RequirePushedAuthRequest: requirePushedAuthRequest,
+		RequireRequestObject:     requireRequestObject,
+		BackChannelLogoutURI:     backChannelLogoutURI,
+		FrontChannelLogoutURI:    frontChannelLogoutURI,
+		MinLevelOfAssurance:      minLevelOfAssurance,
 	}
 }
 
@@ -179,6 +194,21 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 			return false
 		}
 	}
+	if e.RequirePushedAuthRequest != c.RequirePushedAuthRequest {
+		return false
+	}
+	if e.RequireRequestObject != c.RequireRequestObject {
+		return false
+	}
+	if e.BackChannelLogoutURI != c.BackChannelLogoutURI {
+		return false
+	}
+	if e.FrontChannelLogoutURI != c.FrontChannelLogoutURI {
+		return false
+	}
+	if e.MinLevelOfAssurance != c.MinLevelOfAssurance {
+		return false
+	}
 
 	return true
 }
@@ -214,6 +244,11 @@ type OIDCConfigChangedEvent struct {
 	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
 	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
 	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
+	RequirePushedAuthRequest *bool                       `json:"requirePushedAuthRequest,omitempty"`
+	RequireRequestObject     *bool                       `json:"requireRequestObject,omitempty"`
+	BackChannelLogoutURI     *string                     `json:"backChannelLogoutURI,omitempty"`
+	FrontChannelLogoutURI    *string                     `json:"frontChannelLogoutURI,omitempty"`
+	MinLevelOfAssurance      *domain.LevelOfAssurance    `json:"minLevelOfAssurance,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Data() interface{} {
@@ -334,6 +369,36 @@ func ChangeAdditionalOrigins(additionalOrigins []string) func(event *OIDCConfigC
 	}
 }
 
+func ChangeRequirePushedAuthRequest(requirePushedAuthRequest bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequirePushedAuthRequest = &requirePushedAuthRequest
+	}
+}
+
+func ChangeRequireRequestObject(requireRequestObject bool) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RequireRequestObject = &requireRequestObject
+	}
+}
+
+func ChangeBackChannelLogoutURI(backChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.BackChannelLogoutURI = &backChannelLogoutURI
+	}
+}
+
+func ChangeFrontChannelLogoutURI(frontChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.FrontChannelLogoutURI = &frontChannelLogoutURI
+	}
+}
+
+func ChangeMinLevelOfAssurance(minLevelOfAssurance domain.LevelOfAssurance) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.MinLevelOfAssurance = &minLevelOfAssurance
+	}
+}
+
 func OIDCConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
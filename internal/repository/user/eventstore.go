@@ -43,6 +43,8 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(UserRemovedType, UserRemovedEventMapper).
 		RegisterFilterEventMapper(UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(UserTokenRemovedType, UserTokenRemovedEventMapper).
+		RegisterFilterEventMapper(UserTokenExchangedType, UserTokenExchangedEventMapper).
+		RegisterFilterEventMapper(UserImpersonatedType, UserImpersonatedEventMapper).
 		RegisterFilterEventMapper(UserDomainClaimedType, DomainClaimedEventMapper).
 		RegisterFilterEventMapper(UserDomainClaimedSentType, DomainClaimedSentEventMapper).
 		RegisterFilterEventMapper(UserUserNameChangedType, UsernameChangedEventMapper).
@@ -56,11 +58,14 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(HumanInitializedCheckSucceededType, HumanInitializedCheckSucceededEventMapper).
 		RegisterFilterEventMapper(HumanInitializedCheckFailedType, HumanInitializedCheckFailedEventMapper).
 		RegisterFilterEventMapper(HumanSignedOutType, HumanSignedOutEventMapper).
+		RegisterFilterEventMapper(HumanBackChannelLogoutRequestedType, HumanBackChannelLogoutRequestedEventMapper).
+		RegisterFilterEventMapper(HumanBackChannelLogoutSentType, HumanBackChannelLogoutSentEventMapper).
 		RegisterFilterEventMapper(HumanPasswordChangedType, HumanPasswordChangedEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCodeAddedType, HumanPasswordCodeAddedEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCodeSentType, HumanPasswordCodeSentEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCheckSucceededType, HumanPasswordCheckSucceededEventMapper).
 		RegisterFilterEventMapper(HumanPasswordCheckFailedType, HumanPasswordCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanPasswordHashUpdatedType, HumanPasswordHashUpdatedEventMapper).
 		RegisterFilterEventMapper(UserIDPLinkAddedType, UserIDPLinkAddedEventMapper).
 		RegisterFilterEventMapper(UserIDPLinkRemovedType, UserIDPLinkRemovedEventMapper).
 		RegisterFilterEventMapper(UserIDPLinkCascadeRemovedType, UserIDPLinkCascadeRemovedEventMapper).
@@ -86,6 +91,26 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(HumanMFAOTPRemovedType, HumanOTPRemovedEventMapper).
 		RegisterFilterEventMapper(HumanMFAOTPCheckSucceededType, HumanOTPCheckSucceededEventMapper).
 		RegisterFilterEventMapper(HumanMFAOTPCheckFailedType, HumanOTPCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSAddedType, HumanOTPSMSAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSRemovedType, HumanOTPSMSRemovedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCodeAddedType, HumanOTPSMSCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCodeSentType, HumanOTPSMSCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCheckSucceededType, HumanOTPSMSCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPSMSCheckFailedType, HumanOTPSMSCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailAddedType, HumanOTPEmailAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailRemovedType, HumanOTPEmailRemovedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCodeAddedType, HumanOTPEmailCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCodeSentType, HumanOTPEmailCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCheckSucceededType, HumanOTPEmailCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFAOTPEmailCheckFailedType, HumanOTPEmailCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCodeAddedType, HumanMagicLinkCodeAddedEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCodeSentType, HumanMagicLinkCodeSentEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCheckSucceededType, HumanMagicLinkCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMagicLinkCheckFailedType, HumanMagicLinkCheckFailedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodesAddedType, HumanRecoveryCodesAddedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodesRemovedType, HumanRecoveryCodesRemovedEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodeCheckSucceededType, HumanRecoveryCodeCheckSucceededEventMapper).
+		RegisterFilterEventMapper(HumanMFARecoveryCodeCheckFailedType, HumanRecoveryCodeCheckFailedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenAddedType, HumanU2FAddedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenVerifiedType, HumanU2FVerifiedEventMapper).
 		RegisterFilterEventMapper(HumanU2FTokenSignCountChangedType, HumanU2FSignCountChangedEventMapper).
@@ -112,6 +137,10 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(MachineChangedEventType, MachineChangedEventMapper).
 		RegisterFilterEventMapper(MachineKeyAddedEventType, MachineKeyAddedEventMapper).
 		RegisterFilterEventMapper(MachineKeyRemovedEventType, MachineKeyRemovedEventMapper).
+		RegisterFilterEventMapper(MachineSecretSetType, MachineSecretSetEventMapper).
+		RegisterFilterEventMapper(MachineSecretRemovedType, MachineSecretRemovedEventMapper).
+		RegisterFilterEventMapper(MachineSecretCheckSucceededType, MachineSecretCheckSucceededEventMapper).
+		RegisterFilterEventMapper(MachineSecretCheckFailedType, MachineSecretCheckFailedEventMapper).
 		RegisterFilterEventMapper(PersonalAccessTokenAddedType, PersonalAccessTokenAddedEventMapper).
 		RegisterFilterEventMapper(PersonalAccessTokenRemovedType, PersonalAccessTokenRemovedEventMapper)
 }
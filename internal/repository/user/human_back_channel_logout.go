@@ -0,0 +1,112 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+const (
+	humanBackChannelLogoutPrefix        = humanEventPrefix + "backchannel.logout."
+	HumanBackChannelLogoutRequestedType = humanBackChannelLogoutPrefix + "requested"
+	HumanBackChannelLogoutSentType      = humanBackChannelLogoutPrefix + "sent"
+)
+
+type HumanBackChannelLogoutRequestedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID          string        `json:"id"`
+	UserAgentID string        `json:"userAgentID"`
+	ClientID    string        `json:"clientID"`
+	LogoutURI   string        `json:"logoutURI"`
+	LogoutToken string        `json:"logoutToken"`
+	Expiry      time.Duration `json:"expiry"`
+}
+
+func (e *HumanBackChannelLogoutRequestedEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutRequestedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanBackChannelLogoutRequestedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	userAgentID,
+	clientID,
+	logoutURI,
+	logoutToken string,
+	expiry time.Duration,
+) *HumanBackChannelLogoutRequestedEvent {
+	return &HumanBackChannelLogoutRequestedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutRequestedType,
+		),
+		ID:          id,
+		UserAgentID: userAgentID,
+		ClientID:    clientID,
+		LogoutURI:   logoutURI,
+		LogoutToken: logoutToken,
+		Expiry:      expiry,
+	}
+}
+
+func HumanBackChannelLogoutRequestedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	logoutRequested := &HumanBackChannelLogoutRequestedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, logoutRequested)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ooy9e", "unable to unmarshal human back-channel logout requested")
+	}
+	return logoutRequested, nil
+}
+
+type HumanBackChannelLogoutSentEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+}
+
+func (e *HumanBackChannelLogoutSentEvent) Data() interface{} {
+	return e
+}
+
+func (e *HumanBackChannelLogoutSentEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewHumanBackChannelLogoutSentEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+) *HumanBackChannelLogoutSentEvent {
+	return &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanBackChannelLogoutSentType,
+		),
+		ID: id,
+	}
+}
+
+func HumanBackChannelLogoutSentEventMapper(event *repository.Event) (eventstore.Event, error) {
+	logoutSent := &HumanBackChannelLogoutSentEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, logoutSent)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-iu8Ae", "unable to unmarshal human back-channel logout sent")
+	}
+	return logoutSent, nil
+}
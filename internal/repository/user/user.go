@@ -22,6 +22,8 @@ const (
 	UserRemovedType           = userEventTypePrefix + "removed"
 	UserTokenAddedType        = userEventTypePrefix + "token.added"
 	UserTokenRemovedType      = userEventTypePrefix + "token.removed"
+	UserTokenExchangedType    = userEventTypePrefix + "token.exchanged"
+	UserImpersonatedType      = userEventTypePrefix + "impersonated"
 	UserDomainClaimedType     = userEventTypePrefix + "domain.claimed"
 	UserDomainClaimedSentType = userEventTypePrefix + "domain.claimed.sent"
 	UserUserNameChangedType   = userEventTypePrefix + "username.changed"
@@ -50,10 +52,14 @@ func NewRemoveUsernameUniqueConstraint(userName, resourceOwner string, userLogin
 
 type UserLockedEvent struct {
 	eventstore.BaseEvent `json:"-"`
+
+	// Lockout is set if the user was locked because of too many failed checks,
+	// only these locks are released after the auto unlock duration of the lockout policy
+	Lockout bool `json:"lockout,omitempty"`
 }
 
 func (e *UserLockedEvent) Data() interface{} {
-	return nil
+	return e
 }
 
 func (e *UserLockedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
@@ -70,10 +76,29 @@ func NewUserLockedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *U
 	}
 }
 
-func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
+func NewUserLockedByLockoutEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserLockedEvent {
 	return &UserLockedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserLockedType,
+		),
+		Lockout: true,
+	}
+}
+
+func UserLockedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	locked := &UserLockedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
-	}, nil
+	}
+	if len(event.Data) == 0 {
+		return locked, nil
+	}
+	err := json.Unmarshal(event.Data, locked)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ahk3e", "unable to unmarshal user locked")
+	}
+	return locked, nil
 }
 
 type UserUnlockedEvent struct {
@@ -219,6 +244,9 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+
+	Actor        *domain.TokenActor        `json:"actor,omitempty"`
+	Confirmation *domain.TokenConfirmation `json:"confirmation,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Data() interface{} {
@@ -240,6 +268,8 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	actor *domain.TokenActor,
+	confirmation *domain.TokenConfirmation,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -255,6 +285,8 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		Actor:             actor,
+		Confirmation:      confirmation,
 	}
 }
 
@@ -311,6 +343,103 @@ func UserTokenRemovedEventMapper(event *repository.Event) (eventstore.Event, err
 	return tokenRemoved, nil
 }
 
+// UserTokenExchangedEvent records an access token of the user issued in exchange of another token
+// the actor is set if another user (or service) acts on behalf of the user (delegation)
+type UserTokenExchangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID       string             `json:"tokenId"`
+	ApplicationID string             `json:"applicationId"`
+	Actor         *domain.TokenActor `json:"actor,omitempty"`
+}
+
+func (e *UserTokenExchangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserTokenExchangedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserTokenExchangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	applicationID string,
+	actor *domain.TokenActor,
+) *UserTokenExchangedEvent {
+	return &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserTokenExchangedType,
+		),
+		TokenID:       tokenID,
+		ApplicationID: applicationID,
+		Actor:         actor,
+	}
+}
+
+func UserTokenExchangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	tokenExchanged := &UserTokenExchangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, tokenExchanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Ohsh6", "unable to unmarshal token exchanged")
+	}
+
+	return tokenExchanged, nil
+}
+
+// UserImpersonatedEvent records an access token of the user issued to the actor impersonating the user
+type UserImpersonatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID       string             `json:"tokenId"`
+	ApplicationID string             `json:"applicationId"`
+	Actor         *domain.TokenActor `json:"actor"`
+}
+
+func (e *UserImpersonatedEvent) Data() interface{} {
+	return e
+}
+
+func (e *UserImpersonatedEvent) UniqueConstraints() []*eventstore.EventUniqueConstraint {
+	return nil
+}
+
+func NewUserImpersonatedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID,
+	applicationID string,
+	actor *domain.TokenActor,
+) *UserImpersonatedEvent {
+	return &UserImpersonatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserImpersonatedType,
+		),
+		TokenID:       tokenID,
+		ApplicationID: applicationID,
+		Actor:         actor,
+	}
+}
+
+func UserImpersonatedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	impersonated := &UserImpersonatedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, impersonated)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-eiN8o", "unable to unmarshal user impersonated")
+	}
+
+	return impersonated, nil
+}
+
 type DomainClaimedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
@@ -22,6 +22,8 @@ type TokenView struct {
 	PreferredLanguage string
 	RefreshTokenID    string
 	IsPAT             bool
+	Actor             *domain.TokenActor
+	Confirmation      *domain.TokenConfirmation
 }
 
 type TokenSearchRequest struct {
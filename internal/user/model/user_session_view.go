@@ -22,11 +22,14 @@ type UserSessionView struct {
 	SelectedIDPConfigID          string
 	PasswordVerification         time.Time
 	PasswordlessVerification     time.Time
+	MagicLinkVerification        time.Time
 	ExternalLoginVerification    time.Time
 	SecondFactorVerification     time.Time
 	SecondFactorVerificationType domain.MFAType
 	MultiFactorVerification      time.Time
 	MultiFactorVerificationType  domain.MFAType
+	BrowserUserAgent             string
+	RemoteIP                     string
 	Sequence                     uint64
 }
 
@@ -1,12 +1,14 @@
 package model
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"time"
 
 	"github.com/lib/pq"
 	"github.com/zitadel/logging"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -26,22 +28,24 @@ const (
 )
 
 type TokenView struct {
-	ID                string         `json:"tokenId" gorm:"column:id;primary_key"`
-	CreationDate      time.Time      `json:"-" gorm:"column:creation_date"`
-	ChangeDate        time.Time      `json:"-" gorm:"column:change_date"`
-	ResourceOwner     string         `json:"-" gorm:"column:resource_owner"`
-	UserID            string         `json:"-" gorm:"column:user_id"`
-	ApplicationID     string         `json:"applicationId" gorm:"column:application_id"`
-	UserAgentID       string         `json:"userAgentId" gorm:"column:user_agent_id"`
-	Audience          pq.StringArray `json:"audience" gorm:"column:audience"`
-	Scopes            pq.StringArray `json:"scopes" gorm:"column:scopes"`
-	Expiration        time.Time      `json:"expiration" gorm:"column:expiration"`
-	Sequence          uint64         `json:"-" gorm:"column:sequence"`
-	PreferredLanguage string         `json:"preferredLanguage" gorm:"column:preferred_language"`
-	RefreshTokenID    string         `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
-	IsPAT             bool           `json:"-" gorm:"is_pat"`
-	Deactivated       bool           `json:"-" gorm:"-"`
-	InstanceID        string         `json:"instanceID" gorm:"column:instance_id;primary_key"`
+	ID                string             `json:"tokenId" gorm:"column:id;primary_key"`
+	CreationDate      time.Time          `json:"-" gorm:"column:creation_date"`
+	ChangeDate        time.Time          `json:"-" gorm:"column:change_date"`
+	ResourceOwner     string             `json:"-" gorm:"column:resource_owner"`
+	UserID            string             `json:"-" gorm:"column:user_id"`
+	ApplicationID     string             `json:"applicationId" gorm:"column:application_id"`
+	UserAgentID       string             `json:"userAgentId" gorm:"column:user_agent_id"`
+	Audience          pq.StringArray     `json:"audience" gorm:"column:audience"`
+	Scopes            pq.StringArray     `json:"scopes" gorm:"column:scopes"`
+	Expiration        time.Time          `json:"expiration" gorm:"column:expiration"`
+	Sequence          uint64             `json:"-" gorm:"column:sequence"`
+	PreferredLanguage string             `json:"preferredLanguage" gorm:"column:preferred_language"`
+	RefreshTokenID    string             `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
+	IsPAT             bool               `json:"-" gorm:"is_pat"`
+	Actor             *TokenActor        `json:"actor,omitempty" gorm:"column:actor"`
+	Confirmation      *TokenConfirmation `json:"confirmation,omitempty" gorm:"column:confirmation"`
+	Deactivated       bool               `json:"-" gorm:"-"`
+	InstanceID        string             `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
 
 func TokenViewToModel(token *TokenView) *usr_model.TokenView {
@@ -60,9 +64,49 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		Actor:             (*domain.TokenActor)(token.Actor),
+		Confirmation:      (*domain.TokenConfirmation)(token.Confirmation),
 	}
 }
 
+type TokenActor domain.TokenActor
+
+func (a *TokenActor) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *TokenActor) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, a)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), a)
+	}
+	return nil
+}
+
+type TokenConfirmation domain.TokenConfirmation
+
+func (c *TokenConfirmation) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+func (c *TokenConfirmation) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		return json.Unmarshal(b, c)
+	}
+	if s, ok := src.(string); ok {
+		return json.Unmarshal([]byte(s), c)
+	}
+	return nil
+}
+
 func (t *TokenView) AppendEventIfMyToken(event *es_models.Event) (err error) {
 	view := new(TokenView)
 	switch eventstore.EventType(event.Type) {
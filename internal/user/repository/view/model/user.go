@@ -88,6 +88,9 @@ type HumanView struct {
 	Region                   string         `json:"region" gorm:"column:region"`
 	StreetAddress            string         `json:"streetAddress" gorm:"column:street_address"`
 	OTPState                 int32          `json:"-" gorm:"column:otp_state"`
+	OTPSMSAdded              bool           `json:"-" gorm:"column:otp_sms_added"`
+	OTPEmailAdded            bool           `json:"-" gorm:"column:otp_email_added"`
+	RecoveryCodesRemaining   uint64         `json:"-" gorm:"column:recovery_codes_remaining"`
 	U2FTokens                WebAuthNTokens `json:"-" gorm:"column:u2f_tokens"`
 	MFAMaxSetUp              int32          `json:"-" gorm:"column:mfa_max_set_up"`
 	MFAInitSkipped           time.Time      `json:"-" gorm:"column:mfa_init_skipped"`
@@ -178,6 +181,9 @@ func UserToModel(user *UserView, prefixAvatarURL string) *model.UserView {
 			Region:                   user.Region,
 			StreetAddress:            user.StreetAddress,
 			OTPState:                 model.MFAState(user.OTPState),
+			OTPSMSAdded:              user.OTPSMSAdded,
+			OTPEmailAdded:            user.OTPEmailAdded,
+			RecoveryCodesRemaining:   user.RecoveryCodesRemaining,
 			MFAMaxSetUp:              domain.MFALevel(user.MFAMaxSetUp),
 			MFAInitSkipped:           user.MFAInitSkipped,
 			InitRequired:             user.InitRequired,
@@ -327,6 +333,24 @@ func (u *UserView) AppendEvent(event *models.Event) (err error) {
 	case user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPRemovedType:
 		u.OTPState = int32(model.MFAStateUnspecified)
+	case user.HumanMFAOTPSMSAddedType:
+		u.OTPSMSAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPSMSRemovedType:
+		u.OTPSMSAdded = false
+	case user.HumanMFAOTPEmailAddedType:
+		u.OTPEmailAdded = true
+		u.MFAInitSkipped = time.Time{}
+	case user.HumanMFAOTPEmailRemovedType:
+		u.OTPEmailAdded = false
+	case user.HumanMFARecoveryCodesAddedType:
+		err = u.setRecoveryCodes(event)
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		if u.RecoveryCodesRemaining > 0 {
+			u.RecoveryCodesRemaining--
+		}
+	case user.HumanMFARecoveryCodesRemovedType:
+		u.RecoveryCodesRemaining = 0
 	case user.HumanU2FTokenAddedType:
 		err = u.addU2FToken(event)
 	case user.HumanU2FTokenVerifiedType:
@@ -435,6 +459,16 @@ func (u *UserView) removePasswordlessToken(event *models.Event) error {
 	return nil
 }
 
+func (u *UserView) setRecoveryCodes(event *models.Event) error {
+	codes := new(user.HumanRecoveryCodesAddedEvent)
+	if err := json.Unmarshal(event.Data, codes); err != nil {
+		logging.Log("MODEL-ieX3a").WithError(err).Error("could not unmarshal event data")
+		return caos_errs.ThrowInternal(nil, "MODEL-Ohd4i", "could not unmarshal data")
+	}
+	u.RecoveryCodesRemaining = uint64(len(codes.Codes))
+	return nil
+}
+
 func (u *UserView) addU2FToken(event *models.Event) error {
 	token, err := webAuthNViewFromEvent(event)
 	if err != nil {
@@ -521,7 +555,9 @@ func (u *UserView) ComputeMFAMaxSetUp() {
 			return
 		}
 	}
-	if u.OTPState == int32(model.MFAStateReady) {
+	if u.OTPState == int32(model.MFAStateReady) ||
+		(u.OTPSMSAdded && u.IsPhoneVerified) ||
+		(u.OTPEmailAdded && u.IsEmailVerified) {
 		u.MFAMaxSetUp = int32(domain.MFALevelSecondFactor)
 		return
 	}
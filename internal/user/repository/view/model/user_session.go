@@ -37,11 +37,14 @@ type UserSessionView struct {
 	SelectedIDPConfigID          string    `json:"selectedIDPConfigID" gorm:"column:selected_idp_config_id"`
 	PasswordVerification         time.Time `json:"-" gorm:"column:password_verification"`
 	PasswordlessVerification     time.Time `json:"-" gorm:"column:passwordless_verification"`
+	MagicLinkVerification        time.Time `json:"-" gorm:"column:magic_link_verification"`
 	ExternalLoginVerification    time.Time `json:"-" gorm:"column:external_login_verification"`
 	SecondFactorVerification     time.Time `json:"-" gorm:"column:second_factor_verification"`
 	SecondFactorVerificationType int32     `json:"-" gorm:"column:second_factor_verification_type"`
 	MultiFactorVerification      time.Time `json:"-" gorm:"column:multi_factor_verification"`
 	MultiFactorVerificationType  int32     `json:"-" gorm:"column:multi_factor_verification_type"`
+	BrowserUserAgent             string    `json:"-" gorm:"column:browser_user_agent"`
+	RemoteIP                     string    `json:"-" gorm:"column:remote_ip"`
 	Sequence                     uint64    `json:"-" gorm:"column:sequence"`
 	InstanceID                   string    `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
@@ -71,11 +74,14 @@ func UserSessionToModel(userSession *UserSessionView, prefixAvatarURL string) *m
 		SelectedIDPConfigID:          userSession.SelectedIDPConfigID,
 		PasswordVerification:         userSession.PasswordVerification,
 		PasswordlessVerification:     userSession.PasswordlessVerification,
+		MagicLinkVerification:        userSession.MagicLinkVerification,
 		ExternalLoginVerification:    userSession.ExternalLoginVerification,
 		SecondFactorVerification:     userSession.SecondFactorVerification,
 		SecondFactorVerificationType: domain.MFAType(userSession.SecondFactorVerificationType),
 		MultiFactorVerification:      userSession.MultiFactorVerification,
 		MultiFactorVerificationType:  domain.MFAType(userSession.MultiFactorVerificationType),
+		BrowserUserAgent:             userSession.BrowserUserAgent,
+		RemoteIP:                     userSession.RemoteIP,
 		Sequence:                     userSession.Sequence,
 	}
 }
@@ -91,6 +97,7 @@ func UserSessionsToModel(userSessions []*UserSessionView, prefixAvatarURL string
 func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	v.Sequence = event.Sequence
 	v.ChangeDate = event.CreationDate
+	v.setBrowserInfo(event)
 	switch eventstore.EventType(event.Type) {
 	case user.UserV1PasswordCheckSucceededType,
 		user.HumanPasswordCheckSucceededType:
@@ -117,6 +124,11 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1PasswordCheckFailedType,
 		user.HumanPasswordCheckFailedType:
 		v.PasswordVerification = time.Time{}
+	case user.HumanMagicLinkCheckSucceededType:
+		v.MagicLinkVerification = event.CreationDate
+		v.State = int32(domain.UserSessionStateActive)
+	case user.HumanMagicLinkCheckFailedType:
+		v.MagicLinkVerification = time.Time{}
 	case user.UserV1PasswordChangedType,
 		user.HumanPasswordChangedType:
 		data := new(es_model.PasswordChange)
@@ -139,10 +151,21 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 	case user.UserV1MFAOTPCheckSucceededType,
 		user.HumanMFAOTPCheckSucceededType:
 		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTP)
+	case user.HumanMFAOTPSMSCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPSMS)
+	case user.HumanMFAOTPEmailCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeOTPEmail)
+	case user.HumanMFARecoveryCodeCheckSucceededType:
+		v.setSecondFactorVerification(event.CreationDate, domain.MFATypeRecoveryCode)
 	case user.UserV1MFAOTPCheckFailedType,
 		user.UserV1MFAOTPRemovedType,
 		user.HumanMFAOTPCheckFailedType,
 		user.HumanMFAOTPRemovedType,
+		user.HumanMFAOTPSMSCheckFailedType,
+		user.HumanMFAOTPSMSRemovedType,
+		user.HumanMFAOTPEmailCheckFailedType,
+		user.HumanMFAOTPEmailRemovedType,
+		user.HumanMFARecoveryCodeCheckFailedType,
 		user.HumanU2FTokenCheckFailedType,
 		user.HumanU2FTokenRemovedType:
 		v.SecondFactorVerification = time.Time{}
@@ -163,6 +186,7 @@ func (v *UserSessionView) AppendEvent(event *models.Event) error {
 		user.UserDeactivatedType:
 		v.PasswordlessVerification = time.Time{}
 		v.PasswordVerification = time.Time{}
+		v.MagicLinkVerification = time.Time{}
 		v.SecondFactorVerification = time.Time{}
 		v.SecondFactorVerificationType = int32(domain.MFALevelNotSetUp)
 		v.MultiFactorVerification = time.Time{}
@@ -181,3 +205,22 @@ func (v *UserSessionView) setSecondFactorVerification(verificationTime time.Time
 	v.SecondFactorVerificationType = int32(mfaType)
 	v.State = int32(domain.UserSessionStateActive)
 }
+
+// setBrowserInfo keeps the device information of the latest check on the user agent
+func (v *UserSessionView) setBrowserInfo(event *models.Event) {
+	if len(event.Data) == 0 {
+		return
+	}
+	info := new(user.AuthRequestInfo)
+	if err := json.Unmarshal(event.Data, info); err != nil {
+		return
+	}
+	if info.BrowserInfo == nil || info.UserAgentID != v.UserAgentID {
+		return
+	}
+	v.BrowserUserAgent = info.UserAgent
+	v.RemoteIP = ""
+	if info.RemoteIP != nil {
+		v.RemoteIP = info.RemoteIP.String()
+	}
+}
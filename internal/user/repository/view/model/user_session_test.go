@@ -2,12 +2,14 @@ package model
 
 import (
 	"encoding/json"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	es_model "github.com/zitadel/zitadel/internal/user/repository/eventsourcing/model"
@@ -192,6 +194,88 @@ func TestAppendEvent(t *testing.T) {
 			},
 			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: time.Time{}},
 		},
+		{
+			name: "append human otp sms check succeeded event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMFAOTPSMSCheckSucceededType)},
+				userView: &UserSessionView{},
+			},
+			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: now(), SecondFactorVerificationType: int32(domain.MFATypeOTPSMS)},
+		},
+		{
+			name: "append human otp email check failed event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMFAOTPEmailCheckFailedType)},
+				userView: &UserSessionView{SecondFactorVerification: now(), SecondFactorVerificationType: int32(domain.MFATypeOTPEmail)},
+			},
+			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: time.Time{}, SecondFactorVerificationType: int32(domain.MFATypeOTPEmail)},
+		},
+		{
+			name: "append human recovery code check succeeded event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMFARecoveryCodeCheckSucceededType)},
+				userView: &UserSessionView{},
+			},
+			result: &UserSessionView{ChangeDate: now(), SecondFactorVerification: now(), SecondFactorVerificationType: int32(domain.MFATypeRecoveryCode)},
+		},
+		{
+			name: "append human magic link check succeeded event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMagicLinkCheckSucceededType)},
+				userView: &UserSessionView{},
+			},
+			result: &UserSessionView{ChangeDate: now(), MagicLinkVerification: now()},
+		},
+		{
+			name: "append human magic link check failed event",
+			args: args{
+				event:    &es_models.Event{CreationDate: now(), Type: es_models.EventType(user.HumanMagicLinkCheckFailedType)},
+				userView: &UserSessionView{MagicLinkVerification: now()},
+			},
+			result: &UserSessionView{ChangeDate: now(), MagicLinkVerification: time.Time{}},
+		},
+		{
+			name: "append human magic link check succeeded event with browser info",
+			args: args{
+				event: &es_models.Event{
+					CreationDate: now(),
+					Type:         es_models.EventType(user.HumanMagicLinkCheckSucceededType),
+					Data: func() []byte {
+						d, _ := json.Marshal(&user.AuthRequestInfo{
+							UserAgentID: "agent1",
+							BrowserInfo: &user.BrowserInfo{
+								UserAgent: "browser",
+								RemoteIP:  net.ParseIP("127.0.0.1"),
+							},
+						})
+						return d
+					}(),
+				},
+				userView: &UserSessionView{UserAgentID: "agent1"},
+			},
+			result: &UserSessionView{UserAgentID: "agent1", ChangeDate: now(), MagicLinkVerification: now(), BrowserUserAgent: "browser", RemoteIP: "127.0.0.1"},
+		},
+		{
+			name: "append human magic link check succeeded event with browser info of other user agent",
+			args: args{
+				event: &es_models.Event{
+					CreationDate: now(),
+					Type:         es_models.EventType(user.HumanMagicLinkCheckSucceededType),
+					Data: func() []byte {
+						d, _ := json.Marshal(&user.AuthRequestInfo{
+							UserAgentID: "agent2",
+							BrowserInfo: &user.BrowserInfo{
+								UserAgent: "browser",
+								RemoteIP:  net.ParseIP("127.0.0.1"),
+							},
+						})
+						return d
+					}(),
+				},
+				userView: &UserSessionView{UserAgentID: "agent1"},
+			},
+			result: &UserSessionView{UserAgentID: "agent1", ChangeDate: now(), MagicLinkVerification: now()},
+		},
 		{
 			name: "append user signed out event",
 			args: args{
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -42,6 +43,11 @@ func mockAddressData(address *es_model.Address) []byte {
 	return data
 }
 
+func mockRecoveryCodesData(count int) []byte {
+	data, _ := json.Marshal(user.NewHumanRecoveryCodesAddedEvent(context.Background(), &user.NewAggregate("AggregateID", "GrantedOrgID").Aggregate, make([]*crypto.CryptoValue, count)))
+	return data
+}
+
 func getFullHuman(password *es_model.Password) *es_model.User {
 	return &es_model.User{
 		UserName: "UserName",
@@ -348,6 +354,30 @@ func TestUserAppendEvent(t *testing.T) {
 			},
 			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", OTPState: int32(model.MFAStateUnspecified)}, State: int32(model.UserStateActive)},
 		},
+		{
+			name: "append human recovery codes added event",
+			args: args{
+				event: &es_models.Event{AggregateID: "AggregateID", Sequence: 1, Type: es_models.EventType(user.HumanMFARecoveryCodesAddedType), ResourceOwner: "GrantedOrgID", Data: mockRecoveryCodesData(3)},
+				user:  &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", RecoveryCodesRemaining: 1}, State: int32(model.UserStateActive)},
+			},
+			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", RecoveryCodesRemaining: 3}, State: int32(model.UserStateActive)},
+		},
+		{
+			name: "append human recovery code check succeeded event",
+			args: args{
+				event: &es_models.Event{AggregateID: "AggregateID", Sequence: 1, Type: es_models.EventType(user.HumanMFARecoveryCodeCheckSucceededType), ResourceOwner: "GrantedOrgID"},
+				user:  &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", RecoveryCodesRemaining: 3}, State: int32(model.UserStateActive)},
+			},
+			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", RecoveryCodesRemaining: 2}, State: int32(model.UserStateActive)},
+		},
+		{
+			name: "append human recovery codes removed event",
+			args: args{
+				event: &es_models.Event{AggregateID: "AggregateID", Sequence: 1, Type: es_models.EventType(user.HumanMFARecoveryCodesRemovedType), ResourceOwner: "GrantedOrgID"},
+				user:  &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country", RecoveryCodesRemaining: 2}, State: int32(model.UserStateActive)},
+			},
+			result: &UserView{ID: "AggregateID", ResourceOwner: "GrantedOrgID", UserName: "UserName", HumanView: &HumanView{FirstName: "FirstName", LastName: "LastName", Email: "Email", Phone: "Phone", Country: "Country"}, State: int32(model.UserStateActive)},
+		},
 		{
 			name: "append user mfa init skipped event",
 			args: args{
@@ -402,6 +432,9 @@ func TestUserAppendEvent(t *testing.T) {
 				if human.OTPState != tt.result.OTPState {
 					t.Errorf("got wrong result OTPState: expected: %v, actual: %v ", tt.result.OTPState, human.OTPState)
 				}
+				if human.RecoveryCodesRemaining != tt.result.RecoveryCodesRemaining {
+					t.Errorf("got wrong result RecoveryCodesRemaining: expected: %v, actual: %v ", tt.result.RecoveryCodesRemaining, human.RecoveryCodesRemaining)
+				}
 				if human.MFAInitSkipped.Round(1*time.Second) != tt.result.MFAInitSkipped.Round(1*time.Second) {
 					t.Errorf("got wrong result MFAInitSkipped: expected: %v, actual: %v ", tt.result.MFAInitSkipped.Round(1*time.Second), human.MFAInitSkipped.Round(1*time.Second))
 				}
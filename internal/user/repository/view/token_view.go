@@ -43,6 +43,25 @@ func TokensByUserID(db *gorm.DB, table, userID, instanceID string) ([]*usr_model
 	return tokens, err
 }
 
+func TokensByUserAgentID(db *gorm.DB, table, agentID, instanceID string) ([]*usr_model.TokenView, error) {
+	tokens := make([]*usr_model.TokenView, 0)
+	userAgentIDQuery := &model.TokenSearchQuery{
+		Key:    model.TokenSearchKeyUserAgentID,
+		Method: domain.SearchMethodEquals,
+		Value:  agentID,
+	}
+	instanceIDQuery := &model.TokenSearchQuery{
+		Key:    model.TokenSearchKeyInstanceID,
+		Method: domain.SearchMethodEquals,
+		Value:  instanceID,
+	}
+	query := repository.PrepareSearchQuery(table, usr_model.TokenSearchRequest{
+		Queries: []*model.TokenSearchQuery{userAgentIDQuery, instanceIDQuery},
+	})
+	_, err := query(db, &tokens)
+	return tokens, err
+}
+
 func PutToken(db *gorm.DB, table string, token *usr_model.TokenView) error {
 	save := repository.PrepareSave(table)
 	return save(db, token)